@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// crossDeviceFixScript moves the remote's agent directory onto the same
+// filesystem as /tmp, where Mutagen stages the agent before installing it.
+// An existing ~/.mutagen directory is kept under a backup name; Mutagen
+// reinstalls the agent if /tmp is cleared.
+const crossDeviceFixScript = `set -e
+dir="/tmp/mutagen-$(id -un)"
+mkdir -p "$dir"
+chmod 700 "$dir"
+if [ -L ~/.mutagen ]; then rm ~/.mutagen; elif [ -e ~/.mutagen ]; then mv ~/.mutagen ~/.mutagen.bak-$(date +%Y%m%d%H%M%S); fi
+ln -s "$dir" ~/.mutagen`
+
+// runRemoteScript runs a shell script on host over SSH, with the options
+// runSSHCheck uses. It is a variable so tests can replace it.
+var runRemoteScript = func(ctx context.Context, host, script string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	args := append(mutagen.SSHOptions(), host, script)
+	return exec.CommandContext(ctx, "ssh", args...).CombinedOutput()
+}
+
+// agentFix is a remedy for a session that failed to start because the
+// agent couldn't be installed on host, followed by another attempt.
+type agentFix struct {
+	host  string
+	retry sessionSnapshot
+}
+
+// noteCreateFailure remembers a fix for a failed session create, if mutagui
+// knows one, so the UI can offer it.
+func (a *App) noteCreateFailure(err error, snap sessionSnapshot) {
+	if !errors.Is(err, mutagen.ErrCrossDeviceLink) {
+		return
+	}
+	host := ""
+	for _, endpoint := range []string{snap.beta, snap.alpha} {
+		if e := project.ParseEndpoint(endpoint); e.IsSSH() {
+			host = e.SSHDestination()
+			break
+		}
+	}
+	if host != "" {
+		a.pendingFix = &agentFix{host: host, retry: snap}
+	}
+}
+
+// OfferFix returns a description of the fix for the last failed start, or
+// nil if there is none. Each fix is offered once; ApplyFix runs the one
+// offered last.
+func (a *App) OfferFix() []string {
+	fix := a.pendingFix
+	if fix == nil {
+		return nil
+	}
+	a.pendingFix = nil
+	a.offeredFix = fix
+	return []string{
+		fmt.Sprintf("Starting %s failed because the Mutagen agent couldn't be", fix.retry.name),
+		fmt.Sprintf("moved across filesystems on %s. mutagui can point ~/.mutagen there", fix.host),
+		"at a directory in /tmp, on the same filesystem as the agent upload:",
+		"",
+		"  ln -s /tmp/mutagen-$USER ~/.mutagen",
+		"",
+		"An existing ~/.mutagen is renamed to ~/.mutagen.bak-<date>.",
+		"Then " + fix.retry.name + " is started again.",
+	}
+}
+
+// ApplyFix runs the fix returned by the last OfferFix on the remote host,
+// then retries the start.
+func (a *App) ApplyFix(ctx context.Context) {
+	fix := a.offeredFix
+	a.offeredFix = nil
+	if fix == nil {
+		a.SetStatus(ui.StatusWarning, "No fix to apply")
+		return
+	}
+	if a.DryRun {
+		a.SetStatus(ui.StatusInfo, "Would link ~/.mutagen on "+fix.host+" and start "+fix.retry.name+" again")
+		return
+	}
+
+	a.LogEvent(ui.StatusInfo, "Linking ~/.mutagen to /tmp on "+fix.host)
+	if output, err := runRemoteScript(ctx, fix.host, crossDeviceFixScript); err != nil {
+		text := strings.TrimSpace(string(output))
+		if text == "" {
+			text = err.Error()
+		}
+		a.SetStatus(ui.StatusError, "Failed to link ~/.mutagen on "+fix.host+": "+text)
+		return
+	}
+	a.LogEvent(ui.StatusInfo, "Linked ~/.mutagen on "+fix.host+"; starting "+fix.retry.name+" again")
+
+	if err := a.recreate(ctx, fix.retry); err != nil {
+		a.SetStatus(ui.StatusError, "Fixed the agent directory, but "+fix.retry.name+" still failed to start: "+err.Error())
+		return
+	}
+	a.SetStatus(ui.StatusInfo, "Started session: "+fix.retry.name)
+}
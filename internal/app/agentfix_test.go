@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// stubRemoteScript replaces runRemoteScript for the test, recording the
+// hosts it was run on.
+func stubRemoteScript(t *testing.T, output string, err error) *[]string {
+	t.Helper()
+	var hosts []string
+	original := runRemoteScript
+	runRemoteScript = func(ctx context.Context, host, script string) ([]byte, error) {
+		hosts = append(hosts, host)
+		return []byte(output), err
+	}
+	t.Cleanup(func() { runRemoteScript = original })
+	return &hosts
+}
+
+func TestApplyFix_LinksAgentDirectoryAndRetries(t *testing.T) {
+	hosts := stubRemoteScript(t, "", nil)
+	mock := &MockClient{CreateSessionError: fmt.Errorf("mutagen sync create failed: %w", mutagen.ErrCrossDeviceLink)}
+	app := newSpecApp(mock, project.NotRunning, "")
+	app.DryRun = true // Skip endpoint preparation, which would ssh to the host
+	app.State.Projects[0].File.Sessions["web"] = project.SessionDefinition{Alpha: "/local/path", Beta: "user@server:/srv/web"}
+
+	app.StartSelectedSpec(context.Background())
+	lines := app.OfferFix()
+	if len(lines) == 0 || !strings.Contains(strings.Join(lines, "\n"), "user@server") {
+		t.Fatalf("OfferFix() = %v, want a fix for user@server", lines)
+	}
+	if again := app.OfferFix(); again != nil {
+		t.Errorf("fix offered twice: %v", again)
+	}
+
+	app.DryRun = false
+	mock.CreateSessionError = nil
+	app.ApplyFix(context.Background())
+	if len(*hosts) != 1 || (*hosts)[0] != "user@server" {
+		t.Errorf("remote script ran on %v, want user@server", *hosts)
+	}
+	if len(mock.CreateSessionCalls) != 2 {
+		t.Errorf("CreateSession called %d times, want a retry", len(mock.CreateSessionCalls))
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Text != "Started session: web" {
+		t.Errorf("status = %+v, want started", msg)
+	}
+}
+
+func TestApplyFix_ReportsRemoteFailure(t *testing.T) {
+	stubRemoteScript(t, "mkdir: Permission denied", errors.New("exit status 1"))
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	app.noteCreateFailure(fmt.Errorf("failed: %w", mutagen.ErrCrossDeviceLink), sessionSnapshot{name: "code", alpha: "/local", beta: "server:/srv"})
+	app.OfferFix()
+
+	app.ApplyFix(context.Background())
+	if len(mock.CreateSessionCalls) != 0 {
+		t.Error("should not retry after the fix failed")
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Type != ui.StatusError || !strings.Contains(msg.Text, "Permission denied") {
+		t.Errorf("status = %+v, want the remote error", msg)
+	}
+}
+
+func TestNoteCreateFailure_IgnoresOtherErrors(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	app.noteCreateFailure(errors.New("connection refused"), sessionSnapshot{name: "code", beta: "server:/srv"})
+	app.noteCreateFailure(fmt.Errorf("failed: %w", mutagen.ErrCrossDeviceLink), sessionSnapshot{name: "code", alpha: "/a", beta: "/b"})
+	if lines := app.OfferFix(); lines != nil {
+		t.Errorf("OfferFix() = %v, want nil", lines)
+	}
+}
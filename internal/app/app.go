@@ -3,16 +3,21 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/osteele/mutagui/internal/clock"
 	"github.com/osteele/mutagui/internal/config"
 	"github.com/osteele/mutagui/internal/mutagen"
 	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/state"
 	"github.com/osteele/mutagui/internal/ui"
 )
 
@@ -23,27 +28,148 @@ type AppState struct {
 	StatusMessage *ui.StatusMessage
 	LastRefresh   *time.Time
 	ShowPaths     bool
+	Events        *ui.EventLog
+	SortBy        config.SortBy
 }
 
+// MutagenBinary returns the mutagen command to run: MUTAGUI_MUTAGEN_BIN if
+// it is set, then the configured binary, with a leading ~/ expanded, then
+// the mutagen found in PATH.
+func MutagenBinary(cfg *config.Config) string {
+	binary := os.Getenv("MUTAGUI_MUTAGEN_BIN")
+	if binary == "" {
+		binary = cfg.Client.Binary
+	}
+	if rest, ok := strings.CutPrefix(binary, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			binary = filepath.Join(home, rest)
+		}
+	}
+	if binary == "" {
+		return mutagen.DefaultBinary
+	}
+	return binary
+}
+
+// NewMutagenClient creates a Mutagen client that runs the configured binary
+// through runner, with the configured timeouts and list attempts.
+func NewMutagenClient(cfg *config.Config, runner mutagen.CommandRunner) *mutagen.Client {
+	client := mutagen.NewClientWithRunner(ClientTimeouts(cfg), runner)
+	client.SetBinary(MutagenBinary(cfg))
+	client.SetListAttempts(cfg.Client.ListAttempts)
+	return client
+}
+
+// ClientTimeouts returns the configured timeouts for Mutagen CLI calls,
+// using the defaults for any that are unset.
+func ClientTimeouts(cfg *config.Config) mutagen.Timeouts {
+	defaults := config.DefaultConfig().Client
+	secs := func(value, fallback int64) time.Duration {
+		if value <= 0 {
+			value = fallback
+		}
+		return time.Duration(value) * time.Second
+	}
+	return mutagen.Timeouts{
+		List:   secs(cfg.Client.ListTimeoutSecs, defaults.ListTimeoutSecs),
+		Create: secs(cfg.Client.CreateTimeoutSecs, defaults.CreateTimeoutSecs),
+		Mutate: secs(cfg.Client.MutateTimeoutSecs, defaults.MutateTimeoutSecs),
+	}
+}
+
+// eventLogCapacity is the number of events retained in the event log.
+const eventLogCapacity = 200
+
 // App represents the application state.
 type App struct {
 	Config *config.Config
 	Client mutagen.MutagenClient
 	State  *AppState
+	Clock  clock.Clock
+	Store  *state.Store // State remembered between runs
+
+	// DryRun indicates that mutating commands are reported rather than executed.
+	// The client's runner does the skipping; the app also skips endpoint preparation.
+	DryRun bool
+
+	// Clipboard writes text to the system clipboard; nil uses clipboard.Copy
+	Clipboard func(text string) error
 
 	shouldQuit bool
+
+	// projectBaseDir is the directory LoadProjects searched, reused by rescans
+	projectBaseDir string
+	// lastRescan is when project discovery last ran
+	lastRescan time.Time
+	// discovered is the index at which the last discovery found each
+	// project file, keyed by path, for the discovery sort order
+	discovered map[string]int
+	// lastList is when sessions were last listed successfully. Refreshes
+	// run concurrently, in commands, so listMu guards it.
+	lastList time.Time
+	listMu   sync.Mutex
+	// projectFiles caches parsed project files between discoveries
+	projectFiles *project.FileCache
+	// watch is the project file watch, while WatchProjectFiles runs. Rescans
+	// add to it from their own goroutines, so watchMu guards it.
+	watch   *projectWatch
+	watchMu sync.Mutex
+
+	// lastAction is the inverse of the last mutating operation, for undo
+	lastAction *undoAction
+
+	// reconnects tracks disconnected sessions, keyed by session identifier.
+	// Refreshes write it while the list reads it, so reconnectMu guards it.
+	reconnects  map[string]*reconnectState
+	reconnectMu sync.Mutex
+
+	// pendingFix is a remedy for the last failed start, until the UI offers
+	// it, and offeredFix the one the user is deciding on
+	pendingFix *agentFix
+	offeredFix *agentFix
+
+	// cycleHistory samples each session's successful cycle count, keyed by
+	// session identifier. Refreshes write it while the sync status dialog
+	// reads it, so cycleMu guards it.
+	cycleHistory map[string][]cycleSample
+	cycleMu      sync.Mutex
+
+	// transfers tracks the staging speed of session endpoints, keyed by
+	// session key and endpoint. Refreshes write it while the list reads it,
+	// so transferMu guards it.
+	transfers  map[string]*transferState
+	transferMu sync.Mutex
+
+	// alerts is whether each session was conflicting or halted at the last
+	// refresh, keyed by session identifier, and notified when each
+	// notification was last sent
+	alerts   map[string]sessionAlert
+	notified map[string]time.Time
+
+	// focus is the focus mode in effect, if any
+	focus *focusState
+
+	// nextFlush is when each scheduled spec is next flushed, keyed by spec
+	// key. The flush ticker and the C key both update it, so flushMu guards it.
+	nextFlush map[string]time.Time
+	flushMu   sync.Mutex
 }
 
 // NewApp creates a new App with the given configuration.
 func NewApp(cfg *config.Config) *App {
 	return &App{
 		Config: cfg,
-		Client: mutagen.NewClient(30 * time.Second),
+		Client: NewMutagenClient(cfg, mutagen.ExecRunner{}),
+		Clock:  clock.Real{},
+		Store:  state.NewMemoryStore(),
 		State: &AppState{
 			Projects:  []*project.Project{},
 			Selection: ui.NewSelectionManager(),
 			ShowPaths: cfg.UI.DefaultDisplayMode == config.DisplayModePaths,
+			Events:    ui.NewEventLog(eventLogCapacity),
+			SortBy:    cfg.UI.SortBy,
 		},
+		projectFiles: project.NewFileCache(),
 	}
 }
 
@@ -62,30 +188,88 @@ func (a *App) LoadProjects(ctx context.Context, projectDir string) error {
 		}
 	}
 
-	projects, err := project.FindProjects(baseDir, searchPaths, a.Config.Projects.ExcludePatterns)
+	projects, err := project.FindProjectsCached(a.projectFiles, baseDir, searchPaths, a.Config.Projects.ExcludePatterns, a.Config.Projects.IncludeUserConfig)
 	if err != nil {
 		return err
 	}
 
+	a.projectBaseDir = baseDir
+	a.lastRescan = a.Clock.Now()
+	a.logProjectWarnings(projects)
+	a.discovered = discoveryOrder(projects)
+	sortProjects(projects, a.State.SortBy, a.discovered)
 	a.State.Projects = projects
 	a.State.Selection.RebuildFromProjects(projects)
 	return nil
 }
 
+// SearchPaths returns the directories that project discovery searches.
+func (a *App) SearchPaths() []string {
+	return project.SearchPaths(a.projectBaseDir, a.Config.Projects.SearchPaths, a.Config.Projects.IncludeUserConfig)
+}
+
+// logProjectWarnings records the problems found while parsing project files.
+func (a *App) logProjectWarnings(projects []*project.Project) {
+	for _, proj := range projects {
+		for _, warning := range proj.File.Warnings {
+			a.LogEvent(ui.StatusWarning, filepath.Base(proj.File.Path)+": "+warning)
+		}
+	}
+}
+
+// listDebounce is how recently sessions must have been listed for a
+// refresh that isn't forced to keep the list it has.
+const listDebounce = time.Second
+
 // RefreshSessions fetches the latest session data and updates project states.
-func (a *App) RefreshSessions(ctx context.Context) error {
+// Unless force is set, it does nothing if sessions were listed less than
+// listDebounce ago, so that an automatic refresh and a keypress that land
+// together run mutagen once. Refreshes after changes to sessions are forced.
+func (a *App) RefreshSessions(ctx context.Context, force bool) error {
+	a.listMu.Lock()
+	lastList := a.lastList
+	a.listMu.Unlock()
+	if !force && !lastList.IsZero() && a.Clock.Now().Sub(lastList) < listDebounce {
+		return nil
+	}
+	started := a.Clock.Now()
 	sessions, err := a.Client.ListSessions(ctx)
 	if err != nil {
-		a.SetStatus(ui.StatusError, "Failed to refresh sessions: "+err.Error())
+		if a.State.LastRefresh == nil {
+			a.SetStatus(ui.StatusError, "Failed to refresh sessions: "+err.Error())
+		} else {
+			// The previous sessions stay listed
+			a.SetStatus(ui.StatusWarning, ui.StaleListText(*a.State.LastRefresh, err))
+		}
 		return err
 	}
+	a.listMu.Lock()
+	if started.After(a.lastList) {
+		a.lastList = started
+	}
+	a.listMu.Unlock()
+
+	if a.rescanDue() {
+		if _, err := a.RescanProjects(ctx); err != nil {
+			a.LogEvent(ui.StatusWarning, "Failed to rescan projects: "+err.Error())
+		}
+	}
 
 	// Update each project with session data
 	for _, proj := range a.State.Projects {
 		proj.UpdateFromSessions(sessions)
 	}
+	a.updateOrphanedProject(sessions)
+	a.resortProjects()
+	a.rememberStartedSpecs()
+	a.recordCycles(sessions)
+	a.recordTransfers(sessions)
+	a.notifyProblems(sessions)
+	a.markScheduled()
 
-	now := time.Now()
+	a.superviseConnections(ctx)
+
+	now := a.Clock.Now()
 	a.State.LastRefresh = &now
 	// Only update status to "refreshed" if there's no existing error/warning
 	if a.State.StatusMessage == nil || a.State.StatusMessage.Type == ui.StatusInfo {
@@ -94,11 +278,45 @@ func (a *App) RefreshSessions(ctx context.Context) error {
 	return nil
 }
 
+// rememberStartedSpecs records running specs in the store, so that after
+// they stop they show as stopped rather than new, and marks each spec with
+// whether it has ever run.
+func (a *App) rememberStartedSpecs() {
+	changed := false
+	now := a.Clock.Now()
+	for _, proj := range a.State.Projects {
+		if proj.Orphaned {
+			continue
+		}
+		for i := range proj.Specs {
+			spec := &proj.Specs[i]
+			key := state.SpecKey(proj.File.Path, spec.Name)
+			if spec.IsRunning() && a.Store.MarkStarted(key, now) {
+				changed = true
+			}
+			spec.EverStarted = a.Store.HasStarted(key)
+		}
+	}
+	if changed {
+		if err := a.Store.Save(); err != nil {
+			a.LogEvent(ui.StatusWarning, "Failed to save state: "+err.Error())
+		}
+	}
+}
+
 // SetStatus sets a status message.
 func (a *App) SetStatus(msgType ui.StatusMessageType, text string) {
+	if a.DryRun {
+		text = "[dry-run] " + text
+	}
 	a.State.StatusMessage = &ui.StatusMessage{Type: msgType, Text: text}
 }
 
+// LogEvent records an event in the event log without changing the status message.
+func (a *App) LogEvent(msgType ui.StatusMessageType, text string) {
+	a.State.Events.Add(ui.Event{Time: a.Clock.Now(), Type: msgType, Text: text})
+}
+
 // ClearStatus clears the status message.
 func (a *App) ClearStatus() {
 	a.State.StatusMessage = nil
@@ -132,8 +350,51 @@ func (a *App) SelectPrevious() {
 // ToggleProjectFold toggles the fold state of the project at the given index.
 func (a *App) ToggleProjectFold(projIdx int) {
 	if projIdx >= 0 && projIdx < len(a.State.Projects) {
-		a.State.Projects[projIdx].Folded = !a.State.Projects[projIdx].Folded
+		proj := a.State.Projects[projIdx]
+		proj.Folded = !proj.Folded
 		a.State.Selection.RebuildFromProjects(a.State.Projects)
+		a.State.Selection.SelectProject(projIdx)
+		a.rememberFold(proj)
+	}
+}
+
+// ApplyFoldState folds or unfolds the loaded projects as the fold_state
+// setting says.
+func (a *App) ApplyFoldState() {
+	a.applyFoldState(a.State.Projects)
+}
+
+func (a *App) applyFoldState(projects []*project.Project) {
+	for _, proj := range projects {
+		switch a.Config.UI.FoldState {
+		case config.FoldStateExpanded:
+			proj.Folded = false
+		case config.FoldStateRemember:
+			if folded, ok := a.Store.Folded(proj.File.Path); ok {
+				proj.Folded = folded
+			}
+		}
+	}
+}
+
+// rememberFold saves the project's fold state when fold_state is remember.
+func (a *App) rememberFold(proj *project.Project) {
+	if a.Config.UI.FoldState != config.FoldStateRemember || proj.Orphaned || !a.Store.SetFolded(proj.File.Path, proj.Folded) {
+		return
+	}
+	if err := a.Store.Save(); err != nil {
+		a.LogEvent(ui.StatusWarning, "Failed to save state: "+err.Error())
+	}
+}
+
+// DeclineStarterConfig remembers that the offer of a starter config was
+// declined, so that later runs don't make it again.
+func (a *App) DeclineStarterConfig() {
+	if !a.Store.DeclineConfig() {
+		return
+	}
+	if err := a.Store.Save(); err != nil {
+		a.LogEvent(ui.StatusWarning, "Failed to save state: "+err.Error())
 	}
 }
 
@@ -228,6 +489,65 @@ func (a *App) GetSelectedSession() *mutagen.SyncSession {
 	return spec.RunningSession
 }
 
+// selectedRunningSpec returns the selected spec if it has a running session.
+func (a *App) selectedRunningSpec() *project.SyncSpec {
+	projIdx, specIdx := a.GetSelectedSpec()
+	if projIdx < 0 || specIdx < 0 || projIdx >= len(a.State.Projects) {
+		return nil
+	}
+	proj := a.State.Projects[projIdx]
+	if specIdx >= len(proj.Specs) || proj.Specs[specIdx].RunningSession == nil {
+		return nil
+	}
+	return &proj.Specs[specIdx]
+}
+
+// PollSelectedSession re-fetches only the selected spec's running session,
+// leaving the others untouched.
+func (a *App) PollSelectedSession(ctx context.Context) error {
+	spec := a.selectedRunningSpec()
+	if spec == nil {
+		return nil
+	}
+	updated, err := a.Client.GetSession(ctx, sessionKey(spec.RunningSession))
+	if err != nil {
+		return err
+	}
+	a.replaceSession(spec, *updated)
+	return nil
+}
+
+// MonitorSelectedSession streams updates of the selected spec's running
+// session until ctx is canceled. Apply each with ApplySessionUpdate.
+func (a *App) MonitorSelectedSession(ctx context.Context) (<-chan mutagen.SyncSession, error) {
+	session := a.GetSelectedSession()
+	if session == nil {
+		return nil, errors.New("no running session selected")
+	}
+	return a.Client.MonitorSession(ctx, sessionKey(session))
+}
+
+// ApplySessionUpdate replaces the selected session with a streamed update.
+// Updates for other sessions, which arrive if the selection moved since the
+// stream started, are dropped.
+func (a *App) ApplySessionUpdate(updated mutagen.SyncSession) {
+	spec := a.selectedRunningSpec()
+	if spec == nil || sessionKey(spec.RunningSession) != sessionKey(&updated) {
+		return
+	}
+	a.replaceSession(spec, updated)
+}
+
+// replaceSession replaces the spec's session with fresher data from the
+// daemon, keeping the sync time that only a full refresh tracks. The old
+// session is left as it was, since the UI may be drawing it.
+func (a *App) replaceSession(spec *project.SyncSpec, updated mutagen.SyncSession) {
+	updated.SyncTime = spec.RunningSession.SyncTime
+	spec.RunningSession = &updated
+	a.recordCycleSample(spec.RunningSession)
+	a.recordTransferSample(spec.RunningSession)
+}
+
 // StartSelectedSpec starts the selected spec.
 func (a *App) StartSelectedSpec(ctx context.Context) {
 	projIdx, specIdx := a.GetSelectedSpec()
@@ -253,20 +573,46 @@ func (a *App) StartSelectedSpec(ctx context.Context) {
 		return
 	}
 
-	// Terminate any existing sessions with this name to avoid duplicates
-	// (may exist from previous runs or other sources)
-	_ = a.Client.TerminateSession(ctx, spec.Name)
-
-	// Prepare endpoint directories before creating session
-	if err := prepareEndpoints(ctx, sessionDef.Alpha, sessionDef.Beta); err != nil {
-		a.SetStatus(ui.StatusError, "Failed to prepare endpoints: "+err.Error())
+	if err := a.RunPreStartHook(ctx, proj); err != nil {
+		a.SetStatus(ui.StatusError, "Not starting "+spec.Name+": "+err.Error())
 		return
 	}
 
-	opts := buildSessionOptions(&sessionDef, proj.File.Defaults)
-	err := a.Client.CreateSession(ctx, spec.Name, sessionDef.Alpha, sessionDef.Beta, opts)
-	if err != nil {
-		a.SetStatus(ui.StatusError, "Failed to start session: "+err.Error())
+	// A spec with several betas runs a session for each
+	replicas := sessionDef.Replicas(spec.Name)
+	for _, replica := range replicas {
+		def := sessionDef.WithBeta(replica.Beta)
+
+		// Check the endpoints first, so an unreachable host doesn't cost
+		// the session that is being replaced
+		if err := a.checkSessionEndpoints(ctx, proj, &def); err != nil {
+			a.SetStatus(ui.StatusError, "Not starting "+replica.Name+": "+err.Error())
+			return
+		}
+
+		// Terminate any existing sessions with this name to avoid duplicates
+		// (may exist from previous runs or other sources)
+		if err := a.clearSessionName(ctx, replica.Name); err != nil {
+			a.SetStatus(ui.StatusError, "Failed to replace "+replica.Name+": "+err.Error())
+			return
+		}
+
+		// Prepare endpoint directories before creating session
+		if err := a.prepareSessionEndpoints(ctx, proj, &def); err != nil {
+			a.SetStatus(ui.StatusError, "Failed to prepare endpoints: "+err.Error())
+			return
+		}
+
+		opts := projectSessionOptions(proj, &def)
+		err := a.Client.CreateSession(ctx, replica.Name, def.Alpha, def.Beta, opts)
+		if err != nil {
+			a.SetStatus(ui.StatusError, "Failed to start session: "+err.Error())
+			a.noteCreateFailure(err, sessionSnapshot{name: replica.Name, alpha: def.Alpha, beta: def.Beta, opts: opts})
+			return
+		}
+	}
+	if len(replicas) > 1 {
+		a.SetStatus(ui.StatusInfo, fmt.Sprintf("Started %d sessions: %s", len(replicas), spec.Name))
 		return
 	}
 	a.SetStatus(ui.StatusInfo, "Started session: "+spec.Name)
@@ -281,45 +627,122 @@ func (a *App) StartSelectedProject(ctx context.Context) {
 	}
 
 	proj := a.State.Projects[projIdx]
+	if a.rejectOrphaned(proj) {
+		return
+	}
+	a.startProject(ctx, proj)
+}
+
+// startProject starts the project's specs that aren't running and sets the
+// status. Returns false if a hook or any start failed.
+func (a *App) startProject(ctx context.Context, proj *project.Project) bool {
+	if a.useProjectCommands(proj) {
+		return a.startProjectWithMutagen(ctx, proj)
+	}
 	a.SetStatus(ui.StatusInfo, "Starting "+proj.File.DisplayName()+"...")
 
-	// Start each non-running session individually
-	// (mutagen project start fails if any session is already running)
-	started := 0
+	needsStart := false
 	for i := range proj.Specs {
-		spec := &proj.Specs[i]
-		if spec.IsRunning() {
-			continue // Skip already running sessions
+		if !proj.Specs[i].IsRunning() {
+			needsStart = true
+			break
 		}
-
-		sessionDef, exists := proj.File.Sessions[spec.Name]
-		if !exists {
-			continue
+	}
+	if needsStart {
+		if err := a.RunPreStartHook(ctx, proj); err != nil {
+			a.SetStatus(ui.StatusError, "Not starting "+proj.File.DisplayName()+": "+err.Error())
+			return false
 		}
+	}
 
-		// Terminate any existing sessions with this name to avoid duplicates
-		// (may exist from previous runs or other sources)
-		_ = a.Client.TerminateSession(ctx, spec.Name)
+	order, err := startOrder(proj)
+	if err != nil {
+		a.SetStatus(ui.StatusError, "Not starting "+proj.File.DisplayName()+": "+err.Error())
+		return false
+	}
 
-		// Prepare endpoint directories before creating session
-		if err := prepareEndpoints(ctx, sessionDef.Alpha, sessionDef.Beta); err != nil {
-			a.SetStatus(ui.StatusError, "Failed to prepare endpoints for "+spec.Name+": "+err.Error())
-			return
+	// Start each non-running session individually, dependencies first
+	// (mutagen project start fails if any session is already running).
+	// Specs whose dependencies have all been started run concurrently.
+	waves := startWaves(proj, order)
+	var attempted []string
+	var errs []error
+	for _, wave := range waves {
+		for _, dep := range waveDependencies(proj, wave) {
+			if err := a.waitForWatching(ctx, dep); err != nil {
+				a.SetStatus(ui.StatusError, fmt.Sprintf("Started %d session(s); not starting the specs that depend on %s: %v", len(attempted), dep, err))
+				return false
+			}
 		}
 
-		opts := buildSessionOptions(&sessionDef, proj.File.Defaults)
-		if err := a.Client.CreateSession(ctx, spec.Name, sessionDef.Alpha, sessionDef.Beta, opts); err != nil {
-			a.SetStatus(ui.StatusError, "Failed to start "+spec.Name+": "+err.Error())
-			return
+		names := make([]string, len(wave))
+		snapshots := make([]sessionSnapshot, len(wave))
+		for k, i := range wave {
+			names[k] = proj.Specs[i].Name
+		}
+		waveErrs := runConcurrently(len(wave), func(k int) error {
+			var err error
+			snapshots[k], err = a.startProjectSpec(ctx, proj, names[k])
+			return err
+		})
+		attempted = append(attempted, names...)
+		errs = append(errs, waveErrs...)
+
+		// Later waves depend on this one, so stop if anything failed
+		failed := false
+		for k, err := range waveErrs {
+			if err != nil {
+				a.noteCreateFailure(err, snapshots[k])
+				failed = true
+			}
+		}
+		if failed {
+			a.reportFailures("Started", "start", attempted, errs)
+			return false
 		}
-		started++
 	}
 
-	if started == 0 {
+	if len(attempted) == 0 {
 		a.SetStatus(ui.StatusWarning, "All sessions already running")
 	} else {
-		a.SetStatus(ui.StatusInfo, fmt.Sprintf("Started %d session(s)", started))
+		a.SetStatus(ui.StatusInfo, fmt.Sprintf("Started %d session(s)", len(attempted)))
+	}
+	return true
+}
+
+// startProjectSpec replaces any session with the spec's name with a new
+// one created from its definition, or does so for each replica of a spec
+// with several betas. It runs concurrently with the other specs being
+// started, so it only reports errors, without setting the status. The
+// snapshot describes the session it tried to create last.
+func (a *App) startProjectSpec(ctx context.Context, proj *project.Project, name string) (sessionSnapshot, error) {
+	sessionDef := proj.File.Sessions[name]
+	var snap sessionSnapshot
+	for _, replica := range sessionDef.Replicas(name) {
+		def := sessionDef.WithBeta(replica.Beta)
+		opts := projectSessionOptions(proj, &def)
+		snap = sessionSnapshot{name: replica.Name, alpha: def.Alpha, beta: def.Beta, opts: opts}
+
+		if err := a.checkSessionEndpoints(ctx, proj, &def); err != nil {
+			return snap, err
+		}
+
+		// Terminate any existing sessions with this name to avoid duplicates
+		// (may exist from previous runs or other sources)
+		if err := a.clearSessionName(ctx, replica.Name); err != nil {
+			return snap, fmt.Errorf("failed to replace the existing session: %w", err)
+		}
+
+		// Prepare endpoint directories before creating session
+		if err := a.prepareSessionEndpoints(ctx, proj, &def); err != nil {
+			return snap, fmt.Errorf("failed to prepare endpoints: %w", err)
+		}
+
+		if err := a.Client.CreateSession(ctx, replica.Name, def.Alpha, def.Beta, opts); err != nil {
+			return snap, err
+		}
 	}
+	return snap, nil
 }
 
 // TerminateSelected terminates the selected spec or all specs in the project.
@@ -332,43 +755,155 @@ func (a *App) TerminateSelected(ctx context.Context) {
 				a.SetStatus(ui.StatusWarning, "Session not running")
 				return
 			}
-			sessionName := spec.RunningSession.Name
+			snapshots, canRecreate := snapshotSpec(a.State.Projects[projIdx], spec)
 			a.SetStatus(ui.StatusInfo, "Terminating "+spec.Name+"...")
-			if err := a.Client.TerminateSession(ctx, sessionName); err != nil {
+			if err := forEachSession(spec, func(name string) error {
+				return a.Client.TerminateSession(ctx, name)
+			}); err != nil {
 				a.SetStatus(ui.StatusError, "Failed to terminate: "+err.Error())
 				return
 			}
+			if canRecreate {
+				a.recordRecreateUndo(snapshots)
+			} else {
+				a.recordNoUndo(spec.Name + " has no definition to recreate it from")
+			}
 			a.SetStatus(ui.StatusInfo, "Terminated session: "+spec.Name)
 		}
 	} else if a.State.Selection.IsProjectSelected() {
 		projIdx := a.GetSelectedProjectIndex()
 		if projIdx >= 0 && projIdx < len(a.State.Projects) {
-			proj := a.State.Projects[projIdx]
-			a.SetStatus(ui.StatusInfo, "Terminating "+proj.File.DisplayName()+"...")
+			a.terminateProject(ctx, a.State.Projects[projIdx])
+		}
+	}
+}
+
+// terminateProject terminates the project's running sessions and sets the
+// status. Returns false if any terminate failed.
+func (a *App) terminateProject(ctx context.Context, proj *project.Project) bool {
+	if a.useProjectCommands(proj) {
+		return a.terminateProjectWithMutagen(ctx, proj)
+	}
+	a.SetStatus(ui.StatusInfo, "Terminating "+proj.File.DisplayName()+"...")
+
+	// Terminate each running session individually
+	// This handles both regular sessions and push sessions correctly
+	running := runningSpecs(proj, func(*project.SyncSpec) bool { return true })
+	errs := runConcurrently(len(running), func(k int) error {
+		return forEachSession(running[k], func(name string) error {
+			return a.Client.TerminateSession(ctx, name)
+		})
+	})
+	var snapshots []sessionSnapshot
+	for k, spec := range running {
+		if errs[k] != nil {
+			continue
+		}
+		if snaps, canRecreate := snapshotSpec(proj, spec); canRecreate {
+			snapshots = append(snapshots, snaps...)
+		}
+	}
+	a.recordRecreateUndo(snapshots)
 
-			// Terminate each running session individually
-			// This handles both regular sessions and push sessions correctly
-			terminated := 0
+	if len(running) == 0 {
+		a.SetStatus(ui.StatusWarning, "No sessions running")
+	} else if a.reportFailures("Terminated", "terminate", specNames(running), errs) > 0 {
+		return false
+	} else {
+		a.SetStatus(ui.StatusInfo, fmt.Sprintf("Terminated %d session(s)", len(succeededSessions(running, errs))))
+	}
+	return true
+}
+
+// ResetSelected resets the selected spec's session, or every running
+// session in the selected project. A reset discards mutagen's record of the
+// last synced state, so the next cycle rescans both endpoints from scratch.
+// Paused sessions are reset too.
+func (a *App) ResetSelected(ctx context.Context) {
+	if a.State.Selection.IsSpecSelected() {
+		projIdx, specIdx := a.GetSelectedSpec()
+		if projIdx >= 0 && specIdx >= 0 {
+			spec := &a.State.Projects[projIdx].Specs[specIdx]
+			if spec.RunningSession == nil {
+				a.SetStatus(ui.StatusWarning, "Session not running")
+				return
+			}
+			if err := forEachSession(spec, func(name string) error {
+				return a.Client.ResetSession(ctx, name)
+			}); err != nil {
+				a.SetStatus(ui.StatusError, "Failed to reset: "+err.Error())
+				return
+			}
+			a.recordNoUndo("A reset can't be undone: the sync history is discarded")
+			a.SetStatus(ui.StatusInfo, "Reset "+spec.Name+"; it will rescan both endpoints from scratch")
+		}
+	} else if a.State.Selection.IsProjectSelected() {
+		projIdx := a.GetSelectedProjectIndex()
+		if projIdx >= 0 && projIdx < len(a.State.Projects) {
+			proj := a.State.Projects[projIdx]
+			reset := 0
 			for i := range proj.Specs {
 				spec := &proj.Specs[i]
 				if spec.RunningSession != nil {
-					if err := a.Client.TerminateSession(ctx, spec.RunningSession.Name); err != nil {
-						a.SetStatus(ui.StatusError, "Failed to terminate "+spec.Name+": "+err.Error())
+					if err := forEachSession(spec, func(name string) error {
+						return a.Client.ResetSession(ctx, name)
+					}); err != nil {
+						a.SetStatus(ui.StatusError, "Failed to reset "+spec.Name+": "+err.Error())
 						return
 					}
-					terminated++
+					reset += len(spec.Sessions())
 				}
 			}
 
-			if terminated == 0 {
+			if reset == 0 {
 				a.SetStatus(ui.StatusWarning, "No sessions running")
 			} else {
-				a.SetStatus(ui.StatusInfo, fmt.Sprintf("Terminated %d session(s)", terminated))
+				a.recordNoUndo("A reset can't be undone: the sync history is discarded")
+				a.SetStatus(ui.StatusInfo, fmt.Sprintf("Reset %d session(s); they will rescan both endpoints from scratch", reset))
 			}
 		}
 	}
 }
 
+// DescribeRunningSelected names the running sessions of the selected spec or
+// project, which a reset or terminate would affect.
+func (a *App) DescribeRunningSelected() []string {
+	projIdx := a.GetSelectedProjectIndex()
+	if projIdx < 0 || projIdx >= len(a.State.Projects) {
+		return nil
+	}
+	proj := a.State.Projects[projIdx]
+
+	specs := proj.Specs
+	if _, specIdx := a.GetSelectedSpec(); specIdx >= 0 && specIdx < len(proj.Specs) {
+		specs = proj.Specs[specIdx : specIdx+1]
+	}
+
+	var lines []string
+	for _, spec := range specs {
+		if spec.RunningSession == nil {
+			continue
+		}
+		line := spec.Name
+		if spec.RunningSession.Paused {
+			line += " (paused)"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// TerminateSession terminates a session by identifier, whether or not it
+// belongs to a project. It is used to clean up the daemon's session list.
+func (a *App) TerminateSession(ctx context.Context, identifier, name string) {
+	if err := a.Client.TerminateSession(ctx, identifier); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to terminate "+name+": "+err.Error())
+		return
+	}
+	a.recordNoUndo("Sessions terminated from the daemon list can't be recreated")
+	a.SetStatus(ui.StatusInfo, "Terminated session: "+name)
+}
+
 // FlushSelected flushes the selected spec or all specs in the project.
 func (a *App) FlushSelected(ctx context.Context) {
 	if a.State.Selection.IsSpecSelected() {
@@ -379,18 +914,24 @@ func (a *App) FlushSelected(ctx context.Context) {
 				a.SetStatus(ui.StatusWarning, "Session not running")
 				return
 			}
-			sessionName := spec.RunningSession.Name
 			a.SetStatus(ui.StatusInfo, "Flushing "+spec.Name+"...")
-			if err := a.Client.FlushSession(ctx, sessionName); err != nil {
+			if err := forEachSession(spec, func(name string) error {
+				return a.Client.FlushSession(ctx, name)
+			}); err != nil {
 				a.SetStatus(ui.StatusError, "Failed to flush: "+err.Error())
 				return
 			}
+			a.recordNoUndo("A flush can't be undone: the synced changes are already applied")
 			a.SetStatus(ui.StatusInfo, "Flushed session: "+spec.Name)
 		}
 	} else if a.State.Selection.IsProjectSelected() {
 		projIdx := a.GetSelectedProjectIndex()
 		if projIdx >= 0 && projIdx < len(a.State.Projects) {
 			proj := a.State.Projects[projIdx]
+			if a.useProjectCommands(proj) {
+				a.flushProjectWithMutagen(ctx, proj)
+				return
+			}
 			a.SetStatus(ui.StatusInfo, "Flushing "+proj.File.DisplayName()+"...")
 
 			// Flush each running session individually
@@ -398,17 +939,20 @@ func (a *App) FlushSelected(ctx context.Context) {
 			for i := range proj.Specs {
 				spec := &proj.Specs[i]
 				if spec.RunningSession != nil {
-					if err := a.Client.FlushSession(ctx, spec.RunningSession.Name); err != nil {
+					if err := forEachSession(spec, func(name string) error {
+						return a.Client.FlushSession(ctx, name)
+					}); err != nil {
 						a.SetStatus(ui.StatusError, "Failed to flush "+spec.Name+": "+err.Error())
 						return
 					}
-					flushed++
+					flushed += len(spec.Sessions())
 				}
 			}
 
 			if flushed == 0 {
 				a.SetStatus(ui.StatusWarning, "No sessions running")
 			} else {
+				a.recordNoUndo("A flush can't be undone: the synced changes are already applied")
 				a.SetStatus(ui.StatusInfo, fmt.Sprintf("Flushed %d session(s)", flushed))
 			}
 		}
@@ -425,18 +969,24 @@ func (a *App) TogglePauseSelected(ctx context.Context) {
 				a.SetStatus(ui.StatusWarning, "Session not running")
 				return
 			}
-			sessionName := spec.RunningSession.Name
+			names := specSessionNames(spec)
 			if spec.RunningSession.Paused {
-				if err := a.Client.ResumeSession(ctx, sessionName); err != nil {
+				if err := forEachSession(spec, func(name string) error {
+					return a.Client.ResumeSession(ctx, name)
+				}); err != nil {
 					a.SetStatus(ui.StatusError, "Failed to resume: "+err.Error())
 					return
 				}
+				a.recordPauseUndo(names, false)
 				a.SetStatus(ui.StatusInfo, "Resumed session: "+spec.Name)
 			} else {
-				if err := a.Client.PauseSession(ctx, sessionName); err != nil {
+				if err := forEachSession(spec, func(name string) error {
+					return a.Client.PauseSession(ctx, name)
+				}); err != nil {
 					a.SetStatus(ui.StatusError, "Failed to pause: "+err.Error())
 					return
 				}
+				a.recordPauseUndo(names, true)
 				a.SetStatus(ui.StatusInfo, "Paused session: "+spec.Name)
 			}
 		}
@@ -453,43 +1003,113 @@ func (a *App) TogglePauseSelected(ctx context.Context) {
 				}
 			}
 
+			if a.useProjectCommands(proj) {
+				a.setProjectPausedWithMutagen(ctx, proj, hasRunning)
+				return
+			}
+
 			if hasRunning {
 				// Pause all running sessions individually
-				paused := 0
-				for i := range proj.Specs {
-					spec := &proj.Specs[i]
-					if spec.RunningSession != nil && !spec.RunningSession.Paused {
-						if err := a.Client.PauseSession(ctx, spec.RunningSession.Name); err != nil {
-							a.SetStatus(ui.StatusError, "Failed to pause "+spec.Name+": "+err.Error())
-							return
-						}
-						paused++
-					}
+				targets := runningSpecs(proj, func(spec *project.SyncSpec) bool { return !spec.RunningSession.Paused })
+				errs := runConcurrently(len(targets), func(k int) error {
+					return forEachSession(targets[k], func(name string) error {
+						return a.Client.PauseSession(ctx, name)
+					})
+				})
+				paused := succeededSessions(targets, errs)
+				a.recordPauseUndo(paused, true)
+				if a.reportFailures("Paused", "pause", specNames(targets), errs) == 0 {
+					a.SetStatus(ui.StatusInfo, fmt.Sprintf("Paused %d session(s)", len(paused)))
 				}
-				a.SetStatus(ui.StatusInfo, fmt.Sprintf("Paused %d session(s)", paused))
 			} else {
 				// Resume all paused sessions individually
-				resumed := 0
-				for i := range proj.Specs {
-					spec := &proj.Specs[i]
-					if spec.RunningSession != nil && spec.RunningSession.Paused {
-						if err := a.Client.ResumeSession(ctx, spec.RunningSession.Name); err != nil {
-							a.SetStatus(ui.StatusError, "Failed to resume "+spec.Name+": "+err.Error())
-							return
-						}
-						resumed++
-					}
-				}
-				if resumed == 0 {
+				targets := runningSpecs(proj, func(spec *project.SyncSpec) bool { return spec.RunningSession.Paused })
+				if len(targets) == 0 {
 					a.SetStatus(ui.StatusWarning, "No sessions to resume")
-				} else {
-					a.SetStatus(ui.StatusInfo, fmt.Sprintf("Resumed %d session(s)", resumed))
+					return
+				}
+				errs := runConcurrently(len(targets), func(k int) error {
+					return forEachSession(targets[k], func(name string) error {
+						return a.Client.ResumeSession(ctx, name)
+					})
+				})
+				resumed := succeededSessions(targets, errs)
+				a.recordPauseUndo(resumed, false)
+				if a.reportFailures("Resumed", "resume", specNames(targets), errs) == 0 {
+					a.SetStatus(ui.StatusInfo, fmt.Sprintf("Resumed %d session(s)", len(resumed)))
 				}
 			}
 		}
 	}
 }
 
+// TogglePauseAll is a master switch for every project's sessions: if any
+// session is running unpaused, it pauses all running sessions; otherwise it
+// resumes all paused ones. A failure on one session doesn't stop the rest,
+// and the status reports how many succeeded and which failed.
+func (a *App) TogglePauseAll(ctx context.Context) {
+	var specs []*project.SyncSpec
+	for _, proj := range a.State.Projects {
+		for i := range proj.Specs {
+			specs = append(specs, &proj.Specs[i])
+		}
+	}
+	a.togglePause(ctx, specs, true)
+}
+
+// TogglePauseMarked pauses or resumes the specs marked in the list, in the
+// same way that TogglePauseAll does for every spec.
+func (a *App) TogglePauseMarked(ctx context.Context, specs []*project.SyncSpec) {
+	a.togglePause(ctx, specs, false)
+}
+
+// togglePause pauses the running specs if any are unpaused, and otherwise
+// resumes the paused ones. All says whether specs is every spec, which the
+// status mentions.
+func (a *App) togglePause(ctx context.Context, specs []*project.SyncSpec, all bool) {
+	var running, paused []*project.SyncSpec
+	for _, spec := range specs {
+		if spec.RunningSession == nil {
+			continue
+		}
+		if spec.RunningSession.Paused {
+			paused = append(paused, spec)
+		} else {
+			running = append(running, spec)
+		}
+	}
+
+	pausing := len(running) > 0
+	targets, action, verb := paused, "resume", "Resumed"
+	if pausing {
+		targets, action, verb = running, "pause", "Paused"
+	}
+	if len(targets) == 0 {
+		a.SetStatus(ui.StatusWarning, "No running sessions")
+		return
+	}
+
+	errs := runConcurrently(len(targets), func(k int) error {
+		return forEachSession(targets[k], func(name string) error {
+			if pausing {
+				return a.Client.PauseSession(ctx, name)
+			}
+			return a.Client.ResumeSession(ctx, name)
+		})
+	})
+	done := succeededSessions(targets, errs)
+	a.recordPauseUndo(done, pausing)
+
+	if a.reportFailures(verb, action, specNames(targets), errs) > 0 {
+		return
+	}
+	if all {
+		a.SetStatus(ui.StatusInfo, fmt.Sprintf("%s all %d session(s)", verb, len(done)))
+	} else {
+		a.SetStatus(ui.StatusInfo, fmt.Sprintf("%s %d marked session(s)", verb, len(done)))
+	}
+}
+
 // ResumeSelected resumes the selected spec or all specs in the project.
 func (a *App) ResumeSelected(ctx context.Context) {
 	if a.State.Selection.IsSpecSelected() {
@@ -500,33 +1120,46 @@ func (a *App) ResumeSelected(ctx context.Context) {
 				a.SetStatus(ui.StatusWarning, "Session not running")
 				return
 			}
-			sessionName := spec.RunningSession.Name
-			if err := a.Client.ResumeSession(ctx, sessionName); err != nil {
+			wasPaused := spec.RunningSession.Paused
+			if err := forEachSession(spec, func(name string) error {
+				return a.Client.ResumeSession(ctx, name)
+			}); err != nil {
 				a.SetStatus(ui.StatusError, "Failed to resume: "+err.Error())
 				return
 			}
+			if wasPaused {
+				a.recordPauseUndo(specSessionNames(spec), false)
+			}
 			a.SetStatus(ui.StatusInfo, "Resumed session: "+spec.Name)
 		}
 	} else if a.State.Selection.IsProjectSelected() {
 		projIdx := a.GetSelectedProjectIndex()
 		if projIdx >= 0 && projIdx < len(a.State.Projects) {
 			proj := a.State.Projects[projIdx]
-			resumed := 0
-			for i := range proj.Specs {
-				spec := &proj.Specs[i]
-				if spec.RunningSession != nil {
-					if err := a.Client.ResumeSession(ctx, spec.RunningSession.Name); err != nil {
-						a.SetStatus(ui.StatusError, "Failed to resume "+spec.Name+": "+err.Error())
-						return
-					}
-					resumed++
+			if a.useProjectCommands(proj) {
+				a.setProjectPausedWithMutagen(ctx, proj, false)
+				return
+			}
+			running := runningSpecs(proj, func(*project.SyncSpec) bool { return true })
+			if len(running) == 0 {
+				a.SetStatus(ui.StatusWarning, "No sessions to resume")
+				return
+			}
+			errs := runConcurrently(len(running), func(k int) error {
+				return forEachSession(running[k], func(name string) error {
+					return a.Client.ResumeSession(ctx, name)
+				})
+			})
+			var wasPaused []string
+			for k, spec := range running {
+				if errs[k] == nil && spec.RunningSession.Paused {
+					wasPaused = append(wasPaused, specSessionNames(spec)...)
 				}
 			}
+			a.recordPauseUndo(wasPaused, false)
 
-			if resumed == 0 {
-				a.SetStatus(ui.StatusWarning, "No sessions to resume")
-			} else {
-				a.SetStatus(ui.StatusInfo, fmt.Sprintf("Resumed %d session(s)", resumed))
+			if a.reportFailures("Resumed", "resume", specNames(running), errs) == 0 {
+				a.SetStatus(ui.StatusInfo, fmt.Sprintf("Resumed %d session(s)", len(running)))
 			}
 		}
 	}
@@ -550,27 +1183,81 @@ func (a *App) PushSelectedSpec(ctx context.Context) {
 		return
 	}
 
-	// Terminate any existing sessions with this name to avoid duplicates
-	// (handles both running sessions and stray duplicates)
-	_ = a.Client.TerminateSession(ctx, spec.Name)
+	// A spec with several betas pushes to each
+	for _, replica := range sessionDef.Replicas(spec.Name) {
+		def := sessionDef.WithBeta(replica.Beta)
 
-	// Prepare endpoint directories before creating session
-	if err := prepareEndpoints(ctx, sessionDef.Alpha, sessionDef.Beta); err != nil {
-		a.SetStatus(ui.StatusError, "Failed to prepare endpoints: "+err.Error())
-		return
-	}
+		// Check the endpoints before the two-way session is replaced
+		if err := a.checkSessionEndpoints(ctx, proj, &def); err != nil {
+			a.SetStatus(ui.StatusError, "Not pushing "+replica.Name+": "+err.Error())
+			return
+		}
 
-	// Build session options from session definition and project defaults
-	opts := buildSessionOptions(&sessionDef, proj.File.Defaults)
+		// Terminate any existing sessions with this name to avoid duplicates
+		// (handles both running sessions and stray duplicates)
+		if err := a.clearSessionName(ctx, replica.Name); err != nil {
+			a.SetStatus(ui.StatusError, "Failed to replace "+replica.Name+": "+err.Error())
+			return
+		}
 
-	err := a.Client.CreatePushSession(ctx, spec.Name, sessionDef.Alpha, sessionDef.Beta, opts)
-	if err != nil {
-		a.SetStatus(ui.StatusError, "Failed to create push session: "+err.Error())
-		return
+		// Prepare endpoint directories before creating session
+		if err := a.prepareSessionEndpoints(ctx, proj, &def); err != nil {
+			a.SetStatus(ui.StatusError, "Failed to prepare endpoints: "+err.Error())
+			return
+		}
+
+		// Build session options from session definition and project defaults
+		opts := projectSessionOptions(proj, &def)
+
+		err := a.Client.CreatePushSession(ctx, replica.Name, def.Alpha, def.Beta, opts)
+		if err != nil {
+			a.SetStatus(ui.StatusError, "Failed to create push session: "+err.Error())
+			return
+		}
 	}
+	a.recordNoUndo("A push can't be undone: files on beta were overwritten")
 	a.SetStatus(ui.StatusInfo, "Created push session: "+spec.Name)
 }
 
+// DescribePushSelected explains, in plain language, what creating push
+// sessions for the selection would do: one line per spec naming the source
+// and the target that will be overwritten. With push previews on, each is
+// followed by the settings the session would be created with.
+func (a *App) DescribePushSelected() []string {
+	projIdx := a.GetSelectedProjectIndex()
+	if projIdx < 0 || projIdx >= len(a.State.Projects) {
+		return nil
+	}
+	proj := a.State.Projects[projIdx]
+
+	specs := proj.Specs
+	if _, specIdx := a.GetSelectedSpec(); specIdx >= 0 && specIdx < len(proj.Specs) {
+		specs = proj.Specs[specIdx : specIdx+1]
+	}
+
+	var lines []string
+	for _, spec := range specs {
+		def, exists := proj.File.Sessions[spec.Name]
+		if !exists {
+			continue
+		}
+		for _, replica := range def.Replicas(spec.Name) {
+			line := fmt.Sprintf("%s: copying FROM %s TO %s, overwriting %s", replica.Name, def.Alpha, replica.Beta, replica.Beta)
+			if !project.ParseEndpoint(def.Alpha).IsLocal() && project.ParseEndpoint(replica.Beta).IsLocal() {
+				line += " (remote → local)"
+			}
+			lines = append(lines, line)
+			if a.Config.Confirmations.PushPreview {
+				replicaDef := def.WithBeta(replica.Beta)
+				for _, option := range describePushOptions(projectSessionOptions(proj, &replicaDef)) {
+					lines = append(lines, "    "+option)
+				}
+			}
+		}
+	}
+	return lines
+}
+
 // PushSelectedProject creates push sessions for all specs in the selected project.
 func (a *App) PushSelectedProject(ctx context.Context) {
 	projIdx := a.GetSelectedProjectIndex()
@@ -580,10 +1267,28 @@ func (a *App) PushSelectedProject(ctx context.Context) {
 	}
 
 	proj := a.State.Projects[projIdx]
+	if a.rejectOrphaned(proj) {
+		return
+	}
 	a.SetStatus(ui.StatusInfo, "Creating push sessions for "+proj.File.DisplayName()+"...")
 
+	// Check every replica's endpoints before any session is terminated, so
+	// that one unreachable host doesn't leave the whole project stopped
+	for _, spec := range proj.Specs {
+		def, exists := proj.File.Sessions[spec.Name]
+		if !exists {
+			continue
+		}
+		if err := a.checkSessionEndpoints(ctx, proj, &def); err != nil {
+			a.SetStatus(ui.StatusError, "Not pushing "+spec.Name+": "+err.Error())
+			return
+		}
+	}
+
 	// Terminate all existing sessions first (project-level and by name to catch strays)
 	_ = a.Client.ProjectTerminate(ctx, proj.File.Path)
+	// From here on, even a failed push has replaced sessions
+	a.recordNoUndo("A push can't be undone: files on beta were overwritten")
 
 	for _, spec := range proj.Specs {
 		sessionDef, exists := proj.File.Sessions[spec.Name]
@@ -591,21 +1296,28 @@ func (a *App) PushSelectedProject(ctx context.Context) {
 			continue
 		}
 
-		// Terminate any stray sessions with this name
-		_ = a.Client.TerminateSession(ctx, spec.Name)
+		for _, replica := range sessionDef.Replicas(spec.Name) {
+			def := sessionDef.WithBeta(replica.Beta)
 
-		// Prepare endpoint directories before creating session
-		if err := prepareEndpoints(ctx, sessionDef.Alpha, sessionDef.Beta); err != nil {
-			a.SetStatus(ui.StatusError, "Failed to prepare endpoints for "+spec.Name+": "+err.Error())
-			return
-		}
+			// Terminate any stray sessions with this name
+			if err := a.clearSessionName(ctx, replica.Name); err != nil {
+				a.SetStatus(ui.StatusError, "Failed to replace "+replica.Name+": "+err.Error())
+				return
+			}
 
-		// Build session options from session definition and project defaults
-		opts := buildSessionOptions(&sessionDef, proj.File.Defaults)
+			// Prepare endpoint directories before creating session
+			if err := a.prepareSessionEndpoints(ctx, proj, &def); err != nil {
+				a.SetStatus(ui.StatusError, "Failed to prepare endpoints for "+replica.Name+": "+err.Error())
+				return
+			}
 
-		if err := a.Client.CreatePushSession(ctx, spec.Name, sessionDef.Alpha, sessionDef.Beta, opts); err != nil {
-			a.SetStatus(ui.StatusError, "Failed to create push session for "+spec.Name+": "+err.Error())
-			return
+			// Build session options from session definition and project defaults
+			opts := projectSessionOptions(proj, &def)
+
+			if err := a.Client.CreatePushSession(ctx, replica.Name, def.Alpha, def.Beta, opts); err != nil {
+				a.SetStatus(ui.StatusError, "Failed to create push session for "+replica.Name+": "+err.Error())
+				return
+			}
 		}
 	}
 	a.SetStatus(ui.StatusInfo, "Created push sessions for all specs in project")
@@ -634,8 +1346,7 @@ func (a *App) PushConflictsToBeta(ctx context.Context) {
 	proj := a.State.Projects[projIdx]
 	pushed := 0
 	for i := range proj.Specs {
-		spec := &proj.Specs[i]
-		if spec.RunningSession != nil && spec.RunningSession.HasConflicts() {
+		if specHasConflicts(&proj.Specs[i]) {
 			a.pushSpecConflictsToBeta(ctx, projIdx, i)
 			pushed++
 		}
@@ -648,7 +1359,8 @@ func (a *App) PushConflictsToBeta(ctx context.Context) {
 	}
 }
 
-// pushSpecConflictsToBeta handles pushing a single spec's conflicts to beta.
+// pushSpecConflictsToBeta handles pushing a single spec's conflicts to beta,
+// or to each beta of a spec with several.
 func (a *App) pushSpecConflictsToBeta(ctx context.Context, projIdx, specIdx int) {
 	proj := a.State.Projects[projIdx]
 	spec := &proj.Specs[specIdx]
@@ -658,25 +1370,37 @@ func (a *App) pushSpecConflictsToBeta(ctx context.Context, projIdx, specIdx int)
 		return
 	}
 
-	// Terminate any existing sessions with this name to avoid duplicates
-	_ = a.Client.TerminateSession(ctx, spec.Name)
+	for _, replica := range sessionDef.Replicas(spec.Name) {
+		def := sessionDef.WithBeta(replica.Beta)
 
-	// Prepare endpoint directories
-	if err := prepareEndpoints(ctx, sessionDef.Alpha, sessionDef.Beta); err != nil {
-		a.SetStatus(ui.StatusError, "Failed to prepare endpoints: "+err.Error())
-		return
-	}
+		// Terminate any existing sessions with this name to avoid duplicates
+		if err := a.clearSessionName(ctx, replica.Name); err != nil {
+			a.SetStatus(ui.StatusError, "Failed to replace "+replica.Name+": "+err.Error())
+			return
+		}
 
-	// Build session options from session definition and project defaults
-	opts := buildSessionOptions(&sessionDef, proj.File.Defaults)
+		// Prepare endpoint directories
+		if err := a.prepareSessionEndpoints(ctx, proj, &def); err != nil {
+			a.SetStatus(ui.StatusError, "Failed to prepare endpoints: "+err.Error())
+			return
+		}
 
-	// Create a one-way push session to overwrite beta with alpha
-	if err := a.Client.CreatePushSession(ctx, spec.Name, sessionDef.Alpha, sessionDef.Beta, opts); err != nil {
-		a.SetStatus(ui.StatusError, "Failed to create push session: "+err.Error())
-		return
+		// Build session options from session definition and project defaults
+		opts := projectSessionOptions(proj, &def)
+
+		// Create a one-way push session to overwrite beta with alpha
+		if err := a.Client.CreatePushSession(ctx, replica.Name, def.Alpha, def.Beta, opts); err != nil {
+			a.SetStatus(ui.StatusError, "Failed to create push session: "+err.Error())
+			return
+		}
 	}
 }
 
+// specHasConflicts returns true if any of a spec's sessions has conflicts.
+func specHasConflicts(spec *project.SyncSpec) bool {
+	return slices.ContainsFunc(spec.Sessions(), (*mutagen.SyncSession).HasConflicts)
+}
+
 // PullConflictsToAlpha resolves conflicts by pulling beta changes to alpha.
 // This terminates the existing session and creates a one-way pull session.
 // Works for both spec-level and project-level selections.
@@ -699,17 +1423,29 @@ func (a *App) PullConflictsToAlpha(ctx context.Context) {
 	// Project selected - pull all specs with conflicts
 	proj := a.State.Projects[projIdx]
 	pulled := 0
+	var skipped []string
 	for i := range proj.Specs {
 		spec := &proj.Specs[i]
-		if spec.RunningSession != nil && spec.RunningSession.HasConflicts() {
-			a.pullSpecConflictsToAlpha(ctx, projIdx, i)
-			pulled++
+		if !specHasConflicts(spec) {
+			continue
+		}
+		// A spec with several betas has more than one to pull from
+		if def := proj.File.Sessions[spec.Name]; def.IsMultiBeta() {
+			skipped = append(skipped, spec.Name)
+			continue
 		}
+		a.pullSpecConflictsToAlpha(ctx, projIdx, i)
+		pulled++
 	}
 
-	if pulled == 0 {
+	switch {
+	case pulled == 0 && len(skipped) > 0:
+		a.SetStatus(ui.StatusWarning, "Can't pull "+strings.Join(skipped, ", ")+": several betas to pull from")
+	case pulled == 0:
 		a.SetStatus(ui.StatusWarning, "No conflicts to resolve")
-	} else {
+	case len(skipped) > 0:
+		a.SetStatus(ui.StatusWarning, fmt.Sprintf("Created pull sessions for %d spec(s); skipped %s: several betas to pull from", pulled, strings.Join(skipped, ", ")))
+	default:
 		a.SetStatus(ui.StatusInfo, fmt.Sprintf("Created pull sessions for %d spec(s)", pulled))
 	}
 }
@@ -723,18 +1459,26 @@ func (a *App) pullSpecConflictsToAlpha(ctx context.Context, projIdx, specIdx int
 		a.SetStatus(ui.StatusError, "Session definition not found for "+spec.Name)
 		return
 	}
+	// Its betas may differ, and alpha can only be overwritten with one
+	if sessionDef.IsMultiBeta() {
+		a.SetStatus(ui.StatusWarning, "Can't pull "+spec.Name+": it has several betas to pull from; resolve its conflicts one by one")
+		return
+	}
 
 	// Terminate any existing sessions with this name to avoid duplicates
-	_ = a.Client.TerminateSession(ctx, spec.Name)
+	if err := a.clearSessionName(ctx, spec.Name); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to replace "+spec.Name+": "+err.Error())
+		return
+	}
 
 	// Prepare endpoint directories
-	if err := prepareEndpoints(ctx, sessionDef.Alpha, sessionDef.Beta); err != nil {
+	if err := a.prepareSessionEndpoints(ctx, proj, &sessionDef); err != nil {
 		a.SetStatus(ui.StatusError, "Failed to prepare endpoints: "+err.Error())
 		return
 	}
 
 	// Build session options from session definition and project defaults
-	opts := buildSessionOptions(&sessionDef, proj.File.Defaults)
+	opts := projectSessionOptions(proj, &sessionDef)
 
 	// Create a one-way pull session to overwrite alpha with beta
 	// Note: For pull, we swap alpha and beta in the CreatePushSession call
@@ -745,6 +1489,22 @@ func (a *App) pullSpecConflictsToAlpha(ctx context.Context, projIdx, specIdx int
 	}
 }
 
+// ResolveConflict resolves one conflict of session in favor of winner
+// (mutagen.WinnerAlpha or mutagen.WinnerBeta) by removing the file from the
+// other endpoint, leaving the rest of the session's conflicts alone.
+func (a *App) ResolveConflict(ctx context.Context, session *mutagen.SyncSession, conflict mutagen.Conflict, winner string) {
+	if session == nil {
+		a.SetStatus(ui.StatusWarning, "No session to resolve")
+		return
+	}
+	if err := a.Client.ResolveConflict(ctx, session, conflict, winner); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to resolve "+conflict.Root+": "+err.Error())
+		return
+	}
+	a.recordNoUndo("A resolved conflict can't be undone: the other version was deleted")
+	a.SetStatus(ui.StatusInfo, "Kept the "+winner+" version of "+conflict.Root)
+}
+
 // GetEditor returns the configured editor from environment variables.
 func GetEditor() string {
 	if editor := os.Getenv("VISUAL"); editor != "" {
@@ -814,6 +1574,9 @@ func (a *App) OpenEditor(projIdx int) error {
 	}
 
 	proj := a.State.Projects[projIdx]
+	if a.rejectOrphaned(proj) {
+		return nil
+	}
 	editor := GetEditor()
 	filePath := proj.File.Path
 
@@ -827,6 +1590,14 @@ func (a *App) OpenEditor(projIdx int) error {
 	editorProgram := editorParts[0]
 	editorArgs := append(editorParts[1:], filePath)
 
+	// Check before launching: a GUI editor would otherwise fail with a
+	// confusing error, and a terminal editor after the TUI is suspended
+	if _, err := exec.LookPath(editorProgram); err != nil {
+		err = fmt.Errorf("editor '%s' not found in PATH", editorProgram)
+		a.SetStatus(ui.StatusError, err.Error()+" (set $VISUAL or $EDITOR)")
+		return err
+	}
+
 	if IsGUIEditor(editorProgram) {
 		// GUI editor - spawn detached
 		cmd := exec.Command(editorProgram, editorArgs...)
@@ -857,6 +1628,11 @@ func (e *terminalEditorError) Error() string {
 	return "terminal editor requested"
 }
 
+// Is lets errors.Is match the UI's sentinel, which tells it to suspend.
+func (e *terminalEditorError) Is(target error) bool {
+	return target == ui.ErrTerminalEditor
+}
+
 // IsTerminalEditorError checks if an error indicates a terminal editor is needed.
 func IsTerminalEditorError(err error) bool {
 	_, ok := err.(*terminalEditorError)
@@ -914,81 +1690,46 @@ func parseEditorCommand(cmd string) []string {
 	return parts
 }
 
-// buildSessionOptions creates SessionOptions from a SessionDefinition and project defaults.
-func buildSessionOptions(def *project.SessionDefinition, defaults *project.DefaultConfig) *mutagen.SessionOptions {
-	opts := &mutagen.SessionOptions{}
-
-	// Apply mode from definition
-	if def.Mode != nil {
-		opts.Mode = *def.Mode
-	}
-
-	// Apply ignore patterns - merge defaults and definition
-	if defaults != nil && defaults.Ignore != nil && defaults.Ignore.Paths != nil {
-		opts.Ignore = append(opts.Ignore, defaults.Ignore.Paths...)
-	}
-	if def.Ignore != nil && def.Ignore.Paths != nil {
-		opts.Ignore = append(opts.Ignore, def.Ignore.Paths...)
-	}
-
-	// Apply ignore VCS setting - definition overrides defaults
-	if def.Ignore != nil && def.Ignore.VCS != nil {
-		opts.IgnoreVCS = def.Ignore.VCS
-	} else if defaults != nil && defaults.Ignore != nil && defaults.Ignore.VCS != nil {
-		opts.IgnoreVCS = defaults.Ignore.VCS
-	}
-
-	// Apply symlink mode from Extra field
-	if def.Extra != nil {
-		if symlink, ok := def.Extra["symlink"].(map[string]interface{}); ok {
-			if mode, ok := symlink["mode"].(string); ok {
-				opts.SymlinkMode = mode
-			}
-		}
+// projectSessionOptions builds the options for creating one of a project's
+// sessions, labelling it so it can be recognized as created by mutagui.
+func projectSessionOptions(proj *project.Project, def *project.SessionDefinition) *mutagen.SessionOptions {
+	opts := buildSessionOptions(def, proj.File.Defaults)
+	if value := mutagen.LabelValue(proj.File.DisplayName()); value != "" {
+		opts.Labels = map[string]string{mutagen.ProjectLabel: value}
 	}
-
 	return opts
 }
 
-// endpointType represents the type of a mutagen endpoint.
-type endpointType int
-
-const (
-	endpointLocal  endpointType = iota // Local filesystem path
-	endpointSSH                        // SSH remote (host:path or user@host:path)
-	endpointScheme                     // URL-style scheme (docker://, kubernetes://, etc.)
-)
-
-// parseEndpoint parses a mutagen endpoint string and returns its type, host, and path.
-// URL-style schemes (docker://, kubernetes://) return endpointScheme.
-// SSH endpoints (host:path) return endpointSSH with host and path.
-// Local paths return endpointLocal with empty host.
-func parseEndpoint(endpoint string) (epType endpointType, host, path string) {
-	// Check for URL-style scheme (e.g., docker://container/path, kubernetes://namespace/pod:path)
-	if strings.Contains(endpoint, "://") {
-		return endpointScheme, "", endpoint
-	}
-
-	// Check for SSH-style remote endpoint (contains : but not Windows drive letter like C:)
-	colonIdx := strings.Index(endpoint, ":")
-	if colonIdx > 1 { // More than one char before colon (not a Windows drive)
-		return endpointSSH, endpoint[:colonIdx], endpoint[colonIdx+1:]
+// buildSessionOptions creates SessionOptions from a SessionDefinition and
+// project defaults, merged by project.ResolveSessionConfig.
+func buildSessionOptions(def *project.SessionDefinition, defaults *project.DefaultConfig) *mutagen.SessionOptions {
+	resolved := project.ResolveSessionConfig(def, defaults)
+	return &mutagen.SessionOptions{
+		Mode:                 resolved.Mode,
+		Ignore:               resolved.IgnorePaths(),
+		IgnoreVCS:            resolved.IgnoreVCS,
+		SymlinkMode:          resolved.Symlink.Mode,
+		WatchMode:            resolved.Watch.Mode,
+		WatchPollingInterval: resolved.Watch.PollingInterval,
+		PermissionsMode:      resolved.Permissions.Mode,
+		DefaultFileMode:      resolved.Permissions.DefaultFileMode,
+		DefaultDirectoryMode: resolved.Permissions.DefaultDirectoryMode,
+		DefaultOwner:         resolved.Permissions.DefaultOwner,
+		DefaultGroup:         resolved.Permissions.DefaultGroup,
 	}
-
-	// Local path
-	return endpointLocal, "", endpoint
-}
-
-// isSSHEndpoint returns true if the endpoint is an SSH remote (host:path).
-func isSSHEndpoint(endpoint string) bool {
-	epType, _, _ := parseEndpoint(endpoint)
-	return epType == endpointSSH
 }
 
-// isLocalEndpoint returns true if the endpoint is a local filesystem path.
-func isLocalEndpoint(endpoint string) bool {
-	epType, _, _ := parseEndpoint(endpoint)
-	return epType == endpointLocal
+// clearSessionName terminates any session with the given name so that a
+// new one can be created under it, waiting until the daemon has removed it.
+// It is not an error if no such session exists.
+func (a *App) clearSessionName(ctx context.Context, name string) error {
+	if err := a.Client.TerminateSession(ctx, name); err != nil {
+		return nil // Nothing to terminate
+	}
+	if a.DryRun {
+		return nil // The terminate was only reported, so the session stays listed
+	}
+	return a.Client.WaitForTerminated(ctx, name)
 }
 
 // ensureLocalDirectory creates the local directory if it doesn't exist.
@@ -1019,18 +1760,18 @@ func prepareRemoteDirectory(ctx context.Context, host, path string) error {
 // prepareEndpoint prepares a single endpoint directory if applicable.
 // Returns nil for URL-style schemes (docker://, kubernetes://) which are handled by Mutagen.
 func prepareEndpoint(ctx context.Context, endpoint, label string) error {
-	epType, host, path := parseEndpoint(endpoint)
+	e := project.ParseEndpoint(endpoint)
 
-	switch epType {
-	case endpointLocal:
-		if err := ensureLocalDirectory(path); err != nil {
+	switch {
+	case e.IsLocal():
+		if err := ensureLocalDirectory(e.Path); err != nil {
 			return fmt.Errorf("failed to prepare %s endpoint: %w", label, err)
 		}
-	case endpointSSH:
-		if err := prepareRemoteDirectory(ctx, host, path); err != nil {
+	case e.IsSSH():
+		if err := prepareRemoteDirectory(ctx, e.SSHDestination(), e.Path); err != nil {
 			return fmt.Errorf("failed to prepare %s endpoint: %w", label, err)
 		}
-	case endpointScheme:
+	default:
 		// URL-style schemes (docker://, kubernetes://, etc.) are handled by Mutagen
 		// Skip directory preparation for these endpoints
 	}
@@ -1038,6 +1779,21 @@ func prepareEndpoint(ctx context.Context, endpoint, label string) error {
 	return nil
 }
 
+// prepareSessionEndpoints prepares endpoint directories unless in dry-run mode,
+// where the preparation is only logged. It refuses a definition whose alpha
+// and beta are the same location, however they are spelled.
+func (a *App) prepareSessionEndpoints(ctx context.Context, proj *project.Project, def *project.SessionDefinition) error {
+	alpha, beta := def.Alpha, def.Beta
+	if project.SameEndpoint(alpha, beta, proj.File.Dir()) {
+		return fmt.Errorf("alpha and beta are the same location (%s)", project.NormalizeEndpoint(alpha, proj.File.Dir()))
+	}
+	if a.DryRun {
+		a.LogEvent(ui.StatusInfo, "[dry-run] would prepare endpoints "+alpha+" and "+beta)
+		return nil
+	}
+	return prepareEndpoints(ctx, alpha, beta)
+}
+
 // prepareEndpoints ensures both alpha and beta directories exist before creating a session.
 // Skips preparation for URL-style endpoints (docker://, kubernetes://) which are handled by Mutagen.
 func prepareEndpoints(ctx context.Context, alpha, beta string) error {
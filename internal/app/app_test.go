@@ -3,135 +3,58 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/osteele/mutagui/internal/clock"
 	"github.com/osteele/mutagui/internal/config"
 	"github.com/osteele/mutagui/internal/mutagen"
 	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/state"
 	"github.com/osteele/mutagui/internal/ui"
 )
 
-func TestParseEndpoint(t *testing.T) {
-	tests := []struct {
-		name     string
-		endpoint string
-		wantType endpointType
-		wantHost string
-		wantPath string
-	}{
-		{
-			name:     "local absolute path",
-			endpoint: "/home/user/project",
-			wantType: endpointLocal,
-			wantHost: "",
-			wantPath: "/home/user/project",
-		},
-		{
-			name:     "local relative path",
-			endpoint: "project/src",
-			wantType: endpointLocal,
-			wantHost: "",
-			wantPath: "project/src",
-		},
-		{
-			name:     "local home path",
-			endpoint: "~/projects",
-			wantType: endpointLocal,
-			wantHost: "",
-			wantPath: "~/projects",
-		},
-		{
-			name:     "ssh endpoint",
-			endpoint: "server:/path/to/dir",
-			wantType: endpointSSH,
-			wantHost: "server",
-			wantPath: "/path/to/dir",
-		},
-		{
-			name:     "ssh with user",
-			endpoint: "user@server:/path/to/dir",
-			wantType: endpointSSH,
-			wantHost: "user@server",
-			wantPath: "/path/to/dir",
-		},
-		{
-			name:     "docker scheme",
-			endpoint: "docker://container/path",
-			wantType: endpointScheme,
-			wantHost: "",
-			wantPath: "docker://container/path",
-		},
-		{
-			name:     "kubernetes scheme",
-			endpoint: "kubernetes://namespace/pod:container/path",
-			wantType: endpointScheme,
-			wantHost: "",
-			wantPath: "kubernetes://namespace/pod:container/path",
-		},
-		{
-			name:     "windows drive letter (local)",
-			endpoint: "C:/Users/test",
-			wantType: endpointLocal,
-			wantHost: "",
-			wantPath: "C:/Users/test",
-		},
-	}
+func TestClientTimeouts(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Client.CreateTimeoutSecs = 300
+	cfg.Client.MutateTimeoutSecs = 0
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			epType, host, path := parseEndpoint(tt.endpoint)
-			if epType != tt.wantType {
-				t.Errorf("parseEndpoint() type = %v, want %v", epType, tt.wantType)
-			}
-			if host != tt.wantHost {
-				t.Errorf("parseEndpoint() host = %q, want %q", host, tt.wantHost)
-			}
-			if path != tt.wantPath {
-				t.Errorf("parseEndpoint() path = %q, want %q", path, tt.wantPath)
-			}
-		})
+	got := ClientTimeouts(cfg)
+	want := mutagen.Timeouts{List: 30 * time.Second, Create: 5 * time.Minute, Mutate: 30 * time.Second}
+	if got != want {
+		t.Errorf("ClientTimeouts() = %+v, want %+v", got, want)
 	}
 }
 
-func TestIsSSHEndpoint(t *testing.T) {
-	tests := []struct {
-		endpoint string
-		want     bool
-	}{
-		{"server:/path", true},
-		{"user@server:/path", true},
-		{"/local/path", false},
-		{"docker://container/path", false},
-		{"C:/Windows/path", false},
+func TestMutagenBinary(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.endpoint, func(t *testing.T) {
-			if got := isSSHEndpoint(tt.endpoint); got != tt.want {
-				t.Errorf("isSSHEndpoint(%q) = %v, want %v", tt.endpoint, got, tt.want)
-			}
-		})
+	cfg := config.DefaultConfig()
+	t.Setenv("MUTAGUI_MUTAGEN_BIN", "")
+	if got := MutagenBinary(cfg); got != "mutagen" {
+		t.Errorf("MutagenBinary() = %q, want mutagen by default", got)
 	}
-}
 
-func TestIsLocalEndpoint(t *testing.T) {
-	tests := []struct {
-		endpoint string
-		want     bool
-	}{
-		{"/local/path", true},
-		{"~/path", true},
-		{"relative/path", true},
-		{"server:/path", false},
-		{"docker://container", false},
+	cfg.Client.Binary = "~/.nix-profile/bin/mutagen"
+	if got, want := MutagenBinary(cfg), filepath.Join(home, ".nix-profile/bin/mutagen"); got != want {
+		t.Errorf("MutagenBinary() = %q, want %q", got, want)
+	}
+	if client := NewMutagenClient(cfg, mutagen.ExecRunner{}); client.Binary() != filepath.Join(home, ".nix-profile/bin/mutagen") {
+		t.Errorf("client runs %q, want the configured binary", client.Binary())
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.endpoint, func(t *testing.T) {
-			if got := isLocalEndpoint(tt.endpoint); got != tt.want {
-				t.Errorf("isLocalEndpoint(%q) = %v, want %v", tt.endpoint, got, tt.want)
-			}
-		})
+	t.Setenv("MUTAGUI_MUTAGEN_BIN", "/opt/homebrew/bin/mutagen")
+	if got := MutagenBinary(cfg); got != "/opt/homebrew/bin/mutagen" {
+		t.Errorf("MutagenBinary() = %q, want the environment to win", got)
 	}
 }
 
@@ -242,6 +165,29 @@ func TestGetEditor(t *testing.T) {
 	})
 }
 
+func TestOpenEditor_MissingEditor(t *testing.T) {
+	t.Setenv("VISUAL", "mutagui-no-such-editor --wait")
+	app := newTestApp(&MockClient{})
+	app.State.Projects = []*project.Project{createTestProjectWithFile("test-proj", []string{"spec-a"})}
+
+	err := app.OpenEditor(0)
+	if err == nil || err.Error() != "editor 'mutagui-no-such-editor' not found in PATH" {
+		t.Fatalf("OpenEditor() error = %v, want not found in PATH", err)
+	}
+	if IsTerminalEditorError(err) {
+		t.Error("a missing editor should not suspend the TUI for a terminal editor")
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Type != ui.StatusError {
+		t.Errorf("status = %+v, want an error", msg)
+	}
+}
+
+func TestTerminalEditorError_MatchesUISentinel(t *testing.T) {
+	if !errors.Is(errTerminalEditor, ui.ErrTerminalEditor) {
+		t.Error("errTerminalEditor should match ui.ErrTerminalEditor")
+	}
+}
+
 func TestBuildSessionOptions(t *testing.T) {
 	t.Run("empty definition", func(t *testing.T) {
 		def := &project.SessionDefinition{}
@@ -310,6 +256,32 @@ func TestBuildSessionOptions(t *testing.T) {
 			t.Error("IgnoreVCS should be false from definition")
 		}
 	})
+
+	t.Run("symlink, watch, and permissions", func(t *testing.T) {
+		defaults := &project.DefaultConfig{
+			Symlink:     &project.SymlinkConfig{Mode: "ignore"},
+			Watch:       &project.WatchConfig{Mode: "no-watch", PollingInterval: 60},
+			Permissions: &project.PermissionsConfig{DefaultOwner: "root", DefaultGroup: "staff"},
+		}
+		def := &project.SessionDefinition{
+			Symlink:     &project.SymlinkConfig{Mode: "posix-raw"},
+			Watch:       &project.WatchConfig{Mode: "force-poll"},
+			Permissions: &project.PermissionsConfig{Mode: "manual", DefaultFileMode: "0644", DefaultOwner: "deploy"},
+		}
+		opts := buildSessionOptions(def, defaults)
+		want := mutagen.SessionOptions{
+			SymlinkMode:          "posix-raw",
+			WatchMode:            "force-poll",
+			WatchPollingInterval: 60, // Not set by the definition, so taken from the defaults
+			PermissionsMode:      "manual",
+			DefaultFileMode:      "0644",
+			DefaultOwner:         "deploy",
+			DefaultGroup:         "staff",
+		}
+		if !reflect.DeepEqual(*opts, want) {
+			t.Errorf("buildSessionOptions() = %+v, want %+v", *opts, want)
+		}
+	})
 }
 
 func TestNewApp(t *testing.T) {
@@ -499,6 +471,10 @@ func TestGetSpecConflicts_WithConflicts(t *testing.T) {
 
 // MockClient implements mutagen.MutagenClient for testing
 type MockClient struct {
+	// mu guards the call records, since project-wide actions call the
+	// client from several goroutines
+	mu sync.Mutex
+
 	// Track calls
 	CreateSessionCalls     []CreateSessionCall
 	CreatePushSessionCalls []CreateSessionCall
@@ -507,6 +483,12 @@ type MockClient struct {
 	ResumeCalls            []string
 	FlushCalls             []string
 	ResetCalls             []string
+	ResolveCalls           []string // "session root winner" for each resolved conflict
+	GetSessionCalls        []string
+	WaitTerminatedCalls    []string
+	ProjectCalls           []string // "verb path" for each mutagen project command
+	DaemonCalls            []string // "ping" or "start" for each daemon command
+	ListSessionsCalls      int
 	ListSessionsResult     []mutagen.SyncSession
 	ListSessionsError      error
 
@@ -517,6 +499,28 @@ type MockClient struct {
 	PauseError             error
 	ResumeError            error
 	FlushError             error
+	ResolveError           error
+	WaitTerminatedError    error
+	PingDaemonError        error // Cleared by a successful StartDaemon
+	StartDaemonError       error
+	StopDaemonError        error
+
+	// Streamed by MonitorSession, which then closes the channel
+	MonitorCalls    []string
+	MonitorSessions []mutagen.SyncSession
+	MonitorError    error
+
+	// Per-session errors for CreateSession, overriding CreateSessionError
+	CreateSessionErrors map[string]error
+
+	// Free space by endpoint URL, for EndpointFreeSpace
+	FreeSpace      map[string]uint64
+	FreeSpaceError error
+
+	// Forward sessions; ForwardCalls records "verb name" for each forward command
+	ListForwardsResult []mutagen.ForwardSession
+	ForwardCalls       []string
+	ForwardError       error
 }
 
 type CreateSessionCall struct {
@@ -525,62 +529,217 @@ type CreateSessionCall struct {
 }
 
 func (m *MockClient) ListSessions(ctx context.Context) ([]mutagen.SyncSession, error) {
+	m.mu.Lock()
+	m.ListSessionsCalls++
+	m.mu.Unlock()
 	return m.ListSessionsResult, m.ListSessionsError
 }
 
+func (m *MockClient) GetSession(ctx context.Context, name string) (*mutagen.SyncSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.GetSessionCalls = append(m.GetSessionCalls, name)
+	for i := range m.ListSessionsResult {
+		if m.ListSessionsResult[i].Name == name {
+			session := m.ListSessionsResult[i]
+			return &session, nil
+		}
+	}
+	return nil, fmt.Errorf("session %q not found", name)
+}
+
+func (m *MockClient) MonitorSession(ctx context.Context, name string) (<-chan mutagen.SyncSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.MonitorCalls = append(m.MonitorCalls, name)
+	if m.MonitorError != nil {
+		return nil, m.MonitorError
+	}
+	updates := make(chan mutagen.SyncSession, len(m.MonitorSessions))
+	for _, session := range m.MonitorSessions {
+		updates <- session
+	}
+	close(updates)
+	return updates, nil
+}
+
 func (m *MockClient) CreateSession(ctx context.Context, name, alpha, beta string, opts *mutagen.SessionOptions) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.CreateSessionCalls = append(m.CreateSessionCalls, CreateSessionCall{name, alpha, beta, opts})
+	if err, ok := m.CreateSessionErrors[name]; ok {
+		return err
+	}
 	return m.CreateSessionError
 }
 
 func (m *MockClient) CreatePushSession(ctx context.Context, name, alpha, beta string, opts *mutagen.SessionOptions) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.CreatePushSessionCalls = append(m.CreatePushSessionCalls, CreateSessionCall{name, alpha, beta, opts})
 	return m.CreatePushSessionError
 }
 
 func (m *MockClient) TerminateSession(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.TerminateCalls = append(m.TerminateCalls, name)
 	return m.TerminateError
 }
 
+func (m *MockClient) WaitForTerminated(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.WaitTerminatedCalls = append(m.WaitTerminatedCalls, name)
+	return m.WaitTerminatedError
+}
+
 func (m *MockClient) PauseSession(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.PauseCalls = append(m.PauseCalls, name)
 	return m.PauseError
 }
 
 func (m *MockClient) ResumeSession(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.ResumeCalls = append(m.ResumeCalls, name)
 	return m.ResumeError
 }
 
 func (m *MockClient) FlushSession(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.FlushCalls = append(m.FlushCalls, name)
 	return m.FlushError
 }
 
 func (m *MockClient) ResetSession(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.ResetCalls = append(m.ResetCalls, name)
 	return nil
 }
 
-func (m *MockClient) ProjectStart(ctx context.Context, path string) error       { return nil }
-func (m *MockClient) ProjectTerminate(ctx context.Context, path string) error   { return nil }
-func (m *MockClient) ProjectPause(ctx context.Context, path string) error       { return nil }
-func (m *MockClient) ProjectResume(ctx context.Context, path string) error      { return nil }
-func (m *MockClient) ProjectFlush(ctx context.Context, path string) error       { return nil }
+func (m *MockClient) ResolveConflict(ctx context.Context, session *mutagen.SyncSession, conflict mutagen.Conflict, winner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ResolveCalls = append(m.ResolveCalls, session.Name+" "+conflict.Root+" "+winner)
+	return m.ResolveError
+}
+
+func (m *MockClient) EndpointFreeSpace(ctx context.Context, endpoint mutagen.Endpoint) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.FreeSpace[endpoint.URL()], m.FreeSpaceError
+}
+
+func (m *MockClient) ProjectStart(ctx context.Context, path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ProjectCalls = append(m.ProjectCalls, "start "+path)
+	return nil
+}
+
+func (m *MockClient) ProjectTerminate(ctx context.Context, path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ProjectCalls = append(m.ProjectCalls, "terminate "+path)
+	return nil
+}
+
+func (m *MockClient) ProjectPause(ctx context.Context, path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ProjectCalls = append(m.ProjectCalls, "pause "+path)
+	return nil
+}
+
+func (m *MockClient) ProjectResume(ctx context.Context, path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ProjectCalls = append(m.ProjectCalls, "resume "+path)
+	return nil
+}
+
+func (m *MockClient) ProjectFlush(ctx context.Context, path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ProjectCalls = append(m.ProjectCalls, "flush "+path)
+	return nil
+}
+func (m *MockClient) PingDaemon(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DaemonCalls = append(m.DaemonCalls, "ping")
+	return m.PingDaemonError
+}
+
+func (m *MockClient) ListForwards(ctx context.Context) ([]mutagen.ForwardSession, error) {
+	return m.ListForwardsResult, nil
+}
+
+func (m *MockClient) PauseForward(ctx context.Context, name string) error {
+	return m.forwardCommand("pause", name)
+}
+
+func (m *MockClient) ResumeForward(ctx context.Context, name string) error {
+	return m.forwardCommand("resume", name)
+}
+
+func (m *MockClient) TerminateForward(ctx context.Context, name string) error {
+	return m.forwardCommand("terminate", name)
+}
+
+func (m *MockClient) forwardCommand(verb, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ForwardCalls = append(m.ForwardCalls, verb+" "+name)
+	return m.ForwardError
+}
+
+func (m *MockClient) StopDaemon(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DaemonCalls = append(m.DaemonCalls, "stop")
+	return m.StopDaemonError
+}
+
+func (m *MockClient) StartDaemon(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DaemonCalls = append(m.DaemonCalls, "start")
+	if m.StartDaemonError == nil {
+		m.PingDaemonError = nil
+	}
+	return m.StartDaemonError
+}
+
 func (m *MockClient) IsInstalled() bool                                         { return true }
 func (m *MockClient) GetVersion() (string, error)                               { return "0.0.0", nil }
 
+// sortedCalls returns recorded call arguments sorted and joined with
+// commas, for comparing calls made concurrently.
+func sortedCalls(calls []string) string {
+	sorted := slices.Clone(calls)
+	slices.Sort(sorted)
+	return strings.Join(sorted, ",")
+}
+
 // newTestApp creates an App with a mock client for testing
 func newTestApp(mock *MockClient) *App {
 	cfg := config.DefaultConfig()
 	return &App{
 		Config: cfg,
 		Client: mock,
+		Clock:  clock.Real{},
+		Store:  state.NewMemoryStore(),
 		State: &AppState{
 			Projects:  []*project.Project{},
 			Selection: ui.NewSelectionManager(),
 			ShowPaths: true,
+			Events:    ui.NewEventLog(eventLogCapacity),
 		},
 	}
 }
@@ -610,6 +769,22 @@ func createTestProjectWithFile(name string, specs []string) *project.Project {
 	return proj
 }
 
+// newSpecApp creates an app with one project holding one spec, web,
+// unfolded and selected. Unless state is NotRunning, web runs in state as
+// the session named session.
+func newSpecApp(mock *MockClient, state project.SyncSpecState, session string) *App {
+	app := newTestApp(mock)
+	proj := createTestProjectWithFile("proj", []string{"web"})
+	proj.Folded = false
+	if state != project.NotRunning {
+		proj.Specs[0].SetSession(&mutagen.SyncSession{Name: session}, state)
+	}
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(1)
+	return app
+}
+
 // ============================================================================
 // Workflow Tests
 // ============================================================================
@@ -643,6 +818,25 @@ func TestTerminateSelected_Spec(t *testing.T) {
 	}
 }
 
+func TestTerminateSession_ByIdentifier(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+
+	app.TerminateSession(context.Background(), "sync_abc", "orphan")
+	if len(mock.TerminateCalls) != 1 || mock.TerminateCalls[0] != "sync_abc" {
+		t.Errorf("TerminateCalls = %v, want [sync_abc]", mock.TerminateCalls)
+	}
+	if _, ok := app.DescribeUndo(); ok {
+		t.Error("terminating a daemon session should not be undoable")
+	}
+
+	mock.TerminateError = errors.New("no such session")
+	app.TerminateSession(context.Background(), "sync_abc", "orphan")
+	if msg := app.State.StatusMessage; msg == nil || msg.Type != ui.StatusError {
+		t.Errorf("status = %+v, want an error", msg)
+	}
+}
+
 func TestTerminateSelected_Spec_NotRunning(t *testing.T) {
 	mock := &MockClient{}
 	app := newTestApp(mock)
@@ -773,6 +967,155 @@ func TestTogglePauseSelected_Resume(t *testing.T) {
 	}
 }
 
+func TestStartSelectedSpec_WaitsForTerminatedSession(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+
+	proj := createTestProjectWithFile("test-proj", []string{"spec1"})
+	proj.Folded = false
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SelectNext()
+
+	app.StartSelectedSpec(context.Background())
+	if len(mock.WaitTerminatedCalls) != 1 || mock.WaitTerminatedCalls[0] != "spec1" {
+		t.Errorf("WaitTerminatedCalls = %v, want [spec1]", mock.WaitTerminatedCalls)
+	}
+	if len(mock.CreateSessionCalls) != 1 {
+		t.Errorf("CreateSessionCalls = %d, want 1", len(mock.CreateSessionCalls))
+	}
+
+	// A session that won't go away blocks the create
+	mock.WaitTerminatedError = errors.New("session spec1 still exists after terminate")
+	app.StartSelectedSpec(context.Background())
+	if len(mock.CreateSessionCalls) != 1 {
+		t.Errorf("CreateSessionCalls = %d, want no create after a failed wait", len(mock.CreateSessionCalls))
+	}
+	if app.State.StatusMessage.Type != ui.StatusError {
+		t.Errorf("status = %+v, want error", app.State.StatusMessage)
+	}
+
+	// Nothing to wait for when there was no session to terminate
+	mock.WaitTerminatedCalls = nil
+	mock.TerminateError = errors.New("no matching sessions")
+	app.StartSelectedSpec(context.Background())
+	if len(mock.WaitTerminatedCalls) != 0 {
+		t.Errorf("WaitTerminatedCalls = %v, want none", mock.WaitTerminatedCalls)
+	}
+}
+
+func TestStartSelectedSpec_DryRunSkipsWait(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	app.DryRun = true
+
+	proj := createTestProjectWithFile("test-proj", []string{"spec1"})
+	proj.Folded = false
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SelectNext()
+
+	app.StartSelectedSpec(context.Background())
+	if len(mock.WaitTerminatedCalls) != 0 {
+		t.Errorf("WaitTerminatedCalls = %v, want none for a reported terminate", mock.WaitTerminatedCalls)
+	}
+	if len(mock.CreateSessionCalls) != 1 {
+		t.Errorf("CreateSessionCalls = %d, want 1", len(mock.CreateSessionCalls))
+	}
+}
+
+func TestRefreshSessions_RemembersStartedSpecs(t *testing.T) {
+	mock := &MockClient{ListSessionsResult: []mutagen.SyncSession{{Name: "spec1"}}}
+	app := newTestApp(mock)
+	proj := createTestProjectWithFile("test-proj", []string{"spec1", "spec2"})
+	app.State.Projects = []*project.Project{proj}
+
+	if err := app.RefreshSessions(context.Background(), true); err != nil {
+		t.Fatalf("RefreshSessions() error = %v", err)
+	}
+	if !proj.Specs[0].EverStarted || proj.Specs[1].EverStarted {
+		t.Errorf("EverStarted = %v, %v; want true, false", proj.Specs[0].EverStarted, proj.Specs[1].EverStarted)
+	}
+
+	// Once stopped, the spec is still remembered as started
+	mock.ListSessionsResult = nil
+	if err := app.RefreshSessions(context.Background(), true); err != nil {
+		t.Fatalf("RefreshSessions() error = %v", err)
+	}
+	if proj.Specs[0].IsRunning() || !proj.Specs[0].EverStarted {
+		t.Errorf("stopped spec: running = %v, EverStarted = %v", proj.Specs[0].IsRunning(), proj.Specs[0].EverStarted)
+	}
+}
+
+func TestStartSelectedSpec_RefusesSameAlphaAndBeta(t *testing.T) {
+	t.Setenv("HOME", "/Users/me")
+	mock := &MockClient{}
+	app := newTestApp(mock)
+
+	proj := createTestProjectWithFile("test-proj", []string{"spec1"})
+	proj.File.Sessions["spec1"] = project.SessionDefinition{Alpha: "~/code", Beta: "/Users/me/code/"}
+	proj.Folded = false
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SelectNext()
+
+	app.StartSelectedSpec(context.Background())
+	if len(mock.CreateSessionCalls) != 0 {
+		t.Errorf("CreateSessionCalls = %d, want none for identical endpoints", len(mock.CreateSessionCalls))
+	}
+	if msg := app.State.StatusMessage; msg == nil || !strings.Contains(msg.Text, "same location") {
+		t.Errorf("status = %+v, want same-location error", msg)
+	}
+}
+
+func TestTogglePauseAll(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+
+	p1 := createTestProjectWithFile("p1", []string{"a", "b"})
+	p1.Specs[0].RunningSession = &mutagen.SyncSession{Name: "a", Paused: true}
+	p1.Specs[1].RunningSession = &mutagen.SyncSession{Name: "b"}
+	p2 := createTestProjectWithFile("p2", []string{"c", "d"})
+	p2.Specs[0].RunningSession = &mutagen.SyncSession{Name: "c"}
+	app.State.Projects = []*project.Project{p1, p2}
+
+	// Some are running unpaused, so everything running gets paused
+	app.TogglePauseAll(context.Background())
+	if got := sortedCalls(mock.PauseCalls); got != "b,c" {
+		t.Errorf("PauseCalls = %v, want b and c", mock.PauseCalls)
+	}
+	if app.State.StatusMessage.Type != ui.StatusInfo {
+		t.Errorf("status = %+v, want info", app.State.StatusMessage)
+	}
+
+	// Once all are paused, everything is resumed
+	p1.Specs[1].RunningSession.Paused = true
+	p2.Specs[0].RunningSession.Paused = true
+	app.TogglePauseAll(context.Background())
+	if len(mock.ResumeCalls) != 3 {
+		t.Errorf("ResumeCalls = %v, want all three sessions", mock.ResumeCalls)
+	}
+}
+
+func TestTogglePauseAll_ReportsFailures(t *testing.T) {
+	mock := &MockClient{PauseError: errors.New("daemon unreachable")}
+	app := newTestApp(mock)
+
+	proj := createTestProjectWithFile("p1", []string{"a", "b"})
+	proj.Specs[0].RunningSession = &mutagen.SyncSession{Name: "a"}
+	proj.Specs[1].RunningSession = &mutagen.SyncSession{Name: "b"}
+	app.State.Projects = []*project.Project{proj}
+
+	app.TogglePauseAll(context.Background())
+	if len(mock.PauseCalls) != 2 {
+		t.Errorf("PauseCalls = %v, want both attempted despite failures", mock.PauseCalls)
+	}
+	msg := app.State.StatusMessage
+	if msg.Type != ui.StatusError || !strings.Contains(msg.Text, "Paused 0 of 2") || !strings.Contains(msg.Text, "a, b") {
+		t.Errorf("status = %+v, want aggregate failure report", msg)
+	}
+}
+
 func TestRefreshSessions(t *testing.T) {
 	mock := &MockClient{
 		ListSessionsResult: []mutagen.SyncSession{
@@ -786,7 +1129,7 @@ func TestRefreshSessions(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	err := app.RefreshSessions(ctx)
+	err := app.RefreshSessions(ctx, true)
 
 	// Verify
 	if err != nil {
@@ -797,6 +1140,94 @@ func TestRefreshSessions(t *testing.T) {
 	}
 }
 
+func TestRefreshSessions_Debounce(t *testing.T) {
+	mock := &MockClient{ListSessionsResult: []mutagen.SyncSession{{Name: "spec1", Status: "Watching"}}}
+	app := newTestApp(mock)
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	app.Clock = fake
+	ctx := context.Background()
+
+	if err := app.RefreshSessions(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	// A second refresh within the window reuses the list
+	fake.Advance(listDebounce / 2)
+	if err := app.RefreshSessions(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if mock.ListSessionsCalls != 1 {
+		t.Errorf("ListSessions called %d times within the window, want 1", mock.ListSessionsCalls)
+	}
+	// Forcing bypasses the window
+	if err := app.RefreshSessions(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	if mock.ListSessionsCalls != 2 {
+		t.Errorf("ListSessions called %d times after a forced refresh, want 2", mock.ListSessionsCalls)
+	}
+	// Once the window passes, sessions are listed again
+	fake.Advance(listDebounce)
+	if err := app.RefreshSessions(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if mock.ListSessionsCalls != 3 {
+		t.Errorf("ListSessions called %d times after the window, want 3", mock.ListSessionsCalls)
+	}
+}
+
+func TestRefreshSessions_FailureIsNotDebounced(t *testing.T) {
+	mock := &MockClient{ListSessionsError: errors.New("daemon not running")}
+	app := newTestApp(mock)
+	ctx := context.Background()
+
+	_ = app.RefreshSessions(ctx, false)
+	mock.ListSessionsError = nil
+	if err := app.RefreshSessions(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if mock.ListSessionsCalls != 2 {
+		t.Errorf("ListSessions called %d times, want a retry after the failure", mock.ListSessionsCalls)
+	}
+}
+
+func TestRefreshSessions_KeepsSelectedSession(t *testing.T) {
+	mock := &MockClient{
+		ListSessionsResult: []mutagen.SyncSession{
+			{Name: "spec1", Identifier: "sync_1", Status: "scanning"},
+			{Name: "stray", Identifier: "sync_9", Status: "scanning"},
+		},
+	}
+	app := newTestApp(mock)
+	app.State.SortBy = config.SortByName
+	proj := createTestProjectWithFile("test-proj", []string{"spec1"})
+	proj.File.Path = "/projects/test-proj.yml"
+	proj.Folded = false
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(1)
+
+	ctx := context.Background()
+	if err := app.RefreshSessions(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	app.GetSelectedSession().SyncTime = mutagen.SyncTimeAt
+	app.State.Projects[1].Specs[0].RunningSession.SyncTime = mutagen.SyncTimeAt
+
+	mock.ListSessionsResult = []mutagen.SyncSession{
+		{Name: "spec1", Identifier: "sync_1", Status: "watching"},
+		{Name: "stray", Identifier: "sync_9", Status: "watching"},
+	}
+	if err := app.RefreshSessions(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	if got := app.GetSelectedSession(); got == nil || got.Identifier != "sync_1" || got.Status != "watching" || got.SyncTime != mutagen.SyncTimeAt {
+		t.Errorf("selected session = %+v, want sync_1 refreshed with its sync time", got)
+	}
+	if got := app.State.Projects[1].Specs[0].RunningSession; got.Status != "watching" || got.SyncTime != mutagen.SyncTimeAt {
+		t.Errorf("orphaned session = %+v, want it refreshed with its sync time", got)
+	}
+}
+
 func TestRefreshSessions_Error(t *testing.T) {
 	mock := &MockClient{
 		ListSessionsError: errors.New("connection failed"),
@@ -805,7 +1236,7 @@ func TestRefreshSessions_Error(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	err := app.RefreshSessions(ctx)
+	err := app.RefreshSessions(ctx, true)
 
 	// Verify
 	if err == nil {
@@ -816,6 +1247,29 @@ func TestRefreshSessions_Error(t *testing.T) {
 	}
 }
 
+func TestRefreshSessions_FailureKeepsLastList(t *testing.T) {
+	mock := &MockClient{ListSessionsResult: []mutagen.SyncSession{{Name: "spec1", Identifier: "sync_1", Status: "watching"}}}
+	app := newTestApp(mock)
+	proj := createTestProjectWithFile("test-proj", []string{"spec1"})
+	app.State.Projects = []*project.Project{proj}
+	ctx := context.Background()
+	if err := app.RefreshSessions(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ListSessionsError = errors.New("mutagen sync list failed: unable to connect to daemon")
+	if err := app.RefreshSessions(ctx, true); err == nil {
+		t.Error("RefreshSessions() should return the error")
+	}
+	if proj.Specs[0].RunningSession == nil {
+		t.Error("the failed refresh dropped the running session")
+	}
+	status := app.State.StatusMessage
+	if status == nil || status.Type != ui.StatusWarning || !strings.HasPrefix(status.Text, "Failed to refresh sessions; showing them as of ") {
+		t.Errorf("status = %+v, want a warning that the list is stale", status)
+	}
+}
+
 func TestTerminateSelected_Error(t *testing.T) {
 	mock := &MockClient{
 		TerminateError: errors.New("terminate failed"),
@@ -887,3 +1341,326 @@ func TestResumeSelected_Spec(t *testing.T) {
 		t.Errorf("ResumeCalls = %d, want 1", len(mock.ResumeCalls))
 	}
 }
+
+func TestStartSelectedSpec_DryRun(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	app.DryRun = true
+
+	proj := createTestProjectWithFile("test", []string{"spec1"})
+	proj.Folded = false
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SelectNext() // Select spec1
+
+	app.StartSelectedSpec(context.Background())
+
+	if len(mock.CreateSessionCalls) != 1 {
+		t.Fatalf("CreateSessionCalls = %d, want 1", len(mock.CreateSessionCalls))
+	}
+	if app.State.StatusMessage == nil || app.State.StatusMessage.Text != "[dry-run] Started session: spec1" {
+		t.Errorf("StatusMessage = %v, want dry-run prefixed status", app.State.StatusMessage)
+	}
+	if app.State.Events.Len() != 1 {
+		t.Errorf("event count = %d, want endpoint preparation to be logged", app.State.Events.Len())
+	}
+}
+
+func TestPollSelectedSession_ReplacesSession(t *testing.T) {
+	mock := &MockClient{
+		ListSessionsResult: []mutagen.SyncSession{{Name: "web", Status: "staging-beta"}},
+	}
+	app := newTestApp(mock)
+	proj := createTestProjectWithFile("proj", []string{"web"})
+	proj.Folded = false
+	running := &mutagen.SyncSession{Name: "web", Status: "scanning"}
+	proj.Specs[0].State = project.RunningTwoWay
+	proj.Specs[0].RunningSession = running
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(1)
+
+	if err := app.PollSelectedSession(context.Background()); err != nil {
+		t.Fatalf("PollSelectedSession() error = %v", err)
+	}
+	if len(mock.GetSessionCalls) != 1 || mock.GetSessionCalls[0] != "web" {
+		t.Errorf("GetSessionCalls = %v, want [web]", mock.GetSessionCalls)
+	}
+	if got := app.GetSelectedSession(); got.Status != "staging-beta" {
+		t.Errorf("Status = %q, want staging-beta", got.Status)
+	}
+	if running.Status != "scanning" {
+		t.Error("the session the UI may be drawing should not be overwritten")
+	}
+}
+
+func TestMonitorSelectedSession_AppliesUpdates(t *testing.T) {
+	mock := &MockClient{
+		MonitorSessions: []mutagen.SyncSession{
+			{Name: "web", Status: "staging-beta"},
+			{Name: "other", Status: "halted-on-root-emptied"},
+		},
+	}
+	app := newTestApp(mock)
+	proj := createTestProjectWithFile("proj", []string{"web"})
+	proj.Folded = false
+	running := &mutagen.SyncSession{Name: "web", Status: "scanning", SyncTime: mutagen.SyncTimeAt}
+	proj.Specs[0].State = project.RunningTwoWay
+	proj.Specs[0].RunningSession = running
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(1)
+
+	updates, err := app.MonitorSelectedSession(context.Background())
+	if err != nil {
+		t.Fatalf("MonitorSelectedSession() error = %v", err)
+	}
+	if len(mock.MonitorCalls) != 1 || mock.MonitorCalls[0] != "web" {
+		t.Errorf("MonitorCalls = %v, want [web]", mock.MonitorCalls)
+	}
+
+	app.ApplySessionUpdate(<-updates)
+	got := app.GetSelectedSession()
+	if got.Status != "staging-beta" {
+		t.Errorf("Status = %q, want staging-beta", got.Status)
+	}
+	if got.SyncTime != mutagen.SyncTimeAt {
+		t.Error("update should keep the sync time")
+	}
+
+	// Updates for another session are dropped
+	app.ApplySessionUpdate(<-updates)
+	if got := app.GetSelectedSession(); got.Name != "web" || got.Status != "staging-beta" {
+		t.Errorf("session = %s/%s, want the web update only", got.Name, got.Status)
+	}
+}
+
+func TestMonitorSelectedSession_NothingRunning(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	app.State.Projects = []*project.Project{createTestProjectWithFile("proj", []string{"web"})}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	if _, err := app.MonitorSelectedSession(context.Background()); err == nil {
+		t.Error("MonitorSelectedSession() should fail without a running session")
+	}
+	if len(mock.MonitorCalls) != 0 {
+		t.Errorf("MonitorCalls = %v, want none", mock.MonitorCalls)
+	}
+}
+
+// conflictedProject returns a project whose specs run two-way sessions
+// between local temporary directories, with conflicts in the first one.
+func conflictedProject(t *testing.T, names ...string) *project.Project {
+	proj := createTestProjectWithFile("proj", names)
+	proj.Folded = false
+	for i, name := range names {
+		def := project.SessionDefinition{Alpha: t.TempDir(), Beta: t.TempDir()}
+		proj.File.Sessions[name] = def
+		proj.Specs[i].State = project.RunningTwoWay
+		proj.Specs[i].RunningSession = &mutagen.SyncSession{Name: name}
+	}
+	proj.Specs[0].RunningSession.Conflicts = []mutagen.Conflict{{Root: "file.txt"}}
+	return proj
+}
+
+func TestResolveConflicts_SwapsEndpointsForPull(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	proj := conflictedProject(t, "web")
+	def := proj.File.Sessions["web"]
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(1)
+
+	app.PushConflictsToBeta(context.Background())
+	app.PullConflictsToAlpha(context.Background())
+
+	if len(mock.CreatePushSessionCalls) != 2 {
+		t.Fatalf("CreatePushSessionCalls = %+v, want push then pull", mock.CreatePushSessionCalls)
+	}
+	if push := mock.CreatePushSessionCalls[0]; push.Alpha != def.Alpha || push.Beta != def.Beta {
+		t.Errorf("push = %s → %s, want %s → %s", push.Alpha, push.Beta, def.Alpha, def.Beta)
+	}
+	if pull := mock.CreatePushSessionCalls[1]; pull.Alpha != def.Beta || pull.Beta != def.Alpha {
+		t.Errorf("pull = %s → %s, want %s → %s", pull.Alpha, pull.Beta, def.Beta, def.Alpha)
+	}
+}
+
+func TestPullConflictsToAlpha_ProjectPullsConflictedSpecs(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	proj := conflictedProject(t, "web", "api")
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	app.PullConflictsToAlpha(context.Background())
+	if len(mock.CreatePushSessionCalls) != 1 || mock.CreatePushSessionCalls[0].Name != "web" {
+		t.Errorf("CreatePushSessionCalls = %+v, want web only", mock.CreatePushSessionCalls)
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Text != "Created pull sessions for 1 spec(s)" {
+		t.Errorf("status = %+v, want 1 pull session", msg)
+	}
+}
+
+func TestResolveConflict(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	session := &mutagen.SyncSession{Name: "web"}
+
+	app.ResolveConflict(context.Background(), session, mutagen.Conflict{Root: "a.txt"}, mutagen.WinnerBeta)
+	if len(mock.ResolveCalls) != 1 || mock.ResolveCalls[0] != "web a.txt beta" {
+		t.Errorf("ResolveCalls = %v, want [web a.txt beta]", mock.ResolveCalls)
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Text != "Kept the beta version of a.txt" {
+		t.Errorf("status = %+v", msg)
+	}
+
+	mock.ResolveError = errors.New("permission denied")
+	app.ResolveConflict(context.Background(), session, mutagen.Conflict{Root: "b.txt"}, mutagen.WinnerAlpha)
+	if msg := app.State.StatusMessage; msg == nil || msg.Type != ui.StatusError || msg.Text != "Failed to resolve b.txt: permission denied" {
+		t.Errorf("status = %+v, want the error", msg)
+	}
+}
+
+func TestDescribePushSelected(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	proj := createTestProjectWithFile("proj", []string{"down", "up"})
+	proj.File.Sessions["down"] = project.SessionDefinition{Alpha: "server:/data", Beta: "/local/data"}
+	proj.File.Sessions["up"] = project.SessionDefinition{Alpha: "/local/src", Beta: "server:/src"}
+	proj.Folded = false
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	// Project selected: one line per spec
+	lines := app.DescribePushSelected()
+	if len(lines) != 2 {
+		t.Fatalf("DescribePushSelected() = %v, want 2 lines", lines)
+	}
+	want := "down: copying FROM server:/data TO /local/data, overwriting /local/data (remote → local)"
+	if lines[0] != want {
+		t.Errorf("lines[0] = %q, want %q", lines[0], want)
+	}
+
+	// Spec selected: only that spec
+	app.State.Selection.SetIndex(2)
+	lines = app.DescribePushSelected()
+	want = "up: copying FROM /local/src TO server:/src, overwriting server:/src"
+	if len(lines) != 1 || lines[0] != want {
+		t.Errorf("DescribePushSelected() = %v, want [%q]", lines, want)
+	}
+}
+
+func TestApplyFoldState(t *testing.T) {
+	newProjects := func() []*project.Project {
+		a := createTestProjectWithFile("a", []string{"spec"})
+		a.File.Path = "/p/a.yml"
+		a.Folded = true
+		b := createTestProjectWithFile("b", []string{"spec"})
+		b.File.Path = "/p/b.yml"
+		b.Folded = true
+		return []*project.Project{a, b}
+	}
+
+	t.Run("collapsed", func(t *testing.T) {
+		app := newTestApp(&MockClient{})
+		app.State.Projects = newProjects()
+		app.ApplyFoldState()
+		if !app.State.Projects[0].Folded || !app.State.Projects[1].Folded {
+			t.Error("projects should stay folded")
+		}
+	})
+
+	t.Run("expanded", func(t *testing.T) {
+		app := newTestApp(&MockClient{})
+		app.Config.UI.FoldState = config.FoldStateExpanded
+		app.State.Projects = newProjects()
+		app.ApplyFoldState()
+		if app.State.Projects[0].Folded || app.State.Projects[1].Folded {
+			t.Error("projects should be unfolded")
+		}
+	})
+
+	t.Run("remember", func(t *testing.T) {
+		app := newTestApp(&MockClient{})
+		app.Config.UI.FoldState = config.FoldStateRemember
+		app.State.Projects = newProjects()
+		app.State.Selection.RebuildFromProjects(app.State.Projects)
+		app.ToggleProjectFold(1)
+
+		// A later run with the same store restores the unfolded project
+		next := newTestApp(&MockClient{})
+		next.Config.UI.FoldState = config.FoldStateRemember
+		next.Store = app.Store
+		next.State.Projects = newProjects()
+		next.ApplyFoldState()
+		if !next.State.Projects[0].Folded || next.State.Projects[1].Folded {
+			t.Errorf("folded = %v, %v; want true, false",
+				next.State.Projects[0].Folded, next.State.Projects[1].Folded)
+		}
+	})
+}
+
+func TestTogglePauseMarked(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+
+	proj := createTestProjectWithFile("p1", []string{"a", "b", "c"})
+	for i := range proj.Specs {
+		proj.Specs[i].RunningSession = &mutagen.SyncSession{Name: proj.Specs[i].Name}
+	}
+	app.State.Projects = []*project.Project{proj}
+
+	app.TogglePauseMarked(context.Background(), []*project.SyncSpec{&proj.Specs[0], &proj.Specs[2]})
+	if got := sortedCalls(mock.PauseCalls); got != "a,c" {
+		t.Errorf("PauseCalls = %v, want a and c", mock.PauseCalls)
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Text != "Paused 2 marked session(s)" {
+		t.Errorf("status = %+v", msg)
+	}
+}
+
+func TestResetSelected_Spec(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+
+	proj := createTestProjectWithFile("test-proj", []string{"spec1", "spec2"})
+	proj.Specs[1].State = project.RunningTwoWay
+	proj.Specs[1].RunningSession = &mutagen.SyncSession{Name: "spec2", Paused: true}
+	proj.Folded = false
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(2) // spec2
+
+	if lines := app.DescribeRunningSelected(); len(lines) != 1 || lines[0] != "spec2 (paused)" {
+		t.Errorf("DescribeRunningSelected() = %q", lines)
+	}
+	app.ResetSelected(context.Background())
+	if strings.Join(mock.ResetCalls, ",") != "spec2" {
+		t.Errorf("ResetCalls = %v, want [spec2]", mock.ResetCalls)
+	}
+	if msg := app.State.StatusMessage; msg.Type != ui.StatusInfo || !strings.Contains(msg.Text, "from scratch") {
+		t.Errorf("status = %+v, want an explanation of the rescan", msg)
+	}
+}
+
+func TestResetSelected_Project(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+
+	proj := createTestProjectWithFile("test-proj", []string{"a", "b", "c"})
+	proj.Specs[0].State = project.RunningTwoWay
+	proj.Specs[0].RunningSession = &mutagen.SyncSession{Name: "a"}
+	proj.Specs[2].State = project.RunningPush
+	proj.Specs[2].RunningSession = &mutagen.SyncSession{Name: "c-push"}
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	app.ResetSelected(context.Background())
+	if strings.Join(mock.ResetCalls, ",") != "a,c-push" {
+		t.Errorf("ResetCalls = %v, want the running sessions [a c-push]", mock.ResetCalls)
+	}
+	if msg := app.State.StatusMessage.Text; !strings.HasPrefix(msg, "Reset 2 session(s)") {
+		t.Errorf("status = %q", msg)
+	}
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// StartAllProjects starts the stopped specs of every project, one project
+// at a time so that each project's hooks and start order apply as they do
+// when it is started on its own. A failure in one project doesn't stop the
+// rest; it is logged, and the status names the projects that failed.
+func (a *App) StartAllProjects(ctx context.Context) {
+	var projects []*project.Project
+	sessions := 0
+	for _, proj := range a.State.Projects {
+		if n := len(proj.Specs) - countRunning(proj); n > 0 && !proj.Orphaned {
+			projects = append(projects, proj)
+			sessions += n
+		}
+	}
+	if len(projects) == 0 {
+		a.SetStatus(ui.StatusWarning, "All sessions already running")
+		return
+	}
+
+	failed := a.forEachProject(projects, func(proj *project.Project) bool {
+		return a.startProject(ctx, proj)
+	})
+	a.recordNoUndo("Starting every project can't be undone; terminate them instead")
+	a.reportProjects("Started", projects, sessions, failed)
+}
+
+// TerminateAll terminates the running sessions of every project. Undo
+// recreates the sessions of every project that was terminated.
+func (a *App) TerminateAll(ctx context.Context) {
+	var projects []*project.Project
+	sessions := 0
+	for _, proj := range a.State.Projects {
+		if n := countRunning(proj); n > 0 {
+			projects = append(projects, proj)
+			sessions += n
+		}
+	}
+	if len(projects) == 0 {
+		a.SetStatus(ui.StatusWarning, "No sessions running")
+		return
+	}
+
+	var undos []*undoAction
+	failed := a.forEachProject(projects, func(proj *project.Project) bool {
+		a.lastAction = nil
+		ok := a.terminateProject(ctx, proj)
+		if a.lastAction != nil && a.lastAction.run != nil {
+			undos = append(undos, a.lastAction)
+		}
+		return ok
+	})
+	a.lastAction = nil
+	if len(undos) > 0 {
+		a.recordUndo(fmt.Sprintf("Recreate the sessions of %d project(s)", len(undos)), func(ctx context.Context) error {
+			for _, undo := range undos {
+				if err := undo.run(ctx); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	} else {
+		a.recordNoUndo("The terminated sessions have no definitions to recreate them from")
+	}
+	a.reportProjects("Terminated", projects, sessions, failed)
+}
+
+// DescribeTerminateAll lists each project with running sessions and how
+// many it has, for confirming TerminateAll.
+func (a *App) DescribeTerminateAll() []string {
+	var lines []string
+	for _, proj := range a.State.Projects {
+		if n := countRunning(proj); n > 0 {
+			lines = append(lines, fmt.Sprintf("%s: %d session(s)", proj.File.DisplayName(), n))
+		}
+	}
+	return lines
+}
+
+// forEachProject runs action on each project, which sets the status, and
+// logs the status of each project the action fails on. Returns the names of
+// those projects.
+func (a *App) forEachProject(projects []*project.Project, action func(proj *project.Project) bool) []string {
+	var failed []string
+	for _, proj := range projects {
+		if action(proj) {
+			continue
+		}
+		failed = append(failed, proj.File.DisplayName())
+		if msg := a.State.StatusMessage; msg != nil {
+			a.LogEvent(msg.Type, msg.Text)
+		}
+	}
+	return failed
+}
+
+// reportProjects sets the status after an action on several projects.
+func (a *App) reportProjects(verb string, projects []*project.Project, sessions int, failed []string) {
+	if len(failed) > 0 {
+		a.SetStatus(ui.StatusError, fmt.Sprintf("%s %d of %d project(s); failed: %s",
+			verb, len(projects)-len(failed), len(projects), strings.Join(failed, ", ")))
+		return
+	}
+	a.SetStatus(ui.StatusInfo, fmt.Sprintf("%s %d project(s) (%d sessions)", verb, len(projects), sessions))
+}
+
+// countRunning returns the number of the project's specs that are running.
+func countRunning(proj *project.Project) int {
+	n := 0
+	for i := range proj.Specs {
+		if proj.Specs[i].IsRunning() {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,160 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// bulkTestProject returns a project whose specs are named after the project
+// and numbered from 1; running says which of them have a session.
+func bulkTestProject(name string, running ...bool) *project.Project {
+	specs := make([]string, len(running))
+	for i := range running {
+		specs[i] = name + string(rune('1'+i))
+	}
+	proj := createTestProjectWithFile(name, specs)
+	proj.File.Path = "/projects/" + name + ".yml"
+	for i, r := range running {
+		if r {
+			proj.Specs[i].State = project.RunningTwoWay
+			proj.Specs[i].RunningSession = &mutagen.SyncSession{Name: specs[i]}
+		}
+	}
+	return proj
+}
+
+func createdNames(calls []CreateSessionCall) []string {
+	names := make([]string, len(calls))
+	for i, call := range calls {
+		names[i] = call.Name
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestStartAllProjects(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	orphans := bulkTestProject("stray", true)
+	orphans.Orphaned = true
+	app.State.Projects = []*project.Project{
+		bulkTestProject("a", false, false),
+		bulkTestProject("b", true, false),
+		bulkTestProject("c", true),
+		orphans,
+	}
+
+	app.StartAllProjects(context.Background())
+
+	if got := createdNames(mock.CreateSessionCalls); strings.Join(got, ",") != "a1,a2,b2" {
+		t.Errorf("created %v, want a1, a2, and b2", got)
+	}
+	if msg := app.State.StatusMessage; msg.Type != ui.StatusInfo || msg.Text != "Started 2 project(s) (3 sessions)" {
+		t.Errorf("status = %+v", msg)
+	}
+}
+
+func TestStartAllProjects_ContinuesPastFailures(t *testing.T) {
+	mock := &MockClient{CreateSessionErrors: map[string]error{"a1": errors.New("host unreachable")}}
+	app := newTestApp(mock)
+	app.State.Projects = []*project.Project{
+		bulkTestProject("a", false),
+		bulkTestProject("b", false),
+	}
+
+	app.StartAllProjects(context.Background())
+
+	if got := createdNames(mock.CreateSessionCalls); strings.Join(got, ",") != "a1,b1" {
+		t.Errorf("created %v, want both projects attempted", got)
+	}
+	if msg := app.State.StatusMessage; msg.Type != ui.StatusError || msg.Text != "Started 1 of 2 project(s); failed: a" {
+		t.Errorf("status = %+v", msg)
+	}
+	if !slices.ContainsFunc(app.State.Events.Events(), func(e ui.Event) bool { return strings.Contains(e.Text, "host unreachable") }) {
+		t.Error("the failed project's error should be logged")
+	}
+}
+
+func TestStartAllProjects_NothingToStart(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	app.State.Projects = []*project.Project{bulkTestProject("a", true)}
+
+	app.StartAllProjects(context.Background())
+
+	if len(mock.CreateSessionCalls) != 0 || app.State.StatusMessage.Type != ui.StatusWarning {
+		t.Errorf("calls = %v, status = %+v", mock.CreateSessionCalls, app.State.StatusMessage)
+	}
+}
+
+func TestStartAllProjects_ProjectCommands(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	app.Config.Projects.UseProjectCommands = true
+	app.State.Projects = []*project.Project{
+		bulkTestProject("a", false),
+		bulkTestProject("b", true),
+		bulkTestProject("c", false, true),
+	}
+
+	app.StartAllProjects(context.Background())
+
+	if got := strings.Join(mock.ProjectCalls, ","); got != "start /projects/a.yml,start /projects/c.yml" {
+		t.Errorf("ProjectCalls = %v", mock.ProjectCalls)
+	}
+}
+
+func TestTerminateAll(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	app.State.Projects = []*project.Project{
+		bulkTestProject("a", true, true),
+		bulkTestProject("b", false),
+		bulkTestProject("c", false, true),
+	}
+
+	if got := strings.Join(app.DescribeTerminateAll(), "; "); got != "a: 2 session(s); c: 1 session(s)" {
+		t.Errorf("DescribeTerminateAll() = %q", got)
+	}
+
+	app.TerminateAll(context.Background())
+
+	terminated := slices.Sorted(slices.Values(mock.TerminateCalls))
+	if strings.Join(terminated, ",") != "a1,a2,c2" {
+		t.Errorf("TerminateCalls = %v, want a1, a2, and c2", mock.TerminateCalls)
+	}
+	if msg := app.State.StatusMessage; msg.Type != ui.StatusInfo || msg.Text != "Terminated 2 project(s) (3 sessions)" {
+		t.Errorf("status = %+v", msg)
+	}
+
+	// Undo recreates the sessions of every project
+	if description, ok := app.DescribeUndo(); !ok || description != "Recreate the sessions of 2 project(s)" {
+		t.Fatalf("DescribeUndo() = %q, %v", description, ok)
+	}
+	app.UndoLast(context.Background())
+	if got := createdNames(mock.CreateSessionCalls); strings.Join(got, ",") != "a1,a2,c2" {
+		t.Errorf("undo recreated %v, want a1, a2, and c2", got)
+	}
+}
+
+func TestTerminateAll_ReportsFailedProjects(t *testing.T) {
+	mock := &MockClient{TerminateError: errors.New("daemon gone")}
+	app := newTestApp(mock)
+	app.State.Projects = []*project.Project{bulkTestProject("a", true), bulkTestProject("b", true)}
+
+	app.TerminateAll(context.Background())
+
+	if len(mock.TerminateCalls) != 2 {
+		t.Errorf("TerminateCalls = %v, want both projects attempted", mock.TerminateCalls)
+	}
+	if msg := app.State.StatusMessage; msg.Type != ui.StatusError || msg.Text != "Terminated 0 of 2 project(s); failed: a, b" {
+		t.Errorf("status = %+v", msg)
+	}
+}
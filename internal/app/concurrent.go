@@ -0,0 +1,129 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// maxConcurrentCommands bounds how many mutagen commands an action on a
+// whole project runs at once.
+const maxConcurrentCommands = 4
+
+// runConcurrently calls fn for each index in [0, n), with at most
+// maxConcurrentCommands calls in flight, and returns their errors by index.
+// Since the calls run on other goroutines, fn should only use the client
+// and LogEvent, leaving the status and other app state to the caller.
+func runConcurrently(n int, fn func(i int) error) []error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, maxConcurrentCommands)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = fn(i)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// reportFailures logs each error of an action run on the named specs and,
+// if any failed, sets an error status saying how many the action succeeded
+// on. With a single failure the status includes its error. Returns the
+// number of failures.
+func (a *App) reportFailures(verb, action string, names []string, errs []error) int {
+	var failed []string
+	var firstErr error
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		a.LogEvent(ui.StatusError, fmt.Sprintf("Failed to %s %s: %v", action, names[i], err))
+		if firstErr == nil {
+			firstErr = err
+		}
+		failed = append(failed, names[i])
+	}
+
+	succeeded := len(names) - len(failed)
+	switch len(failed) {
+	case 0:
+	case 1:
+		a.SetStatus(ui.StatusError, fmt.Sprintf("%s %d of %d session(s); failed to %s %s: %v",
+			verb, succeeded, len(names), action, failed[0], firstErr))
+	default:
+		a.SetStatus(ui.StatusError, fmt.Sprintf("%s %d of %d session(s); failed: %s",
+			verb, succeeded, len(names), strings.Join(failed, ", ")))
+	}
+	return len(failed)
+}
+
+// runningSpecs returns the project's specs with a running session that
+// satisfy keep.
+func runningSpecs(proj *project.Project, keep func(*project.SyncSpec) bool) []*project.SyncSpec {
+	var specs []*project.SyncSpec
+	for i := range proj.Specs {
+		if spec := &proj.Specs[i]; spec.RunningSession != nil && keep(spec) {
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+// specNames returns the names of specs, for reportFailures.
+func specNames(specs []*project.SyncSpec) []string {
+	names := make([]string, len(specs))
+	for i, spec := range specs {
+		names[i] = spec.Name
+	}
+	return names
+}
+
+// succeededSessions returns the running session names of the specs whose
+// command succeeded.
+func succeededSessions(specs []*project.SyncSpec, errs []error) []string {
+	var names []string
+	for i, spec := range specs {
+		if errs[i] == nil {
+			names = append(names, specSessionNames(spec)...)
+		}
+	}
+	return names
+}
+
+// specSessionNames returns the names of the spec's running sessions.
+func specSessionNames(spec *project.SyncSpec) []string {
+	var names []string
+	for _, session := range spec.Sessions() {
+		names = append(names, session.Name)
+	}
+	return names
+}
+
+// forEachSession calls fn with the name of each of the spec's running
+// sessions: its one session, or each running replica's for a spec with
+// several betas. It carries on past failures; the errors of a spec with
+// several sessions say which session each came from.
+func forEachSession(spec *project.SyncSpec, fn func(name string) error) error {
+	names := specSessionNames(spec)
+	var errs []error
+	for _, name := range names {
+		if err := fn(name); err != nil {
+			if len(names) > 1 {
+				err = fmt.Errorf("%s: %w", name, err)
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
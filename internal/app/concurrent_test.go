@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// newLocalProject returns a project with the given specs, each syncing
+// between two temporary directories so that starting them touches nothing
+// outside the test.
+func newLocalProject(t *testing.T, n int) *project.Project {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("spec%02d", i)
+	}
+	proj := createTestProjectWithFile("big", names)
+	for _, name := range names {
+		proj.File.Sessions[name] = project.SessionDefinition{Alpha: t.TempDir(), Beta: t.TempDir()}
+	}
+	return proj
+}
+
+func TestRunConcurrently(t *testing.T) {
+	errs := runConcurrently(10, func(i int) error {
+		if i%3 == 0 {
+			return fmt.Errorf("error %d", i)
+		}
+		return nil
+	})
+	for i, err := range errs {
+		if (err != nil) != (i%3 == 0) {
+			t.Errorf("errs[%d] = %v", i, err)
+		}
+	}
+}
+
+func TestStartSelectedProject_StartsAllSpecs(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	proj := newLocalProject(t, 12)
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	app.StartSelectedProject(context.Background())
+
+	var started []string
+	for _, call := range mock.CreateSessionCalls {
+		started = append(started, call.Name)
+	}
+	if len(started) != 12 {
+		t.Errorf("CreateSessionCalls = %v, want all 12 specs", started)
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Text != "Started 12 session(s)" {
+		t.Errorf("status = %+v, want 12 started", msg)
+	}
+}
+
+func TestStartSelectedProject_ReportsFailures(t *testing.T) {
+	mock := &MockClient{CreateSessionErrors: map[string]error{
+		"spec01": errors.New("connection refused"),
+	}}
+	app := newTestApp(mock)
+	proj := newLocalProject(t, 4)
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	app.StartSelectedProject(context.Background())
+	if len(mock.CreateSessionCalls) != 4 {
+		t.Errorf("CreateSessionCalls = %d, want every spec attempted", len(mock.CreateSessionCalls))
+	}
+	msg := app.State.StatusMessage
+	if msg == nil || msg.Type != ui.StatusError || msg.Text != "Started 3 of 4 session(s); failed to start spec01: connection refused" {
+		t.Errorf("status = %+v, want one failure", msg)
+	}
+
+	// Several failures are listed by name, with the details in the event log
+	mock.CreateSessionErrors = nil
+	mock.CreateSessionError = errors.New("connection refused")
+	app.StartSelectedProject(context.Background())
+	if msg := app.State.StatusMessage; msg == nil || !strings.HasSuffix(msg.Text, "failed: spec00, spec01, spec02, spec03") {
+		t.Errorf("status = %+v, want every spec listed", msg)
+	}
+}
+
+func TestTerminateSelected_ProjectKeepsGoingAfterFailure(t *testing.T) {
+	mock := &MockClient{TerminateError: errors.New("daemon busy")}
+	app := newTestApp(mock)
+	proj := newLocalProject(t, 3)
+	for i := range proj.Specs {
+		proj.Specs[i].State = project.RunningTwoWay
+		proj.Specs[i].RunningSession = &mutagen.SyncSession{Name: proj.Specs[i].Name}
+	}
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	app.TerminateSelected(context.Background())
+	if got := sortedCalls(mock.TerminateCalls); got != "spec00,spec01,spec02" {
+		t.Errorf("TerminateCalls = %s, want all three", got)
+	}
+	if msg := app.State.StatusMessage; msg == nil || !strings.HasPrefix(msg.Text, "Terminated 0 of 3 session(s)") {
+		t.Errorf("status = %+v, want 0 of 3", msg)
+	}
+}
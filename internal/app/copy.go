@@ -0,0 +1,62 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/osteele/mutagui/internal/clipboard"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// CopySelectedPaths copies the selected spec's alpha and beta paths, one per
+// line, to the clipboard, with each beta of a spec that has several. With a project selected, it copies the project
+// file path instead.
+func (a *App) CopySelectedPaths() {
+	item := a.State.Selection.SelectedItem()
+	if item == nil || item.ProjectIndex < 0 || item.ProjectIndex >= len(a.State.Projects) {
+		return
+	}
+	proj := a.State.Projects[item.ProjectIndex]
+
+	text, what := proj.File.Path, "project file path"
+	if item.Type == ui.SelectableSpec {
+		spec := &proj.Specs[item.SpecIndex]
+		def, ok := proj.File.Sessions[spec.Name]
+		if !ok {
+			a.SetStatus(ui.StatusError, "Session definition not found in project file")
+			return
+		}
+		paths := append([]string{def.Alpha}, def.BetaEndpoints()...)
+		text, what = strings.Join(paths, "\n"), "paths of "+spec.Name
+	}
+
+	a.copyText(text, what)
+}
+
+// CopySelectedIdentifier copies the identifier of the selected spec's
+// session, for use in mutagen commands.
+func (a *App) CopySelectedIdentifier() {
+	session := a.GetSelectedSession()
+	if session == nil {
+		a.SetStatus(ui.StatusWarning, "No session selected")
+		return
+	}
+	if session.Identifier == "" {
+		a.SetStatus(ui.StatusWarning, session.Name+" has no identifier")
+		return
+	}
+	a.copyText(session.Identifier, "identifier of "+session.Name)
+}
+
+// copyText copies text to the clipboard and reports it in the status bar,
+// describing it as what.
+func (a *App) copyText(text, what string) {
+	copyText := a.Clipboard
+	if copyText == nil {
+		copyText = clipboard.Copy
+	}
+	if err := copyText(text); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to copy "+what+": "+err.Error())
+		return
+	}
+	a.SetStatus(ui.StatusInfo, "Copied "+what+": "+strings.ReplaceAll(text, "\n", "  "))
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+func TestCopySelectedPaths(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	proj := createTestProjectWithFile("proj", []string{"web"})
+	proj.File.Path = "/projects/mutagen.yml"
+	proj.Folded = false
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	var copied []string
+	app.Clipboard = func(text string) error {
+		copied = append(copied, text)
+		return nil
+	}
+
+	app.CopySelectedPaths()
+	app.State.Selection.SetIndex(1)
+	app.CopySelectedPaths()
+
+	want := []string{"/projects/mutagen.yml", "/local/path\n/remote/path"}
+	if strings.Join(copied, "|") != strings.Join(want, "|") {
+		t.Errorf("copied %q, want %q", copied, want)
+	}
+	if got := app.State.StatusMessage.Text; got != "Copied paths of web: /local/path  /remote/path" {
+		t.Errorf("status = %q", got)
+	}
+}
+
+func TestCopySelectedPaths_ReportsError(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	proj := createTestProjectWithFile("proj", []string{"web"})
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.Clipboard = func(string) error { return errors.New("no clipboard command found") }
+
+	app.CopySelectedPaths()
+
+	if status := app.State.StatusMessage; status.Type != ui.StatusError || !strings.Contains(status.Text, "no clipboard command found") {
+		t.Errorf("status = %+v, want the clipboard error", status)
+	}
+}
+
+func TestCopySelectedIdentifier(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	proj := createTestProjectWithFile("proj", []string{"web"})
+	proj.Folded = false
+	proj.Specs[0].SetSession(&mutagen.SyncSession{Name: "web", Identifier: "sync_abc123"}, project.RunningTwoWay)
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	var copied string
+	app.Clipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+
+	app.CopySelectedIdentifier()
+	if copied != "" || app.State.StatusMessage.Text != "No session selected" {
+		t.Errorf("with the project selected, copied %q; status = %q", copied, app.State.StatusMessage.Text)
+	}
+	app.State.Selection.SetIndex(1)
+	app.CopySelectedIdentifier()
+	if copied != "sync_abc123" {
+		t.Errorf("copied %q, want the identifier", copied)
+	}
+	if got := app.State.StatusMessage.Text; got != "Copied identifier of web: sync_abc123" {
+		t.Errorf("status = %q", got)
+	}
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"time"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+// cycleSampleInterval is the minimum time between samples of a session's
+// successful cycle count, so refreshes and the sync status dialog's polling
+// produce evenly spaced history.
+const cycleSampleInterval = 10 * time.Second
+
+// cycleHistoryLen is the number of samples kept per session.
+const cycleHistoryLen = 40
+
+type cycleSample struct {
+	at     time.Time
+	cycles uint64
+}
+
+// recordCycles samples the successful cycle count of each session and
+// drops the history of sessions that no longer exist.
+func (a *App) recordCycles(sessions []mutagen.SyncSession) {
+	a.cycleMu.Lock()
+	defer a.cycleMu.Unlock()
+	seen := make(map[string]bool, len(sessions))
+	for i := range sessions {
+		seen[sessionKey(&sessions[i])] = true
+		a.addCycleSample(&sessions[i])
+	}
+	for key := range a.cycleHistory {
+		if !seen[key] {
+			delete(a.cycleHistory, key)
+		}
+	}
+}
+
+// recordCycleSample samples the successful cycle count of one session.
+func (a *App) recordCycleSample(session *mutagen.SyncSession) {
+	a.cycleMu.Lock()
+	defer a.cycleMu.Unlock()
+	a.addCycleSample(session)
+}
+
+// addCycleSample is recordCycleSample with cycleMu held.
+func (a *App) addCycleSample(session *mutagen.SyncSession) {
+	if session.SuccessfulCycles == nil {
+		return
+	}
+	if a.cycleHistory == nil {
+		a.cycleHistory = make(map[string][]cycleSample)
+	}
+	key := sessionKey(session)
+	now := a.Clock.Now()
+	samples := a.cycleHistory[key]
+	if n := len(samples); n > 0 {
+		last := samples[n-1]
+		if *session.SuccessfulCycles < last.cycles {
+			samples = nil // The count went backwards, so this is a different session
+		} else if now.Sub(last.at) < cycleSampleInterval {
+			return
+		}
+	}
+	samples = append(samples, cycleSample{at: now, cycles: *session.SuccessfulCycles})
+	if len(samples) > cycleHistoryLen {
+		samples = samples[len(samples)-cycleHistoryLen:]
+	}
+	a.cycleHistory[key] = samples
+}
+
+// SelectedCycleHistory returns the number of successful cycles the selected
+// session completed between consecutive samples, oldest first.
+func (a *App) SelectedCycleHistory() []uint64 {
+	session := a.GetSelectedSession()
+	if session == nil {
+		return nil
+	}
+	a.cycleMu.Lock()
+	defer a.cycleMu.Unlock()
+	samples := a.cycleHistory[sessionKey(session)]
+	if len(samples) < 2 {
+		return nil
+	}
+	deltas := make([]uint64, len(samples)-1)
+	for i := 1; i < len(samples); i++ {
+		deltas[i-1] = samples[i].cycles - samples[i-1].cycles
+	}
+	return deltas
+}
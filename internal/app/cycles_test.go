@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/osteele/mutagui/internal/clock"
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func cyclesSession(cycles uint64) mutagen.SyncSession {
+	return mutagen.SyncSession{Name: "spec-a", Identifier: "sync_a", SuccessfulCycles: &cycles}
+}
+
+func TestSelectedCycleHistory(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	app.Clock = fake
+	proj := createTestProjectWithFile("test-proj", []string{"spec-a"})
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(1)
+
+	refresh := func(cycles uint64) {
+		mock.ListSessionsResult = []mutagen.SyncSession{cyclesSession(cycles)}
+		if err := app.RefreshSessions(context.Background(), true); err != nil {
+			t.Fatalf("RefreshSessions() error = %v", err)
+		}
+	}
+
+	refresh(10)
+	if got := app.SelectedCycleHistory(); got != nil {
+		t.Errorf("history after one sample = %v, want nil", got)
+	}
+
+	fake.Advance(cycleSampleInterval)
+	refresh(13)
+	// Samples closer together than the interval are skipped
+	fake.Advance(cycleSampleInterval / 2)
+	refresh(20)
+	fake.Advance(cycleSampleInterval / 2)
+	refresh(20)
+
+	got := app.SelectedCycleHistory()
+	if len(got) != 2 || got[0] != 3 || got[1] != 7 {
+		t.Errorf("SelectedCycleHistory() = %v, want [3 7]", got)
+	}
+
+	// History is dropped once the session is gone
+	mock.ListSessionsResult = nil
+	if err := app.RefreshSessions(context.Background(), true); err != nil {
+		t.Fatal(err)
+	}
+	if len(app.cycleHistory) != 0 {
+		t.Errorf("cycleHistory = %v, want empty after session ended", app.cycleHistory)
+	}
+}
+
+func TestRecordCycleSample_ResetsWhenCountDrops(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	app.Clock = fake
+
+	for _, cycles := range []uint64{50, 60, 2} {
+		session := cyclesSession(cycles)
+		app.recordCycleSample(&session)
+		fake.Advance(cycleSampleInterval)
+	}
+	if samples := app.cycleHistory["sync_a"]; len(samples) != 1 || samples[0].cycles != 2 {
+		t.Errorf("samples = %+v, want a fresh history at 2", samples)
+	}
+}
+
+func TestSelectedCycleHistory_ConcurrentWithRefresh(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	app.Clock = fake
+	proj := createTestProjectWithFile("test-proj", []string{"spec-a"})
+	proj.Specs[0].RunningSession = &mutagen.SyncSession{Name: "spec-a", Identifier: "sync_a"}
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(1)
+
+	// Refreshes record samples while the dialog reads them
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 200 {
+			app.recordCycles([]mutagen.SyncSession{cyclesSession(uint64(i)), {Identifier: "sync_b"}})
+			fake.Advance(cycleSampleInterval)
+		}
+	}()
+	for range 200 {
+		app.SelectedCycleHistory()
+	}
+	<-done
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// RestartDaemon stops and restarts the Mutagen daemon, which makes every
+// session reconnect. This is the fix Mutagen suggests for agent version
+// mismatches and wedged connections.
+func (a *App) RestartDaemon(ctx context.Context) {
+	a.LogEvent(ui.StatusInfo, "Stopping the Mutagen daemon")
+	if err := a.Client.StopDaemon(ctx); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to stop the daemon: "+err.Error())
+		return
+	}
+	a.LogEvent(ui.StatusInfo, "Starting the Mutagen daemon")
+	if err := a.Client.StartDaemon(ctx); err != nil {
+		a.SetStatus(ui.StatusError, "Stopped the daemon, but it failed to start: "+err.Error())
+		return
+	}
+	if a.DryRun {
+		// The stop and start were only reported, so the daemon is as it was
+		a.SetStatus(ui.StatusInfo, "Would restart the Mutagen daemon")
+		return
+	}
+	if err := a.Client.PingDaemon(ctx); err != nil {
+		a.SetStatus(ui.StatusError, "Restarted the daemon, but it isn't responding: "+err.Error())
+		return
+	}
+	a.SetStatus(ui.StatusInfo, "Restarted the Mutagen daemon")
+}
+
+// ThoroughRefresh checks that the Mutagen daemon is responding, starting it
+// if it isn't, then rescans project files and re-lists sessions. Each step
+// is reported in the event log.
+func (a *App) ThoroughRefresh(ctx context.Context) {
+	a.LogEvent(ui.StatusInfo, "Checking the Mutagen daemon")
+	started := false
+	if err := a.Client.PingDaemon(ctx); err != nil {
+		a.LogEvent(ui.StatusWarning, "Daemon is down: "+err.Error())
+		a.LogEvent(ui.StatusInfo, "Starting the Mutagen daemon")
+		if err := a.Client.StartDaemon(ctx); err != nil {
+			a.SetStatus(ui.StatusError, "Daemon is down and failed to start: "+err.Error())
+			return
+		}
+		if err := a.Client.PingDaemon(ctx); err != nil {
+			a.SetStatus(ui.StatusError, "Started the daemon, but it isn't responding: "+err.Error())
+			return
+		}
+		started = true
+	}
+	a.LogEvent(ui.StatusInfo, "Daemon is responding")
+
+	if added, err := a.RescanProjects(ctx); err != nil {
+		a.LogEvent(ui.StatusWarning, "Failed to rescan projects: "+err.Error())
+	} else if added == 0 {
+		a.LogEvent(ui.StatusInfo, "No new project files")
+	}
+
+	a.ClearStatus()
+	if err := a.RefreshSessions(ctx, true); err != nil {
+		return // RefreshSessions reported the error
+	}
+	if started {
+		a.SetStatus(ui.StatusInfo, "Started the daemon; sessions refreshed")
+	} else {
+		a.SetStatus(ui.StatusInfo, "Daemon OK; projects rescanned and sessions refreshed")
+	}
+}
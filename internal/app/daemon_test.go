@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+func TestThoroughRefresh_DaemonUp(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	app.projectBaseDir = t.TempDir()
+
+	app.ThoroughRefresh(context.Background())
+	if got := strings.Join(mock.DaemonCalls, ","); got != "ping" {
+		t.Errorf("DaemonCalls = %s, want ping", got)
+	}
+	if msg := app.State.StatusMessage; msg == nil || !strings.HasPrefix(msg.Text, "Daemon OK") {
+		t.Errorf("status = %+v, want Daemon OK", msg)
+	}
+	if app.State.LastRefresh == nil {
+		t.Error("sessions were not refreshed")
+	}
+}
+
+func TestThoroughRefresh_StartsDaemon(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	mock := &MockClient{PingDaemonError: errors.New("daemon is not responding")}
+	app := newTestApp(mock)
+	app.projectBaseDir = t.TempDir()
+
+	app.ThoroughRefresh(context.Background())
+	if got := strings.Join(mock.DaemonCalls, ","); got != "ping,start,ping" {
+		t.Errorf("DaemonCalls = %s, want ping,start,ping", got)
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Text != "Started the daemon; sessions refreshed" {
+		t.Errorf("status = %+v", msg)
+	}
+}
+
+func TestThoroughRefresh_DaemonWontStart(t *testing.T) {
+	mock := &MockClient{
+		PingDaemonError:  errors.New("daemon is not responding"),
+		StartDaemonError: errors.New("mutagen daemon start failed: permission denied"),
+	}
+	app := newTestApp(mock)
+
+	app.ThoroughRefresh(context.Background())
+	if msg := app.State.StatusMessage; msg == nil || msg.Type != ui.StatusError || !strings.Contains(msg.Text, "permission denied") {
+		t.Errorf("status = %+v, want the start error", msg)
+	}
+	if app.State.LastRefresh != nil {
+		t.Error("sessions should not be listed when the daemon is down")
+	}
+}
+
+func TestRestartDaemon(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+
+	app.RestartDaemon(context.Background())
+	if got := strings.Join(mock.DaemonCalls, ","); got != "stop,start,ping" {
+		t.Errorf("DaemonCalls = %s, want stop,start,ping", got)
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Text != "Restarted the Mutagen daemon" {
+		t.Errorf("status = %+v, want restarted", msg)
+	}
+}
+
+func TestRestartDaemon_DryRun(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	app.DryRun = true
+
+	app.RestartDaemon(context.Background())
+	if msg := app.State.StatusMessage; msg == nil || msg.Text != "[dry-run] Would restart the Mutagen daemon" {
+		t.Errorf("status = %+v, want a dry-run report rather than success", msg)
+	}
+}
+
+func TestRestartDaemon_StopFails(t *testing.T) {
+	mock := &MockClient{StopDaemonError: errors.New("mutagen daemon stop failed: unable to connect")}
+	app := newTestApp(mock)
+
+	app.RestartDaemon(context.Background())
+	if got := strings.Join(mock.DaemonCalls, ","); got != "stop" {
+		t.Errorf("DaemonCalls = %s, want stop only", got)
+	}
+	msg := app.State.StatusMessage
+	if msg == nil || msg.Type != ui.StatusError || !strings.Contains(msg.Text, "unable to connect") {
+		t.Errorf("status = %+v, want an error with the command output", msg)
+	}
+}
@@ -0,0 +1,183 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// Polling settings for waitForWatching; variables so tests can shorten them.
+var (
+	dependencyPollInterval = time.Second
+	dependencyWaitTimeout  = 30 * time.Second
+)
+
+// startOrder returns the indices of the project's specs ordered so that
+// every spec comes after the specs it depends on (its depends_on list in
+// the hooks file).
+// Specs without dependencies keep their listed order. Returns an error
+// naming the specs involved if a dependency is unknown or forms a cycle.
+func startOrder(proj *project.Project) ([]int, error) {
+	index := make(map[string]int, len(proj.Specs))
+	for i, spec := range proj.Specs {
+		index[spec.Name] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	marks := make([]int, len(proj.Specs))
+	order := make([]int, 0, len(proj.Specs))
+	var path []string
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		name := proj.Specs[i].Name
+		switch marks[i] {
+		case done:
+			return nil
+		case visiting:
+			// Report the cycle starting from the first visit of this spec
+			start := 0
+			for path[start] != name {
+				start++
+			}
+			return fmt.Errorf("dependency cycle: %s → %s", strings.Join(path[start:], " → "), name)
+		}
+
+		marks[i] = visiting
+		path = append(path, name)
+		for _, dep := range proj.File.Hooks.DependsOn[name] {
+			j, ok := index[dep]
+			if !ok {
+				return fmt.Errorf("%s depends on unknown spec %s", name, dep)
+			}
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		marks[i] = done
+		order = append(order, i)
+		return nil
+	}
+
+	for i := range proj.Specs {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// startWaves groups the specs to start, given in start order, into waves
+// whose specs can be started concurrently: each spec goes in the wave after
+// the latest one holding a spec it depends on. Running specs and specs
+// without a definition are left out.
+func startWaves(proj *project.Project, order []int) [][]int {
+	waveOf := make(map[string]int)
+	var waves [][]int
+	for _, i := range order {
+		spec := &proj.Specs[i]
+		if _, ok := proj.File.Sessions[spec.Name]; !ok || spec.IsRunning() {
+			continue
+		}
+		wave := 0
+		for _, dep := range proj.File.Hooks.DependsOn[spec.Name] {
+			if w, ok := waveOf[dep]; ok {
+				wave = max(wave, w+1)
+			}
+		}
+		waveOf[spec.Name] = wave
+		if wave == len(waves) {
+			waves = append(waves, nil)
+		}
+		waves[wave] = append(waves[wave], i)
+	}
+	return waves
+}
+
+// waveDependencies returns the session names that the specs of a wave
+// depend on, without duplicates.
+func waveDependencies(proj *project.Project, wave []int) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, i := range wave {
+		for _, name := range dependencySessions(proj, &proj.Specs[i]) {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// dependencySessions returns the session names of a spec's dependencies:
+// each running session's name (a push session has a -push suffix), and
+// otherwise the name starting it uses, which for a spec with several betas
+// is one per replica.
+func dependencySessions(proj *project.Project, spec *project.SyncSpec) []string {
+	var names []string
+	for _, dep := range proj.File.Hooks.DependsOn[spec.Name] {
+		def := proj.File.Sessions[dep]
+		replicas := def.Replicas(dep)
+		var running *project.SyncSpec
+		for i := range proj.Specs {
+			if proj.Specs[i].Name == dep {
+				running = &proj.Specs[i]
+			}
+		}
+		for i, replica := range replicas {
+			name := replica.Name
+			if running != nil {
+				switch {
+				case len(replicas) == 1 && running.RunningSession != nil:
+					name = running.RunningSession.Name
+				case i < len(running.BetaSessions) && running.BetaSessions[i] != nil:
+					name = running.BetaSessions[i].Name
+				}
+			}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// waitForWatching polls a session until its status is watching, meaning
+// its initial sync has finished. A paused session never gets there, and in
+// dry run the session wasn't created, so neither is waited for.
+func (a *App) waitForWatching(ctx context.Context, name string) error {
+	if a.DryRun {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, dependencyWaitTimeout)
+	defer cancel()
+
+	a.LogEvent(ui.StatusInfo, "Waiting for "+name+" to finish its initial sync")
+	for {
+		session, err := a.Client.GetSession(ctx, name)
+		if err == nil && session.Paused {
+			a.LogEvent(ui.StatusWarning, "Not waiting for "+name+", which is paused")
+			return nil
+		}
+		if err == nil && strings.Contains(strings.ToLower(session.Status), "watching") {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s did not finish its initial sync within %s", name, dependencyWaitTimeout)
+		case <-time.After(dependencyPollInterval):
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// newDependencyProject returns a project whose specs depend on each other
+// as given by deps (spec name → dependencies).
+func newDependencyProject(names []string, deps map[string][]string) *project.Project {
+	proj := createTestProjectWithFile("test-proj", names)
+	proj.File.Hooks.DependsOn = deps
+	return proj
+}
+
+func TestStartOrder(t *testing.T) {
+	proj := newDependencyProject([]string{"app", "code", "config"}, map[string][]string{
+		"app":  {"code"},
+		"code": {"config"},
+	})
+	order, err := startOrder(proj)
+	if err != nil {
+		t.Fatalf("startOrder() error = %v", err)
+	}
+	var names []string
+	for _, i := range order {
+		names = append(names, proj.Specs[i].Name)
+	}
+	if got := strings.Join(names, ","); got != "config,code,app" {
+		t.Errorf("startOrder() = %s, want config,code,app", got)
+	}
+}
+
+func TestStartOrder_Errors(t *testing.T) {
+	cyclic := newDependencyProject([]string{"a", "b", "c"}, map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"b"},
+	})
+	if _, err := startOrder(cyclic); err == nil || !strings.Contains(err.Error(), "b → c → b") {
+		t.Errorf("startOrder() error = %v, want cycle b → c → b", err)
+	}
+
+	unknown := newDependencyProject([]string{"a"}, map[string][]string{"a": {"missing"}})
+	if _, err := startOrder(unknown); err == nil || !strings.Contains(err.Error(), "unknown spec missing") {
+		t.Errorf("startOrder() error = %v, want unknown spec", err)
+	}
+}
+
+func TestStartWaves(t *testing.T) {
+	proj := newDependencyProject([]string{"app", "code", "config", "docs", "api"}, map[string][]string{
+		"app":  {"code", "config"},
+		"code": {"config"},
+		"api":  {"config"},
+	})
+	proj.Specs[3].State = project.RunningTwoWay // docs is already running
+	order, err := startOrder(proj)
+	if err != nil {
+		t.Fatalf("startOrder() error = %v", err)
+	}
+
+	var waves []string
+	for _, wave := range startWaves(proj, order) {
+		var names []string
+		for _, i := range wave {
+			names = append(names, proj.Specs[i].Name)
+		}
+		waves = append(waves, strings.Join(names, ","))
+	}
+	if got := strings.Join(waves, " | "); got != "config | code,api | app" {
+		t.Errorf("startWaves() = %s, want config | code,api | app", got)
+	}
+}
+
+func TestStartSelectedProject_StartsDependenciesFirst(t *testing.T) {
+	interval := dependencyPollInterval
+	dependencyPollInterval = time.Millisecond
+	t.Cleanup(func() { dependencyPollInterval = interval })
+
+	mock := &MockClient{ListSessionsResult: []mutagen.SyncSession{{Name: "config", Status: "Watching for changes"}}}
+	app := newTestApp(mock)
+	proj := newDependencyProject([]string{"app", "config"}, map[string][]string{"app": {"config"}})
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	app.StartSelectedProject(context.Background())
+
+	if len(mock.CreateSessionCalls) != 2 || mock.CreateSessionCalls[0].Name != "config" || mock.CreateSessionCalls[1].Name != "app" {
+		t.Errorf("CreateSessionCalls = %+v, want config then app", mock.CreateSessionCalls)
+	}
+	if len(mock.GetSessionCalls) == 0 || mock.GetSessionCalls[0] != "config" {
+		t.Errorf("GetSessionCalls = %v, want a wait on config", mock.GetSessionCalls)
+	}
+}
+
+func TestStartSelectedProject_RefusesCycle(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	proj := newDependencyProject([]string{"a", "b"}, map[string][]string{"a": {"b"}, "b": {"a"}})
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	app.StartSelectedProject(context.Background())
+	if len(mock.CreateSessionCalls) != 0 {
+		t.Errorf("CreateSessionCalls = %d, want none", len(mock.CreateSessionCalls))
+	}
+	if msg := app.State.StatusMessage; msg == nil || !strings.Contains(msg.Text, "dependency cycle") {
+		t.Errorf("status = %+v, want dependency cycle error", msg)
+	}
+}
+
+func TestStartSelectedProject_DoesNotWaitForPausedDependency(t *testing.T) {
+	mock := &MockClient{ListSessionsResult: []mutagen.SyncSession{{Name: "config", Status: "Scanning files", Paused: true}}}
+	app := newTestApp(mock)
+	proj := newDependencyProject([]string{"app", "config"}, map[string][]string{"app": {"config"}})
+	proj.Specs[1].State = project.RunningTwoWay
+	proj.Specs[1].RunningSession = &mutagen.SyncSession{Name: "config", Paused: true}
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	app.StartSelectedProject(context.Background())
+	if len(mock.CreateSessionCalls) != 1 || mock.CreateSessionCalls[0].Name != "app" {
+		t.Errorf("CreateSessionCalls = %+v, want app started without waiting", mock.CreateSessionCalls)
+	}
+}
+
+func TestStartSelectedProject_DryRunDoesNotWait(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	app.DryRun = true
+	proj := newDependencyProject([]string{"app", "config"}, map[string][]string{"app": {"config"}})
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	app.StartSelectedProject(context.Background())
+	if len(mock.GetSessionCalls) != 0 {
+		t.Errorf("GetSessionCalls = %v, want no wait in dry run", mock.GetSessionCalls)
+	}
+	if len(mock.CreateSessionCalls) != 2 {
+		t.Errorf("CreateSessionCalls = %d, want both specs reported", len(mock.CreateSessionCalls))
+	}
+}
+
+func TestDependencySessions_Replicas(t *testing.T) {
+	proj := newDependencyProject([]string{"app", "config"}, map[string][]string{"app": {"config"}})
+	proj.File.Sessions["config"] = project.SessionDefinition{
+		Alpha: "/local/config",
+		Betas: []string{"east:/srv/config", "west:/srv/config"},
+	}
+	app := &proj.Specs[0]
+	config := &proj.Specs[1]
+	if app.Name != "app" {
+		app, config = config, app
+	}
+
+	want := "config@east, config@west"
+	if got := strings.Join(dependencySessions(proj, app), ", "); got != want {
+		t.Errorf("not running: dependencySessions() = %s, want %s", got, want)
+	}
+
+	config.BetaSessions = []*mutagen.SyncSession{{Name: "config@east"}, {Name: "config@west-push"}}
+	config.RunningSession = config.BetaSessions[0]
+	want = "config@east, config@west-push"
+	if got := strings.Join(dependencySessions(proj, app), ", "); got != want {
+		t.Errorf("running: dependencySessions() = %s, want %s", got, want)
+	}
+}
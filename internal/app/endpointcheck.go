@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// runSSHCheck connects to host and runs true, returning ssh's output. It is
+// a variable so tests can replace it.
+var runSSHCheck = func(ctx context.Context, host string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*mutagen.SSHConnectTimeout)
+	defer cancel()
+	args := append(mutagen.SSHOptions(), host, "true")
+	return exec.CommandContext(ctx, "ssh", args...).CombinedOutput()
+}
+
+// CheckEndpoint checks that a session could reach an endpoint, so that a
+// start fails quickly with a clear message rather than slowly inside
+// Mutagen. An SSH endpoint's host must accept a connection; a local path
+// must be writable, or be creatable in a writable directory. URL-style
+// endpoints are left to Mutagen.
+func (a *App) CheckEndpoint(ctx context.Context, endpoint string) error {
+	e := project.ParseEndpoint(endpoint)
+	switch {
+	case e.IsSSH():
+		output, err := runSSHCheck(ctx, e.SSHDestination())
+		if err == nil {
+			return nil
+		}
+		text := strings.TrimSpace(string(output))
+		if text == "" {
+			text = err.Error()
+		}
+		return mutagen.WrapConnectionError(e.Host+" is unreachable: "+lastLine(text), text)
+	case e.IsLocal():
+		return checkLocalPath(project.NormalizeEndpoint(e.Path, ""))
+	}
+	return nil
+}
+
+// checkLocalPath checks that path, or the nearest directory above it that
+// exists, is a writable directory.
+func checkLocalPath(path string) error {
+	dir := path
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", dir)
+			}
+			break
+		}
+		if !errors.Is(err, fs.ErrNotExist) || filepath.Dir(dir) == dir {
+			return err
+		}
+		dir = filepath.Dir(dir)
+	}
+
+	if !isWritable(dir) {
+		return fmt.Errorf("%s is not writable", dir)
+	}
+	return nil
+}
+
+// checkSessionEndpoints checks a definition's endpoints, alpha and each
+// beta, with CheckEndpoint. It is skipped in dry-run mode, where no session is
+// created and the preparation that would follow is only logged.
+func (a *App) checkSessionEndpoints(ctx context.Context, proj *project.Project, def *project.SessionDefinition) error {
+	if a.DryRun {
+		return nil
+	}
+	for _, endpoint := range append([]string{def.Alpha}, def.BetaEndpoints()...) {
+		if project.ParseEndpoint(endpoint).IsLocal() {
+			// Relative paths are relative to the project file
+			endpoint = project.NormalizeEndpoint(endpoint, proj.File.Dir())
+		}
+		if err := a.CheckEndpoint(ctx, endpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lastLine returns the last line of text, which is where ssh puts its
+// reason for failing.
+func lastLine(text string) string {
+	return text[strings.LastIndex(text, "\n")+1:]
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// stubSSHCheck replaces runSSHCheck for the test, recording the hosts it is
+// called with.
+func stubSSHCheck(t *testing.T, output string, err error) *[]string {
+	t.Helper()
+	var hosts []string
+	original := runSSHCheck
+	runSSHCheck = func(ctx context.Context, host string) ([]byte, error) {
+		hosts = append(hosts, host)
+		return []byte(output), err
+	}
+	t.Cleanup(func() { runSSHCheck = original })
+	return &hosts
+}
+
+func TestCheckEndpoint_SSH(t *testing.T) {
+	hosts := stubSSHCheck(t, "", nil)
+	app := newTestApp(&MockClient{})
+	if err := app.CheckEndpoint(context.Background(), "me@studio:/srv/web"); err != nil {
+		t.Errorf("CheckEndpoint() = %v", err)
+	}
+	if !slices.Equal(*hosts, []string{"me@studio"}) {
+		t.Errorf("ssh hosts = %v, want [me@studio]", *hosts)
+	}
+}
+
+func TestCheckEndpoint_SSHUnreachable(t *testing.T) {
+	stubSSHCheck(t, "ssh: connect to host studio port 22: Connection refused\n", errors.New("exit status 255"))
+	app := newTestApp(&MockClient{})
+	err := app.CheckEndpoint(context.Background(), "me@studio:/srv/web")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.HasPrefix(err.Error(), "studio is unreachable: ") || !strings.Contains(err.Error(), "hint:") {
+		t.Errorf("error = %q, want an unreachable message with a hint", err)
+	}
+}
+
+func TestCheckEndpoint_Local(t *testing.T) {
+	hosts := stubSSHCheck(t, "", nil)
+	app := newTestApp(&MockClient{})
+	dir := t.TempDir()
+
+	// The check doesn't write into the sync root, where Mutagen would see it
+	if err := app.CheckEndpoint(context.Background(), dir); err != nil {
+		t.Errorf("CheckEndpoint(dir) = %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("the check wrote into the directory: %v", entries)
+	}
+
+	// A directory that doesn't exist yet is fine if it can be created
+	if err := app.CheckEndpoint(context.Background(), filepath.Join(dir, "new", "web")); err != nil {
+		t.Errorf("CheckEndpoint(missing dir) = %v", err)
+	}
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.CheckEndpoint(context.Background(), filepath.Join(file, "web")); err == nil {
+		t.Error("a path below a file should be an error")
+	}
+	if os.Geteuid() != 0 { // root can write anywhere
+		readOnly := filepath.Join(dir, "ro")
+		if err := os.Mkdir(readOnly, 0o555); err != nil {
+			t.Fatal(err)
+		}
+		if err := app.CheckEndpoint(context.Background(), readOnly); err == nil || !strings.Contains(err.Error(), "not writable") {
+			t.Errorf("CheckEndpoint(read-only) = %v, want not writable", err)
+		}
+	}
+	if len(*hosts) != 0 {
+		t.Errorf("local paths ran ssh to %v", *hosts)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("the check left files behind: %v", entries)
+	}
+}
+
+func TestCheckEndpoint_URLSkipped(t *testing.T) {
+	hosts := stubSSHCheck(t, "", errors.New("unreachable"))
+	app := newTestApp(&MockClient{})
+	if err := app.CheckEndpoint(context.Background(), "docker://web/app"); err != nil {
+		t.Errorf("CheckEndpoint(docker) = %v", err)
+	}
+	if len(*hosts) != 0 {
+		t.Errorf("docker endpoint ran ssh to %v", *hosts)
+	}
+}
+
+func TestStartSelectedSpec_UnreachableKeepsSession(t *testing.T) {
+	stubSSHCheck(t, "ssh: Could not resolve hostname studio: nodename nor servname provided\n", errors.New("exit status 255"))
+	mock := &MockClient{}
+	app := newSpecApp(mock, project.NotRunning, "")
+	sessions := app.State.Projects[0].File.Sessions
+	def := sessions["web"]
+	def.Beta = "studio:/srv/web"
+	sessions["web"] = def
+
+	app.StartSelectedSpec(context.Background())
+
+	if len(mock.TerminateCalls) != 0 || len(mock.CreateSessionCalls) != 0 {
+		t.Errorf("terminated %v and created %v, want neither", mock.TerminateCalls, mock.CreateSessionCalls)
+	}
+	msg := app.State.StatusMessage
+	if msg == nil || !strings.HasPrefix(msg.Text, "Not starting web: studio is unreachable") || !strings.Contains(msg.Text, "check the host name") {
+		t.Errorf("status = %+v", msg)
+	}
+}
+
+func TestPushSelectedProject_ChecksEndpointsFirst(t *testing.T) {
+	stubSSHCheck(t, "ssh: connect to host west port 22: Connection refused\n", errors.New("exit status 255"))
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	proj := createTestProjectWithFile("test-proj", []string{"web"})
+	proj.File.Sessions["web"] = project.SessionDefinition{Alpha: "docker://builder/web", Betas: []string{"docker://east/web", "west:/srv/web"}}
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.recordUndo("Pause web", func(ctx context.Context) error { return nil })
+
+	app.PushSelectedProject(context.Background())
+	if len(mock.ProjectCalls)+len(mock.TerminateCalls)+len(mock.CreatePushSessionCalls) != 0 {
+		t.Errorf("project %v, terminate %v, create %+v; want nothing touched", mock.ProjectCalls, mock.TerminateCalls, mock.CreatePushSessionCalls)
+	}
+	if msg := app.State.StatusMessage; msg == nil || !strings.Contains(msg.Text, "Not pushing web: west is unreachable") {
+		t.Errorf("status = %+v, want the unreachable beta", msg)
+	}
+
+	// A failure after the terminate leaves nothing to undo
+	stubSSHCheck(t, "", nil)
+	mock.CreatePushSessionError = errors.New("connection refused")
+	app.PushSelectedProject(context.Background())
+	if app.lastAction == nil || app.lastAction.run != nil {
+		t.Errorf("lastAction = %+v, want no undo after the sessions were replaced", app.lastAction)
+	}
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// focusState records a focus: what was left running, and the sessions
+// that were paused to quiet everything else.
+type focusState struct {
+	target string   // The spec or project left running
+	paused []string // Names of the sessions the focus paused
+}
+
+// ToggleFocus pauses every running session except those of the selected
+// spec, or of the selected project, so that one sync can be watched
+// without the others' activity. Pressed again, it resumes the sessions it
+// paused, and only those: sessions that were already paused stay paused.
+func (a *App) ToggleFocus(ctx context.Context) {
+	if a.focus != nil {
+		a.unfocus(ctx)
+		return
+	}
+
+	item := a.State.Selection.SelectedItem()
+	if item == nil || item.ProjectIndex < 0 || item.ProjectIndex >= len(a.State.Projects) {
+		a.SetStatus(ui.StatusWarning, "No project or spec selected")
+		return
+	}
+	focused := a.State.Projects[item.ProjectIndex]
+	target := focused.File.DisplayName()
+	if item.Type == ui.SelectableSpec {
+		target = focused.Specs[item.SpecIndex].Name
+	}
+
+	var names []string
+	for projIdx, proj := range a.State.Projects {
+		for specIdx := range proj.Specs {
+			if projIdx == item.ProjectIndex && (item.Type == ui.SelectableProject || specIdx == item.SpecIndex) {
+				continue
+			}
+			for _, session := range proj.Specs[specIdx].Sessions() {
+				if !session.Paused {
+					names = append(names, session.Name)
+				}
+			}
+		}
+	}
+	if len(names) == 0 {
+		a.SetStatus(ui.StatusWarning, "No other running sessions to pause")
+		return
+	}
+
+	errs := runConcurrently(len(names), func(k int) error {
+		return a.Client.PauseSession(ctx, names[k])
+	})
+	var paused []string
+	for i, name := range names {
+		if errs[i] == nil {
+			paused = append(paused, name)
+		}
+	}
+	if len(paused) > 0 {
+		a.focus = &focusState{target: target, paused: paused}
+	}
+	a.recordPauseUndo(paused, true)
+
+	if a.reportFailures("Paused", "pause", names, errs) > 0 {
+		return
+	}
+	a.LogEvent(ui.StatusInfo, fmt.Sprintf("Focused on %s: paused %d other session(s)", target, len(paused)))
+	a.SetStatus(ui.StatusInfo, fmt.Sprintf("Focused on %s: paused %d other session(s); press %s to resume them", target, len(paused), ui.ActionKey("focus")))
+}
+
+// unfocus resumes the sessions the focus paused that are still paused.
+// Sessions that fail to resume stay in the focus, so toggling it again
+// retries them.
+func (a *App) unfocus(ctx context.Context) {
+	focus := a.focus
+	var names []string
+	for _, name := range focus.paused {
+		if session := a.sessionNamed(name); session != nil && session.Paused {
+			names = append(names, name)
+		}
+	}
+
+	errs := runConcurrently(len(names), func(k int) error {
+		return a.Client.ResumeSession(ctx, names[k])
+	})
+	var resumed, failed []string
+	for i, name := range names {
+		if errs[i] == nil {
+			resumed = append(resumed, name)
+		} else {
+			failed = append(failed, name)
+		}
+	}
+	a.focus = nil
+	if len(failed) > 0 {
+		a.focus = &focusState{target: focus.target, paused: failed}
+	}
+	a.recordPauseUndo(resumed, false)
+
+	if a.reportFailures("Resumed", "resume", names, errs) > 0 {
+		return
+	}
+	a.LogEvent(ui.StatusInfo, fmt.Sprintf("Left focus on %s: resumed %d session(s)", focus.target, len(resumed)))
+	a.SetStatus(ui.StatusInfo, fmt.Sprintf("Left focus on %s: resumed %d session(s)", focus.target, len(resumed)))
+}
+
+// sessionNamed returns the running session with the given name, or nil if
+// there is none.
+func (a *App) sessionNamed(name string) *mutagen.SyncSession {
+	for _, proj := range a.State.Projects {
+		for i := range proj.Specs {
+			sessions := proj.Specs[i].Sessions()
+			if j := slices.IndexFunc(sessions, func(s *mutagen.SyncSession) bool { return s.Name == name }); j >= 0 {
+				return sessions[j]
+			}
+		}
+	}
+	return nil
+}
+
+// FocusTarget returns the spec or project that focus mode leaves running,
+// or false outside focus mode.
+func (a *App) FocusTarget() (string, bool) {
+	if a.focus == nil {
+		return "", false
+	}
+	return a.focus.target, true
+}
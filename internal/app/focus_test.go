@@ -0,0 +1,125 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// newFocusApp creates an app with two unfolded projects: site, running web
+// and api, and blog, running posts and with drafts paused by the user.
+func newFocusApp(mock *MockClient) *App {
+	app := newTestApp(mock)
+	site := createTestProjectWithFile("site", []string{"api", "web"})
+	blog := createTestProjectWithFile("blog", []string{"drafts", "posts"})
+	site.File.Path, blog.File.Path = "/projects/site.yml", "/projects/blog.yml"
+	for _, proj := range []*project.Project{site, blog} {
+		proj.Folded = false
+		for i := range proj.Specs {
+			spec := &proj.Specs[i]
+			spec.SetSession(&mutagen.SyncSession{Name: spec.Name}, project.RunningTwoWay)
+		}
+	}
+	blog.Specs[0].RunningSession.Paused = true
+	app.State.Projects = []*project.Project{site, blog}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	return app
+}
+
+// applyPauses marks sessions paused or resumed as the mock was asked to.
+func applyPauses(app *App, names []string, paused bool) {
+	for _, name := range names {
+		app.sessionNamed(name).Paused = paused
+	}
+}
+
+func TestToggleFocus_Spec(t *testing.T) {
+	mock := &MockClient{}
+	app := newFocusApp(mock)
+	ctx := context.Background()
+	app.State.Selection.SetIndex(2) // site/web
+
+	app.ToggleFocus(ctx)
+	slices.Sort(mock.PauseCalls)
+	if want := []string{"api", "posts"}; !slices.Equal(mock.PauseCalls, want) {
+		t.Errorf("paused %v, want %v: every other running session", mock.PauseCalls, want)
+	}
+	if target, ok := app.FocusTarget(); !ok || target != "web" {
+		t.Errorf("FocusTarget() = %q, %v; want web", target, ok)
+	}
+	if got := app.State.StatusMessage.Text; got != "Focused on web: paused 2 other session(s); press z to resume them" {
+		t.Errorf("status = %q", got)
+	}
+	applyPauses(app, mock.PauseCalls, true)
+
+	// The user resumes api by hand before leaving focus
+	app.sessionNamed("api").Paused = false
+	app.ToggleFocus(ctx)
+	if want := []string{"posts"}; !slices.Equal(mock.ResumeCalls, want) {
+		t.Errorf("resumed %v, want %v: not drafts, which the user paused", mock.ResumeCalls, want)
+	}
+	if _, ok := app.FocusTarget(); ok {
+		t.Error("focus should have ended")
+	}
+}
+
+func TestToggleFocus_Project(t *testing.T) {
+	mock := &MockClient{}
+	app := newFocusApp(mock)
+	app.State.Selection.SetIndex(0) // site
+
+	app.ToggleFocus(context.Background())
+	if want := []string{"posts"}; !slices.Equal(mock.PauseCalls, want) {
+		t.Errorf("paused %v, want %v: only the other project's running session", mock.PauseCalls, want)
+	}
+	if target, _ := app.FocusTarget(); target != "site" {
+		t.Errorf("FocusTarget() = %q, want site", target)
+	}
+}
+
+func TestToggleFocus_RetriesFailedResumes(t *testing.T) {
+	mock := &MockClient{}
+	app := newFocusApp(mock)
+	ctx := context.Background()
+	app.State.Selection.SetIndex(2)
+	app.ToggleFocus(ctx)
+	applyPauses(app, mock.PauseCalls, true)
+
+	mock.ResumeError = errors.New("daemon unavailable")
+	app.ToggleFocus(ctx)
+	if _, ok := app.FocusTarget(); !ok {
+		t.Fatal("focus ended although its sessions weren't resumed")
+	}
+
+	mock.ResumeError = nil
+	mock.ResumeCalls = nil
+	app.ToggleFocus(ctx)
+	slices.Sort(mock.ResumeCalls)
+	if want := []string{"api", "posts"}; !slices.Equal(mock.ResumeCalls, want) {
+		t.Errorf("resumed %v, want %v", mock.ResumeCalls, want)
+	}
+	if _, ok := app.FocusTarget(); ok {
+		t.Error("focus should have ended")
+	}
+}
+
+func TestToggleFocus_NothingElseRunning(t *testing.T) {
+	mock := &MockClient{}
+	app := newFocusApp(mock)
+	app.State.Projects = app.State.Projects[:1]
+	app.State.Projects[0].Specs[0].RunningSession = nil
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(2) // web
+
+	app.ToggleFocus(context.Background())
+	if len(mock.PauseCalls) != 0 {
+		t.Errorf("paused %v, want nothing", mock.PauseCalls)
+	}
+	if _, ok := app.FocusTarget(); ok {
+		t.Error("focus shouldn't start with nothing to pause")
+	}
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// PauseForward pauses a network forwarding session. Forwards are addressed
+// by identifier, since naming them is optional; name is used in messages.
+func (a *App) PauseForward(ctx context.Context, identifier, name string) {
+	if err := a.Client.PauseForward(ctx, identifier); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to pause forward "+name+": "+err.Error())
+		return
+	}
+	a.recordNoUndo("Forwards aren't tracked for undo; press " + ui.ActionKey("pause") + " to resume the forward")
+	a.SetStatus(ui.StatusInfo, "Paused forward: "+name)
+}
+
+// ResumeForward resumes a paused network forwarding session.
+func (a *App) ResumeForward(ctx context.Context, identifier, name string) {
+	if err := a.Client.ResumeForward(ctx, identifier); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to resume forward "+name+": "+err.Error())
+		return
+	}
+	a.recordNoUndo("Forwards aren't tracked for undo; press " + ui.ActionKey("pause") + " to pause the forward")
+	a.SetStatus(ui.StatusInfo, "Resumed forward: "+name)
+}
+
+// TerminateForward terminates a network forwarding session.
+func (a *App) TerminateForward(ctx context.Context, identifier, name string) {
+	if err := a.Client.TerminateForward(ctx, identifier); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to terminate forward "+name+": "+err.Error())
+		return
+	}
+	a.recordNoUndo("Terminated forwards can't be recreated")
+	a.SetStatus(ui.StatusInfo, "Terminated forward: "+name)
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+func TestForwardActions(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	ctx := context.Background()
+
+	app.PauseForward(ctx, "forward_1", "web")
+	app.ResumeForward(ctx, "forward_1", "web")
+	app.TerminateForward(ctx, "forward_1", "web")
+
+	want := []string{"pause forward_1", "resume forward_1", "terminate forward_1"}
+	if !slices.Equal(mock.ForwardCalls, want) {
+		t.Errorf("ForwardCalls = %v, want %v", mock.ForwardCalls, want)
+	}
+	if got := app.State.StatusMessage.Text; got != "Terminated forward: web" {
+		t.Errorf("status = %q", got)
+	}
+}
+
+func TestPauseForward_ReportsError(t *testing.T) {
+	mock := &MockClient{ForwardError: errors.New("no such session")}
+	app := newTestApp(mock)
+
+	app.PauseForward(context.Background(), "forward_1", "web")
+
+	status := app.State.StatusMessage
+	if status.Type != ui.StatusError || !strings.Contains(status.Text, "web") || !strings.Contains(status.Text, "no such session") {
+		t.Errorf("status = %+v, want an error naming the forward", status)
+	}
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// SessionActions are the actions that RunSessionAction performs.
+var SessionActions = []string{"start", "pause", "resume", "terminate", "flush", "reset"}
+
+// RunSessionAction performs one action on the named session, for scripts
+// that run mutagui without the TUI, and returns what it did. Starting
+// creates the session from the spec of that name in the loaded project
+// files, running the project's pre-start hook first, unless Mutagen already
+// lists a session of the spec, which it leaves alone; the other actions go
+// straight to the client, so they work on any session Mutagen knows.
+func (a *App) RunSessionAction(ctx context.Context, name, action string) (string, error) {
+	var err error
+	switch action {
+	case "start":
+		proj := a.projectDefining(name)
+		if proj == nil {
+			return "", fmt.Errorf("no project file defines a session named %s", name)
+		}
+		running, listErr := a.specRunning(ctx, proj, name)
+		if listErr != nil {
+			return "", fmt.Errorf("failed to list sessions: %w", listErr)
+		}
+		if running {
+			return name + " is already running", nil
+		}
+		if err := a.RunPreStartHook(ctx, proj); err != nil {
+			return "", fmt.Errorf("not starting %s: %w", name, err)
+		}
+		_, err = a.startProjectSpec(ctx, proj, name)
+	case "pause":
+		err = a.Client.PauseSession(ctx, name)
+	case "resume":
+		err = a.Client.ResumeSession(ctx, name)
+	case "terminate":
+		err = a.Client.TerminateSession(ctx, name)
+	case "flush":
+		err = a.Client.FlushSession(ctx, name)
+	case "reset":
+		err = a.Client.ResetSession(ctx, name)
+	default:
+		return "", fmt.Errorf("unknown action %q (want %s)", action, strings.Join(SessionActions, ", "))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to %s %s: %w", action, name, err)
+	}
+	return pastTense[action] + " " + name, nil
+}
+
+// projectDefining returns the first loaded project whose file defines a
+// session named name, or nil if none does.
+func (a *App) projectDefining(name string) *project.Project {
+	for _, proj := range a.State.Projects {
+		if _, ok := proj.File.Sessions[name]; ok && !proj.Orphaned {
+			return proj
+		}
+	}
+	return nil
+}
+
+// specRunning returns true if Mutagen lists a session of the named spec of
+// proj, or of any of its replicas.
+func (a *App) specRunning(ctx context.Context, proj *project.Project, name string) (bool, error) {
+	sessions, err := a.Client.ListSessions(ctx)
+	if err != nil {
+		return false, err
+	}
+	def := proj.File.Sessions[name]
+	for _, replica := range def.Replicas(name) {
+		for _, session := range sessions {
+			if session.Name == replica.Name {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// pastTense describes each session action once it is done.
+var pastTense = map[string]string{
+	"start":     "Started",
+	"pause":     "Paused",
+	"resume":    "Resumed",
+	"terminate": "Terminated",
+	"flush":     "Flushed",
+	"reset":     "Reset",
+}
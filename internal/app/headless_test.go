@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestRunSessionAction_Dispatch(t *testing.T) {
+	tests := []struct {
+		action string
+		calls  func(*MockClient) []string
+	}{
+		{"pause", func(m *MockClient) []string { return m.PauseCalls }},
+		{"resume", func(m *MockClient) []string { return m.ResumeCalls }},
+		{"terminate", func(m *MockClient) []string { return m.TerminateCalls }},
+		{"flush", func(m *MockClient) []string { return m.FlushCalls }},
+		{"reset", func(m *MockClient) []string { return m.ResetCalls }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			mock := &MockClient{}
+			app := newTestApp(mock)
+			out, err := app.RunSessionAction(context.Background(), "site", tt.action)
+			if err != nil {
+				t.Fatalf("RunSessionAction() error = %v", err)
+			}
+			if got := tt.calls(mock); !slices.Equal(got, []string{"site"}) {
+				t.Errorf("%s calls = %v, want [site]", tt.action, got)
+			}
+			if !strings.HasSuffix(out, " site") {
+				t.Errorf("output = %q, want it to name the session", out)
+			}
+		})
+	}
+}
+
+func TestRunSessionAction_StartUsesDefinition(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	app.State.Projects = []*project.Project{createTestProjectWithFile("proj", []string{"site"})}
+
+	out, err := app.RunSessionAction(context.Background(), "site", "start")
+	if err != nil {
+		t.Fatalf("RunSessionAction() error = %v", err)
+	}
+	if len(mock.CreateSessionCalls) != 1 || mock.CreateSessionCalls[0].Name != "site" || mock.CreateSessionCalls[0].Alpha != "/local/path" {
+		t.Errorf("CreateSessionCalls = %+v, want site from its definition", mock.CreateSessionCalls)
+	}
+	if out != "Started site" {
+		t.Errorf("output = %q, want Started site", out)
+	}
+
+	if _, err := app.RunSessionAction(context.Background(), "nowhere", "start"); err == nil || !strings.Contains(err.Error(), "no project file defines") {
+		t.Errorf("starting an undefined session: error = %v", err)
+	}
+}
+
+func TestRunSessionAction_StartAlreadyRunning(t *testing.T) {
+	mock := &MockClient{ListSessionsResult: []mutagen.SyncSession{{Name: "site"}}}
+	app := newTestApp(mock)
+	app.State.Projects = []*project.Project{createTestProjectWithFile("proj", []string{"site"})}
+
+	out, err := app.RunSessionAction(context.Background(), "site", "start")
+	if err != nil {
+		t.Fatalf("RunSessionAction() error = %v", err)
+	}
+	if out != "site is already running" {
+		t.Errorf("output = %q, want site is already running", out)
+	}
+	if len(mock.TerminateCalls)+len(mock.CreateSessionCalls) != 0 {
+		t.Errorf("terminated %v and created %+v, want the running session left alone", mock.TerminateCalls, mock.CreateSessionCalls)
+	}
+
+	mock.ListSessionsResult, mock.ListSessionsError = nil, errors.New("daemon not running")
+	if _, err := app.RunSessionAction(context.Background(), "site", "start"); err == nil || !strings.Contains(err.Error(), "daemon not running") {
+		t.Errorf("error = %v, want the list failure", err)
+	}
+}
+
+func TestRunSessionAction_StartFailure(t *testing.T) {
+	mock := &MockClient{CreateSessionError: errors.New("connection refused")}
+	app := newTestApp(mock)
+	app.State.Projects = []*project.Project{createTestProjectWithFile("proj", []string{"site"})}
+
+	out, err := app.RunSessionAction(context.Background(), "site", "start")
+	if err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("RunSessionAction() = %q, %v; want the create's failure", out, err)
+	}
+}
+
+func TestRunSessionAction_Errors(t *testing.T) {
+	mock := &MockClient{PauseError: errors.New("no such session")}
+	app := newTestApp(mock)
+
+	_, err := app.RunSessionAction(context.Background(), "site", "pause")
+	if err == nil || err.Error() != "failed to pause site: no such session" {
+		t.Errorf("error = %v, want the client's failure", err)
+	}
+
+	_, err = app.RunSessionAction(context.Background(), "site", "explode")
+	if err == nil || !strings.Contains(err.Error(), `unknown action "explode"`) {
+		t.Errorf("error = %v, want an unknown action", err)
+	}
+	if len(mock.PauseCalls)+len(mock.TerminateCalls) != 1 {
+		t.Errorf("an unknown action reached the client")
+	}
+}
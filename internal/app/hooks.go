@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// preStartTimeout bounds how long a pre-start hook may run.
+const preStartTimeout = 2 * time.Minute
+
+// RunPreStartHook runs the project's pre-start command, if one is configured
+// in its hooks file. The command runs through the shell in the project
+// directory; its output goes to the event log. A failing command returns an
+// error so the caller can abort the start.
+func (a *App) RunPreStartHook(ctx context.Context, proj *project.Project) error {
+	hooks, err := project.LoadHooks(proj.File.Path)
+	if err != nil {
+		return err
+	}
+	if hooks.PreStart == "" {
+		return nil
+	}
+
+	if a.DryRun {
+		a.LogEvent(ui.StatusInfo, "[dry-run] Skipped pre-start hook: "+hooks.PreStart)
+		return nil
+	}
+
+	a.LogEvent(ui.StatusInfo, "Running pre-start hook: "+hooks.PreStart)
+	ctx, cancel := context.WithTimeout(ctx, preStartTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", hooks.PreStart)
+	cmd.Dir = filepath.Dir(proj.File.Path)
+	output, err := cmd.CombinedOutput()
+
+	trimmed := strings.TrimSpace(string(output))
+	for _, line := range strings.Split(trimmed, "\n") {
+		if line != "" {
+			a.LogEvent(ui.StatusInfo, "pre-start: "+line)
+		}
+	}
+
+	if err != nil {
+		if lines := strings.Split(trimmed, "\n"); trimmed != "" {
+			return fmt.Errorf("pre-start hook failed: %w: %s", err, lines[len(lines)-1])
+		}
+		return fmt.Errorf("pre-start hook failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// newHookApp creates an app with one spec selected in a project whose
+// hooks file runs the given pre-start command.
+func newHookApp(t *testing.T, preStart string) (*App, *MockClient) {
+	t.Helper()
+	dir := t.TempDir()
+	hooks := "pre_start = '" + preStart + "'\n"
+	if err := os.WriteFile(filepath.Join(dir, project.HooksFileName), []byte(hooks), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := &MockClient{}
+	app := newSpecApp(mock, project.NotRunning, "")
+	app.State.Projects[0].File.Path = filepath.Join(dir, "mutagen.yml")
+	return app, mock
+}
+
+func TestStartSelectedSpec_RunsPreStartHook(t *testing.T) {
+	app, mock := newHookApp(t, "echo tunnel up")
+
+	app.StartSelectedSpec(context.Background())
+
+	if len(mock.CreateSessionCalls) != 1 {
+		t.Fatalf("CreateSessionCalls = %d, want 1", len(mock.CreateSessionCalls))
+	}
+	var logged bool
+	for _, e := range app.State.Events.Events() {
+		if e.Text == "pre-start: tunnel up" {
+			logged = true
+		}
+	}
+	if !logged {
+		t.Error("hook output was not logged")
+	}
+}
+
+func TestStartSelectedSpec_PreStartFailureAborts(t *testing.T) {
+	app, mock := newHookApp(t, "echo no route to host; exit 3")
+
+	app.StartSelectedSpec(context.Background())
+
+	if len(mock.CreateSessionCalls) != 0 {
+		t.Errorf("CreateSessionCalls = %d, want 0 after hook failure", len(mock.CreateSessionCalls))
+	}
+	status := app.State.StatusMessage
+	if status == nil || !strings.Contains(status.Text, "no route to host") {
+		t.Errorf("StatusMessage = %+v, want hook failure", status)
+	}
+}
+
+func TestRunPreStartHook_NoHooksFile(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	proj := createTestProjectWithFile("proj", []string{"web"})
+	proj.File.Path = filepath.Join(t.TempDir(), "mutagen.yml")
+
+	if err := app.RunPreStartHook(context.Background(), proj); err != nil {
+		t.Errorf("RunPreStartHook() error = %v", err)
+	}
+}
@@ -0,0 +1,219 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// maxIgnoreWalkEntries bounds the directory walk done by AnalyzeIgnores.
+const maxIgnoreWalkEntries = 100000
+
+// IgnoreAnalysis reports which of a spec's ignore patterns match nothing.
+type IgnoreAnalysis struct {
+	Root      string   // Local endpoint directory that was walked
+	Patterns  []string // Patterns checked; negations (!pattern) are not
+	Dead      []string // Patterns that matched no file or directory
+	Truncated bool     // The walk stopped at maxIgnoreWalkEntries
+}
+
+// AnalyzeIgnores walks the spec's local endpoint and reports ignore patterns
+// (from the definition and the project defaults) that match no path there.
+// Such dead patterns are usually left over from files that no longer exist.
+// Only local endpoints can be walked; if no endpoint is local, it returns
+// an error.
+func (a *App) AnalyzeIgnores(ctx context.Context, proj *project.Project, spec *project.SyncSpec) (*IgnoreAnalysis, error) {
+	def, ok := proj.File.Sessions[spec.Name]
+	if !ok {
+		return nil, fmt.Errorf("no definition for %s", spec.Name)
+	}
+
+	// The first local endpoint, trying each beta of a spec with several
+	var root string
+	for _, endpoint := range append([]string{def.Alpha}, def.BetaEndpoints()...) {
+		if project.ParseEndpoint(endpoint).IsLocal() {
+			root = project.NormalizeEndpoint(endpoint, proj.File.Dir())
+			break
+		}
+	}
+	if root == "" {
+		return nil, errors.New("no endpoint is local")
+	}
+
+	result := &IgnoreAnalysis{Root: root}
+	for _, pattern := range buildSessionOptions(&def, proj.File.Defaults).Ignore {
+		if !strings.HasPrefix(pattern, "!") {
+			result.Patterns = append(result.Patterns, pattern)
+		}
+	}
+	if len(result.Patterns) == 0 {
+		return result, nil
+	}
+
+	matched := make([]bool, len(result.Patterns))
+	remaining := len(result.Patterns)
+	entries := 0
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == root {
+				return err
+			}
+			return nil // Skip unreadable entries
+		}
+		if p == root {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entries++; entries > maxIgnoreWalkEntries {
+			result.Truncated = true
+			return fs.SkipAll
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		for i, pattern := range result.Patterns {
+			if !matched[i] && ignoreMatches(pattern, rel, d.IsDir()) {
+				matched[i] = true
+				remaining--
+			}
+		}
+		if remaining == 0 {
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, pattern := range result.Patterns {
+		if !matched[i] {
+			result.Dead = append(result.Dead, pattern)
+		}
+	}
+	return result, nil
+}
+
+// DescribeSelectedIgnores returns lines describing the selected spec's
+// ignore patterns: the patterns in effect, merged from the project defaults
+// and the spec's definition, then those that match nothing. It returns nil
+// if no spec is selected. It walks the local endpoint, so the sync status
+// dialog calls it once, from a command, when it opens.
+func (a *App) DescribeSelectedIgnores(ctx context.Context) []string {
+	projIdx, specIdx := a.GetSelectedSpec()
+	if projIdx < 0 || specIdx < 0 {
+		return nil
+	}
+	proj := a.State.Projects[projIdx]
+	spec := &proj.Specs[specIdx]
+	def, ok := proj.File.Sessions[spec.Name]
+	if !ok {
+		return nil
+	}
+	resolved := project.ResolveSessionConfig(&def, proj.File.Defaults)
+	lines := []string{effectiveIgnores(&resolved)}
+	if len(resolved.Ignores) == 0 {
+		return lines
+	}
+
+	analysis, err := a.AnalyzeIgnores(ctx, proj, spec)
+	if err != nil {
+		return append(lines, "Can't check ignores: "+err.Error())
+	}
+	if len(analysis.Patterns) == 0 {
+		return lines // Only negations
+	}
+	lines = append(lines, fmt.Sprintf("%d pattern(s) checked against %s", len(analysis.Patterns), analysis.Root))
+	if len(analysis.Dead) == 0 {
+		lines = append(lines, "Every pattern matches something")
+	} else {
+		lines = append(lines, "Match nothing: "+strings.Join(analysis.Dead, ", "))
+	}
+	if analysis.Truncated {
+		lines = append(lines, fmt.Sprintf("(stopped after %d entries; results may be incomplete)", maxIgnoreWalkEntries))
+	}
+	return lines
+}
+
+// effectiveIgnores describes the ignores a session is created with, in the
+// order Mutagen applies them and with the part of the project file each is
+// from, as in "In effect: node_modules (defaults), *.log (spec), and VCS
+// directories (defaults)".
+func effectiveIgnores(resolved *project.ResolvedConfig) string {
+	var items []string
+	for _, ignore := range resolved.Ignores {
+		items = append(items, fmt.Sprintf("%s (%s)", ignore.Pattern, ignore.Source))
+	}
+	if resolved.IgnoreVCS != nil && *resolved.IgnoreVCS {
+		for _, setting := range resolved.Settings {
+			if setting.Name == "ignore.vcs" {
+				items = append(items, fmt.Sprintf("VCS directories (%s)", setting.Source))
+			}
+		}
+	}
+	switch len(items) {
+	case 0:
+		return "No ignore patterns"
+	case 1:
+		return "In effect: " + items[0]
+	case 2:
+		return "In effect: " + items[0] + " and " + items[1]
+	}
+	return "In effect: " + strings.Join(items[:len(items)-1], ", ") + ", and " + items[len(items)-1]
+}
+
+// ignoreMatches reports whether a Mutagen ignore pattern matches a path
+// relative to the sync root. It follows the gitignore-like rules Mutagen
+// uses: a trailing / matches only directories, a leading / anchors the
+// pattern to the root, a pattern without a / matches a name at any depth,
+// and ** matches any number of directories.
+func ignoreMatches(pattern, rel string, isDir bool) bool {
+	if strings.HasSuffix(pattern, "/") {
+		if !isDir {
+			return false
+		}
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	if strings.HasPrefix(pattern, "/") {
+		return matchSegments(strings.Split(pattern[1:], "/"), strings.Split(rel, "/"))
+	}
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(rel))
+		return ok
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(rel, "/"))
+}
+
+// matchSegments matches path segments against pattern segments, where a
+// "**" segment matches zero or more path segments.
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
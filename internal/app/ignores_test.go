@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestIgnoreMatches(t *testing.T) {
+	tests := []struct {
+		pattern, rel string
+		isDir, want  bool
+	}{
+		{"*.pyc", "pkg/mod.pyc", false, true},
+		{"*.pyc", "pkg/mod.py", false, false},
+		{"build/", "build", true, true},
+		{"build/", "build", false, false},
+		{"/dist", "dist", true, true},
+		{"/dist", "sub/dist", true, false},
+		{"docs/*.md", "docs/a.md", false, true},
+		{"docs/*.md", "x/docs/a.md", false, false},
+		{"**/cache", "a/b/cache", true, true},
+		{"a/**/z", "a/z", false, true},
+	}
+	for _, tt := range tests {
+		if got := ignoreMatches(tt.pattern, tt.rel, tt.isDir); got != tt.want {
+			t.Errorf("ignoreMatches(%q, %q, %v) = %v, want %v", tt.pattern, tt.rel, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeIgnores_FindsDeadPatterns(t *testing.T) {
+	root := t.TempDir()
+	for _, p := range []string{"node_modules/x/index.js", "src/main.go", "src/main.pyc"} {
+		full := filepath.Join(root, p)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	proj := createTestProjectWithFile("test-proj", []string{"code"})
+	proj.File.Sessions["code"] = project.SessionDefinition{
+		Alpha:  root,
+		Beta:   "server:/code",
+		Ignore: &project.IgnoreConfig{Paths: []string{"node_modules/", "*.pyc", "target/", "!keep.pyc"}},
+	}
+	proj.File.Defaults = &project.DefaultConfig{Ignore: &project.IgnoreConfig{Paths: []string{".DS_Store"}}}
+
+	app := newTestApp(&MockClient{})
+	analysis, err := app.AnalyzeIgnores(context.Background(), proj, &proj.Specs[0])
+	if err != nil {
+		t.Fatalf("AnalyzeIgnores() error = %v", err)
+	}
+	if len(analysis.Patterns) != 4 {
+		t.Errorf("Patterns = %v, want 4 without the negation", analysis.Patterns)
+	}
+	if strings.Join(analysis.Dead, ",") != ".DS_Store,target/" {
+		t.Errorf("Dead = %v, want [.DS_Store target/]", analysis.Dead)
+	}
+}
+
+func TestAnalyzeIgnores_RemoteOnly(t *testing.T) {
+	proj := createTestProjectWithFile("test-proj", []string{"code"})
+	proj.File.Sessions["code"] = project.SessionDefinition{Alpha: "host1:/x", Beta: "host2:/y"}
+
+	app := newTestApp(&MockClient{})
+	if _, err := app.AnalyzeIgnores(context.Background(), proj, &proj.Specs[0]); err == nil {
+		t.Error("AnalyzeIgnores() should fail without a local endpoint")
+	}
+}
+
+func TestDescribeSelectedIgnores(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "node_modules"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	vcs := true
+	proj := createTestProjectWithFile("test-proj", []string{"code"})
+	proj.Folded = false
+	proj.File.Sessions["code"] = project.SessionDefinition{
+		Alpha:  root,
+		Beta:   "server:/code",
+		Ignore: &project.IgnoreConfig{Paths: []string{"*.log"}},
+	}
+	proj.File.Defaults = &project.DefaultConfig{Ignore: &project.IgnoreConfig{Paths: []string{"node_modules/"}, VCS: &vcs}}
+
+	app := newTestApp(&MockClient{})
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(1)
+
+	got := app.DescribeSelectedIgnores(context.Background())
+	want := []string{
+		"In effect: node_modules/ (defaults), *.log (spec), and VCS directories (defaults)",
+		"2 pattern(s) checked against " + root,
+		"Match nothing: *.log",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("DescribeSelectedIgnores() = %q, want %q", got, want)
+	}
+
+	proj.File.Sessions["code"] = project.SessionDefinition{Alpha: root, Beta: "server:/code"}
+	proj.File.Defaults = nil
+	if got := app.DescribeSelectedIgnores(context.Background()); len(got) != 1 || got[0] != "No ignore patterns" {
+		t.Errorf("DescribeSelectedIgnores() = %q, want no patterns", got)
+	}
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// sessionAlert is the state of a session that notifications are sent for.
+type sessionAlert struct {
+	conflicting bool
+	halted      bool
+}
+
+// sendNotification shows a desktop notification. It is a variable so tests
+// can replace it. The command is started but not waited for, since some
+// notifiers stay up until the notification is dismissed.
+var sendNotification = func(title, body string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		// Passing the text as arguments saves quoting it as AppleScript
+		cmd = exec.Command("osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			title, body)
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-Command",
+			"Add-Type -AssemblyName System.Windows.Forms; "+
+				"$n = New-Object System.Windows.Forms.NotifyIcon; "+
+				"$n.Icon = [System.Drawing.SystemIcons]::Warning; $n.Visible = $true; "+
+				"$n.ShowBalloonTip(10000, $env:MUTAGUI_TITLE, $env:MUTAGUI_BODY, 'Warning'); "+
+				"Start-Sleep -Seconds 10; $n.Dispose()")
+		cmd.Env = append(os.Environ(), "MUTAGUI_TITLE="+title, "MUTAGUI_BODY="+body)
+	default:
+		cmd = exec.Command("notify-send", "--app-name=mutagui", title, body)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Reap the notifier; by the time it exits, it has shown the notification or failed
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// notifyProblems sends a desktop notification for each session that has
+// started conflicting or halted since the last refresh. The first list
+// only records the sessions' state, so problems that were there at launch
+// aren't announced. A session's problem isn't notified again until
+// repeat_secs pass, so one that comes and goes doesn't notify every time.
+func (a *App) notifyProblems(sessions []mutagen.SyncSession) {
+	if !a.Config.Notifications.Enabled {
+		return
+	}
+	first := a.alerts == nil
+	if first {
+		a.alerts = make(map[string]sessionAlert)
+		a.notified = make(map[string]time.Time)
+	}
+
+	seen := make(map[string]bool, len(sessions))
+	for i := range sessions {
+		session := &sessions[i]
+		key := sessionKey(session)
+		seen[key] = true
+		previous := a.alerts[key]
+		current := sessionAlert{conflicting: session.HasConflicts(), halted: session.IsHalted()}
+		a.alerts[key] = current
+		if first {
+			continue
+		}
+
+		if current.conflicting && !previous.conflicting {
+			a.notify(key+" conflicts", fmt.Sprintf("%s has %d conflict(s)", session.Name, session.ConflictCount()))
+		}
+		if current.halted && !previous.halted {
+			text := session.Name + " halted"
+			if reason := session.HaltReason(); reason != "" {
+				text += ": " + reason
+			}
+			a.notify(key+" halted", text)
+		}
+	}
+
+	// Forget sessions that went away
+	for key := range a.alerts {
+		if !seen[key] {
+			delete(a.alerts, key)
+		}
+	}
+}
+
+// notify sends a notification unless the same one was sent within the
+// repeat interval.
+func (a *App) notify(id, text string) {
+	now := a.Clock.Now()
+	repeat := time.Duration(a.Config.Notifications.RepeatSecs) * time.Second
+	if last, ok := a.notified[id]; ok && now.Sub(last) < repeat {
+		return
+	}
+	a.notified[id] = now
+	if err := sendNotification("mutagui", text); err != nil {
+		a.LogEvent(ui.StatusWarning, "Failed to send a notification: "+err.Error())
+		return
+	}
+	a.LogEvent(ui.StatusInfo, "Notified: "+text)
+}
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/osteele/mutagui/internal/clock"
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+// stubNotifications replaces sendNotification for the test, recording the
+// text of each notification.
+func stubNotifications(t *testing.T, err error) *[]string {
+	t.Helper()
+	var sent []string
+	original := sendNotification
+	sendNotification = func(title, body string) error {
+		sent = append(sent, body)
+		return err
+	}
+	t.Cleanup(func() { sendNotification = original })
+	return &sent
+}
+
+func newNotifyApp() (*App, *clock.Fake) {
+	app := newTestApp(&MockClient{})
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	app.Clock = fake
+	app.Config.Notifications.Enabled = true
+	app.Config.Notifications.RepeatSecs = 600
+	return app, fake
+}
+
+func notifySession(name string, conflicts int, status string) mutagen.SyncSession {
+	return mutagen.SyncSession{
+		Name:       name,
+		Identifier: "sync_" + name,
+		Status:     status,
+		Conflicts:  make([]mutagen.Conflict, conflicts),
+	}
+}
+
+func TestNotifyProblems_Transitions(t *testing.T) {
+	sent := stubNotifications(t, nil)
+	app, fake := newNotifyApp()
+
+	// Problems already there at launch aren't announced
+	app.notifyProblems([]mutagen.SyncSession{
+		notifySession("web", 2, "watching"),
+		notifySession("api", 0, "watching"),
+	})
+	if len(*sent) != 0 {
+		t.Fatalf("notifications = %v on the first list, want none", *sent)
+	}
+
+	fake.Advance(3 * time.Second)
+	halted := notifySession("api", 1, "halted-on-root-emptied")
+	halted.LastError = "root emptied"
+	app.notifyProblems([]mutagen.SyncSession{notifySession("web", 3, "watching"), halted})
+	want := []string{"api has 1 conflict(s)", "api halted: root emptied"}
+	if !slices.Equal(*sent, want) {
+		t.Errorf("notifications = %q, want %q", *sent, want)
+	}
+
+	// Staying in the same state doesn't notify again
+	fake.Advance(3 * time.Second)
+	app.notifyProblems([]mutagen.SyncSession{notifySession("web", 3, "watching"), halted})
+	if len(*sent) != 2 {
+		t.Errorf("notifications = %q, want no more while nothing changed", *sent)
+	}
+}
+
+func TestNotifyProblems_Debounced(t *testing.T) {
+	sent := stubNotifications(t, nil)
+	app, fake := newNotifyApp()
+	clean := []mutagen.SyncSession{notifySession("web", 0, "watching")}
+	conflicted := []mutagen.SyncSession{notifySession("web", 1, "watching")}
+
+	app.notifyProblems(clean)
+	app.notifyProblems(conflicted)
+	// A conflict that comes and goes within the repeat interval notifies once
+	fake.Advance(time.Minute)
+	app.notifyProblems(clean)
+	app.notifyProblems(conflicted)
+	if len(*sent) != 1 {
+		t.Errorf("notifications = %q, want 1 within the repeat interval", *sent)
+	}
+
+	fake.Advance(10 * time.Minute)
+	app.notifyProblems(clean)
+	app.notifyProblems(conflicted)
+	if len(*sent) != 2 {
+		t.Errorf("notifications = %q, want a second one after the repeat interval", *sent)
+	}
+}
+
+func TestNotifyProblems_Disabled(t *testing.T) {
+	sent := stubNotifications(t, nil)
+	app, _ := newNotifyApp()
+	app.Config.Notifications.Enabled = false
+	app.notifyProblems([]mutagen.SyncSession{notifySession("web", 0, "watching")})
+	app.notifyProblems([]mutagen.SyncSession{notifySession("web", 1, "watching")})
+	if len(*sent) != 0 {
+		t.Errorf("notifications = %q with notifications off", *sent)
+	}
+}
+
+func TestNotifyProblems_Failure(t *testing.T) {
+	stubNotifications(t, errors.New(`exec: "notify-send": executable file not found in $PATH`))
+	app, _ := newNotifyApp()
+	app.notifyProblems([]mutagen.SyncSession{notifySession("web", 0, "watching")})
+	app.notifyProblems([]mutagen.SyncSession{notifySession("web", 1, "watching")})
+
+	last, ok := app.State.Events.Last()
+	if !ok || last.Text != `Failed to send a notification: exec: "notify-send": executable file not found in $PATH` {
+		t.Errorf("last event = %+v, want the failure logged", last)
+	}
+}
+
+func TestRefreshSessions_Notifies(t *testing.T) {
+	sent := stubNotifications(t, nil)
+	mock := &MockClient{ListSessionsResult: []mutagen.SyncSession{notifySession("web", 0, "watching")}}
+	app, _ := newNotifyApp()
+	app.Client = mock
+	ctx := t.Context()
+	if err := app.RefreshSessions(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	mock.ListSessionsResult = []mutagen.SyncSession{notifySession("web", 0, "halted-on-root-deletion")}
+	if err := app.RefreshSessions(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(*sent, []string{"web halted"}) {
+		t.Errorf("notifications = %q, want web halted", *sent)
+	}
+}
@@ -0,0 +1,192 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// defaultSyncMode is the mode mutagen uses when none is given.
+const defaultSyncMode = "two-way-safe"
+
+// OptionChange is a session setting whose running value differs from the
+// one the project file would apply.
+type OptionChange struct {
+	Setting string // "mode", "ignores", "VCS", or "symlinks"
+	Current string
+	Desired string
+}
+
+// String describes the change as in "mode: two-way-safe → one-way-replica".
+func (c OptionChange) String() string {
+	return fmt.Sprintf("%s: %s → %s", c.Setting, c.Current, c.Desired)
+}
+
+// DiffSessionOptions compares the settings of a running session with the
+// options it would be created with now, returning the settings that differ.
+// Unset values on either side are read as mutagen's defaults. Ignore
+// patterns are compared without regard to order.
+func DiffSessionOptions(running *mutagen.SyncSession, desired *mutagen.SessionOptions) []OptionChange {
+	if desired == nil {
+		desired = &mutagen.SessionOptions{}
+	}
+	var changes []OptionChange
+	add := func(setting, current, want string) {
+		if current != want {
+			changes = append(changes, OptionChange{Setting: setting, Current: current, Desired: want})
+		}
+	}
+
+	mode := defaultSyncMode
+	if running.Mode != nil && *running.Mode != "" {
+		mode = *running.Mode
+	}
+	desiredMode := defaultSyncMode
+	if desired.Mode != "" {
+		desiredMode = desired.Mode
+	}
+	add("mode", mode, desiredMode)
+
+	var ignores []string
+	vcs := "ignore"
+	if running.Ignore != nil {
+		ignores = running.Ignore.Paths
+		if running.Ignore.VCS != "" && running.Ignore.VCS != "default" {
+			vcs = running.Ignore.VCS
+		}
+	}
+	add("ignores", patternList(ignores), patternList(desired.Ignore))
+
+	// CreateSession only passes a flag to propagate VCS directories, so
+	// ignoring them is what an unset or true IgnoreVCS gives
+	desiredVCS := "ignore"
+	if desired.IgnoreVCS != nil && !*desired.IgnoreVCS {
+		desiredVCS = "propagate"
+	}
+	add("VCS", vcs, desiredVCS)
+
+	symlinks := "default"
+	if running.Symlink != nil && running.Symlink.Mode != "" {
+		symlinks = running.Symlink.Mode
+	}
+	desiredSymlinks := "default"
+	if desired.SymlinkMode != "" {
+		desiredSymlinks = desired.SymlinkMode
+	}
+	add("symlinks", symlinks, desiredSymlinks)
+
+	return changes
+}
+
+// patternList returns the sorted patterns joined for display, or "(none)".
+func patternList(patterns []string) string {
+	if len(patterns) == 0 {
+		return "(none)"
+	}
+	sorted := append([]string(nil), patterns...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
+
+// reconcilableSpec returns the selected spec and its definition if it is
+// running as a two-way session, which is what starting it would create.
+func (a *App) reconcilableSpec() (*project.Project, *project.SyncSpec, *project.SessionDefinition) {
+	projIdx, specIdx := a.GetSelectedSpec()
+	if projIdx < 0 || specIdx < 0 {
+		return nil, nil, nil
+	}
+	proj := a.State.Projects[projIdx]
+	spec := &proj.Specs[specIdx]
+	if spec.State != project.RunningTwoWay || spec.RunningSession == nil {
+		return nil, nil, nil
+	}
+	def, ok := proj.File.Sessions[spec.Name]
+	if !ok {
+		return nil, nil, nil
+	}
+	return proj, spec, &def
+}
+
+// DescribeSettingsChanges lists how the selected running spec's settings
+// differ from its project file, or nil if they match.
+func (a *App) DescribeSettingsChanges() []string {
+	proj, spec, def := a.reconcilableSpec()
+	if spec == nil {
+		return nil
+	}
+	changes := DiffSessionOptions(spec.RunningSession, projectSessionOptions(proj, def))
+	lines := make([]string, len(changes))
+	for i, change := range changes {
+		lines[i] = change.String()
+	}
+	return lines
+}
+
+// SelectedFileConfig returns the configuration the selected running spec's
+// project file gives its session, which can differ from the one it runs
+// with if the file was edited since. It returns false if no running spec
+// of a loaded project file is selected.
+func (a *App) SelectedFileConfig() (mutagen.SessionConfig, bool) {
+	projIdx, specIdx := a.GetSelectedSpec()
+	if projIdx < 0 || specIdx < 0 || projIdx >= len(a.State.Projects) {
+		return mutagen.SessionConfig{}, false
+	}
+	proj := a.State.Projects[projIdx]
+	spec := &proj.Specs[specIdx]
+	def, ok := proj.File.Sessions[spec.Name]
+	if !ok || spec.RunningSession == nil {
+		return mutagen.SessionConfig{}, false
+	}
+	config := projectSessionOptions(proj, &def).Config()
+	if spec.State == project.RunningPush {
+		// Pushes are always created as replicas, whatever the file's mode
+		config.Mode = "one-way-replica"
+	}
+	return config, true
+}
+
+// DescribeSelectedFileSettings lists the settings other than ignore
+// patterns that the selected spec's project file gives it, and whether each
+// is from the spec or the project defaults, as in "watch.mode: force-poll
+// (spec, overriding defaults)". It returns nil if no spec is selected or
+// the file leaves every setting to Mutagen.
+func (a *App) DescribeSelectedFileSettings() []string {
+	projIdx, specIdx := a.GetSelectedSpec()
+	if projIdx < 0 || specIdx < 0 || projIdx >= len(a.State.Projects) {
+		return nil
+	}
+	proj := a.State.Projects[projIdx]
+	def, ok := proj.File.Sessions[proj.Specs[specIdx].Name]
+	if !ok {
+		return nil
+	}
+	var lines []string
+	for _, setting := range project.ResolveSessionConfig(&def, proj.File.Defaults).Settings {
+		source := setting.Source.String()
+		if setting.Overrides {
+			source += ", overriding defaults"
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s (%s)", setting.Name, setting.Value, source))
+	}
+	return lines
+}
+
+// ReconcileSelectedSpec recreates the selected running spec's session from
+// its project file, applying any settings that changed since it started.
+func (a *App) ReconcileSelectedSpec(ctx context.Context) {
+	_, spec, _ := a.reconcilableSpec()
+	if spec == nil {
+		a.SetStatus(ui.StatusWarning, "Select a running two-way spec to restart")
+		return
+	}
+	// StartSelectedSpec terminates the old session before creating the new one
+	spec.State = project.NotRunning
+	spec.RunningSession = nil
+	a.StartSelectedSpec(ctx)
+	a.recordNoUndo("A restart can't be undone; the old settings are gone")
+}
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestDiffSessionOptions(t *testing.T) {
+	mode := "two-way-resolved"
+	no := false
+	tests := []struct {
+		name    string
+		running mutagen.SyncSession
+		desired mutagen.SessionOptions
+		want    []string
+	}{
+		{
+			name: "defaults match",
+		},
+		{
+			name:    "same ignores in another order",
+			running: mutagen.SyncSession{Ignore: &mutagen.IgnoreConfiguration{Paths: []string{"b", "a"}}},
+			desired: mutagen.SessionOptions{Ignore: []string{"a", "b"}},
+		},
+		{
+			name:    "mode",
+			running: mutagen.SyncSession{},
+			desired: mutagen.SessionOptions{Mode: mode},
+			want:    []string{"mode: two-way-safe → two-way-resolved"},
+		},
+		{
+			name:    "ignores and VCS",
+			running: mutagen.SyncSession{Ignore: &mutagen.IgnoreConfiguration{Paths: []string{"node_modules"}}},
+			desired: mutagen.SessionOptions{Ignore: []string{"node_modules", ".venv"}, IgnoreVCS: &no},
+			want: []string{
+				"ignores: node_modules → .venv, node_modules",
+				"VCS: ignore → propagate",
+			},
+		},
+		{
+			name:    "symlinks",
+			running: mutagen.SyncSession{Mode: &mode, Symlink: &mutagen.SymlinkConfiguration{Mode: "posix-raw"}},
+			desired: mutagen.SessionOptions{Mode: mode},
+			want:    []string{"symlinks: posix-raw → default"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, change := range DiffSessionOptions(&tt.running, &tt.desired) {
+				got = append(got, change.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("DiffSessionOptions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileSelectedSpec(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	proj := createTestProjectWithFile("test-proj", []string{"spec1"})
+	mode := "one-way-safe"
+	def := proj.File.Sessions["spec1"]
+	def.Mode = &mode
+	proj.File.Sessions["spec1"] = def
+	proj.Folded = false
+	proj.Specs[0].State = project.RunningTwoWay
+	proj.Specs[0].RunningSession = &mutagen.SyncSession{Name: "spec1"}
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SelectNext()
+
+	lines := app.DescribeSettingsChanges()
+	if len(lines) != 1 || lines[0] != "mode: two-way-safe → one-way-safe" {
+		t.Fatalf("DescribeSettingsChanges() = %q", lines)
+	}
+
+	app.ReconcileSelectedSpec(context.Background())
+	if len(mock.CreateSessionCalls) != 1 || mock.CreateSessionCalls[0].Opts.Mode != mode {
+		t.Errorf("CreateSessionCalls = %+v, want spec1 recreated with the new mode", mock.CreateSessionCalls)
+	}
+}
+
+func TestSelectedFileConfig(t *testing.T) {
+	app := newSpecApp(&MockClient{}, project.NotRunning, "")
+	proj := app.State.Projects[0]
+	def := proj.File.Sessions["web"]
+	def.Ignore = &project.IgnoreConfig{Paths: []string{"node_modules"}}
+	proj.File.Sessions["web"] = def
+
+	if _, ok := app.SelectedFileConfig(); ok {
+		t.Error("SelectedFileConfig() should be false for a spec that isn't running")
+	}
+
+	proj.Specs[0].State = project.RunningPush
+	proj.Specs[0].RunningSession = &mutagen.SyncSession{Name: "web-push"}
+	config, ok := app.SelectedFileConfig()
+	if !ok {
+		t.Fatal("SelectedFileConfig() = false for a running spec")
+	}
+	if config.Mode != "one-way-replica" {
+		t.Errorf("Mode = %q, want one-way-replica for a push", config.Mode)
+	}
+	if len(config.IgnorePaths) != 1 || config.IgnorePaths[0] != "node_modules" {
+		t.Errorf("IgnorePaths = %v, want [node_modules]", config.IgnorePaths)
+	}
+}
+
+func TestDescribeSelectedFileSettings(t *testing.T) {
+	app := newSpecApp(&MockClient{}, project.NotRunning, "")
+	proj := app.State.Projects[0]
+	proj.File.Defaults = &project.DefaultConfig{
+		Symlink: &project.SymlinkConfig{Mode: "ignore"},
+		Watch:   &project.WatchConfig{Mode: "no-watch"},
+	}
+	def := proj.File.Sessions["web"]
+	def.Watch = &project.WatchConfig{Mode: "force-poll"}
+	proj.File.Sessions["web"] = def
+
+	got := app.DescribeSelectedFileSettings()
+	want := []string{
+		"symlink.mode: ignore (defaults)",
+		"watch.mode: force-poll (spec, overriding defaults)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("DescribeSelectedFileSettings() = %q, want %q", got, want)
+	}
+
+	proj.File.Defaults = nil
+	proj.File.Sessions["web"] = project.SessionDefinition{Alpha: def.Alpha, Beta: def.Beta}
+	if got := app.DescribeSelectedFileSettings(); got != nil {
+		t.Errorf("DescribeSelectedFileSettings() = %q, want nothing for a file that sets nothing", got)
+	}
+}
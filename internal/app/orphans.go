@@ -0,0 +1,105 @@
+package app
+
+import (
+	"slices"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// orphanedProjectPath stands in for the file path of the orphaned sessions
+// project. It is also the project's display name.
+const orphanedProjectPath = "Orphaned sessions"
+
+// OrphanedSessions returns the sessions that belong to no loaded project:
+// their names match no spec, either exactly, with a push session's -push
+// suffix, or as one of its replicas, in any project that their project label
+// (if any) allows.
+func (a *App) OrphanedSessions(sessions []mutagen.SyncSession) []mutagen.SyncSession {
+	var orphans []mutagen.SyncSession
+	for _, session := range sessions {
+		claimed := slices.ContainsFunc(a.State.Projects, func(proj *project.Project) bool {
+			return !proj.Orphaned && claimsSession(proj, &session)
+		})
+		if !claimed {
+			orphans = append(orphans, session)
+		}
+	}
+	return orphans
+}
+
+// claimsSession returns true if the session runs one of the project's
+// specs. A session labeled for a different project is never claimed, even
+// if its name matches.
+func claimsSession(proj *project.Project, session *mutagen.SyncSession) bool {
+	if label := session.GetLabel(mutagen.ProjectLabel); label != "" && label != mutagen.LabelValue(proj.File.DisplayName()) {
+		return false
+	}
+	for _, spec := range proj.Specs {
+		if session.Name == spec.Name || session.Name == spec.Name+"-push" {
+			return true
+		}
+		if def, ok := proj.File.Sessions[spec.Name]; ok && def.IsMultiBeta() &&
+			slices.ContainsFunc(def.Replicas(spec.Name), func(r project.Replica) bool { return r.Name == session.Name }) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateOrphanedProject lists the orphaned sessions in a synthetic project
+// at the end of the project list, so that they can be terminated, and
+// removes it when there are none.
+func (a *App) updateOrphanedProject(sessions []mutagen.SyncSession) {
+	orphans := a.OrphanedSessions(sessions)
+	idx := slices.IndexFunc(a.State.Projects, func(proj *project.Project) bool { return proj.Orphaned })
+	if len(orphans) == 0 {
+		if idx >= 0 {
+			a.keepSelection(func() {
+				// Copy rather than delete in place: the UI shares the old slice
+				a.State.Projects = slices.Concat(a.State.Projects[:idx], a.State.Projects[idx+1:])
+			})
+		}
+		return
+	}
+
+	// Keep the sessions already listed, so their sync times carry over
+	previous := map[string]*mutagen.SyncSession{}
+	if idx >= 0 {
+		for _, spec := range a.State.Projects[idx].Specs {
+			previous[spec.Name] = spec.RunningSession
+		}
+	}
+	specs := make([]project.SyncSpec, len(orphans))
+	for i := range orphans {
+		session := &orphans[i]
+		state := project.RunningTwoWay
+		if session.Mode != nil && *session.Mode == "one-way-replica" {
+			state = project.RunningPush
+		}
+		specs[i] = project.SyncSpec{Name: session.Name, RunningSession: previous[session.Name], EverStarted: true}
+		specs[i].SetSession(session, state)
+	}
+	a.keepSelection(func() {
+		if idx >= 0 {
+			a.State.Projects[idx].Specs = specs
+			return
+		}
+		a.State.Projects = append(a.State.Projects, &project.Project{
+			File:     project.ProjectFile{Path: orphanedProjectPath, Sessions: map[string]project.SessionDefinition{}},
+			Specs:    specs,
+			Orphaned: true,
+		})
+	})
+}
+
+// rejectOrphaned reports that an operation needing a project file can't
+// act on the orphaned sessions project, returning true if proj is it.
+func (a *App) rejectOrphaned(proj *project.Project) bool {
+	if !proj.Orphaned {
+		return false
+	}
+	a.SetStatus(ui.StatusWarning, "Orphaned sessions have no project file; they can only be paused, flushed, or terminated")
+	return true
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/config"
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func sessionNames(sessions []mutagen.SyncSession) []string {
+	names := make([]string, len(sessions))
+	for i, session := range sessions {
+		names[i] = session.Name
+	}
+	return names
+}
+
+func TestOrphanedSessions(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	web := createTestProjectWithFile("web", []string{"code", "assets"})
+	web.File.Path = "/projects/web.yml"
+	api := createTestProjectWithFile("api", []string{"src"})
+	api.File.Path = "/projects/api.yml"
+	app.State.Projects = []*project.Project{web, api}
+
+	sessions := []mutagen.SyncSession{
+		{Name: "code"},        // Spec of web
+		{Name: "assets-push"}, // Push session of a spec of web
+		{Name: "src", Labels: map[string]string{mutagen.ProjectLabel: "api"}},
+		{Name: "deleted"},     // Spec removed from its project file
+		{Name: "code-pushed"}, // Only -push is a push suffix
+		{Name: "src-copy", Labels: map[string]string{mutagen.ProjectLabel: "web"}},
+		{Name: "code", Labels: map[string]string{mutagen.ProjectLabel: "other"}}, // Same name, different project
+	}
+	got := sessionNames(app.OrphanedSessions(sessions))
+	want := []string{"deleted", "code-pushed", "src-copy", "code"}
+	if !slices.Equal(got, want) {
+		t.Errorf("OrphanedSessions() = %v, want %v", got, want)
+	}
+}
+
+func TestRefreshSessions_ListsOrphans(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	app.State.SortBy = config.SortByName
+	proj := createTestProjectWithFile("zeta", []string{"code"})
+	proj.File.Path = "/projects/zeta.yml"
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	ctx := context.Background()
+
+	mode := "one-way-replica"
+	mock.ListSessionsResult = []mutagen.SyncSession{{Name: "code"}, {Name: "stray-push", Mode: &mode}}
+	if err := app.RefreshSessions(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Listed after every project, whatever the sort order
+	if len(app.State.Projects) != 2 || !app.State.Projects[1].Orphaned {
+		t.Fatalf("projects = %v, want the orphaned sessions last", projectNames(app.State.Projects))
+	}
+	orphans := app.State.Projects[1]
+	if orphans.File.DisplayName() != "Orphaned sessions" || len(orphans.Specs) != 1 {
+		t.Fatalf("orphans = %q with %d specs", orphans.File.DisplayName(), len(orphans.Specs))
+	}
+	if spec := orphans.Specs[0]; spec.Name != "stray-push" || spec.State != project.RunningPush {
+		t.Errorf("orphan spec = %q in state %v, want stray-push running as a push", spec.Name, spec.State)
+	}
+
+	// Terminating an orphan uses its real session name
+	app.State.Selection.SetIndex(3)
+	app.TerminateSelected(ctx)
+	if !slices.Equal(mock.TerminateCalls, []string{"stray-push"}) {
+		t.Errorf("TerminateCalls = %v, want [stray-push]", mock.TerminateCalls)
+	}
+
+	// Removed once no sessions are orphaned
+	mock.ListSessionsResult = []mutagen.SyncSession{{Name: "code"}}
+	if err := app.RefreshSessions(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(app.State.Projects) != 1 || app.State.Projects[0].Orphaned {
+		t.Errorf("projects = %v, want the orphans removed", projectNames(app.State.Projects))
+	}
+}
+
+func TestStartSelectedProject_RejectsOrphans(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	mock.ListSessionsResult = []mutagen.SyncSession{{Name: "stray"}}
+	if err := app.RefreshSessions(context.Background(), true); err != nil {
+		t.Fatal(err)
+	}
+	app.State.Selection.SetIndex(0)
+
+	app.StartSelectedProject(context.Background())
+
+	if len(mock.CreateSessionCalls) != 0 || len(mock.ProjectCalls) != 0 {
+		t.Errorf("started orphans: %v %v", mock.CreateSessionCalls, mock.ProjectCalls)
+	}
+	if app.State.StatusMessage == nil || app.State.StatusMessage.Text == "" {
+		t.Error("expected a status explaining that orphans have no project file")
+	}
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// useProjectCommands returns true if project-level operations should go
+// through `mutagen project` rather than per-session commands. The orphaned
+// sessions project has no file, so it always uses per-session commands.
+func (a *App) useProjectCommands(proj *project.Project) bool {
+	return a.Config.Projects.UseProjectCommands && !proj.Orphaned
+}
+
+// startProjectWithMutagen starts a project with `mutagen project start`,
+// which creates every session in the file with the names and labels Mutagen
+// manages itself, and runs the file's own beforeCreate/afterCreate hooks.
+// Returns false if it failed.
+func (a *App) startProjectWithMutagen(ctx context.Context, proj *project.Project) bool {
+	if err := a.RunPreStartHook(ctx, proj); err != nil {
+		a.SetStatus(ui.StatusError, "Not starting "+proj.File.DisplayName()+": "+err.Error())
+		return false
+	}
+	if err := a.Client.ProjectStart(ctx, proj.File.Path); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to start "+proj.File.DisplayName()+": "+err.Error())
+		return false
+	}
+	a.recordNoUndo("Starting a project can't be undone; terminate it instead")
+	a.SetStatus(ui.StatusInfo, "Started project: "+proj.File.DisplayName())
+	return true
+}
+
+// terminateProjectWithMutagen terminates a project with `mutagen project
+// terminate`. Returns false if it failed.
+func (a *App) terminateProjectWithMutagen(ctx context.Context, proj *project.Project) bool {
+	var snapshots []sessionSnapshot
+	for i := range proj.Specs {
+		if proj.Specs[i].RunningSession == nil {
+			continue
+		}
+		if snaps, ok := snapshotSpec(proj, &proj.Specs[i]); ok {
+			snapshots = append(snapshots, snaps...)
+		}
+	}
+	if err := a.Client.ProjectTerminate(ctx, proj.File.Path); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to terminate "+proj.File.DisplayName()+": "+err.Error())
+		return false
+	}
+	a.recordRecreateUndo(snapshots)
+	a.SetStatus(ui.StatusInfo, "Terminated project: "+proj.File.DisplayName())
+	return true
+}
+
+// flushProjectWithMutagen flushes a project with `mutagen project flush`.
+func (a *App) flushProjectWithMutagen(ctx context.Context, proj *project.Project) {
+	if err := a.Client.ProjectFlush(ctx, proj.File.Path); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to flush "+proj.File.DisplayName()+": "+err.Error())
+		return
+	}
+	a.recordNoUndo("A flush can't be undone: the synced changes are already applied")
+	a.SetStatus(ui.StatusInfo, "Flushed project: "+proj.File.DisplayName())
+}
+
+// setProjectPausedWithMutagen pauses or resumes a project with
+// `mutagen project pause` or `mutagen project resume`.
+func (a *App) setProjectPausedWithMutagen(ctx context.Context, proj *project.Project, pause bool) {
+	// Remember which sessions change state, for undo
+	var changed []string
+	for _, spec := range proj.Specs {
+		if spec.RunningSession != nil && spec.RunningSession.Paused != pause {
+			changed = append(changed, spec.RunningSession.Name)
+		}
+	}
+
+	if pause {
+		if err := a.Client.ProjectPause(ctx, proj.File.Path); err != nil {
+			a.SetStatus(ui.StatusError, "Failed to pause "+proj.File.DisplayName()+": "+err.Error())
+			return
+		}
+		a.recordPauseUndo(changed, true)
+		a.SetStatus(ui.StatusInfo, "Paused project: "+proj.File.DisplayName())
+		return
+	}
+	if err := a.Client.ProjectResume(ctx, proj.File.Path); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to resume "+proj.File.DisplayName()+": "+err.Error())
+		return
+	}
+	a.recordPauseUndo(changed, false)
+	a.SetStatus(ui.StatusInfo, "Resumed project: "+proj.File.DisplayName())
+}
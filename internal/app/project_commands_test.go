@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// newProjectCommandsApp returns an app using mutagen project commands, with
+// the project header selected.
+func newProjectCommandsApp(mock *MockClient, proj *project.Project) *App {
+	app := newTestApp(mock)
+	app.Config.Projects.UseProjectCommands = true
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	return app
+}
+
+func TestProjectCommands_ProjectOperations(t *testing.T) {
+	mock := &MockClient{}
+	proj := createTestProjectWithFile("test-proj", []string{"spec1", "spec2"})
+	proj.Specs[0].State = project.RunningTwoWay
+	proj.Specs[0].RunningSession = &mutagen.SyncSession{Name: "spec1"}
+	app := newProjectCommandsApp(mock, proj)
+	ctx := context.Background()
+
+	app.StartSelectedProject(ctx)
+	app.TogglePauseSelected(ctx)
+	app.ResumeSelected(ctx)
+	app.FlushSelected(ctx)
+	app.TerminateSelected(ctx)
+
+	path := proj.File.Path
+	want := []string{"start " + path, "pause " + path, "resume " + path, "flush " + path, "terminate " + path}
+	if !equalStrings(mock.ProjectCalls, want) {
+		t.Errorf("ProjectCalls = %v, want %v", mock.ProjectCalls, want)
+	}
+	if len(mock.CreateSessionCalls)+len(mock.PauseCalls)+len(mock.TerminateCalls) != 0 {
+		t.Error("per-session commands were used alongside project commands")
+	}
+
+	// Terminating through mutagen project can still be undone session by session
+	if description, ok := app.DescribeUndo(); !ok {
+		t.Errorf("DescribeUndo() = %q, false; want undo of the terminate", description)
+	}
+}
+
+func TestProjectCommands_SpecOperationsUseSessions(t *testing.T) {
+	mock := &MockClient{}
+	app := newSpecApp(mock, project.RunningTwoWay, "web")
+	app.Config.Projects.UseProjectCommands = true
+
+	app.TogglePauseSelected(context.Background())
+	if len(mock.ProjectCalls) != 0 || len(mock.PauseCalls) != 1 {
+		t.Errorf("ProjectCalls = %v, PauseCalls = %v; want a session pause", mock.ProjectCalls, mock.PauseCalls)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+// describePushOptions lists the settings a push session would be created
+// with, for previewing a push. Mutagen can't list the changes a session
+// would make before it exists, so the preview shows what decides them: the
+// mode, what is ignored, and how files are written. Settings left at
+// Mutagen's defaults are only listed for the mode, ignores, and VCS.
+func describePushOptions(opts *mutagen.SessionOptions) []string {
+	if opts == nil {
+		opts = &mutagen.SessionOptions{}
+	}
+	vcs := "ignore"
+	if opts.IgnoreVCS != nil && !*opts.IgnoreVCS {
+		vcs = "propagate"
+	}
+	lines := []string{
+		"mode: one-way-replica (files on the target that aren't on the source are deleted)",
+		"ignores: " + patternList(opts.Ignore),
+		"VCS: " + vcs,
+	}
+	add := func(setting, value string) {
+		if value != "" {
+			lines = append(lines, setting+": "+value)
+		}
+	}
+	add("symlinks", opts.SymlinkMode)
+	add("watch", opts.WatchMode)
+	if opts.WatchPollingInterval != 0 {
+		add("watch polling", fmt.Sprintf("every %ds", opts.WatchPollingInterval))
+	}
+	add("permissions", opts.PermissionsMode)
+	add("file mode", opts.DefaultFileMode)
+	add("directory mode", opts.DefaultDirectoryMode)
+	add("owner", opts.DefaultOwner)
+	add("group", opts.DefaultGroup)
+	return lines
+}
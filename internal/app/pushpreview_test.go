@@ -0,0 +1,68 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestDescribePushOptions(t *testing.T) {
+	propagate := false
+	tests := []struct {
+		name string
+		opts *mutagen.SessionOptions
+		want []string
+	}{
+		{"defaults", nil, []string{
+			"mode: one-way-replica (files on the target that aren't on the source are deleted)",
+			"ignores: (none)",
+			"VCS: ignore",
+		}},
+		{"settings", &mutagen.SessionOptions{
+			Mode:                 "two-way-resolved",
+			Ignore:               []string{"node_modules", "*.log"},
+			IgnoreVCS:            &propagate,
+			SymlinkMode:          "posix-raw",
+			WatchPollingInterval: 5,
+			DefaultFileMode:      "0644",
+		}, []string{
+			"mode: one-way-replica (files on the target that aren't on the source are deleted)",
+			"ignores: *.log, node_modules",
+			"VCS: propagate",
+			"symlinks: posix-raw",
+			"watch polling: every 5s",
+			"file mode: 0644",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describePushOptions(tt.opts); strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("describePushOptions() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestDescribePushSelected_Preview(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	proj := createTestProjectWithFile("proj", []string{"up"})
+	proj.File.Sessions["up"] = project.SessionDefinition{
+		Alpha:  "/local/src",
+		Beta:   "server:/src",
+		Ignore: &project.IgnoreConfig{Paths: []string{"build"}},
+	}
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	if lines := app.DescribePushSelected(); len(lines) != 1 {
+		t.Errorf("without previews, DescribePushSelected() = %q, want one line", lines)
+	}
+
+	app.Config.Confirmations.PushPreview = true
+	lines := app.DescribePushSelected()
+	if len(lines) < 3 || !strings.HasPrefix(lines[0], "up: copying FROM /local/src") || lines[2] != "    ignores: build" {
+		t.Errorf("DescribePushSelected() = %q, want the push followed by its settings", lines)
+	}
+}
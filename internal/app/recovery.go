@@ -0,0 +1,214 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// maxReconnectBackoff caps the delay between automatic reconnect attempts.
+const maxReconnectBackoff = 30 * time.Minute
+
+// reconnectState tracks a session that has been observed disconnected.
+type reconnectState struct {
+	disconnectedSince time.Time
+	attempts          int
+	nextAttempt       time.Time
+}
+
+// isDisconnected returns true if either endpoint of an active session is disconnected.
+// Paused sessions are never considered disconnected since they are idle by choice.
+func isDisconnected(session *mutagen.SyncSession) bool {
+	if session.Paused {
+		return false
+	}
+	return !session.Alpha.Connected || !session.Beta.Connected
+}
+
+// sessionKey returns the key used to track a session across refreshes.
+func sessionKey(session *mutagen.SyncSession) string {
+	if session.Identifier != "" {
+		return session.Identifier
+	}
+	return session.Name
+}
+
+// superviseConnections tracks how long each session has been disconnected.
+// With auto-reconnect on, it nudges sessions that have stayed disconnected
+// longer than the configured threshold by pausing and resuming them, which
+// makes Mutagen re-dial the endpoints. Attempts back off exponentially so a
+// session that cannot reconnect is not restarted on every refresh.
+func (a *App) superviseConnections(ctx context.Context) {
+	now := a.Clock.Now()
+	threshold := time.Duration(a.Config.Recovery.DisconnectThresholdSecs) * time.Second
+	seen := make(map[string]bool)
+
+	// Collect the sessions that are due under the lock, and reconnect them
+	// after releasing it
+	type attempt struct {
+		session *mutagen.SyncSession
+		number  int
+	}
+	var due []attempt
+	a.reconnectMu.Lock()
+	if a.reconnects == nil {
+		a.reconnects = make(map[string]*reconnectState)
+	}
+	for _, proj := range a.State.Projects {
+		for i := range proj.Specs {
+			for _, session := range proj.Specs[i].Sessions() {
+				if !isDisconnected(session) {
+					continue
+				}
+
+				key := sessionKey(session)
+				seen[key] = true
+				state, exists := a.reconnects[key]
+				if !exists {
+					a.reconnects[key] = &reconnectState{disconnectedSince: now}
+					continue
+				}
+
+				if !a.Config.Recovery.AutoReconnect ||
+					now.Sub(state.disconnectedSince) < threshold || now.Before(state.nextAttempt) {
+					continue
+				}
+
+				state.attempts++
+				state.nextAttempt = now.Add(reconnectBackoff(threshold, state.attempts))
+				due = append(due, attempt{session, state.attempts})
+			}
+		}
+	}
+
+	// Forget sessions that reconnected or went away
+	for key := range a.reconnects {
+		if !seen[key] {
+			delete(a.reconnects, key)
+		}
+	}
+	a.reconnectMu.Unlock()
+
+	for _, d := range due {
+		a.reconnectSession(ctx, d.session, d.number)
+	}
+}
+
+// reconnectSession pauses and resumes a session, logging the outcome.
+func (a *App) reconnectSession(ctx context.Context, session *mutagen.SyncSession, attempt int) {
+	if err := a.redialSession(ctx, session); err != nil {
+		a.LogEvent(ui.StatusError, fmt.Sprintf("Auto-reconnect of %s failed: %s", session.Name, err.Error()))
+		return
+	}
+	a.LogEvent(ui.StatusWarning, fmt.Sprintf("Auto-reconnected %s (attempt %d)", session.Name, attempt))
+}
+
+// redialSession pauses and resumes a session so Mutagen dials its endpoints again.
+func (a *App) redialSession(ctx context.Context, session *mutagen.SyncSession) error {
+	if err := a.Client.PauseSession(ctx, session.Name); err != nil {
+		return err
+	}
+	return a.Client.ResumeSession(ctx, session.Name)
+}
+
+// Disconnection reports how long a session has been disconnected, once that
+// passes the disconnect threshold, and how many reconnects have been tried.
+func (a *App) Disconnection(session *mutagen.SyncSession) (ui.Disconnection, bool) {
+	a.reconnectMu.Lock()
+	var state reconnectState
+	tracked, ok := a.reconnects[sessionKey(session)]
+	if ok {
+		state = *tracked
+	}
+	a.reconnectMu.Unlock()
+	if !ok || !isDisconnected(session) {
+		return ui.Disconnection{}, false
+	}
+	elapsed := a.Clock.Now().Sub(state.disconnectedSince)
+	if elapsed < time.Duration(a.Config.Recovery.DisconnectThresholdSecs)*time.Second {
+		return ui.Disconnection{}, false
+	}
+	return ui.Disconnection{For: elapsed, Attempts: state.attempts}, true
+}
+
+// ReconnectSelected pauses and resumes the selected spec's sessions, or the
+// disconnected sessions of the selected project, so that Mutagen dials
+// their endpoints again. Sessions paused by the user are left alone, since
+// resuming them would change more than the connection.
+func (a *App) ReconnectSelected(ctx context.Context) {
+	var sessions []*mutagen.SyncSession
+	if projIdx, specIdx := a.GetSelectedSpec(); projIdx >= 0 && specIdx >= 0 {
+		spec := &a.State.Projects[projIdx].Specs[specIdx]
+		running := spec.Sessions()
+		for _, session := range running {
+			if !session.Paused {
+				sessions = append(sessions, session)
+			}
+		}
+		switch {
+		case len(running) == 0:
+			a.SetStatus(ui.StatusWarning, spec.Name+" is not running")
+			return
+		case len(sessions) == 0:
+			a.SetStatus(ui.StatusWarning, spec.Name+" is paused; resume it instead")
+			return
+		}
+	} else if projIdx := a.GetSelectedProjectIndex(); projIdx >= 0 && projIdx < len(a.State.Projects) {
+		for i := range a.State.Projects[projIdx].Specs {
+			for _, session := range a.State.Projects[projIdx].Specs[i].Sessions() {
+				if isDisconnected(session) {
+					sessions = append(sessions, session)
+				}
+			}
+		}
+		if len(sessions) == 0 {
+			a.SetStatus(ui.StatusInfo, "No disconnected sessions in project")
+			return
+		}
+	} else {
+		a.SetStatus(ui.StatusWarning, "No project or spec selected")
+		return
+	}
+
+	var failed []string
+	for _, session := range sessions {
+		if err := a.redialSession(ctx, session); err != nil {
+			a.LogEvent(ui.StatusError, fmt.Sprintf("Reconnect of %s failed: %s", session.Name, err.Error()))
+			failed = append(failed, session.Name)
+			continue
+		}
+		a.reconnectMu.Lock()
+		if state, ok := a.reconnects[sessionKey(session)]; ok {
+			state.attempts++
+		}
+		a.reconnectMu.Unlock()
+		a.LogEvent(ui.StatusInfo, "Reconnecting "+session.Name)
+	}
+	a.recordNoUndo("A reconnect doesn't change anything to undo")
+	switch {
+	case len(failed) == 0:
+		a.SetStatus(ui.StatusInfo, fmt.Sprintf("Reconnecting %d session(s)", len(sessions)))
+	default:
+		a.SetStatus(ui.StatusError, "Failed to reconnect "+strings.Join(failed, ", "))
+	}
+}
+
+// reconnectBackoff returns the delay before the next reconnect attempt.
+// The delay doubles with each attempt, starting from the disconnect threshold.
+func reconnectBackoff(threshold time.Duration, attempts int) time.Duration {
+	if threshold <= 0 {
+		threshold = time.Second
+	}
+	backoff := threshold
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxReconnectBackoff {
+			return maxReconnectBackoff
+		}
+	}
+	return backoff
+}
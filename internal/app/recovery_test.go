@@ -0,0 +1,230 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/osteele/mutagui/internal/clock"
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// newDisconnectedApp creates an app with one spec whose session has a disconnected beta.
+func newDisconnectedApp(mock *MockClient) (*App, *clock.Fake) {
+	app := newTestApp(mock)
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	app.Clock = fake
+	app.Config.Recovery.AutoReconnect = true
+	app.Config.Recovery.DisconnectThresholdSecs = 30
+
+	proj := createTestProjectWithFile("test", []string{"spec1"})
+	proj.Specs[0].State = project.RunningTwoWay
+	proj.Specs[0].RunningSession = &mutagen.SyncSession{
+		Name:       "spec1",
+		Identifier: "sync_abc",
+		Alpha:      mutagen.Endpoint{Connected: true},
+		Beta:       mutagen.Endpoint{Connected: false},
+	}
+	app.State.Projects = []*project.Project{proj}
+	return app, fake
+}
+
+func TestSuperviseConnections_WaitsForThreshold(t *testing.T) {
+	mock := &MockClient{}
+	app, fake := newDisconnectedApp(mock)
+
+	// First observation only starts tracking
+	app.superviseConnections(context.Background())
+	if len(mock.PauseCalls) != 0 {
+		t.Fatalf("PauseCalls = %v, want none on first observation", mock.PauseCalls)
+	}
+
+	// Still under the threshold
+	fake.Advance(10 * time.Second)
+	app.superviseConnections(context.Background())
+	if len(mock.PauseCalls) != 0 {
+		t.Errorf("PauseCalls = %v, want none before threshold", mock.PauseCalls)
+	}
+}
+
+func TestSuperviseConnections_ReconnectsOnceWithBackoff(t *testing.T) {
+	mock := &MockClient{}
+	app, fake := newDisconnectedApp(mock)
+
+	app.superviseConnections(context.Background())
+	fake.Advance(time.Minute)
+
+	app.superviseConnections(context.Background())
+	if len(mock.PauseCalls) != 1 || len(mock.ResumeCalls) != 1 {
+		t.Fatalf("calls = pause %v resume %v, want one of each", mock.PauseCalls, mock.ResumeCalls)
+	}
+	if mock.PauseCalls[0] != "spec1" || mock.ResumeCalls[0] != "spec1" {
+		t.Errorf("reconnected %q/%q, want spec1", mock.PauseCalls[0], mock.ResumeCalls[0])
+	}
+
+	// Backoff prevents an immediate second attempt
+	fake.Advance(10 * time.Second)
+	app.superviseConnections(context.Background())
+	if len(mock.PauseCalls) != 1 {
+		t.Errorf("PauseCalls = %v, want no retry during backoff", mock.PauseCalls)
+	}
+
+	// After the backoff expires, another attempt is made
+	fake.Advance(time.Minute)
+	app.superviseConnections(context.Background())
+	if len(mock.PauseCalls) != 2 {
+		t.Errorf("PauseCalls = %v, want a retry after backoff", mock.PauseCalls)
+	}
+
+	if app.State.Events.Len() != 2 {
+		t.Errorf("event count = %d, want 2", app.State.Events.Len())
+	}
+}
+
+func TestSuperviseConnections_ForgetsReconnectedSessions(t *testing.T) {
+	mock := &MockClient{}
+	app, _ := newDisconnectedApp(mock)
+
+	app.superviseConnections(context.Background())
+	if _, ok := app.reconnects["sync_abc"]; !ok {
+		t.Fatal("expected disconnected session to be tracked")
+	}
+
+	app.State.Projects[0].Specs[0].RunningSession.Beta.Connected = true
+	app.superviseConnections(context.Background())
+	if _, ok := app.reconnects["sync_abc"]; ok {
+		t.Error("expected reconnected session to be forgotten")
+	}
+}
+
+func TestSuperviseConnections_IgnoresPaused(t *testing.T) {
+	mock := &MockClient{}
+	app, _ := newDisconnectedApp(mock)
+	app.State.Projects[0].Specs[0].RunningSession.Paused = true
+
+	app.superviseConnections(context.Background())
+	if len(app.reconnects) != 0 {
+		t.Errorf("tracked %d sessions, want paused session ignored", len(app.reconnects))
+	}
+}
+
+func TestReconnectBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{20, maxReconnectBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := reconnectBackoff(30*time.Second, tt.attempts); got != tt.want {
+			t.Errorf("reconnectBackoff(30s, %d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSuperviseConnections_TracksWithoutAutoReconnect(t *testing.T) {
+	mock := &MockClient{}
+	app, fake := newDisconnectedApp(mock)
+	app.Config.Recovery.AutoReconnect = false
+	session := app.State.Projects[0].Specs[0].RunningSession
+
+	app.superviseConnections(context.Background())
+	fake.Advance(10 * time.Second)
+	app.superviseConnections(context.Background())
+	if _, ok := app.Disconnection(session); ok {
+		t.Error("Disconnection() reported before the threshold")
+	}
+
+	fake.Advance(time.Minute)
+	app.superviseConnections(context.Background())
+	d, ok := app.Disconnection(session)
+	if !ok || d.For != 70*time.Second || d.Attempts != 0 {
+		t.Errorf("Disconnection() = %+v, %v; want 70s and no attempts", d, ok)
+	}
+	if len(mock.PauseCalls) != 0 {
+		t.Errorf("PauseCalls = %v, want none without auto-reconnect", mock.PauseCalls)
+	}
+
+	// Once connected again, it isn't reported
+	session.Beta.Connected = true
+	if _, ok := app.Disconnection(session); ok {
+		t.Error("Disconnection() reported a connected session")
+	}
+}
+
+func TestReconnectSelected_Spec(t *testing.T) {
+	mock := &MockClient{}
+	app, fake := newDisconnectedApp(mock)
+	app.Config.Recovery.AutoReconnect = false
+	proj := app.State.Projects[0]
+	proj.Folded = false
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(1)
+	session := proj.Specs[0].RunningSession
+
+	app.superviseConnections(context.Background())
+	fake.Advance(time.Minute)
+	app.ReconnectSelected(context.Background())
+	if len(mock.PauseCalls) != 1 || mock.PauseCalls[0] != "spec1" || len(mock.ResumeCalls) != 1 || mock.ResumeCalls[0] != "spec1" {
+		t.Errorf("calls = pause %v resume %v, want spec1 paused then resumed", mock.PauseCalls, mock.ResumeCalls)
+	}
+	if d, ok := app.Disconnection(session); !ok || d.Attempts != 1 {
+		t.Errorf("Disconnection() = %+v, %v; want one attempt", d, ok)
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Text != "Reconnecting 1 session(s)" {
+		t.Errorf("status = %+v", msg)
+	}
+
+	// A session paused by the user isn't resumed
+	session.Paused = true
+	app.ReconnectSelected(context.Background())
+	if len(mock.PauseCalls) != 1 {
+		t.Errorf("PauseCalls = %v, want the paused session left alone", mock.PauseCalls)
+	}
+}
+
+func TestReconnectSelected_ProjectOnlyDisconnected(t *testing.T) {
+	mock := &MockClient{}
+	app, _ := newDisconnectedApp(mock)
+	proj := app.State.Projects[0]
+	proj.File.Sessions["spec2"] = proj.File.Sessions["spec1"]
+	proj.Specs = append(proj.Specs, project.SyncSpec{
+		Name:  "spec2",
+		State: project.RunningTwoWay,
+		RunningSession: &mutagen.SyncSession{
+			Name:  "spec2",
+			Alpha: mutagen.Endpoint{Connected: true},
+			Beta:  mutagen.Endpoint{Connected: true},
+		},
+	})
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	app.ReconnectSelected(context.Background())
+	if len(mock.PauseCalls) != 1 || mock.PauseCalls[0] != "spec1" {
+		t.Errorf("PauseCalls = %v, want only the disconnected spec1", mock.PauseCalls)
+	}
+}
+
+func TestDisconnection_ConcurrentWithRefresh(t *testing.T) {
+	app, _ := newDisconnectedApp(&MockClient{})
+	app.Config.Recovery.AutoReconnect = false
+	session := app.State.Projects[0].Specs[0].RunningSession
+
+	// Refreshes track the session while the list reads it
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 200 {
+			app.superviseConnections(context.Background())
+		}
+	}()
+	for range 200 {
+		app.Disconnection(session)
+	}
+	<-done
+}
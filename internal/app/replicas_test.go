@@ -0,0 +1,152 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/osteele/mutagui/internal/clock"
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// newReplicaApp creates an app with a selected spec, web, that replicates
+// its alpha to two docker containers, which need no endpoint preparation.
+func newReplicaApp(mock *MockClient) *App {
+	app := newSpecApp(mock, project.NotRunning, "")
+	app.State.Projects[0].File.Sessions["web"] = project.SessionDefinition{
+		Alpha: "docker://builder/web",
+		Betas: []string{"docker://east/web", "docker://west/web"},
+	}
+	return app
+}
+
+func TestStartSelectedSpec_Replicas(t *testing.T) {
+	mock := &MockClient{}
+	app := newReplicaApp(mock)
+
+	app.StartSelectedSpec(context.Background())
+	var created []string
+	for _, call := range mock.CreateSessionCalls {
+		created = append(created, call.Name+" "+call.Beta)
+	}
+	if want := []string{"web@east docker://east/web", "web@west docker://west/web"}; !slices.Equal(created, want) {
+		t.Errorf("created = %v, want %v", created, want)
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Text != "Started 2 sessions: web" {
+		t.Errorf("status = %+v", msg)
+	}
+}
+
+func TestReplicas_RefreshAndTerminate(t *testing.T) {
+	mock := &MockClient{ListSessionsResult: []mutagen.SyncSession{
+		{Name: "web@east", Identifier: "sync_e"},
+		{Name: "web@west", Identifier: "sync_w"},
+	}}
+	app := newReplicaApp(mock)
+	if err := app.RefreshSessions(context.Background(), true); err != nil {
+		t.Fatal(err)
+	}
+	if slices.ContainsFunc(app.State.Projects, func(p *project.Project) bool { return p.Orphaned }) {
+		t.Error("the replicas' sessions should not be listed as orphans")
+	}
+	spec := &app.State.Projects[0].Specs[0]
+	if running, total := spec.ReplicaCounts(); running != 2 || total != 2 {
+		t.Errorf("ReplicaCounts() = %d/%d, want 2/2", running, total)
+	}
+
+	app.TerminateSelected(context.Background())
+	if !slices.Equal(mock.TerminateCalls, []string{"web@east", "web@west"}) {
+		t.Errorf("TerminateCalls = %v, want both replicas", mock.TerminateCalls)
+	}
+
+	mock.CreateSessionCalls = nil
+	app.UndoLast(context.Background())
+	if len(mock.CreateSessionCalls) != 2 || mock.CreateSessionCalls[1].Beta != "docker://west/web" {
+		t.Errorf("undo created %+v, want both replicas", mock.CreateSessionCalls)
+	}
+}
+
+func TestPushSelectedSpec_Replicas(t *testing.T) {
+	mock := &MockClient{}
+	app := newReplicaApp(mock)
+
+	app.PushSelectedSpec(context.Background())
+	var pushed []string
+	for _, call := range mock.CreatePushSessionCalls {
+		pushed = append(pushed, call.Name+" "+call.Beta)
+	}
+	if want := []string{"web@east docker://east/web", "web@west docker://west/web"}; !slices.Equal(pushed, want) {
+		t.Errorf("pushed = %v, want %v", pushed, want)
+	}
+	if lines := app.DescribePushSelected(); len(lines) != 2 || !strings.HasPrefix(lines[1], "web@west: copying FROM docker://builder/web TO docker://west/web") {
+		t.Errorf("DescribePushSelected() = %q, want a line per beta", lines)
+	}
+}
+
+func TestPullConflictsToAlpha_RefusesReplicas(t *testing.T) {
+	mock := &MockClient{}
+	app := newReplicaApp(mock)
+
+	app.PullConflictsToAlpha(context.Background())
+	if len(mock.CreatePushSessionCalls) != 0 || len(mock.TerminateCalls) != 0 {
+		t.Errorf("pulled %+v, terminated %v; want nothing done", mock.CreatePushSessionCalls, mock.TerminateCalls)
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Type != ui.StatusWarning || !strings.Contains(msg.Text, "several betas") {
+		t.Errorf("status = %+v, want a warning about the betas", msg)
+	}
+}
+
+func TestReplicas_CopyAndScheduledFlush(t *testing.T) {
+	mock := &MockClient{ListSessionsResult: []mutagen.SyncSession{
+		{Name: "web@east", Identifier: "sync_e", Paused: true},
+		{Name: "web@west", Identifier: "sync_w", Paused: true},
+	}}
+	app := newReplicaApp(mock)
+	if err := app.RefreshSessions(context.Background(), true); err != nil {
+		t.Fatal(err)
+	}
+
+	var copied string
+	app.Clipboard = func(text string) error { copied = text; return nil }
+	app.CopySelectedPaths()
+	if want := "docker://builder/web\ndocker://east/web\ndocker://west/web"; copied != want {
+		t.Errorf("copied %q, want %q", copied, want)
+	}
+
+	app.flushScheduled(context.Background(), &app.State.Projects[0].Specs[0])
+	if !slices.Equal(mock.FlushCalls, []string{"web@east", "web@west"}) {
+		t.Errorf("FlushCalls = %v, want both replicas", mock.FlushCalls)
+	}
+}
+
+func TestReconnect_Replicas(t *testing.T) {
+	mock := &MockClient{}
+	app := newReplicaApp(mock)
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	app.Clock = fake
+	app.Config.Recovery.AutoReconnect = true
+	app.Config.Recovery.DisconnectThresholdSecs = 30
+	spec := &app.State.Projects[0].Specs[0]
+	spec.BetaSessions = []*mutagen.SyncSession{
+		{Name: "web@east", Identifier: "sync_east", Alpha: mutagen.Endpoint{Connected: true}, Beta: mutagen.Endpoint{Connected: true}},
+		{Name: "web@west", Identifier: "sync_west", Alpha: mutagen.Endpoint{Connected: true}, Beta: mutagen.Endpoint{Connected: false}},
+	}
+	spec.RunningSession = spec.BetaSessions[0]
+
+	app.superviseConnections(context.Background())
+	fake.Advance(time.Minute)
+	app.superviseConnections(context.Background())
+	if !slices.Equal(mock.PauseCalls, []string{"web@west"}) {
+		t.Errorf("auto-reconnect paused %v, want the disconnected second replica", mock.PauseCalls)
+	}
+
+	mock.PauseCalls = nil
+	app.ReconnectSelected(context.Background())
+	if !slices.Equal(mock.PauseCalls, []string{"web@east", "web@west"}) {
+		t.Errorf("reconnecting the spec paused %v, want every replica", mock.PauseCalls)
+	}
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// rescanDue returns true if the periodic project rescan is enabled and its interval has elapsed.
+func (a *App) rescanDue() bool {
+	interval := time.Duration(a.Config.Projects.RescanIntervalSecs) * time.Second
+	return interval > 0 && !a.Clock.Now().Before(a.lastRescan.Add(interval))
+}
+
+// RescanProjects re-runs project discovery and merges the result into the
+// project list. Projects that were already loaded keep their fold state and
+// session data, and the selection stays on the same project or spec.
+// Returns the number of newly found projects.
+func (a *App) RescanProjects(ctx context.Context) (int, error) {
+	a.lastRescan = a.Clock.Now()
+
+	found, err := project.FindProjectsCached(a.projectFiles, a.projectBaseDir, a.Config.Projects.SearchPaths,
+		a.Config.Projects.ExcludePatterns, a.Config.Projects.IncludeUserConfig)
+	if err != nil {
+		return 0, err
+	}
+
+	existing := make(map[string]*project.Project, len(a.State.Projects))
+	for _, proj := range a.State.Projects {
+		existing[proj.File.Path] = proj
+	}
+
+	var added []*project.Project
+	merged := make([]*project.Project, 0, len(found))
+	for _, proj := range found {
+		if old, ok := existing[proj.File.Path]; ok {
+			merged = append(merged, old)
+			continue
+		}
+		merged = append(merged, proj)
+		a.logProjectWarnings([]*project.Project{proj})
+		a.applyFoldState([]*project.Project{proj})
+		added = append(added, proj)
+	}
+
+	// Keep the orphaned sessions until the next refresh updates them
+	if i := slices.IndexFunc(a.State.Projects, func(proj *project.Project) bool { return proj.Orphaned }); i >= 0 {
+		merged = append(merged, a.State.Projects[i])
+	}
+
+	a.discovered = discoveryOrder(found)
+	a.keepSelection(func() {
+		sortProjects(merged, a.State.SortBy, a.discovered)
+		a.State.Projects = merged
+	})
+
+	if len(added) > 0 {
+		a.watchProjectFiles(added)
+		a.LogEvent(ui.StatusInfo, fmt.Sprintf("Found %d new project file(s)", len(added)))
+	}
+	return len(added), nil
+}
@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/osteele/mutagui/internal/clock"
+)
+
+const rescanTestYAML = `sync:
+  code:
+    alpha: /local
+    beta: server:/remote
+`
+
+// newRescanApp creates an app that has loaded projects from a temporary directory.
+func newRescanApp(t *testing.T) (*App, *clock.Fake, string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir()) // Keep user config directories out of discovery
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "mutagen.yml"), []byte(rescanTestYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	app := newTestApp(&MockClient{})
+	app.Clock = fake
+	app.Config.Projects.RescanIntervalSecs = 60
+	if err := app.LoadProjects(context.Background(), dir); err != nil {
+		t.Fatalf("LoadProjects() error = %v", err)
+	}
+	if len(app.State.Projects) != 1 {
+		t.Fatalf("loaded %d projects, want 1", len(app.State.Projects))
+	}
+	return app, fake, dir
+}
+
+func TestRefreshSessions_RescansAfterInterval(t *testing.T) {
+	app, fake, dir := newRescanApp(t)
+	original := app.State.Projects[0]
+	original.Folded = false
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(1) // the "code" spec
+
+	if err := os.WriteFile(filepath.Join(dir, "mutagen-extra.yml"), []byte(rescanTestYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Before the interval elapses, the new file is not picked up
+	fake.Advance(30 * time.Second)
+	if err := app.RefreshSessions(context.Background(), true); err != nil {
+		t.Fatalf("RefreshSessions() error = %v", err)
+	}
+	if len(app.State.Projects) != 1 {
+		t.Fatalf("projects = %d before interval, want 1", len(app.State.Projects))
+	}
+
+	fake.Advance(30 * time.Second)
+	if err := app.RefreshSessions(context.Background(), true); err != nil {
+		t.Fatalf("RefreshSessions() error = %v", err)
+	}
+	if len(app.State.Projects) != 2 {
+		t.Fatalf("projects = %d after interval, want 2", len(app.State.Projects))
+	}
+
+	// The existing project is kept as-is, with its fold state and selection
+	var kept bool
+	for i, proj := range app.State.Projects {
+		if proj == original {
+			kept = true
+			if projIdx, specIdx := app.State.Selection.SelectedSpec(); projIdx != i || specIdx != 0 {
+				t.Errorf("SelectedSpec() = (%d, %d), want (%d, 0)", projIdx, specIdx, i)
+			}
+		}
+	}
+	if !kept {
+		t.Error("existing project was replaced by rescan")
+	}
+	if original.Folded {
+		t.Error("existing project lost its fold state")
+	}
+}
+
+func TestRefreshSessions_NoRescanWhenDisabled(t *testing.T) {
+	app, fake, dir := newRescanApp(t)
+	app.Config.Projects.RescanIntervalSecs = 0
+
+	if err := os.WriteFile(filepath.Join(dir, "mutagen-extra.yml"), []byte(rescanTestYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fake.Advance(time.Hour)
+	if err := app.RefreshSessions(context.Background(), true); err != nil {
+		t.Fatalf("RefreshSessions() error = %v", err)
+	}
+	if len(app.State.Projects) != 1 {
+		t.Errorf("projects = %d, want 1 with rescanning disabled", len(app.State.Projects))
+	}
+}
+
+func TestRescanProjects_LogsParseWarnings(t *testing.T) {
+	app, _, dir := newRescanApp(t)
+	before := app.State.Events.Len()
+
+	malformed := "sync:\n  - alpha: /local\n    beta: server:/remote\n"
+	if err := os.WriteFile(filepath.Join(dir, "mutagen-broken.yml"), []byte(malformed), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.RescanProjects(context.Background()); err != nil {
+		t.Fatalf("RescanProjects() error = %v", err)
+	}
+	if len(app.State.Projects) != 2 {
+		t.Errorf("projects = %d, want the malformed file listed too", len(app.State.Projects))
+	}
+	// One warning plus the "found" notice
+	if got := app.State.Events.Len() - before; got != 2 {
+		t.Errorf("logged %d events, want 2", got)
+	}
+}
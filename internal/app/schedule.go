@@ -0,0 +1,179 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/state"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// defaultScheduledFlushInterval is used when scheduled_flush_secs is not positive.
+const defaultScheduledFlushInterval = 5 * time.Minute
+
+// ScheduledFlushCheckInterval is how often the UI asks for the scheduled
+// flushes that are due, on a ticker of its own so that they keep to their
+// interval whether or not the list is being refreshed.
+const ScheduledFlushCheckInterval = 10 * time.Second
+
+// scheduledFlushInterval returns how often scheduled specs are flushed.
+func (a *App) scheduledFlushInterval() time.Duration {
+	if secs := a.Config.Refresh.ScheduledFlushSecs; secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return defaultScheduledFlushInterval
+}
+
+// ToggleScheduleSelected switches the selected spec between continuous sync
+// and scheduled mode, in which its session stays paused and is flushed
+// periodically. The mode is remembered across runs.
+func (a *App) ToggleScheduleSelected(ctx context.Context) {
+	if !a.State.Selection.IsSpecSelected() {
+		a.SetStatus(ui.StatusWarning, "Select a spec to schedule its flushes")
+		return
+	}
+	projIdx, specIdx := a.GetSelectedSpec()
+	if projIdx < 0 || specIdx < 0 {
+		return
+	}
+	proj := a.State.Projects[projIdx]
+	spec := &proj.Specs[specIdx]
+	if spec.RunningSession == nil {
+		a.SetStatus(ui.StatusWarning, "Session not running")
+		return
+	}
+	key := state.SpecKey(proj.File.Path, spec.Name)
+
+	// A spec with several betas schedules each of its sessions
+	if spec.Scheduled {
+		for _, session := range spec.Sessions() {
+			if !session.Paused {
+				continue
+			}
+			if err := a.Client.ResumeSession(ctx, session.Name); err != nil {
+				a.SetStatus(ui.StatusError, "Failed to resume: "+err.Error())
+				return
+			}
+		}
+		a.setScheduled(key, false)
+		spec.Scheduled = false
+		a.flushMu.Lock()
+		delete(a.nextFlush, key)
+		a.flushMu.Unlock()
+		a.recordNoUndo("Press " + ui.ActionKey("schedule") + " again to switch back to scheduled flushes")
+		a.SetStatus(ui.StatusInfo, "Continuous sync resumed: "+spec.Name)
+		return
+	}
+
+	for _, session := range spec.Sessions() {
+		if session.Paused {
+			continue
+		}
+		if err := a.Client.PauseSession(ctx, session.Name); err != nil {
+			a.SetStatus(ui.StatusError, "Failed to pause: "+err.Error())
+			return
+		}
+	}
+	a.setScheduled(key, true)
+	spec.Scheduled = true
+	a.flushMu.Lock()
+	if a.nextFlush == nil {
+		a.nextFlush = make(map[string]time.Time)
+	}
+	a.nextFlush[key] = a.Clock.Now().Add(a.scheduledFlushInterval())
+	a.flushMu.Unlock()
+	a.recordNoUndo("Press " + ui.ActionKey("schedule") + " again to switch back to continuous sync")
+	a.SetStatus(ui.StatusInfo, fmt.Sprintf("%s paused; flushing every %s", spec.Name, a.scheduledFlushInterval()))
+}
+
+// setScheduled records the spec's mode in the store and saves it.
+func (a *App) setScheduled(key string, scheduled bool) {
+	if a.Store.SetScheduled(key, scheduled) {
+		if err := a.Store.Save(); err != nil {
+			a.LogEvent(ui.StatusWarning, "Failed to save state: "+err.Error())
+		}
+	}
+}
+
+// markScheduled marks each spec with whether it is scheduled.
+func (a *App) markScheduled() {
+	for _, proj := range a.State.Projects {
+		for i := range proj.Specs {
+			spec := &proj.Specs[i]
+			spec.Scheduled = a.Store.Scheduled(state.SpecKey(proj.File.Path, spec.Name))
+		}
+	}
+}
+
+// RunScheduledFlushes flushes the running scheduled specs whose interval
+// has elapsed. The UI calls it every ScheduledFlushCheckInterval. A spec's
+// first interval starts when it is first seen, so a restart doesn't flush
+// everything at once.
+func (a *App) RunScheduledFlushes(ctx context.Context) {
+	a.markScheduled()
+	now := a.Clock.Now()
+	var due []*project.SyncSpec
+	a.flushMu.Lock()
+	if a.nextFlush == nil {
+		a.nextFlush = make(map[string]time.Time)
+	}
+	for _, proj := range a.State.Projects {
+		for i := range proj.Specs {
+			spec := &proj.Specs[i]
+			if !spec.Scheduled || spec.RunningSession == nil {
+				continue
+			}
+			key := state.SpecKey(proj.File.Path, spec.Name)
+			next, ok := a.nextFlush[key]
+			if !ok {
+				a.nextFlush[key] = now.Add(a.scheduledFlushInterval())
+				continue
+			}
+			if now.Before(next) {
+				continue
+			}
+			a.nextFlush[key] = now.Add(a.scheduledFlushInterval())
+			due = append(due, spec)
+		}
+	}
+	a.flushMu.Unlock()
+
+	for _, spec := range due {
+		a.flushScheduled(ctx, spec)
+	}
+}
+
+// flushScheduled runs one sync cycle of a scheduled spec: mutagen can't
+// flush a paused session, so it is resumed for the flush and paused again,
+// even if someone resumed it by hand in the meantime. A spec with several
+// betas flushes each of its sessions.
+func (a *App) flushScheduled(ctx context.Context, spec *project.SyncSpec) {
+	for _, session := range spec.Sessions() {
+		if err := a.flushPaused(ctx, session); err != nil {
+			a.LogEvent(ui.StatusWarning, fmt.Sprintf("Scheduled flush of %s failed: %v", session.Name, err))
+			return
+		}
+	}
+	a.LogEvent(ui.StatusInfo, "Scheduled flush of "+spec.Name)
+}
+
+// flushPaused resumes a session if it is paused, flushes it, and pauses it.
+func (a *App) flushPaused(ctx context.Context, session *mutagen.SyncSession) error {
+	if session.Paused {
+		if err := a.Client.ResumeSession(ctx, session.Name); err != nil {
+			return err
+		}
+	}
+	err := a.Client.FlushSession(ctx, session.Name)
+	if pauseErr := a.Client.PauseSession(ctx, session.Name); pauseErr != nil && err == nil {
+		err = pauseErr
+	}
+	if err != nil {
+		return err
+	}
+	session.Paused = true
+	return nil
+}
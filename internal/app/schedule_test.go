@@ -0,0 +1,129 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/osteele/mutagui/internal/clock"
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/state"
+)
+
+func newScheduleTestApp(t *testing.T) (*App, *MockClient, *clock.Fake) {
+	t.Helper()
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	app.Clock = fake
+	app.Config.Refresh.ScheduledFlushSecs = 60
+
+	mock.ListSessionsResult = []mutagen.SyncSession{{Name: "spec-a", Identifier: "sync_a", Status: "watching"}}
+	proj := createTestProjectWithFile("test-proj", []string{"spec-a"})
+	proj.Folded = false
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(1)
+	if err := app.RefreshSessions(context.Background(), true); err != nil {
+		t.Fatal(err)
+	}
+	return app, mock, fake
+}
+
+func TestToggleScheduleSelected(t *testing.T) {
+	app, mock, _ := newScheduleTestApp(t)
+	spec := &app.State.Projects[0].Specs[0]
+
+	app.ToggleScheduleSelected(context.Background())
+	if strings.Join(mock.PauseCalls, ",") != "spec-a" {
+		t.Errorf("PauseCalls = %v, want the session paused", mock.PauseCalls)
+	}
+	if !spec.Scheduled || !app.Store.Scheduled(state.SpecKey("", "spec-a")) {
+		t.Error("spec should be scheduled and remembered")
+	}
+	if msg := app.State.StatusMessage.Text; msg != "spec-a paused; flushing every 1m0s" {
+		t.Errorf("status = %q", msg)
+	}
+
+	spec.RunningSession.Paused = true
+	app.ToggleScheduleSelected(context.Background())
+	if strings.Join(mock.ResumeCalls, ",") != "spec-a" {
+		t.Errorf("ResumeCalls = %v, want the session resumed", mock.ResumeCalls)
+	}
+	if spec.Scheduled || app.Store.Scheduled(state.SpecKey("", "spec-a")) {
+		t.Error("spec should be back to continuous sync")
+	}
+}
+
+func TestRunScheduledFlushes(t *testing.T) {
+	app, mock, fake := newScheduleTestApp(t)
+	app.ToggleScheduleSelected(context.Background())
+	mock.ListSessionsResult[0].Paused = true
+	mock.PauseCalls = nil
+
+	if err := app.RefreshSessions(context.Background(), true); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.Advance(30 * time.Second)
+	app.RunScheduledFlushes(context.Background())
+	if len(mock.FlushCalls) != 0 {
+		t.Fatalf("flushed before the interval elapsed: %v", mock.FlushCalls)
+	}
+
+	fake.Advance(30 * time.Second)
+	app.RunScheduledFlushes(context.Background())
+	if len(mock.FlushCalls) != 1 || len(mock.ResumeCalls) != 1 || len(mock.PauseCalls) != 1 {
+		t.Errorf("resume/flush/pause = %v/%v/%v, want one of each", mock.ResumeCalls, mock.FlushCalls, mock.PauseCalls)
+	}
+
+	// The next flush waits a full interval again
+	fake.Advance(30 * time.Second)
+	app.RunScheduledFlushes(context.Background())
+	if len(mock.FlushCalls) != 1 {
+		t.Errorf("FlushCalls = %v, want no second flush yet", mock.FlushCalls)
+	}
+}
+
+func TestRunScheduledFlushes_RestoredSpecWaitsAnInterval(t *testing.T) {
+	app, mock, fake := newScheduleTestApp(t)
+	app.Store.SetScheduled(state.SpecKey("", "spec-a"), true)
+
+	// A spec scheduled in an earlier run is marked but not flushed right away
+	if err := app.RefreshSessions(context.Background(), true); err != nil {
+		t.Fatal(err)
+	}
+	if !app.State.Projects[0].Specs[0].Scheduled {
+		t.Error("spec should be marked scheduled from the store")
+	}
+	app.RunScheduledFlushes(context.Background())
+	if len(mock.FlushCalls) != 0 {
+		t.Errorf("FlushCalls = %v, want none on the first check", mock.FlushCalls)
+	}
+
+	fake.Advance(time.Minute)
+	app.RunScheduledFlushes(context.Background())
+	if len(mock.FlushCalls) != 1 {
+		t.Errorf("FlushCalls = %v, want one flush after the interval", mock.FlushCalls)
+	}
+}
+
+func TestRunScheduledFlushes_WithoutRefreshes(t *testing.T) {
+	app, mock, fake := newScheduleTestApp(t)
+	app.ToggleScheduleSelected(context.Background())
+	mock.ListSessionsCalls = 0
+
+	// Flushes keep time on their own, without the list being refreshed
+	for range 7 {
+		fake.Advance(ScheduledFlushCheckInterval)
+		app.RunScheduledFlushes(context.Background())
+	}
+	if len(mock.FlushCalls) != 1 {
+		t.Errorf("FlushCalls = %v, want one flush a minute in", mock.FlushCalls)
+	}
+	if mock.ListSessionsCalls != 0 {
+		t.Errorf("ListSessionsCalls = %d, want flushes not to refresh the list", mock.ListSessionsCalls)
+	}
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// startDetached starts a command without waiting for it, and reaps it when
+// it exits. Tests replace it.
+var startDetached = func(cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// endpointOpenCommand returns the command that opens an endpoint. For an
+// SSH endpoint it is an interactive shell on the host, in the endpoint's
+// directory, and interactive is true since it needs the terminal. For a
+// local path it is the file manager of goos. Other endpoints, such as
+// docker:// URLs, can't be opened.
+func endpointOpenCommand(endpoint, goos string) (args []string, interactive bool, err error) {
+	e := project.ParseEndpoint(endpoint)
+	path := e.Path
+	switch {
+	case e.IsSSH():
+		script := "exec $SHELL -l"
+		if cd := remoteChdir(path); cd != "" {
+			script = cd + "; " + script
+		}
+		return []string{"ssh", "-t", e.SSHDestination(), script}, true, nil
+	case e.IsLocal():
+		if strings.HasPrefix(path, "~/") || path == "~" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to get home directory: %w", err)
+			}
+			path = filepath.Join(home, strings.TrimPrefix(path[1:], "/"))
+		}
+		switch goos {
+		case "darwin":
+			return []string{"open", path}, false, nil
+		case "windows":
+			return []string{"explorer", path}, false, nil
+		default:
+			return []string{"xdg-open", path}, false, nil
+		}
+	default:
+		return nil, false, fmt.Errorf("can't open %s", endpoint)
+	}
+}
+
+// remoteChdir returns a command for the remote shell that changes to path,
+// quoted except for a leading ~ so the remote shell expands it.
+func remoteChdir(path string) string {
+	quote := func(s string) string { return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'" }
+	switch {
+	case path == "" || path == "~" || path == "~/":
+		return ""
+	case strings.HasPrefix(path, "~/"):
+		return "cd ~/" + quote(path[2:])
+	default:
+		return "cd " + quote(path)
+	}
+}
+
+// OpenSelectedBeta opens the selected spec's beta endpoint: a shell on the
+// host for an SSH endpoint, or the file manager for a local one. The shell
+// is returned for the caller to run with the terminal; the file manager is
+// started here, and nil is returned.
+func (a *App) OpenSelectedBeta() *exec.Cmd {
+	projIdx, specIdx := a.GetSelectedSpec()
+	if projIdx < 0 || specIdx < 0 {
+		a.SetStatus(ui.StatusWarning, "Select a spec to open its beta endpoint")
+		return nil
+	}
+	proj := a.State.Projects[projIdx]
+	spec := &proj.Specs[specIdx]
+	def, ok := proj.File.Sessions[spec.Name]
+	if !ok {
+		a.SetStatus(ui.StatusError, "Session definition not found in project file")
+		return nil
+	}
+	if def.IsMultiBeta() {
+		a.SetStatus(ui.StatusWarning, "Can't open "+spec.Name+": it has several betas")
+		return nil
+	}
+
+	// Relative local paths are relative to the project file, not to
+	// mutagui's working directory
+	beta := project.NormalizeEndpoint(def.Beta, proj.File.Dir())
+	args, interactive, err := endpointOpenCommand(beta, runtime.GOOS)
+	if err != nil {
+		a.SetStatus(ui.StatusError, err.Error())
+		return nil
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	if interactive {
+		return cmd
+	}
+	if err := startDetached(cmd); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to open "+beta+": "+err.Error())
+		return nil
+	}
+	a.SetStatus(ui.StatusInfo, "Opened "+beta)
+	return nil
+}
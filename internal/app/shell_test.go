@@ -0,0 +1,97 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+func TestEndpointOpenCommand(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	tests := []struct {
+		endpoint    string
+		goos        string
+		want        []string
+		interactive bool
+	}{
+		{"server:/srv/app", "linux", []string{"ssh", "-t", "server", "cd '/srv/app'; exec $SHELL -l"}, true},
+		{"me@server:~/code/it's", "darwin", []string{"ssh", "-t", "me@server", `cd ~/'code/it'\''s'; exec $SHELL -l`}, true},
+		{"server:", "linux", []string{"ssh", "-t", "server", "exec $SHELL -l"}, true},
+		{"/local/src", "darwin", []string{"open", "/local/src"}, false},
+		{"/local/src", "linux", []string{"xdg-open", "/local/src"}, false},
+		{`C:\src`, "windows", []string{"explorer", `C:\src`}, false},
+		{"~/src", "linux", []string{"xdg-open", filepath.Join(home, "src")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint+" on "+tt.goos, func(t *testing.T) {
+			args, interactive, err := endpointOpenCommand(tt.endpoint, tt.goos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(args, tt.want) || interactive != tt.interactive {
+				t.Errorf("endpointOpenCommand() = %q, %v; want %q, %v", args, interactive, tt.want, tt.interactive)
+			}
+		})
+	}
+
+	if _, _, err := endpointOpenCommand("docker://web/app", "linux"); err == nil {
+		t.Error("a docker endpoint should not be openable")
+	}
+}
+
+func TestOpenSelectedBeta(t *testing.T) {
+	var started []string
+	saved := startDetached
+	startDetached = func(cmd *exec.Cmd) error {
+		started = cmd.Args
+		return nil
+	}
+	t.Cleanup(func() { startDetached = saved })
+
+	app := newTestApp(&MockClient{})
+	proj := createTestProjectWithFile("proj", []string{"remote", "local"})
+	proj.File.Sessions["remote"] = project.SessionDefinition{Alpha: "/src", Beta: "server:/srv"}
+	proj.File.Sessions["local"] = project.SessionDefinition{Alpha: "server:/srv", Beta: "mirror"}
+	proj.File.Path = "/projects/web/mutagen.yml"
+	proj.Folded = false
+	app.State.Projects = []*project.Project{proj}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+
+	// SSH: the shell is returned to run in the terminal
+	app.State.Selection.SetIndex(1)
+	cmd := app.OpenSelectedBeta()
+	if cmd == nil || strings.Join(cmd.Args, " ") != "ssh -t server cd '/srv'; exec $SHELL -l" {
+		t.Fatalf("OpenSelectedBeta() = %v, want an ssh shell", cmd)
+	}
+	if started != nil {
+		t.Error("the shell should not be started in the background")
+	}
+
+	// Local: the file manager is started here
+	app.State.Selection.SetIndex(2)
+	if cmd := app.OpenSelectedBeta(); cmd != nil {
+		t.Errorf("OpenSelectedBeta() = %v, want nil for a local beta", cmd.Args)
+	}
+	mirror := filepath.Join("/projects/web", "mirror")
+	if len(started) != 2 || started[1] != mirror {
+		t.Errorf("started %q, want the file manager on %s, next to the project file", started, mirror)
+	}
+	if msg := app.State.StatusMessage; msg.Type != ui.StatusInfo || msg.Text != "Opened "+mirror {
+		t.Errorf("status = %+v", msg)
+	}
+
+	// Project selected
+	app.State.Selection.SetIndex(0)
+	if cmd := app.OpenSelectedBeta(); cmd != nil || app.State.StatusMessage.Type != ui.StatusWarning {
+		t.Errorf("with a project selected: cmd = %v, status = %+v", cmd, app.State.StatusMessage)
+	}
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectSnapshot is the exported state of a project and its specs.
+type ProjectSnapshot struct {
+	Project string         `json:"project" yaml:"project"`
+	File    string         `json:"file,omitempty" yaml:"file,omitempty"`
+	Specs   []SpecSnapshot `json:"specs" yaml:"specs"`
+}
+
+// SpecSnapshot is the exported state of a spec. Status is Mutagen's status
+// for the running session, and empty if the spec isn't running.
+type SpecSnapshot struct {
+	Name      string           `json:"name" yaml:"name"`
+	State     string           `json:"state" yaml:"state"` // "running", "paused", or "not running"
+	Status    string           `json:"status,omitempty" yaml:"status,omitempty"`
+	Conflicts int              `json:"conflicts" yaml:"conflicts"`
+	Alpha     EndpointSnapshot `json:"alpha" yaml:"alpha"`
+	Beta      EndpointSnapshot `json:"beta" yaml:"beta"`
+	// Betas lists each beta of a spec with several, of which Beta is the
+	// first running one
+	Betas []EndpointSnapshot `json:"betas,omitempty" yaml:"betas,omitempty"`
+}
+
+// EndpointSnapshot is the exported state of one end of a spec.
+type EndpointSnapshot struct {
+	Path      string `json:"path" yaml:"path"`
+	Connected bool   `json:"connected" yaml:"connected"`
+}
+
+// Snapshot returns the state of every project, in display order.
+func (a *App) Snapshot() []ProjectSnapshot {
+	snapshots := make([]ProjectSnapshot, 0, len(a.State.Projects))
+	for _, proj := range a.State.Projects {
+		ps := ProjectSnapshot{Project: proj.File.DisplayName(), Specs: []SpecSnapshot{}}
+		if !proj.Orphaned {
+			ps.File = proj.File.Path
+		}
+		for i := range proj.Specs {
+			ps.Specs = append(ps.Specs, specSnapshot(proj, &proj.Specs[i]))
+		}
+		snapshots = append(snapshots, ps)
+	}
+	return snapshots
+}
+
+func specSnapshot(proj *project.Project, spec *project.SyncSpec) SpecSnapshot {
+	def := proj.File.Sessions[spec.Name]
+	var betas []EndpointSnapshot
+	if def.IsMultiBeta() {
+		for i, replica := range def.Replicas(spec.Name) {
+			if i < len(spec.BetaSessions) && spec.BetaSessions[i] != nil {
+				betas = append(betas, endpointSnapshot(&spec.BetaSessions[i].Beta))
+			} else {
+				betas = append(betas, EndpointSnapshot{Path: replica.Beta})
+			}
+		}
+	}
+
+	session := spec.RunningSession
+	if session == nil {
+		snapshot := SpecSnapshot{
+			Name:  spec.Name,
+			State: "not running",
+			Alpha: EndpointSnapshot{Path: def.Alpha},
+			Betas: betas,
+		}
+		if endpoints := def.BetaEndpoints(); len(endpoints) > 0 {
+			snapshot.Beta = EndpointSnapshot{Path: endpoints[0]}
+		}
+		return snapshot
+	}
+	state := "running"
+	if session.Paused {
+		state = "paused"
+	}
+	return SpecSnapshot{
+		Name:      spec.Name,
+		State:     state,
+		Status:    session.Status,
+		Conflicts: session.ConflictCount(),
+		Alpha:     endpointSnapshot(&session.Alpha),
+		Beta:      endpointSnapshot(&session.Beta),
+		Betas:     betas,
+	}
+}
+
+// endpointSnapshot gives the endpoint's path as a URL Mutagen would accept,
+// without abbreviating the home directory.
+func endpointSnapshot(e *mutagen.Endpoint) EndpointSnapshot {
+	path := e.Path
+	if e.Host != nil {
+		path = *e.Host + ":" + path
+	}
+	return EndpointSnapshot{Path: path, Connected: e.Connected}
+}
+
+// WriteSnapshot writes snapshots to w as "json" or "yaml".
+func WriteSnapshot(w io.Writer, format string, snapshots []ProjectSnapshot) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(snapshots)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(snapshots); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("unknown export format %q (want json or yaml)", format)
+	}
+}
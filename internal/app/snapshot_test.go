@@ -0,0 +1,113 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// snapshotTestApp returns an app with one project whose "web" spec is running
+// with a conflict and whose "docs" spec is stopped, refreshed from a mock.
+func snapshotTestApp(t *testing.T) *App {
+	host := "server"
+	mock := &MockClient{
+		ListSessionsResult: []mutagen.SyncSession{{
+			Name:      "web",
+			Status:    "watching",
+			Alpha:     mutagen.Endpoint{Protocol: "local", Path: "/local/path", Connected: true},
+			Beta:      mutagen.Endpoint{Protocol: "ssh", Host: &host, Path: "/remote/path"},
+			Conflicts: []mutagen.Conflict{{Root: "index.html"}},
+		}},
+	}
+	app := newTestApp(mock)
+	proj := createTestProjectWithFile("site", []string{"docs", "web"})
+	proj.File.Path = "/projects/site/mutagen.yml"
+	app.State.Projects = []*project.Project{proj}
+	if err := app.RefreshSessions(context.Background(), true); err != nil {
+		t.Fatal(err)
+	}
+	return app
+}
+
+func TestSnapshot(t *testing.T) {
+	app := snapshotTestApp(t)
+
+	want := []ProjectSnapshot{{
+		Project: app.State.Projects[0].File.DisplayName(),
+		File:    "/projects/site/mutagen.yml",
+		Specs: []SpecSnapshot{
+			{
+				Name:  "docs",
+				State: "not running",
+				Alpha: EndpointSnapshot{Path: "/local/path"},
+				Beta:  EndpointSnapshot{Path: "/remote/path"},
+			},
+			{
+				Name:      "web",
+				State:     "running",
+				Status:    "watching",
+				Conflicts: 1,
+				Alpha:     EndpointSnapshot{Path: "/local/path", Connected: true},
+				Beta:      EndpointSnapshot{Path: "server:/remote/path"},
+			},
+		},
+	}}
+	if got := app.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Snapshot() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestWriteSnapshot_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSnapshot(&buf, "json", snapshotTestApp(t).Snapshot()); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	specs := decoded[0]["specs"].([]any)
+	web := specs[1].(map[string]any)
+	if web["name"] != "web" || web["state"] != "running" || web["conflicts"] != 1.0 {
+		t.Errorf("web spec = %v", web)
+	}
+	if beta := web["beta"].(map[string]any); beta["connected"] != false || beta["path"] != "server:/remote/path" {
+		t.Errorf("web beta = %v", beta)
+	}
+	if _, ok := specs[0].(map[string]any)["status"]; ok {
+		t.Error("a spec that isn't running should have no status")
+	}
+}
+
+func TestWriteSnapshot_YAML(t *testing.T) {
+	snapshots := snapshotTestApp(t).Snapshot()
+	var buf bytes.Buffer
+	if err := WriteSnapshot(&buf, "yaml", snapshots); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "- project: ") {
+		t.Errorf("output = %q, want a YAML list of projects", buf.String())
+	}
+
+	var decoded []ProjectSnapshot
+	if err := yaml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, snapshots) {
+		t.Errorf("round trip = %+v, want %+v", decoded, snapshots)
+	}
+}
+
+func TestWriteSnapshot_UnknownFormat(t *testing.T) {
+	if err := WriteSnapshot(&bytes.Buffer{}, "xml", nil); err == nil {
+		t.Error("WriteSnapshot() with an unknown format should fail")
+	}
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/osteele/mutagui/internal/config"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// compareProjects returns the comparison function for a sort order, given
+// the index at which discovery found each project file. Projects that tie
+// are ordered by name, then by file path. The orphaned sessions project
+// always comes last.
+func compareProjects(by config.SortBy, discovered map[string]int) func(p, q *project.Project) int {
+	rank := func(proj *project.Project) int {
+		if i, ok := discovered[proj.File.Path]; ok {
+			return i
+		}
+		return len(discovered)
+	}
+	return func(p, q *project.Project) int {
+		if p.Orphaned != q.Orphaned {
+			if p.Orphaned {
+				return 1
+			}
+			return -1
+		}
+		switch by {
+		case config.SortByDiscovery:
+			if c := cmp.Compare(rank(p), rank(q)); c != 0 {
+				return c
+			}
+		case config.SortByStatus:
+			if c := cmp.Compare(statusRank(p), statusRank(q)); c != 0 {
+				return c
+			}
+		case config.SortByConflicts:
+			if c := cmp.Compare(q.ConflictCount(), p.ConflictCount()); c != 0 {
+				return c
+			}
+		}
+		return cmp.Or(
+			cmp.Compare(strings.ToLower(p.File.DisplayName()), strings.ToLower(q.File.DisplayName())),
+			cmp.Compare(p.File.Path, q.File.Path))
+	}
+}
+
+// statusRank orders projects by status: conflicted, then running, then not running.
+func statusRank(proj *project.Project) int {
+	switch {
+	case proj.ConflictCount() > 0:
+		return 0
+	case proj.IsRunning():
+		return 1
+	default:
+		return 2
+	}
+}
+
+// sortProjects orders projects in place.
+func sortProjects(projects []*project.Project, by config.SortBy, discovered map[string]int) {
+	slices.SortStableFunc(projects, compareProjects(by, discovered))
+}
+
+// discoveryOrder indexes project files by the order discovery found them in.
+func discoveryOrder(projects []*project.Project) map[string]int {
+	order := make(map[string]int, len(projects))
+	for i, proj := range projects {
+		order[proj.File.Path] = i
+	}
+	return order
+}
+
+// resortProjects re-sorts the project list if the order has changed, for
+// example because a project's sessions started or hit conflicts. The
+// selection stays on the same project or spec. The UI may be drawing the
+// current list, so a sorted copy replaces it.
+func (a *App) resortProjects() {
+	if slices.IsSortedFunc(a.State.Projects, compareProjects(a.State.SortBy, a.discovered)) {
+		return
+	}
+	a.keepSelection(func() {
+		sorted := slices.Clone(a.State.Projects)
+		sortProjects(sorted, a.State.SortBy, a.discovered)
+		a.State.Projects = sorted
+	})
+}
+
+// keepSelection runs update, which may reorder or replace the project list,
+// then rebuilds the selection and re-selects the same project or spec,
+// tracked by project file path since indices may shift.
+func (a *App) keepSelection(update func()) {
+	var selectedPath string
+	var selected ui.SelectableItem
+	if item := a.State.Selection.SelectedItem(); item != nil && item.ProjectIndex < len(a.State.Projects) {
+		selectedPath = a.State.Projects[item.ProjectIndex].File.Path
+		selected = *item
+	}
+
+	update()
+	a.State.Selection.RebuildFromProjects(a.State.Projects)
+
+	if selectedPath == "" {
+		return
+	}
+	for i, proj := range a.State.Projects {
+		if proj.File.Path == selectedPath {
+			selected.ProjectIndex = i
+			if !a.State.Selection.SelectItem(selected) {
+				a.State.Selection.SelectProject(i)
+			}
+			return
+		}
+	}
+}
+
+// CycleSort switches to the next project sort order.
+func (a *App) CycleSort() {
+	a.State.SortBy = a.State.SortBy.Next()
+	a.resortProjects()
+	a.SetStatus(ui.StatusInfo, "Sorting projects by "+string(a.State.SortBy))
+}
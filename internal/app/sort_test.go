@@ -0,0 +1,129 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/config"
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// sortTestProject returns a project with one spec, both called name. The
+// spec is running, with the given number of conflicts, if running is set.
+func sortTestProject(name string, running bool, conflicts int) *project.Project {
+	proj := createTestProjectWithFile(name, []string{name})
+	proj.File.Path = "/projects/" + name + ".yml"
+	proj.Folded = false
+	if running {
+		proj.Specs[0].State = project.RunningTwoWay
+		proj.Specs[0].RunningSession = &mutagen.SyncSession{Name: name}
+		for range conflicts {
+			proj.Specs[0].RunningSession.Conflicts = append(proj.Specs[0].RunningSession.Conflicts, mutagen.Conflict{Root: "file.txt"})
+		}
+	}
+	return proj
+}
+
+// mixedProjects returns projects in discovery order with a mix of states.
+func mixedProjects() []*project.Project {
+	return []*project.Project{
+		sortTestProject("echo", false, 0),
+		sortTestProject("charlie", true, 0),
+		sortTestProject("bravo", true, 1),
+		sortTestProject("alpha", false, 0),
+		sortTestProject("delta", true, 3),
+	}
+}
+
+func projectNames(projects []*project.Project) []string {
+	names := make([]string, len(projects))
+	for i, proj := range projects {
+		names[i] = proj.File.DisplayName()
+	}
+	return names
+}
+
+func TestSortProjects(t *testing.T) {
+	tests := []struct {
+		by   config.SortBy
+		want []string
+	}{
+		{config.SortByDiscovery, []string{"echo", "charlie", "bravo", "alpha", "delta"}},
+		{config.SortByName, []string{"alpha", "bravo", "charlie", "delta", "echo"}},
+		{config.SortByStatus, []string{"bravo", "delta", "charlie", "alpha", "echo"}},
+		{config.SortByConflicts, []string{"delta", "bravo", "alpha", "charlie", "echo"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.by), func(t *testing.T) {
+			projects := mixedProjects()
+			discovered := discoveryOrder(projects)
+			slices.Reverse(projects) // Discovery order is kept by index, not by position
+			sortProjects(projects, tt.by, discovered)
+			if got := projectNames(projects); !slices.Equal(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCycleSort_KeepsSelection(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	app.State.SortBy = config.SortByName
+	app.State.Projects = mixedProjects()
+	app.discovered = discoveryOrder(app.State.Projects)
+	sortProjects(app.State.Projects, config.SortByName, app.discovered)
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(5) // charlie's spec
+
+	for _, want := range []config.SortBy{config.SortByStatus, config.SortByConflicts, config.SortByDiscovery, config.SortByName} {
+		app.CycleSort()
+		if app.State.SortBy != want {
+			t.Fatalf("SortBy = %q, want %q", app.State.SortBy, want)
+		}
+		projIdx, specIdx := app.State.Selection.SelectedSpec()
+		if projIdx < 0 || app.State.Projects[projIdx].File.DisplayName() != "charlie" || specIdx != 0 {
+			t.Errorf("after sorting by %s, selection = (%d, %d), want charlie's spec", want, projIdx, specIdx)
+		}
+	}
+}
+
+func TestRefreshSessions_ResortsByStatus(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	app.State.SortBy = config.SortByStatus
+	app.State.Projects = []*project.Project{
+		sortTestProject("alpha", false, 0),
+		sortTestProject("bravo", false, 0),
+	}
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	app.State.Selection.SetIndex(0) // alpha
+
+	mock.ListSessionsResult = []mutagen.SyncSession{{Name: "bravo"}}
+	if err := app.RefreshSessions(t.Context(), true); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := projectNames(app.State.Projects), []string{"bravo", "alpha"}; !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+	if projIdx := app.State.Selection.SelectedProjectIndex(); projIdx != 1 {
+		t.Errorf("selected project = %d, want 1 (alpha)", projIdx)
+	}
+}
+
+func TestResortProjects_ReplacesTheListTheUIHolds(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	app.State.SortBy = config.SortByName
+	app.State.Projects = mixedProjects()
+	app.discovered = discoveryOrder(app.State.Projects)
+	shown := app.State.Projects // The list the UI's model was handed
+
+	app.resortProjects()
+	if got := projectNames(shown); !slices.Equal(got, []string{"echo", "charlie", "bravo", "alpha", "delta"}) {
+		t.Errorf("the UI's list was reordered in place: %v", got)
+	}
+	if got := projectNames(app.State.Projects); got[0] != "alpha" {
+		t.Errorf("order = %v, want sorted by name", got)
+	}
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// Summary totals the running sessions across all projects. The size of each
+// session is the size of its alpha tree, or of its beta tree if alpha hasn't
+// been scanned.
+func (a *App) Summary() ui.Summary {
+	var s ui.Summary
+	for _, proj := range a.State.Projects {
+		for i := range proj.Specs {
+			session := proj.Specs[i].RunningSession
+			if session == nil {
+				continue
+			}
+			if session.Paused {
+				s.Paused++
+			} else {
+				s.Running++
+			}
+			if session.HasConflicts() {
+				s.Conflicting++
+			}
+			if size := session.Alpha.TotalFileSize; size != nil {
+				s.Bytes += *size
+			} else if size := session.Beta.TotalFileSize; size != nil {
+				s.Bytes += *size
+			}
+		}
+	}
+	return s
+}
+
+// AggregateStagingProgress totals the staging of every running session,
+// for a single readout of a big start's initial sync. receivedBytes counts
+// what the staging endpoints have received so far. expectedBytes takes the
+// size of the other endpoint's tree as what each staging endpoint will
+// receive, as it does on an initial sync; if that tree hasn't been scanned,
+// or is already exceeded, the received bytes are scaled by the files left.
+// files is the number of files still to be staged. All three are zero when
+// no session is staging.
+func (a *App) AggregateStagingProgress() (receivedBytes, expectedBytes uint64, files int) {
+	for _, proj := range a.State.Projects {
+		for i := range proj.Specs {
+			for _, session := range proj.Specs[i].Sessions() {
+				for _, ep := range []struct{ staging, source *mutagen.Endpoint }{
+					{&session.Alpha, &session.Beta},
+					{&session.Beta, &session.Alpha},
+				} {
+					received, expected, left, ok := endpointStaging(ep.staging, ep.source)
+					if !ok {
+						continue
+					}
+					receivedBytes += received
+					expectedBytes += expected
+					files += left
+				}
+			}
+		}
+	}
+	return receivedBytes, expectedBytes, files
+}
+
+// endpointStaging returns the bytes an endpoint has received and is
+// expected to receive, and the files it has left, while it is staging
+// files from source.
+func endpointStaging(staging, source *mutagen.Endpoint) (received, expected uint64, files int, ok bool) {
+	prog := staging.StagingProgress
+	if prog == nil {
+		return 0, 0, 0, false
+	}
+	if prog.TotalReceivedSize != nil {
+		received = *prog.TotalReceivedSize
+	} else if prog.ReceivedSize != nil {
+		received = *prog.ReceivedSize
+	}
+	var receivedFiles, expectedFiles uint64
+	if prog.ReceivedFiles != nil {
+		receivedFiles = *prog.ReceivedFiles
+	}
+	if prog.ExpectedFiles != nil {
+		expectedFiles = max(*prog.ExpectedFiles, receivedFiles)
+	}
+	files = int(expectedFiles - receivedFiles)
+
+	switch {
+	case source.TotalFileSize != nil && *source.TotalFileSize >= received:
+		expected = *source.TotalFileSize
+	case receivedFiles > 0:
+		expected = received * expectedFiles / receivedFiles
+	default:
+		expected = received
+	}
+	return received, expected, files, true
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+func TestSummary(t *testing.T) {
+	projects := mixedProjects()
+	size := func(n uint64) *uint64 { return &n }
+	// charlie: running, 1 KiB on alpha
+	projects[1].Specs[0].RunningSession.Alpha.TotalFileSize = size(1024)
+	// bravo: paused with a conflict; alpha not scanned, so beta's size counts
+	projects[2].Specs[0].RunningSession.Paused = true
+	projects[2].Specs[0].RunningSession.Beta.TotalFileSize = size(512)
+	// delta: running with conflicts, sizes on both sides count once
+	projects[4].Specs[0].RunningSession.Alpha.TotalFileSize = size(2048)
+	projects[4].Specs[0].RunningSession.Beta.TotalFileSize = size(2048)
+
+	app := newTestApp(&MockClient{})
+	app.State.Projects = projects
+
+	want := ui.Summary{Running: 2, Paused: 1, Conflicting: 2, Bytes: 1024 + 512 + 2048}
+	if got := app.Summary(); got != want {
+		t.Errorf("Summary() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSummary_NoSessions(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	app.State.Projects = mixedProjects()[:1]
+
+	if got := app.Summary(); got != (ui.Summary{}) {
+		t.Errorf("Summary() = %+v, want zero", got)
+	}
+}
+
+func TestAggregateStagingProgress(t *testing.T) {
+	size := func(n uint64) *uint64 { return &n }
+	projects := mixedProjects()
+	// charlie: staging 40 of 100 files on beta, from a 1000-byte alpha
+	charlie := projects[1].Specs[0].RunningSession
+	charlie.Alpha.TotalFileSize = size(1000)
+	charlie.Beta.StagingProgress = &mutagen.StagingProgress{
+		TotalReceivedSize: size(400), ReceivedFiles: size(40), ExpectedFiles: size(100),
+	}
+	// bravo: staging on alpha, beta not scanned, so 300 bytes for a quarter
+	// of the files scales to 1200
+	bravo := projects[2].Specs[0].RunningSession
+	bravo.Alpha.StagingProgress = &mutagen.StagingProgress{
+		TotalReceivedSize: size(300), ReceivedFiles: size(5), ExpectedFiles: size(20),
+	}
+	// delta: running without staging progress
+	projects[4].Specs[0].RunningSession.Alpha.TotalFileSize = size(5000)
+
+	app := newTestApp(&MockClient{})
+	app.State.Projects = projects
+
+	received, expected, files := app.AggregateStagingProgress()
+	if received != 700 || expected != 2200 || files != 75 {
+		t.Errorf("AggregateStagingProgress() = %d, %d, %d; want 700, 2200, 75", received, expected, files)
+	}
+}
+
+func TestAggregateStagingProgress_NoneStaging(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	app.State.Projects = mixedProjects()
+
+	if received, expected, files := app.AggregateStagingProgress(); received != 0 || expected != 0 || files != 0 {
+		t.Errorf("AggregateStagingProgress() = %d, %d, %d; want zeros", received, expected, files)
+	}
+}
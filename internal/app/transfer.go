@@ -0,0 +1,123 @@
+package app
+
+import (
+	"time"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// transferSample is the received size of the file an endpoint is staging.
+type transferSample struct {
+	at       time.Time
+	received uint64
+}
+
+// transferState tracks an endpoint's staging speed across refreshes.
+type transferState struct {
+	last transferSample
+	rate float64 // Bytes per second between the last two samples; 0 until measured
+}
+
+// measureRate returns the bytes per second received between two samples,
+// or false if the samples are simultaneous or the size went backwards,
+// which means a new file started.
+func measureRate(prev, cur transferSample) (float64, bool) {
+	elapsed := cur.at.Sub(prev.at).Seconds()
+	if elapsed <= 0 || cur.received < prev.received {
+		return 0, false
+	}
+	return float64(cur.received-prev.received) / elapsed, true
+}
+
+// estimateRemaining returns how long receiving the rest of the file takes
+// at rate, or false if the expected size or rate is unknown.
+func estimateRemaining(received, expected uint64, rate float64) (time.Duration, bool) {
+	if expected == 0 || rate <= 0 || received > expected {
+		return 0, false
+	}
+	return time.Duration(float64(expected-received) / rate * float64(time.Second)), true
+}
+
+// recordTransfers samples the staging progress of each session and drops
+// the state of sessions that no longer exist.
+func (a *App) recordTransfers(sessions []mutagen.SyncSession) {
+	a.transferMu.Lock()
+	defer a.transferMu.Unlock()
+	seen := make(map[string]bool, 2*len(sessions))
+	for i := range sessions {
+		key := sessionKey(&sessions[i])
+		seen[key+"/alpha"] = true
+		seen[key+"/beta"] = true
+		a.addTransferSample(&sessions[i])
+	}
+	for key := range a.transfers {
+		if !seen[key] {
+			delete(a.transfers, key)
+		}
+	}
+}
+
+// recordTransferSample samples the staging progress of one session.
+func (a *App) recordTransferSample(session *mutagen.SyncSession) {
+	a.transferMu.Lock()
+	defer a.transferMu.Unlock()
+	a.addTransferSample(session)
+}
+
+// addTransferSample is recordTransferSample with transferMu held.
+func (a *App) addTransferSample(session *mutagen.SyncSession) {
+	if a.transfers == nil {
+		a.transfers = make(map[string]*transferState)
+	}
+	key := sessionKey(session)
+	a.recordEndpointTransfer(key+"/alpha", &session.Alpha)
+	a.recordEndpointTransfer(key+"/beta", &session.Beta)
+}
+
+func (a *App) recordEndpointTransfer(key string, e *mutagen.Endpoint) {
+	prog := e.StagingProgress
+	if prog == nil || prog.ReceivedSize == nil {
+		delete(a.transfers, key)
+		return
+	}
+	cur := transferSample{at: a.Clock.Now(), received: *prog.ReceivedSize}
+	st, ok := a.transfers[key]
+	if !ok {
+		a.transfers[key] = &transferState{last: cur}
+		return
+	}
+	if cur.received < st.last.received {
+		*st = transferState{last: cur}
+		return
+	}
+	if rate, ok := measureRate(st.last, cur); ok {
+		st.rate = rate
+		st.last = cur
+	}
+}
+
+// TransferRate returns the staging speed of a session, and how long the
+// file being staged will take if it can be estimated. Returns false until
+// two samples of the same file have been seen.
+func (a *App) TransferRate(session *mutagen.SyncSession) (ui.TransferRate, bool) {
+	a.transferMu.Lock()
+	defer a.transferMu.Unlock()
+	key := sessionKey(session)
+	for _, ep := range []struct {
+		suffix   string
+		endpoint *mutagen.Endpoint
+	}{{"/alpha", &session.Alpha}, {"/beta", &session.Beta}} {
+		st, ok := a.transfers[key+ep.suffix]
+		if !ok || st.rate <= 0 || ep.endpoint.StagingProgress == nil {
+			continue
+		}
+		rate := ui.TransferRate{BytesPerSecond: st.rate}
+		prog := ep.endpoint.StagingProgress
+		if prog.ExpectedSize != nil {
+			rate.Remaining, _ = estimateRemaining(st.last.received, *prog.ExpectedSize, st.rate)
+		}
+		return rate, true
+	}
+	return ui.TransferRate{}, false
+}
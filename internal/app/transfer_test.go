@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/osteele/mutagui/internal/clock"
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestMeasureRate(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		prev     transferSample
+		cur      transferSample
+		want     float64
+		measured bool
+	}{
+		{"steady", transferSample{t0, 1000}, transferSample{t0.Add(2 * time.Second), 7000}, 3000, true},
+		{"stalled", transferSample{t0, 1000}, transferSample{t0.Add(time.Second), 1000}, 0, true},
+		{"new file", transferSample{t0, 5000}, transferSample{t0.Add(time.Second), 100}, 0, false},
+		{"same instant", transferSample{t0, 1000}, transferSample{t0, 2000}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := measureRate(tt.prev, tt.cur)
+			if got != tt.want || ok != tt.measured {
+				t.Errorf("measureRate() = %v, %v, want %v, %v", got, ok, tt.want, tt.measured)
+			}
+		})
+	}
+}
+
+func TestEstimateRemaining(t *testing.T) {
+	if got, ok := estimateRemaining(1000, 4000, 1000); !ok || got != 3*time.Second {
+		t.Errorf("estimateRemaining() = %v, %v, want 3s", got, ok)
+	}
+	if _, ok := estimateRemaining(1000, 0, 1000); ok {
+		t.Error("no ETA without an expected size")
+	}
+	if _, ok := estimateRemaining(1000, 4000, 0); ok {
+		t.Error("no ETA without a measured rate")
+	}
+}
+
+func TestTransferRate(t *testing.T) {
+	mock := &MockClient{}
+	app := newTestApp(mock)
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	app.Clock = fake
+	app.State.Projects = []*project.Project{createTestProjectWithFile("test-proj", []string{"spec-a"})}
+
+	staging := func(received uint64) *mutagen.SyncSession {
+		expected := uint64(10_000_000)
+		mock.ListSessionsResult = []mutagen.SyncSession{{
+			Name: "spec-a", Identifier: "sync_a", Status: "staging-beta",
+			Beta: mutagen.Endpoint{StagingProgress: &mutagen.StagingProgress{
+				ReceivedSize: &received, ExpectedSize: &expected,
+			}},
+		}}
+		if err := app.RefreshSessions(context.Background(), true); err != nil {
+			t.Fatal(err)
+		}
+		return &mock.ListSessionsResult[0]
+	}
+
+	if _, ok := app.TransferRate(staging(1_000_000)); ok {
+		t.Error("rate reported from a single sample")
+	}
+	fake.Advance(2 * time.Second)
+	rate, ok := app.TransferRate(staging(3_000_000))
+	if !ok || rate.BytesPerSecond != 1_000_000 || rate.Remaining != 7*time.Second {
+		t.Errorf("TransferRate() = %+v, %v, want 1 MB/s with 7s left", rate, ok)
+	}
+
+	// A smaller size means a new file; the rate waits for another sample
+	fake.Advance(2 * time.Second)
+	if _, ok := app.TransferRate(staging(500_000)); ok {
+		t.Error("rate reported across files")
+	}
+}
+
+func TestTransferRate_ConcurrentWithRefresh(t *testing.T) {
+	app := newTestApp(&MockClient{})
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	app.Clock = fake
+	session := func(received uint64) mutagen.SyncSession {
+		return mutagen.SyncSession{Identifier: "sync_a", Beta: mutagen.Endpoint{
+			StagingProgress: &mutagen.StagingProgress{ReceivedSize: &received},
+		}}
+	}
+
+	// Refreshes record samples while the list reads them
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 200 {
+			app.recordTransfers([]mutagen.SyncSession{session(uint64(i) * 1000)})
+			fake.Advance(time.Second)
+		}
+	}()
+	shown := session(0)
+	for range 200 {
+		app.TransferRate(&shown)
+	}
+	<-done
+}
@@ -0,0 +1,242 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// undoAction is the inverse of the last mutating operation.
+type undoAction struct {
+	// description says what undoing will do, e.g. "Resume web"
+	description string
+	// unavailable explains why the last operation cannot be undone; run is nil when set
+	unavailable string
+	run         func(ctx context.Context) error
+}
+
+// sessionSnapshot records what is needed to recreate a terminated session.
+type sessionSnapshot struct {
+	name  string
+	alpha string
+	beta  string
+	opts  *mutagen.SessionOptions
+	push  bool
+}
+
+// snapshotSession captures a running spec's session so it can be recreated
+// with the same endpoints, options, and mode. Returns false if the spec has
+// no session definition to recreate it from.
+func snapshotSession(proj *project.Project, spec *project.SyncSpec) (sessionSnapshot, bool) {
+	def, exists := proj.File.Sessions[spec.Name]
+	if !exists {
+		return sessionSnapshot{}, false
+	}
+	snap := sessionSnapshot{
+		name:  spec.Name,
+		alpha: def.Alpha,
+		beta:  def.Beta,
+		opts:  projectSessionOptions(proj, &def),
+		push:  spec.State == project.RunningPush,
+	}
+	// Keep a mode the running session had even if the definition doesn't set one
+	if session := spec.RunningSession; session != nil && session.Mode != nil && !snap.push && snap.opts.Mode == "" {
+		snap.opts.Mode = *session.Mode
+	}
+	return snap, true
+}
+
+// snapshotSpec captures a running spec's sessions so they can be recreated:
+// its one session, or each running replica of a spec with several betas.
+// Returns false if the spec has no session definition to recreate them from.
+func snapshotSpec(proj *project.Project, spec *project.SyncSpec) ([]sessionSnapshot, bool) {
+	def, exists := proj.File.Sessions[spec.Name]
+	if !exists {
+		return nil, false
+	}
+	if !def.IsMultiBeta() {
+		snap, _ := snapshotSession(proj, spec)
+		return []sessionSnapshot{snap}, true
+	}
+	var snapshots []sessionSnapshot
+	for i, replica := range def.Replicas(spec.Name) {
+		if i >= len(spec.BetaSessions) || spec.BetaSessions[i] == nil {
+			continue
+		}
+		replicaDef := def.WithBeta(replica.Beta)
+		snapshots = append(snapshots, sessionSnapshot{
+			name:  replica.Name,
+			alpha: replicaDef.Alpha,
+			beta:  replicaDef.Beta,
+			opts:  projectSessionOptions(proj, &replicaDef),
+		})
+	}
+	return snapshots, true
+}
+
+// RestartSelected terminates the selected spec's running sessions and
+// creates them again from the project file, as a push if the spec was
+// running as one. It is the usual recovery for a stuck session, done in one
+// step so the spec keeps its mode.
+func (a *App) RestartSelected(ctx context.Context) {
+	projIdx, specIdx := a.GetSelectedSpec()
+	if projIdx < 0 || specIdx < 0 {
+		a.SetStatus(ui.StatusWarning, "Select a running spec to restart")
+		return
+	}
+	proj := a.State.Projects[projIdx]
+	spec := &proj.Specs[specIdx]
+	if spec.RunningSession == nil {
+		a.SetStatus(ui.StatusWarning, "Session not running")
+		return
+	}
+	snapshots, canRecreate := snapshotSpec(proj, spec)
+	if !canRecreate {
+		a.SetStatus(ui.StatusWarning, spec.Name+" has no definition to recreate it from")
+		return
+	}
+
+	a.SetStatus(ui.StatusInfo, "Restarting "+spec.Name+"...")
+	if err := forEachSession(spec, func(name string) error {
+		if err := a.Client.TerminateSession(ctx, name); err != nil {
+			return err
+		}
+		if a.DryRun {
+			return nil // The terminate was only reported, so the session stays listed
+		}
+		return a.Client.WaitForTerminated(ctx, name)
+	}); err != nil {
+		a.SetStatus(ui.StatusError, "Failed to terminate "+spec.Name+": "+err.Error())
+		return
+	}
+	for i, snap := range snapshots {
+		if err := a.recreate(ctx, snap); err != nil {
+			// Undo retries the sessions that weren't recreated
+			a.recordRecreateUndo(snapshots[i:])
+			a.SetStatus(ui.StatusError, "Terminated "+spec.Name+" but failed to recreate it: "+err.Error())
+			return
+		}
+	}
+
+	a.recordNoUndo("A restart can't be undone; the old sessions are gone")
+	text := "Restarted " + spec.Name
+	if spec.State == project.RunningPush {
+		text += " as a push"
+	}
+	a.LogEvent(ui.StatusInfo, text)
+	a.SetStatus(ui.StatusInfo, text)
+}
+
+// recreate creates the session again from the snapshot.
+func (a *App) recreate(ctx context.Context, snap sessionSnapshot) error {
+	// Clear any session created with this name since the terminate
+	if err := a.clearSessionName(ctx, snap.name); err != nil {
+		return err
+	}
+	if snap.push {
+		return a.Client.CreatePushSession(ctx, snap.name, snap.alpha, snap.beta, snap.opts)
+	}
+	return a.Client.CreateSession(ctx, snap.name, snap.alpha, snap.beta, snap.opts)
+}
+
+// recordUndo remembers how to reverse the operation that just succeeded.
+func (a *App) recordUndo(description string, run func(ctx context.Context) error) {
+	a.lastAction = &undoAction{description: description, run: run}
+}
+
+// recordNoUndo records that the operation that just ran cannot be reversed,
+// so that an undo does not reverse an older operation instead.
+func (a *App) recordNoUndo(reason string) {
+	a.lastAction = &undoAction{unavailable: reason}
+}
+
+// recordRecreateUndo records recreating sessions that were just terminated.
+func (a *App) recordRecreateUndo(snapshots []sessionSnapshot) {
+	if len(snapshots) == 0 {
+		a.recordNoUndo("No sessions were terminated")
+		return
+	}
+	a.recordUndo("Recreate "+snapshotNames(snapshots), func(ctx context.Context) error {
+		for _, snap := range snapshots {
+			if err := a.recreate(ctx, snap); err != nil {
+				return fmt.Errorf("failed to recreate %s: %w", snap.name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// recordPauseUndo records the inverse of pausing (resume) or resuming (pause)
+// the named sessions.
+func (a *App) recordPauseUndo(names []string, paused bool) {
+	if len(names) == 0 {
+		verb := "resumed"
+		if paused {
+			verb = "paused"
+		}
+		a.recordNoUndo("No sessions were " + verb)
+		return
+	}
+	if paused {
+		a.recordUndo("Resume "+strings.Join(names, ", "), func(ctx context.Context) error {
+			for _, name := range names {
+				if err := a.Client.ResumeSession(ctx, name); err != nil {
+					return fmt.Errorf("failed to resume %s: %w", name, err)
+				}
+			}
+			return nil
+		})
+		return
+	}
+	a.recordUndo("Pause "+strings.Join(names, ", "), func(ctx context.Context) error {
+		for _, name := range names {
+			if err := a.Client.PauseSession(ctx, name); err != nil {
+				return fmt.Errorf("failed to pause %s: %w", name, err)
+			}
+		}
+		return nil
+	})
+}
+
+func snapshotNames(snapshots []sessionSnapshot) string {
+	names := make([]string, len(snapshots))
+	for i, snap := range snapshots {
+		names[i] = snap.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// DescribeUndo returns what undoing the last operation would do. If there is
+// nothing to undo, or the last operation has no inverse, ok is false and the
+// description explains why.
+func (a *App) DescribeUndo() (description string, ok bool) {
+	if a.lastAction == nil {
+		return "Nothing to undo", false
+	}
+	if a.lastAction.run == nil {
+		return a.lastAction.unavailable, false
+	}
+	return a.lastAction.description, true
+}
+
+// UndoLast reverses the last mutating operation, if it has an inverse.
+// An undo is not itself undoable.
+func (a *App) UndoLast(ctx context.Context) {
+	description, ok := a.DescribeUndo()
+	if !ok {
+		a.SetStatus(ui.StatusWarning, description)
+		return
+	}
+	action := a.lastAction
+	a.lastAction = nil
+	if err := action.run(ctx); err != nil {
+		a.SetStatus(ui.StatusError, "Undo failed: "+err.Error())
+		return
+	}
+	a.LogEvent(ui.StatusInfo, "Undid last action: "+description)
+	a.SetStatus(ui.StatusInfo, "Undone: "+description)
+}
@@ -0,0 +1,163 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// newUndoApp creates an app with a running "web" spec selected.
+func newUndoApp(mock *MockClient) *App {
+	app := newSpecApp(mock, project.RunningTwoWay, "web")
+	mode := "two-way-resolved"
+	app.State.Projects[0].Specs[0].RunningSession.Mode = &mode
+	return app
+}
+
+func TestUndo_RecreatesTerminatedSession(t *testing.T) {
+	mock := &MockClient{}
+	app := newUndoApp(mock)
+
+	app.TerminateSelected(context.Background())
+	description, ok := app.DescribeUndo()
+	if !ok || description != "Recreate web" {
+		t.Fatalf("DescribeUndo() = %q, %v; want Recreate web", description, ok)
+	}
+
+	app.UndoLast(context.Background())
+	if len(mock.CreateSessionCalls) != 1 {
+		t.Fatalf("CreateSessionCalls = %d, want 1", len(mock.CreateSessionCalls))
+	}
+	call := mock.CreateSessionCalls[0]
+	if call.Name != "web" || call.Alpha != "/local/path" {
+		t.Errorf("recreated %+v, want web from /local/path", call)
+	}
+	if call.Opts == nil || call.Opts.Mode != "two-way-resolved" {
+		t.Errorf("recreated with opts %+v, want the session's prior mode", call.Opts)
+	}
+
+	// An undo is not itself undoable
+	if _, ok := app.DescribeUndo(); ok {
+		t.Error("DescribeUndo() should be unavailable after undoing")
+	}
+}
+
+func TestUndo_ReversesPause(t *testing.T) {
+	mock := &MockClient{}
+	app := newUndoApp(mock)
+
+	app.TogglePauseSelected(context.Background())
+	app.UndoLast(context.Background())
+
+	if len(mock.ResumeCalls) != 1 || mock.ResumeCalls[0] != "web" {
+		t.Errorf("ResumeCalls = %v, want [web]", mock.ResumeCalls)
+	}
+}
+
+func TestUndo_UnavailableAfterFlush(t *testing.T) {
+	mock := &MockClient{}
+	app := newUndoApp(mock)
+
+	app.TogglePauseSelected(context.Background())
+	app.FlushSelected(context.Background())
+
+	description, ok := app.DescribeUndo()
+	if ok {
+		t.Fatal("DescribeUndo() should be unavailable after a flush")
+	}
+	app.UndoLast(context.Background())
+	if len(mock.ResumeCalls) != 0 {
+		t.Error("undo after a flush must not reverse the earlier pause")
+	}
+	if app.State.StatusMessage == nil || app.State.StatusMessage.Text != description {
+		t.Errorf("StatusMessage = %+v, want %q", app.State.StatusMessage, description)
+	}
+}
+
+func TestRestartSelected_Push(t *testing.T) {
+	mock := &MockClient{}
+	app := newSpecApp(mock, project.RunningPush, "web-push")
+	app.RestartSelected(context.Background())
+
+	if len(mock.TerminateCalls) == 0 || mock.TerminateCalls[0] != "web-push" {
+		t.Errorf("TerminateCalls = %v, want the push session terminated first", mock.TerminateCalls)
+	}
+	if len(mock.CreatePushSessionCalls) != 1 || mock.CreatePushSessionCalls[0].Name != "web" {
+		t.Errorf("CreatePushSessionCalls = %+v, want web recreated as a push", mock.CreatePushSessionCalls)
+	}
+	if len(mock.CreateSessionCalls) != 0 {
+		t.Errorf("CreateSessionCalls = %+v, want no two-way session", mock.CreateSessionCalls)
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Text != "Restarted web as a push" {
+		t.Errorf("status = %+v", msg)
+	}
+}
+
+func TestRestartSelected_TwoWay(t *testing.T) {
+	mock := &MockClient{}
+	app := newSpecApp(mock, project.RunningTwoWay, "web")
+	mode := "two-way-resolved"
+	app.State.Projects[0].Specs[0].RunningSession.Mode = &mode
+	app.RestartSelected(context.Background())
+
+	if len(mock.CreateSessionCalls) != 1 || mock.CreateSessionCalls[0].Name != "web" {
+		t.Fatalf("CreateSessionCalls = %+v, want web recreated", mock.CreateSessionCalls)
+	}
+	if got := mock.CreateSessionCalls[0].Opts.Mode; got != mode {
+		t.Errorf("Mode = %q, want the running session's %q", got, mode)
+	}
+	if len(mock.CreatePushSessionCalls) != 0 {
+		t.Errorf("CreatePushSessionCalls = %+v, want no push", mock.CreatePushSessionCalls)
+	}
+}
+
+func TestRestartSelected_DryRunDoesNotWait(t *testing.T) {
+	mock := &MockClient{}
+	app := newSpecApp(mock, project.RunningTwoWay, "web")
+	app.DryRun = true
+	app.RestartSelected(context.Background())
+
+	if len(mock.WaitTerminatedCalls) != 0 {
+		t.Errorf("WaitTerminatedCalls = %v, want none, since a dry run leaves the session listed", mock.WaitTerminatedCalls)
+	}
+	if len(mock.CreateSessionCalls) != 1 {
+		t.Errorf("CreateSessionCalls = %+v, want web recreated", mock.CreateSessionCalls)
+	}
+}
+
+func TestRestartSelected_RecreateFailureCanBeUndone(t *testing.T) {
+	mock := &MockClient{}
+	app := newSpecApp(mock, project.RunningTwoWay, "web")
+	mock.CreateSessionError = errors.New("connection refused")
+	app.RestartSelected(context.Background())
+
+	if msg := app.State.StatusMessage; msg == nil || msg.Type != ui.StatusError {
+		t.Errorf("status = %+v, want an error", msg)
+	}
+	mock.CreateSessionError = nil
+	app.UndoLast(context.Background())
+	if len(mock.CreateSessionCalls) != 2 {
+		t.Errorf("CreateSessionCalls = %d, want undo to retry the create", len(mock.CreateSessionCalls))
+	}
+}
+
+func TestUndo_FailedTerminateDoesNotUndoEarlierAction(t *testing.T) {
+	mock := &MockClient{}
+	app := newUndoApp(mock)
+
+	app.TogglePauseSelected(context.Background())
+	mock.TerminateError = errors.New("no such session")
+	app.State.Selection.SetIndex(0) // The project, whose terminate reports failures per spec
+	app.TerminateSelected(context.Background())
+
+	if _, ok := app.DescribeUndo(); ok {
+		t.Fatal("DescribeUndo() should be unavailable after a terminate that did nothing")
+	}
+	app.UndoLast(context.Background())
+	if len(mock.ResumeCalls) != 0 {
+		t.Error("undo after a failed terminate must not reverse the earlier pause")
+	}
+}
@@ -0,0 +1,211 @@
+package app
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/ui"
+)
+
+// watchDebounce is how long a project file must be quiet before it is
+// reloaded, since an editor's save can take several writes. A variable so
+// tests can shorten it.
+var watchDebounce = 200 * time.Millisecond
+
+// projectWatch is the watch on the loaded project files. Rescans add the
+// files they find while the watch's goroutine reads paths, so mu guards it.
+type projectWatch struct {
+	watcher *fsnotify.Watcher
+	mu      sync.Mutex
+	paths   map[string]bool // The watched project files
+	dirs    map[string]bool // Their directories, which the watcher watches
+}
+
+// add watches a project file, if it isn't watched already.
+func (w *projectWatch) add(path string) error {
+	path = filepath.Clean(path)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.paths[path] {
+		return nil
+	}
+	w.paths[path] = true
+	dir := filepath.Dir(path)
+	if w.dirs[dir] {
+		return nil
+	}
+	if err := w.watcher.Add(dir); err != nil {
+		return err
+	}
+	w.dirs[dir] = true
+	return nil
+}
+
+// watches returns true if path is a watched project file.
+func (w *projectWatch) watches(path string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.paths[path]
+}
+
+// WatchProjectFiles watches the loaded project files and calls changed with
+// a file's path once it has been written, replaced, or removed. Editors
+// often save by renaming a new file over the old one, so the watch is on
+// each file's directory. Files found by later rescans are added to the
+// watch. Calling stop ends the watch.
+func (a *App) WatchProjectFiles(changed func(path string)) (stop func(), err error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	watch := &projectWatch{watcher: watcher, paths: make(map[string]bool), dirs: make(map[string]bool)}
+	a.watchMu.Lock()
+	a.watch = watch
+	a.watchMu.Unlock()
+	a.watchProjectFiles(a.State.Projects)
+
+	var mu sync.Mutex
+	timers := make(map[string]*time.Timer)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				path := filepath.Clean(event.Name)
+				if !watch.watches(path) || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) {
+					continue
+				}
+				mu.Lock()
+				if t, ok := timers[path]; ok {
+					t.Reset(watchDebounce)
+				} else {
+					timers[path] = time.AfterFunc(watchDebounce, func() {
+						mu.Lock()
+						delete(timers, path)
+						mu.Unlock()
+						changed(path)
+					})
+				}
+				mu.Unlock()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				a.LogEvent(ui.StatusWarning, "Project file watch: "+err.Error())
+			}
+		}
+	}()
+
+	return func() {
+		a.watchMu.Lock()
+		a.watch = nil
+		a.watchMu.Unlock()
+		close(done)
+		watcher.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, t := range timers {
+			t.Stop()
+		}
+	}, nil
+}
+
+// watchProjectFiles adds the files of projects to the project file watch,
+// if there is one.
+func (a *App) watchProjectFiles(projects []*project.Project) {
+	a.watchMu.Lock()
+	watch := a.watch
+	a.watchMu.Unlock()
+	if watch == nil {
+		return
+	}
+	for _, proj := range projects {
+		if proj.Orphaned {
+			continue
+		}
+		if err := watch.add(proj.File.Path); err != nil {
+			a.LogEvent(ui.StatusWarning, "Can't watch "+filepath.Dir(proj.File.Path)+": "+err.Error())
+		}
+	}
+}
+
+// LoadProjectFile re-reads a project file that changed on disk, for
+// ReloadProjectFile.
+func (a *App) LoadProjectFile(path string) (*project.ProjectFile, error) {
+	return a.projectFiles.Load(path)
+}
+
+// ReloadProjectFile updates the project of a file that changed on disk
+// with the result of LoadProjectFile. Sessions of specs that are still defined are
+// kept; a spec that was removed while running is listed with the orphaned
+// sessions on the next refresh. A deleted file removes its project, and a
+// file that no longer parses is left as it was.
+func (a *App) ReloadProjectFile(path string, file *project.ProjectFile, err error) {
+	idx := slices.IndexFunc(a.State.Projects, func(proj *project.Project) bool {
+		return !proj.Orphaned && filepath.Clean(proj.File.Path) == filepath.Clean(path)
+	})
+	if idx < 0 {
+		return
+	}
+	proj := a.State.Projects[idx]
+	name := proj.File.DisplayName()
+
+	if errors.Is(err, fs.ErrNotExist) {
+		a.keepSelection(func() {
+			// Copy rather than delete in place: the UI shares the old slice
+			a.State.Projects = slices.Concat(a.State.Projects[:idx], a.State.Projects[idx+1:])
+		})
+		a.LogEvent(ui.StatusInfo, name+": project file was removed")
+		a.SetStatus(ui.StatusInfo, "Removed "+name+": its project file is gone")
+		return
+	}
+	if err != nil {
+		a.SetStatus(ui.StatusWarning, "Failed to reload "+name+": "+err.Error())
+		return
+	}
+
+	var selectedSpec string
+	if projIdx, specIdx := a.GetSelectedSpec(); projIdx == idx && specIdx >= 0 {
+		selectedSpec = proj.Specs[specIdx].Name
+	}
+	var added, removed []string
+	a.keepSelection(func() {
+		added, removed = proj.ReloadFile(*file)
+	})
+	// keepSelection keeps the spec's position; follow it by name instead
+	if selectedSpec != "" {
+		projIdx := a.GetSelectedProjectIndex()
+		if specIdx := slices.IndexFunc(proj.Specs, func(s project.SyncSpec) bool { return s.Name == selectedSpec }); specIdx >= 0 {
+			a.State.Selection.SelectItem(ui.SelectableItem{Type: ui.SelectableSpec, ProjectIndex: projIdx, SpecIndex: specIdx})
+		} else {
+			a.State.Selection.SelectProject(projIdx)
+		}
+	}
+	a.logProjectWarnings([]*project.Project{proj})
+
+	text := "Reloaded " + name
+	var changes []string
+	if len(added) > 0 {
+		changes = append(changes, "added "+strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		changes = append(changes, "removed "+strings.Join(removed, ", "))
+	}
+	if len(changes) > 0 {
+		text += ": " + strings.Join(changes, "; ")
+	}
+	a.LogEvent(ui.StatusInfo, text)
+	a.SetStatus(ui.StatusInfo, text)
+}
@@ -0,0 +1,196 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+const watchTestYAML = `sync:
+  web:
+    alpha: /local/web
+    beta: server:/web
+  api:
+    alpha: /local/api
+    beta: server:/api
+`
+
+// newWatchApp creates an app that has loaded one project file, unfolded.
+func newWatchApp(t *testing.T) (*App, string) {
+	t.Helper()
+	app, _, dir := newRescanApp(t)
+	path := filepath.Join(dir, "mutagen.yml")
+	if err := os.WriteFile(path, []byte(watchTestYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	reloadProjectFile(app, path)
+	app.State.Projects[0].Folded = false
+	app.State.Selection.RebuildFromProjects(app.State.Projects)
+	return app, path
+}
+
+// reloadProjectFile loads and applies a project file, as the UI does.
+func reloadProjectFile(app *App, path string) {
+	file, err := app.LoadProjectFile(path)
+	app.ReloadProjectFile(path, file, err)
+}
+
+func projectSpecNames(proj *project.Project) []string {
+	var names []string
+	for _, spec := range proj.Specs {
+		names = append(names, spec.Name)
+	}
+	return names
+}
+
+func TestReloadProjectFile(t *testing.T) {
+	app, path := newWatchApp(t)
+	proj := app.State.Projects[0]
+	if got := projectSpecNames(proj); !slices.Equal(got, []string{"api", "web"}) {
+		t.Fatalf("specs = %v, want [api web]", got)
+	}
+	running := &mutagen.SyncSession{Name: "web", Identifier: "sync_web"}
+	proj.Specs[1].SetSession(running, project.RunningTwoWay)
+	app.State.Selection.SetIndex(2) // web
+
+	// Remove api and add docs, which sorts before web
+	rewritten := `sync:
+  web:
+    alpha: /local/web
+    beta: server:/web
+  docs:
+    alpha: /local/docs
+    beta: server:/docs
+`
+	if err := os.WriteFile(path, []byte(rewritten), 0644); err != nil {
+		t.Fatal(err)
+	}
+	reloadProjectFile(app, path)
+
+	if got := projectSpecNames(proj); !slices.Equal(got, []string{"docs", "web"}) {
+		t.Errorf("specs = %v, want [docs web]", got)
+	}
+	if _, ok := proj.File.Sessions["docs"]; !ok {
+		t.Error("project file wasn't replaced")
+	}
+	if proj.Specs[1].RunningSession != running {
+		t.Error("web lost its running session")
+	}
+	if projIdx, specIdx := app.GetSelectedSpec(); projIdx != 0 || specIdx < 0 || proj.Specs[specIdx].Name != "web" {
+		t.Errorf("selection = %d/%d, want web", projIdx, specIdx)
+	}
+	if msg := app.State.StatusMessage; msg == nil || msg.Text != "Reloaded mutagen: added docs; removed api" {
+		t.Errorf("status = %+v", msg)
+	}
+}
+
+func TestReloadProjectFile_Invalid(t *testing.T) {
+	app, path := newWatchApp(t)
+	if err := os.WriteFile(path, []byte("sync: [unclosed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	reloadProjectFile(app, path)
+	if got := projectSpecNames(app.State.Projects[0]); len(got) != 2 {
+		t.Errorf("specs = %v, want the previous two", got)
+	}
+	if msg := app.State.StatusMessage; msg == nil || !strings.HasPrefix(msg.Text, "Failed to reload") {
+		t.Errorf("status = %+v, want a reload failure", msg)
+	}
+}
+
+func TestReloadProjectFile_Removed(t *testing.T) {
+	app, path := newWatchApp(t)
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	reloadProjectFile(app, path)
+	if len(app.State.Projects) != 0 {
+		t.Errorf("projects = %d, want the project removed", len(app.State.Projects))
+	}
+}
+
+func TestWatchProjectFiles(t *testing.T) {
+	debounce := watchDebounce
+	watchDebounce = 10 * time.Millisecond
+	t.Cleanup(func() { watchDebounce = debounce })
+
+	app, path := newWatchApp(t)
+	changed := make(chan string, 10)
+	stop, err := app.WatchProjectFiles(func(p string) { changed <- p })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	// Other files in the directory are ignored
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "notes.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Save the way many editors do: write a new file and rename it over the old one
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(watchTestYAML+"  # edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case got := <-changed:
+		if got != filepath.Clean(path) {
+			t.Errorf("changed(%q), want %q", got, path)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change reported")
+	}
+	// The burst of events is reported once
+	select {
+	case got := <-changed:
+		t.Errorf("changed(%q) reported again", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWatchProjectFiles_Rescanned(t *testing.T) {
+	debounce := watchDebounce
+	watchDebounce = 10 * time.Millisecond
+	t.Cleanup(func() { watchDebounce = debounce })
+
+	app, _ := newWatchApp(t)
+	changed := make(chan string, 10)
+	stop, err := app.WatchProjectFiles(func(p string) { changed <- p })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	// A project file in another directory, found by a rescan
+	other := t.TempDir()
+	path := filepath.Join(other, "mutagen.yml")
+	if err := os.WriteFile(path, []byte(watchTestYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app.Config.Projects.SearchPaths = []string{other}
+	if added, err := app.RescanProjects(context.Background()); err != nil || added != 1 {
+		t.Fatalf("RescanProjects() = %d, %v; want the new file", added, err)
+	}
+
+	if err := os.WriteFile(path, []byte(watchTestYAML+"  # edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-changed:
+		if got != filepath.Clean(path) {
+			t.Errorf("changed(%q), want %q", got, path)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the rescanned file's change wasn't reported")
+	}
+}
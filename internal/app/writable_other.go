@@ -0,0 +1,9 @@
+//go:build !unix
+
+package app
+
+// isWritable can't tell on this platform without writing to dir, so it
+// leaves the check to Mutagen.
+func isWritable(string) bool {
+	return true
+}
@@ -0,0 +1,11 @@
+//go:build unix
+
+package app
+
+import "golang.org/x/sys/unix"
+
+// isWritable returns true if the user may create files in dir, without
+// creating one.
+func isWritable(dir string) bool {
+	return unix.Access(dir, unix.W_OK) == nil
+}
@@ -0,0 +1,82 @@
+// Package clipboard copies text to the system clipboard by running the
+// platform's clipboard command.
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrNoClipboard is returned when none of the platform's clipboard commands
+// is installed.
+var ErrNoClipboard = errors.New("no clipboard command found (install wl-clipboard or xclip)")
+
+// Command is a clipboard command, which reads the text to copy from stdin.
+type Command struct {
+	Name string
+	Args []string
+}
+
+// Overridden in tests.
+var (
+	goos     = runtime.GOOS
+	getenv   = os.Getenv
+	lookPath = exec.LookPath
+	run      = runCommand
+)
+
+// candidates returns the clipboard commands for a platform, most preferred
+// first. On Linux, wl-copy is tried first under Wayland, and clip.exe last
+// so that copying works under WSL.
+func candidates(goos string, getenv func(string) string) []Command {
+	switch goos {
+	case "darwin":
+		return []Command{{Name: "pbcopy"}}
+	case "windows":
+		return []Command{{Name: "clip.exe"}}
+	}
+
+	var cmds []Command
+	if getenv("WAYLAND_DISPLAY") != "" {
+		cmds = append(cmds, Command{Name: "wl-copy"})
+	}
+	return append(cmds,
+		Command{Name: "xclip", Args: []string{"-selection", "clipboard"}},
+		Command{Name: "clip.exe"})
+}
+
+// Find returns the first installed clipboard command for this platform, or
+// ErrNoClipboard.
+func Find() (Command, error) {
+	for _, cmd := range candidates(goos, getenv) {
+		if _, err := lookPath(cmd.Name); err == nil {
+			return cmd, nil
+		}
+	}
+	return Command{}, ErrNoClipboard
+}
+
+// Copy writes text to the system clipboard.
+func Copy(text string) error {
+	cmd, err := Find()
+	if err != nil {
+		return err
+	}
+	return run(cmd, text)
+}
+
+func runCommand(cmd Command, text string) error {
+	c := exec.Command(cmd.Name, cmd.Args...)
+	c.Stdin = strings.NewReader(text)
+	if output, err := c.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return fmt.Errorf("%s failed: %s", cmd.Name, msg)
+		}
+		return fmt.Errorf("%s failed: %w", cmd.Name, err)
+	}
+	return nil
+}
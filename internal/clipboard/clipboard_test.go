@@ -0,0 +1,91 @@
+package clipboard
+
+import (
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+// stubPlatform makes Find see the given platform and installed commands,
+// and records what Copy runs.
+func stubPlatform(t *testing.T, platform string, env map[string]string, installed ...string) *[]string {
+	oldGOOS, oldGetenv, oldLookPath, oldRun := goos, getenv, lookPath, run
+	t.Cleanup(func() { goos, getenv, lookPath, run = oldGOOS, oldGetenv, oldLookPath, oldRun })
+
+	goos = platform
+	getenv = func(key string) string { return env[key] }
+	lookPath = func(name string) (string, error) {
+		if slices.Contains(installed, name) {
+			return "/usr/bin/" + name, nil
+		}
+		return "", exec.ErrNotFound
+	}
+	var copied []string
+	run = func(cmd Command, text string) error {
+		copied = append(copied, cmd.Name+": "+text)
+		return nil
+	}
+	return &copied
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name      string
+		goos      string
+		env       map[string]string
+		installed []string
+		want      string
+		wantArgs  []string
+	}{
+		{"macOS", "darwin", nil, []string{"pbcopy"}, "pbcopy", nil},
+		{"Windows", "windows", nil, []string{"clip.exe"}, "clip.exe", nil},
+		{"X11", "linux", nil, []string{"xclip", "wl-copy"}, "xclip", []string{"-selection", "clipboard"}},
+		{"Wayland", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, []string{"xclip", "wl-copy"}, "wl-copy", nil},
+		{"Wayland without wl-copy", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, []string{"xclip"}, "xclip", []string{"-selection", "clipboard"}},
+		{"WSL", "linux", nil, []string{"clip.exe"}, "clip.exe", nil},
+		{"FreeBSD", "freebsd", nil, []string{"xclip"}, "xclip", []string{"-selection", "clipboard"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubPlatform(t, tt.goos, tt.env, tt.installed...)
+			cmd, err := Find()
+			if err != nil {
+				t.Fatalf("Find() error = %v", err)
+			}
+			if cmd.Name != tt.want || !slices.Equal(cmd.Args, tt.wantArgs) {
+				t.Errorf("Find() = %s %v, want %s %v", cmd.Name, cmd.Args, tt.want, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestFind_NoneInstalled(t *testing.T) {
+	stubPlatform(t, "linux", nil)
+	if _, err := Find(); !errors.Is(err, ErrNoClipboard) {
+		t.Errorf("Find() error = %v, want ErrNoClipboard", err)
+	}
+	// pbcopy isn't looked for off macOS
+	stubPlatform(t, "linux", nil, "pbcopy")
+	if _, err := Find(); !errors.Is(err, ErrNoClipboard) {
+		t.Errorf("Find() error = %v, want ErrNoClipboard", err)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	copied := stubPlatform(t, "darwin", nil, "pbcopy")
+	if err := Copy("/local/path"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"pbcopy: /local/path"}; !slices.Equal(*copied, want) {
+		t.Errorf("copied %v, want %v", *copied, want)
+	}
+
+	copied = stubPlatform(t, "darwin", nil)
+	if err := Copy("/local/path"); !errors.Is(err, ErrNoClipboard) {
+		t.Errorf("Copy() error = %v, want ErrNoClipboard", err)
+	}
+	if len(*copied) != 0 {
+		t.Errorf("copied %v with no clipboard command", *copied)
+	}
+}
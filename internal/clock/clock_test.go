@@ -0,0 +1,37 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake_Advance(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewFake(start)
+
+	if !c.Now().Equal(start) {
+		t.Errorf("Now() = %v, want %v", c.Now(), start)
+	}
+
+	c.Advance(90 * time.Second)
+	if want := start.Add(90 * time.Second); !c.Now().Equal(want) {
+		t.Errorf("Now() after Advance = %v, want %v", c.Now(), want)
+	}
+}
+
+func TestFake_Set(t *testing.T) {
+	c := NewFake(time.Time{})
+	target := time.Date(2030, 6, 15, 0, 0, 0, 0, time.UTC)
+	c.Set(target)
+	if !c.Now().Equal(target) {
+		t.Errorf("Now() = %v, want %v", c.Now(), target)
+	}
+}
+
+func TestReal_Now(t *testing.T) {
+	before := time.Now()
+	got := Real{}.Now()
+	if got.Before(before) {
+		t.Errorf("Real.Now() = %v, want >= %v", got, before)
+	}
+}
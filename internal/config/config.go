@@ -2,8 +2,14 @@
 package config
 
 import (
+	"bytes"
+	"fmt"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/pelletier/go-toml/v2"
 )
@@ -25,38 +31,213 @@ const (
 	DisplayModeLastRefresh DisplayMode = "lastrefresh"
 )
 
+// PathEllipsis says where long endpoint paths are shortened.
+type PathEllipsis string
+
+const (
+	PathEllipsisMiddle PathEllipsis = "middle" // Keep the root and the leaf directory
+	PathEllipsisEnd    PathEllipsis = "end"
+)
+
+// FoldState says how projects are folded at launch.
+type FoldState string
+
+const (
+	FoldStateCollapsed FoldState = "collapsed" // Start with every project folded
+	FoldStateExpanded  FoldState = "expanded"  // Start with every project unfolded
+	FoldStateRemember  FoldState = "remember"  // Restore each project's fold state from the last run
+)
+
+// Density says how tightly rows of the session list are packed.
+type Density string
+
+const (
+	DensityNormal  Density = "normal"  // Names and statuses in aligned columns
+	DensityCompact Density = "compact" // No column padding, session icons, or cycle counts
+)
+
+// SortBy says how projects are ordered in the session list.
+type SortBy string
+
+const (
+	SortByDiscovery SortBy = "discovery" // In the order project files were found
+	SortByName      SortBy = "name"      // Alphabetically by display name
+	SortByStatus    SortBy = "status"    // Conflicted, then running, then not running
+	SortByConflicts SortBy = "conflicts" // Most conflicts first
+)
+
+// Next returns the sort order that follows s when cycling through them.
+func (s SortBy) Next() SortBy {
+	switch s {
+	case SortByName:
+		return SortByStatus
+	case SortByStatus:
+		return SortByConflicts
+	case SortByConflicts:
+		return SortByDiscovery
+	default:
+		return SortByName
+	}
+}
+
+// SpaceAction says what the space bar does in the session list.
+type SpaceAction string
+
+const (
+	SpaceActionPause SpaceAction = "pause" // Same as p
+	SpaceActionMark  SpaceAction = "mark"  // Mark specs for p to pause or resume together
+)
+
 // UIConfig contains UI-related settings.
 type UIConfig struct {
-	Theme              ThemeMode   `toml:"theme"`
-	DefaultDisplayMode DisplayMode `toml:"default_display_mode"`
+	Theme              ThemeMode    `toml:"theme" comment:"Color theme: auto, light, or dark"`
+	DefaultDisplayMode DisplayMode  `toml:"default_display_mode" comment:"Initial display mode: paths or lastrefresh"`
+	ShowHost           bool         `toml:"show_host" comment:"Show a remote host tag after spec names when paths are hidden"`
+	Borderless         bool         `toml:"borderless" comment:"Draw the list, header, status, and help bars without borders"`
+	PathEllipsis       PathEllipsis `toml:"path_ellipsis" comment:"Where to shorten long endpoint paths: middle or end"`
+	FoldState          FoldState    `toml:"fold_state" comment:"How projects are folded at launch: collapsed, expanded, or remember"`
+	SortBy             SortBy       `toml:"sort_by" comment:"How projects are ordered: discovery, name, status, or conflicts"`
+	Density            Density      `toml:"density" comment:"How rows are packed: normal, or compact for small terminals"`
+
+	// ASCIIIcons draws status icons in ASCII. Without it, they are drawn in
+	// ASCII only on terminals that look like they lack Unicode.
+	ASCIIIcons bool `toml:"ascii_icons" comment:"Draw status icons in ASCII, for terminals that show emoji as boxes (detected from TERM and LANG otherwise)"`
+
+	// Columns lays spec rows out as a table under a header row, with these
+	// columns in this order (see ListColumns). Empty keeps the usual rows.
+	Columns []string `toml:"columns,omitempty" comment:"Columns of a table of specs with a header row, from name, status, alpha, beta, cycles, and conflicts; empty for the usual rows"`
+
+	// PathAbbreviations shorten endpoint paths under a prefix for display,
+	// e.g. "/mnt/bigdisk" = "…/bigdisk". The longest matching prefix wins,
+	// and the home directory is always shown as ~.
+	PathAbbreviations map[string]string `toml:"path_abbreviations,omitempty" comment:"Prefixes of endpoint paths to replace for display"`
+}
+
+// KeysConfig contains key binding settings.
+type KeysConfig struct {
+	Space SpaceAction `toml:"space" comment:"What the space bar does: pause (same as p) or mark (select specs for p to act on together)"`
+	// Bindings maps action names to the keys that trigger them in place of
+	// the defaults. The UI knows the actions, and rejects unknown ones. They are the table's other entries,
+	// e.g. start = "g" or up = ["up", "w"].
+	Bindings map[string][]string `toml:"-"`
 }
 
+// ListColumns are the columns that ui.columns can show.
+var ListColumns = []string{"name", "status", "alpha", "beta", "cycles", "conflicts"}
+
 // RefreshConfig contains auto-refresh settings.
 type RefreshConfig struct {
-	Enabled      bool  `toml:"enabled"`
-	IntervalSecs int64 `toml:"interval_secs"`
+	Enabled      bool  `toml:"enabled" comment:"Refresh the session list automatically"`
+	IntervalSecs int64 `toml:"interval_secs" comment:"Seconds between automatic refreshes"`
+	// ScheduledFlushSecs is how often specs in scheduled mode are flushed.
+	// Flushes run on a ticker of their own, independent of refreshes, that
+	// checks every 10 seconds, so it is at best that
+	ScheduledFlushSecs int64 `toml:"scheduled_flush_secs" comment:"Seconds between flushes of specs kept paused in scheduled mode"`
+
+	// PauseWhenUnfocused stops automatic refreshes while the terminal is in
+	// the background, on terminals that report focus. Refreshes go on at the
+	// idle pace if desktop notifications or auto-reconnect are on, since
+	// those happen on refreshes.
+	PauseWhenUnfocused bool `toml:"pause_when_unfocused" comment:"Stop automatic refreshes while the terminal is in the background"`
+	// IdleAfterSecs is how long without a key or mouse press before
+	// automatic refreshes slow to IdleIntervalSecs; 0 keeps the usual pace
+	IdleAfterSecs    int64 `toml:"idle_after_secs" comment:"Seconds without a key or mouse press before automatic refreshes slow down (0 never slows them)"`
+	IdleIntervalSecs int64 `toml:"idle_interval_secs" comment:"Seconds between automatic refreshes while idle"`
 }
 
 // ProjectConfig contains project discovery settings.
 type ProjectConfig struct {
-	SearchPaths     []string `toml:"search_paths"`
-	ExcludePatterns []string `toml:"exclude_patterns"`
+	SearchPaths     []string `toml:"search_paths" comment:"Additional directories to search for mutagen project files"`
+	ExcludePatterns []string `toml:"exclude_patterns" comment:"Directory names to skip while searching"`
+	// IncludeUserConfig enables searching ~/.config/mutagen/projects and ~/.mutagen/projects
+	IncludeUserConfig bool `toml:"include_user_config" comment:"Also search ~/.config/mutagen/projects and ~/.mutagen/projects"`
+	// RescanIntervalSecs is how often to look for new project files; 0 disables rescanning
+	RescanIntervalSecs int64 `toml:"rescan_interval_secs" comment:"Seconds between rescans for new project files (0 disables)"`
+	// WatchFiles reloads a loaded project file as soon as it changes on disk
+	WatchFiles bool `toml:"watch_files" comment:"Reload project files when they are edited"`
+	// UseProjectCommands makes project-level operations run `mutagen project` commands
+	UseProjectCommands bool `toml:"use_project_commands" comment:"Start, stop, pause, resume, and flush whole projects with mutagen project commands"`
 }
 
 // ConfirmationsConfig contains settings for confirmation dialogs.
 type ConfirmationsConfig struct {
 	// PushToBeta controls whether to show confirmation before pushing to beta
-	PushToBeta bool `toml:"push_to_beta"`
+	PushToBeta bool `toml:"push_to_beta" comment:"Confirm before overwriting beta with alpha"`
 	// PullToAlpha controls whether to show confirmation before pulling to alpha
-	PullToAlpha bool `toml:"pull_to_alpha"`
+	PullToAlpha bool `toml:"pull_to_alpha" comment:"Confirm before overwriting alpha with beta"`
+	// CreatePush controls whether to show confirmation before creating a one-way push session
+	CreatePush bool `toml:"create_push" comment:"Confirm before creating a one-way push session"`
+	// PushPreview controls whether push confirmations list the settings each push session would use
+	PushPreview bool `toml:"push_preview" comment:"List the mode, ignores, and other settings in push confirmations"`
+	// Terminate controls whether to show confirmation before terminating running sessions
+	Terminate bool `toml:"terminate" comment:"Confirm before terminating running sessions"`
+	// TerminateThresholdSessions is how many sessions a terminate may stop without confirming
+	TerminateThresholdSessions int `toml:"terminate_threshold_sessions" comment:"Confirm terminating only more than this many sessions (0 confirms every terminate)"`
+	// ResetMinFiles is how many files a session needs before resetting it asks first
+	ResetMinFiles int `toml:"reset_min_files" comment:"Confirm resetting only sessions with at least this many files (0 confirms every reset)"`
+}
+
+// RecoveryConfig contains settings for automatic recovery of unhealthy sessions.
+type RecoveryConfig struct {
+	// AutoReconnect enables pausing and resuming sessions that stay disconnected
+	AutoReconnect bool `toml:"auto_reconnect" comment:"Pause and resume sessions that stay disconnected"`
+	// DisconnectThresholdSecs is how long a session must stay disconnected before a reconnect
+	DisconnectThresholdSecs int64 `toml:"disconnect_threshold_secs" comment:"Seconds a session must stay disconnected before reconnecting"`
+}
+
+// NotificationsConfig contains desktop notification settings.
+type NotificationsConfig struct {
+	// Enabled sends a desktop notification when a session starts conflicting or halts
+	Enabled bool `toml:"enabled" comment:"Send a desktop notification when a session starts conflicting or halts"`
+	// RepeatSecs is how long the same problem of a session stays quiet after a notification
+	RepeatSecs int64 `toml:"repeat_secs" comment:"Seconds before a session's same problem is notified again"`
+}
+
+// ClientConfig contains the Mutagen binary and timeouts for its CLI calls.
+// Unset or zero timeouts use the defaults.
+type ClientConfig struct {
+	// Binary is the mutagen command to run, for installs outside PATH;
+	// MUTAGUI_MUTAGEN_BIN overrides it
+	Binary string `toml:"binary,omitempty" comment:"Path to the mutagen binary, if it isn't the mutagen found in PATH"`
+
+	ListTimeoutSecs   int64 `toml:"list_timeout_secs" comment:"Seconds to wait for listing sessions and other queries"`
+	CreateTimeoutSecs int64 `toml:"create_timeout_secs" comment:"Seconds to wait for creating sessions, starting projects, and flushes, which can include a full scan"`
+	MutateTimeoutSecs int64 `toml:"mutate_timeout_secs" comment:"Seconds to wait for pausing, resuming, terminating, and other changes"`
+
+	// ListAttempts is how many times a session list that fails, as while
+	// the daemon restarts, is tried before the refresh gives up
+	ListAttempts int `toml:"list_attempts" comment:"Times to try listing sessions when mutagen fails, with a short wait between tries"`
+}
+
+// DisplayRule sets the display mode for projects whose name matches a pattern.
+type DisplayRule struct {
+	// Pattern is a glob (as in path.Match) matched against the project name
+	Pattern string      `toml:"pattern" comment:"Glob matched against the project name, e.g. \"infra-*\""`
+	Mode    DisplayMode `toml:"mode" comment:"Display mode for matching projects: paths or lastrefresh"`
 }
 
 // Config represents the application configuration.
 type Config struct {
 	UI            UIConfig            `toml:"ui"`
+	Keys          KeysConfig          `toml:"keys"`
 	Refresh       RefreshConfig       `toml:"refresh"`
 	Projects      ProjectConfig       `toml:"projects"`
 	Confirmations ConfirmationsConfig `toml:"confirmations"`
+	Recovery      RecoveryConfig      `toml:"recovery"`
+	Notifications NotificationsConfig `toml:"notifications"`
+	Client        ClientConfig        `toml:"client"`
+	DisplayRules  []DisplayRule       `toml:"display_rules,omitempty"`
+}
+
+// DisplayModeFor returns the mode of the first display rule whose pattern
+// matches the project name, or false if none match.
+func (c *Config) DisplayModeFor(projectName string) (DisplayMode, bool) {
+	for _, rule := range c.DisplayRules {
+		if ok, _ := path.Match(rule.Pattern, projectName); ok {
+			return rule.Mode, true
+		}
+	}
+	return "", false
 }
 
 // DefaultConfig returns the default configuration.
@@ -65,18 +246,48 @@ func DefaultConfig() *Config {
 		UI: UIConfig{
 			Theme:              ThemeModeAuto,
 			DefaultDisplayMode: DisplayModePaths,
+			PathEllipsis:       PathEllipsisMiddle,
+			FoldState:          FoldStateCollapsed,
+			SortBy:             SortByDiscovery,
+			Density:            DensityNormal,
+		},
+		Keys: KeysConfig{
+			Space: SpaceActionPause,
 		},
 		Refresh: RefreshConfig{
-			Enabled:      true,
-			IntervalSecs: 3,
+			Enabled:            true,
+			IntervalSecs:       3,
+			ScheduledFlushSecs: 300,
+			PauseWhenUnfocused: true,
+			IdleAfterSecs:      300,
+			IdleIntervalSecs:   30,
 		},
 		Projects: ProjectConfig{
-			SearchPaths:     []string{},
-			ExcludePatterns: []string{"node_modules", ".git", "target"},
+			SearchPaths:       []string{},
+			ExcludePatterns:   []string{"node_modules", ".git", "target"},
+			IncludeUserConfig: true,
+			WatchFiles:        true,
 		},
 		Confirmations: ConfirmationsConfig{
 			PushToBeta:  true, // Confirm before pushing alpha → beta
 			PullToAlpha: true, // Confirm before pulling beta → alpha
+			CreatePush:  true, // Confirm before replacing a session with a push
+			Terminate:   true, // Confirm before stopping sessions
+			PushPreview: false,
+		},
+		Recovery: RecoveryConfig{
+			AutoReconnect:           false,
+			DisconnectThresholdSecs: 30,
+		},
+		Notifications: NotificationsConfig{
+			Enabled:    false,
+			RepeatSecs: 600,
+		},
+		Client: ClientConfig{
+			ListTimeoutSecs:   30,
+			CreateTimeoutSecs: 30,
+			MutateTimeoutSecs: 30,
+			ListAttempts:      3,
 		},
 	}
 }
@@ -105,10 +316,133 @@ func Load() (*Config, error) {
 	if err := toml.Unmarshal(data, config); err != nil {
 		return nil, err
 	}
+	if err := loadKeyBindings(data, config); err != nil {
+		return nil, err
+	}
+	if err := validateColumns(config.UI.Columns); err != nil {
+		return nil, err
+	}
 
 	return config, nil
 }
 
+// loadKeyBindings reads the action entries of the [keys] table into
+// Keys.Bindings. They can't be decoded with the rest of the file, since the
+// table mixes them with settings.
+func loadKeyBindings(data []byte, cfg *Config) error {
+	var raw struct {
+		Keys map[string]any `toml:"keys"`
+	}
+	if err := toml.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	actions := slices.Sorted(maps.Keys(raw.Keys))
+	for _, action := range actions {
+		if action == "space" {
+			continue
+		}
+		var bound []string
+		switch value := raw.Keys[action].(type) {
+		case string:
+			bound = []string{value}
+		case []any:
+			for _, item := range value {
+				k, ok := item.(string)
+				if !ok {
+					return fmt.Errorf("[keys]: %s should be a key or a list of keys", action)
+				}
+				bound = append(bound, k)
+			}
+		default:
+			return fmt.Errorf("[keys]: %s should be a key or a list of keys", action)
+		}
+		if len(bound) == 0 || slices.Contains(bound, "") {
+			return fmt.Errorf("[keys]: %s has an empty key", action)
+		}
+		if cfg.Keys.Bindings == nil {
+			cfg.Keys.Bindings = make(map[string][]string)
+		}
+		cfg.Keys.Bindings[action] = bound
+	}
+	return nil
+}
+
+// validateColumns checks that ui.columns names known columns, each once.
+func validateColumns(columns []string) error {
+	for i, column := range columns {
+		if !slices.Contains(ListColumns, column) {
+			return fmt.Errorf("[ui] columns: unknown column %q; the columns are %s", column, strings.Join(ListColumns, ", "))
+		}
+		if slices.Contains(columns[:i], column) {
+			return fmt.Errorf("[ui] columns: %s is listed twice", column)
+		}
+	}
+	return nil
+}
+
+// Path returns the location of the config file, or "" if it cannot be determined.
+func Path() string {
+	return configPathFunc()
+}
+
+// commonProjectDirs are directories under the home directory that are
+// suggested as search paths in a starter config when they exist.
+var commonProjectDirs = []string{"code", "projects", "src", "dev"}
+
+// StarterConfig returns the default configuration adjusted with values
+// discovered from the environment: the theme from MUTAGUI_THEME and any
+// common project directories that exist in the home directory.
+func StarterConfig() *Config {
+	cfg := DefaultConfig()
+
+	switch ThemeMode(os.Getenv("MUTAGUI_THEME")) {
+	case ThemeModeDark:
+		cfg.UI.Theme = ThemeModeDark
+	case ThemeModeLight:
+		cfg.UI.Theme = ThemeModeLight
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		for _, dir := range commonProjectDirs {
+			if info, err := os.Stat(filepath.Join(home, dir)); err == nil && info.IsDir() {
+				cfg.Projects.SearchPaths = append(cfg.Projects.SearchPaths, "~/"+dir)
+			}
+		}
+	}
+
+	return cfg
+}
+
+// starterHeader is written at the top of a starter config file.
+const starterHeader = `# mutagui configuration
+# Generated on first run. Edit the values below; delete a line to use its default.
+
+`
+
+// WriteDefault writes a commented starter config to path, creating parent
+// directories as needed. It does not overwrite an existing file.
+func WriteDefault(path string) error {
+	var buf bytes.Buffer
+	buf.WriteString(starterHeader)
+	if err := toml.NewEncoder(&buf).Encode(StarterConfig()); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 // defaultConfigPath returns the standard config file path.
 // Uses ~/.config/mutagui/config.toml following XDG conventions.
 func defaultConfigPath() string {
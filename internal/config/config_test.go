@@ -1,8 +1,10 @@
 package config
 
 import (
+	"maps"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -42,6 +44,17 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("Projects.ExcludePatterns length = %d, want %d",
 			len(cfg.Projects.ExcludePatterns), len(expectedExclude))
 	}
+	if !cfg.Projects.IncludeUserConfig {
+		t.Error("Projects.IncludeUserConfig = false, want true")
+	}
+	if !cfg.Projects.WatchFiles {
+		t.Error("Projects.WatchFiles = false, want true")
+	}
+
+	// Confirmation defaults
+	if !cfg.Confirmations.Terminate {
+		t.Error("Confirmations.Terminate = false, want true")
+	}
 }
 
 func TestLoad_NoConfigFile(t *testing.T) {
@@ -82,6 +95,8 @@ interval_secs = 10
 [projects]
 search_paths = ["/home/user/projects", "/opt/code"]
 exclude_patterns = ["vendor", "dist"]
+rescan_interval_secs = 60
+include_user_config = false
 `
 	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
 		t.Fatalf("Failed to write config file: %v", err)
@@ -112,6 +127,12 @@ exclude_patterns = ["vendor", "dist"]
 	if len(cfg.Projects.SearchPaths) != 2 {
 		t.Errorf("Projects.SearchPaths length = %d, want 2", len(cfg.Projects.SearchPaths))
 	}
+	if cfg.Projects.IncludeUserConfig {
+		t.Error("Projects.IncludeUserConfig = true, want false")
+	}
+	if cfg.Projects.RescanIntervalSecs != 60 {
+		t.Errorf("Projects.RescanIntervalSecs = %d, want 60", cfg.Projects.RescanIntervalSecs)
+	}
 }
 
 func TestThemeMode_Values(t *testing.T) {
@@ -210,6 +231,8 @@ func TestLoad_Confirmations(t *testing.T) {
 [confirmations]
 push_to_beta = false
 pull_to_alpha = true
+create_push = false
+terminate = false
 `
 	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
 		t.Fatalf("Failed to write config file: %v", err)
@@ -228,4 +251,321 @@ pull_to_alpha = true
 	if cfg.Confirmations.PullToAlpha != true {
 		t.Errorf("Confirmations.PullToAlpha = %v, want true", cfg.Confirmations.PullToAlpha)
 	}
+	if cfg.Confirmations.CreatePush != false {
+		t.Errorf("Confirmations.CreatePush = %v, want false", cfg.Confirmations.CreatePush)
+	}
+	if cfg.Confirmations.Terminate != false {
+		t.Errorf("Confirmations.Terminate = %v, want false", cfg.Confirmations.Terminate)
+	}
+}
+
+func TestLoad_ConfirmationThresholds(t *testing.T) {
+	if cfg := DefaultConfig(); cfg.Confirmations.TerminateThresholdSessions != 0 || cfg.Confirmations.ResetMinFiles != 0 {
+		t.Errorf("default thresholds = %d, %d; want 0 so that every terminate and reset asks",
+			cfg.Confirmations.TerminateThresholdSessions, cfg.Confirmations.ResetMinFiles)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `
+[confirmations]
+terminate_threshold_sessions = 3
+reset_min_files = 10000
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	withConfigPath(t, configPath)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Confirmations.TerminateThresholdSessions != 3 || cfg.Confirmations.ResetMinFiles != 10000 {
+		t.Errorf("thresholds = %d, %d; want 3, 10000", cfg.Confirmations.TerminateThresholdSessions, cfg.Confirmations.ResetMinFiles)
+	}
+	if !cfg.Confirmations.Terminate {
+		t.Error("setting a threshold shouldn't turn off the terminate confirmation")
+	}
+}
+
+func TestLoad_Client(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[client]
+create_timeout_secs = 600
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	withConfigPath(t, configPath)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Client.CreateTimeoutSecs != 600 {
+		t.Errorf("Client.CreateTimeoutSecs = %d, want 600", cfg.Client.CreateTimeoutSecs)
+	}
+	if cfg.Client.ListTimeoutSecs != 30 {
+		t.Errorf("Client.ListTimeoutSecs = %d, want default 30", cfg.Client.ListTimeoutSecs)
+	}
+	if cfg.Client.MutateTimeoutSecs != 30 {
+		t.Errorf("Client.MutateTimeoutSecs = %d, want default 30", cfg.Client.MutateTimeoutSecs)
+	}
+}
+
+func TestLoad_Recovery(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[recovery]
+auto_reconnect = true
+disconnect_threshold_secs = 60
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	withConfigPath(t, configPath)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if !cfg.Recovery.AutoReconnect {
+		t.Error("Recovery.AutoReconnect = false, want true")
+	}
+	if cfg.Recovery.DisconnectThresholdSecs != 60 {
+		t.Errorf("Recovery.DisconnectThresholdSecs = %d, want 60", cfg.Recovery.DisconnectThresholdSecs)
+	}
+}
+
+func TestLoad_Notifications(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `
+[notifications]
+enabled = true
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	withConfigPath(t, configPath)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.Notifications.Enabled {
+		t.Error("Notifications.Enabled = false, want true")
+	}
+	if cfg.Notifications.RepeatSecs != 600 {
+		t.Errorf("Notifications.RepeatSecs = %d, want default 600", cfg.Notifications.RepeatSecs)
+	}
+}
+
+func TestLoad_KeyBindings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `
+[keys]
+space = "mark"
+start = "g"
+up = ["up", "w"]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	withConfigPath(t, configPath)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Keys.Space != SpaceActionMark {
+		t.Errorf("Keys.Space = %q, want mark", cfg.Keys.Space)
+	}
+	want := map[string][]string{"start": {"g"}, "up": {"up", "w"}}
+	if len(cfg.Keys.Bindings) != len(want) {
+		t.Fatalf("Keys.Bindings = %v, want %v", cfg.Keys.Bindings, want)
+	}
+	for action, keys := range want {
+		if got := cfg.Keys.Bindings[action]; strings.Join(got, ",") != strings.Join(keys, ",") {
+			t.Errorf("Keys.Bindings[%s] = %v, want %v", action, got, keys)
+		}
+	}
+}
+
+func TestLoad_KeyBindingErrors(t *testing.T) {
+	tests := []struct {
+		name, content, want string
+	}{
+		{"not a key", "[keys]\nstart = 3\n", "start should be a key or a list of keys"},
+		{"empty key", "[keys]\nstart = [\"\"]\n", "start has an empty key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			withConfigPath(t, configPath)
+			if _, err := Load(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_Columns(t *testing.T) {
+	tests := []struct {
+		name, content string
+		want          []string
+		wantErr       string
+	}{
+		{"unset", "[ui]\n", nil, ""},
+		{"chosen", "[ui]\ncolumns = [\"name\", \"beta\", \"conflicts\"]\n", []string{"name", "beta", "conflicts"}, ""},
+		{"unknown", "[ui]\ncolumns = [\"name\", \"size\"]\n", nil, `unknown column "size"`},
+		{"repeated", "[ui]\ncolumns = [\"name\", \"name\"]\n", nil, "name is listed twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			withConfigPath(t, configPath)
+			cfg, err := Load()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Load() error = %v, want it to mention %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if strings.Join(cfg.UI.Columns, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("UI.Columns = %v, want %v", cfg.UI.Columns, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteDefault_RoundTrips(t *testing.T) {
+	t.Setenv("MUTAGUI_THEME", "dark")
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "mutagui", "config.toml")
+
+	if err := WriteDefault(configPath); err != nil {
+		t.Fatalf("WriteDefault() error = %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read written config: %v", err)
+	}
+	if !strings.Contains(string(data), "# Seconds between automatic refreshes") {
+		t.Errorf("written config lacks field comments:\n%s", data)
+	}
+
+	withConfigPath(t, configPath)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.UI.Theme != ThemeModeDark {
+		t.Errorf("UI.Theme = %v, want detected %v", cfg.UI.Theme, ThemeModeDark)
+	}
+	if cfg.Refresh.IntervalSecs != DefaultConfig().Refresh.IntervalSecs {
+		t.Errorf("Refresh.IntervalSecs = %d, want default", cfg.Refresh.IntervalSecs)
+	}
+}
+
+func TestWriteDefault_DoesNotOverwrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+	if err := os.WriteFile(configPath, []byte("[ui]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	if err := WriteDefault(configPath); err == nil {
+		t.Error("WriteDefault() should fail when the file exists")
+	}
+}
+
+func TestStarterConfig_DiscoversSearchPaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MUTAGUI_THEME", "")
+	if err := os.Mkdir(filepath.Join(home, "code"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := StarterConfig()
+	if len(cfg.Projects.SearchPaths) != 1 || cfg.Projects.SearchPaths[0] != "~/code" {
+		t.Errorf("SearchPaths = %v, want [~/code]", cfg.Projects.SearchPaths)
+	}
+	if cfg.UI.Theme != ThemeModeAuto {
+		t.Errorf("UI.Theme = %v, want %v", cfg.UI.Theme, ThemeModeAuto)
+	}
+}
+
+func TestLoad_DisplayRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[[display_rules]]
+pattern = "mutagen-infra-*"
+mode = "paths"
+
+[[display_rules]]
+pattern = "*"
+mode = "lastrefresh"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	withConfigPath(t, configPath)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if mode, ok := cfg.DisplayModeFor("mutagen-infra-dns"); !ok || mode != DisplayModePaths {
+		t.Errorf("DisplayModeFor(infra) = %q, %v; want paths", mode, ok)
+	}
+	if mode, ok := cfg.DisplayModeFor("mutagen-app"); !ok || mode != DisplayModeLastRefresh {
+		t.Errorf("DisplayModeFor(app) = %q, %v; want lastrefresh from the catch-all", mode, ok)
+	}
+	if _, ok := DefaultConfig().DisplayModeFor("anything"); ok {
+		t.Error("DisplayModeFor() matched with no rules")
+	}
+}
+
+func TestLoad_PathAbbreviations(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := `
+[ui.path_abbreviations]
+"/mnt/bigdisk" = "…/bigdisk"
+"/Volumes/Shared Projects" = "SP:"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	withConfigPath(t, configPath)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := map[string]string{"/mnt/bigdisk": "…/bigdisk", "/Volumes/Shared Projects": "SP:"}
+	if !maps.Equal(cfg.UI.PathAbbreviations, want) {
+		t.Errorf("UI.PathAbbreviations = %v, want %v", cfg.UI.PathAbbreviations, want)
+	}
 }
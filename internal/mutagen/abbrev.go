@@ -0,0 +1,63 @@
+package mutagen
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// pathAbbreviation replaces a path prefix when endpoint paths are shown.
+type pathAbbreviation struct {
+	prefix, replacement string
+}
+
+var (
+	abbreviationsMu   sync.RWMutex
+	pathAbbreviations []pathAbbreviation
+)
+
+// SetPathAbbreviations sets the rules that shorten endpoint paths for
+// display, such as /mnt/bigdisk → …/bigdisk: a path at or under a prefix
+// has the prefix replaced. Trailing slashes on prefixes are ignored.
+func SetPathAbbreviations(rules map[string]string) {
+	var abbreviations []pathAbbreviation
+	for prefix, replacement := range rules {
+		if prefix = strings.TrimSuffix(prefix, "/"); prefix != "" {
+			abbreviations = append(abbreviations, pathAbbreviation{prefix, replacement})
+		}
+	}
+	abbreviationsMu.Lock()
+	pathAbbreviations = abbreviations
+	abbreviationsMu.Unlock()
+}
+
+// AbbreviatePath shortens a path for display with the rule whose prefix is
+// longest, counting the home directory as a rule that replaces it with ~.
+// It returns false if no rule applies.
+func AbbreviatePath(path string) (string, bool) {
+	abbreviationsMu.RLock()
+	rules := slices.Clone(pathAbbreviations)
+	abbreviationsMu.RUnlock()
+	if home := strings.TrimSuffix(homeDir(), "/"); home != "" {
+		rules = append(rules, pathAbbreviation{home, "~"})
+	}
+
+	var best *pathAbbreviation
+	for i, rule := range rules {
+		if !hasPathPrefix(path, rule.prefix) {
+			continue
+		}
+		if best == nil || len(rule.prefix) > len(best.prefix) {
+			best = &rules[i]
+		}
+	}
+	if best == nil {
+		return path, false
+	}
+	return best.replacement + path[len(best.prefix):], true
+}
+
+// hasPathPrefix reports whether path is prefix or lies under it.
+func hasPathPrefix(path, prefix string) bool {
+	return strings.HasPrefix(path, prefix) && (len(path) == len(prefix) || path[len(prefix)] == '/')
+}
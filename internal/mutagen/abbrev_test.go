@@ -0,0 +1,40 @@
+package mutagen
+
+import "testing"
+
+func TestAbbreviatePath(t *testing.T) {
+	t.Setenv("HOME", "/home/me")
+	SetPathAbbreviations(map[string]string{
+		"/mnt/bigdisk":          "…/bigdisk",
+		"/mnt/bigdisk/projects": "P:",
+		"/home/me/work/":        "W:",
+		"/srv":                  "…",
+	})
+	t.Cleanup(func() { SetPathAbbreviations(nil) })
+
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{"/mnt/bigdisk/media", "…/bigdisk/media", true},
+		{"/mnt/bigdisk/projects/web", "P:/web", true}, // The longer prefix wins
+		{"/mnt/bigdisk", "…/bigdisk", true},
+		{"/mnt/bigdisk2/data", "/mnt/bigdisk2/data", false}, // Prefixes match whole directories
+		{"/home/me/work/api", "W:/api", true},               // Longer than the home directory
+		{"/home/me/notes", "~/notes", true},
+		{"/srv/www", "…/www", true},
+		{"/opt/tools", "/opt/tools", false},
+	}
+	for _, tt := range tests {
+		got, ok := AbbreviatePath(tt.path)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("AbbreviatePath(%q) = %q, %v; want %q, %v", tt.path, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	e := Endpoint{Path: "/mnt/bigdisk/projects/web", Host: strPtr("nas")}
+	if got := e.DisplayPath(); got != "nas:P:/web" {
+		t.Errorf("DisplayPath() = %q, want nas:P:/web", got)
+	}
+}
@@ -1,10 +1,15 @@
 package mutagen
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os/exec"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -15,12 +20,23 @@ type MutagenClient interface {
 	// Session operations
 	ListSessions(ctx context.Context) ([]SyncSession, error)
 	CreateSession(ctx context.Context, name, alpha, beta string, opts *SessionOptions) error
+	GetSession(ctx context.Context, name string) (*SyncSession, error)
+	MonitorSession(ctx context.Context, name string) (<-chan SyncSession, error)
 	CreatePushSession(ctx context.Context, name, alpha, beta string, opts *SessionOptions) error
 	TerminateSession(ctx context.Context, name string) error
+	WaitForTerminated(ctx context.Context, name string) error
 	PauseSession(ctx context.Context, name string) error
 	ResumeSession(ctx context.Context, name string) error
 	FlushSession(ctx context.Context, name string) error
 	ResetSession(ctx context.Context, name string) error
+	ResolveConflict(ctx context.Context, session *SyncSession, conflict Conflict, winner string) error
+	EndpointFreeSpace(ctx context.Context, endpoint Endpoint) (uint64, error)
+
+	// Forward operations
+	ListForwards(ctx context.Context) ([]ForwardSession, error)
+	PauseForward(ctx context.Context, name string) error
+	ResumeForward(ctx context.Context, name string) error
+	TerminateForward(ctx context.Context, name string) error
 
 	// Project operations
 	ProjectStart(ctx context.Context, projectFilePath string) error
@@ -29,6 +45,11 @@ type MutagenClient interface {
 	ProjectResume(ctx context.Context, projectFilePath string) error
 	ProjectFlush(ctx context.Context, projectFilePath string) error
 
+	// Daemon operations
+	PingDaemon(ctx context.Context) error
+	StartDaemon(ctx context.Context) error
+	StopDaemon(ctx context.Context) error
+
 	// Utility
 	IsInstalled() bool
 	GetVersion() (string, error)
@@ -39,12 +60,48 @@ var _ MutagenClient = (*Client)(nil)
 
 // Client provides methods for interacting with the Mutagen CLI.
 type Client struct {
-	timeout time.Duration
+	timeouts Timeouts
+	runner   CommandRunner
+	binary   string // The mutagen command that is run
+
+	listAttempts int // Tries of a session list that fails for a transient reason
+}
+
+// DefaultBinary is the mutagen command run unless another is configured,
+// found in PATH.
+const DefaultBinary = "mutagen"
+
+// Timeouts are how long each kind of Mutagen CLI call may take.
+type Timeouts struct {
+	List   time.Duration // Listing sessions and other queries
+	Create time.Duration // Creating sessions, starting projects, and flushes, which may wait for a full scan
+	Mutate time.Duration // Pausing, resuming, terminating, and other quick changes
 }
 
-// wrapConnectionError checks the output for connection-related issues and returns
-// an improved error message with hints about potential causes.
-func wrapConnectionError(baseErr string, output string) error {
+// UniformTimeouts returns Timeouts that give every call the same timeout.
+func UniformTimeouts(timeout time.Duration) Timeouts {
+	return Timeouts{List: timeout, Create: timeout, Mutate: timeout}
+}
+
+// ErrCrossDeviceLink matches (with errors.Is) a failure to install the agent
+// because the remote couldn't move it across filesystems.
+var ErrCrossDeviceLink = errors.New("invalid cross-device link")
+
+// hintError is an error message with a hint, which also matches a known
+// kind of failure so callers can offer to fix it.
+type hintError struct {
+	msg  string
+	kind error
+}
+
+func (e *hintError) Error() string { return e.msg }
+func (e *hintError) Unwrap() error { return e.kind }
+
+// WrapConnectionError returns baseErr as an error, with a hint at the cause
+// if output, from mutagen or from ssh, shows a known connection, agent, or
+// session failure. The error of a failure that can be fixed for the user
+// wraps its kind, such as ErrCrossDeviceLink.
+func WrapConnectionError(baseErr string, output string) error {
 	lowerOutput := strings.ToLower(output)
 
 	// Check for agent connection hanging - most common issue
@@ -66,6 +123,11 @@ func wrapConnectionError(baseErr string, output string) error {
 		return fmt.Errorf("%s (hint: remote host may be unreachable)", baseErr)
 	}
 
+	// Check for unknown hosts
+	if strings.Contains(lowerOutput, "could not resolve hostname") {
+		return fmt.Errorf("%s (hint: check the host name and ~/.ssh/config)", baseErr)
+	}
+
 	// Check for authentication issues
 	if strings.Contains(lowerOutput, "permission denied") ||
 		strings.Contains(lowerOutput, "authentication failed") {
@@ -86,7 +148,10 @@ func wrapConnectionError(baseErr string, output string) error {
 	// Check for cross-device link error (NFS/remote filesystem issue)
 	if strings.Contains(lowerOutput, "invalid cross-device link") ||
 		strings.Contains(lowerOutput, "cross-device") {
-		return fmt.Errorf("%s (hint: /tmp and ~/.mutagen are on different filesystems - manually install agent and create symlink: ln -s <nfs-path>/.mutagen ~/.mutagen)", baseErr)
+		return &hintError{
+			msg:  baseErr + " (hint: /tmp and ~/.mutagen are on different filesystems - manually install agent and create symlink: ln -s <nfs-path>/.mutagen ~/.mutagen)",
+			kind: ErrCrossDeviceLink,
+		}
 	}
 
 	// Check for agent installation issues
@@ -98,21 +163,178 @@ func wrapConnectionError(baseErr string, output string) error {
 	return fmt.Errorf("%s", baseErr)
 }
 
-// NewClient creates a new Mutagen client with the given timeout.
-func NewClient(timeout time.Duration) *Client {
-	return &Client{timeout: timeout}
+// NewClient creates a new Mutagen client with the given timeouts.
+func NewClient(timeouts Timeouts) *Client {
+	return NewClientWithRunner(timeouts, ExecRunner{})
+}
+
+// NewClientWithRunner creates a new Mutagen client that executes commands via runner.
+func NewClientWithRunner(timeouts Timeouts, runner CommandRunner) *Client {
+	return &Client{timeouts: timeouts, runner: runner, binary: DefaultBinary, listAttempts: DefaultListAttempts}
+}
+
+// SetBinary makes the client run the mutagen CLI at path, for installs
+// outside PATH. A bare name is looked up in PATH; an empty path restores
+// DefaultBinary.
+func (c *Client) SetBinary(path string) {
+	if path == "" {
+		path = DefaultBinary
+	}
+	c.binary = path
+}
+
+// Binary returns the mutagen command the client runs.
+func (c *Client) Binary() string {
+	return c.binary
+}
+
+// DefaultListAttempts is how many times ListSessions tries a list that
+// fails for a transient reason, unless SetListAttempts changes it.
+const DefaultListAttempts = 3
+
+// Delays between attempts to list sessions, doubling from the first up to
+// the cap; variables so tests can shorten them.
+var (
+	listRetryDelay    = 250 * time.Millisecond
+	maxListRetryDelay = 2 * time.Second
+)
+
+// SetListAttempts sets how many times ListSessions tries a list that fails
+// for a transient reason. Less than 1 restores DefaultListAttempts.
+func (c *Client) SetListAttempts(attempts int) {
+	if attempts < 1 {
+		attempts = DefaultListAttempts
+	}
+	c.listAttempts = attempts
 }
 
-// ListSessions returns all Mutagen sync sessions.
+// ListSessions returns all Mutagen sync sessions. A list that fails for a
+// transient reason, such as the daemon restarting, is retried with a short
+// backoff.
 func (c *Client) ListSessions(ctx context.Context) ([]SyncSession, error) {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	delay := listRetryDelay
+	for attempt := 1; ; attempt++ {
+		sessions, err := c.listSessions(ctx)
+		if err == nil || attempt >= c.listAttempts || !isTransient(err) {
+			return sessions, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, maxListRetryDelay)
+	}
+}
+
+// transientError marks a failure that may not happen again: the mutagen
+// command exited with an error, rather than timing out, being canceled, or
+// printing output that doesn't parse.
+type transientError struct {
+	error
+}
+
+func (e transientError) Unwrap() error {
+	return e.error
+}
+
+// isTransient returns true if err is worth retrying.
+func isTransient(err error) bool {
+	var transient transientError
+	return errors.As(err, &transient)
+}
+
+// GetSession returns a single sync session by name or identifier.
+// It lists only that session, so it is cheap enough to poll frequently.
+func (c *Client) GetSession(ctx context.Context, name string) (*SyncSession, error) {
+	sessions, err := c.listSessions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, fmt.Errorf("session %q not found", name)
+	}
+	return &sessions[0], nil
+}
+
+// MonitorSession streams updates of a session from `mutagen sync monitor`
+// until ctx is canceled or the command exits, then closes the channel.
+// Canceling ctx also stops the command.
+func (c *Client) MonitorSession(ctx context.Context, name string) (<-chan SyncSession, error) {
+	sr, ok := c.runner.(StreamRunner)
+	if !ok {
+		return nil, errNoStreaming
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	out, err := sr.Stream(ctx, c.binary, "sync", "monitor", name, "--template", "{{json .}}")
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("mutagen sync monitor failed: %w", err)
+	}
+
+	updates := make(chan SyncSession)
+	go func() {
+		defer close(updates)
+		defer out.Close()
+		defer cancel()
+		decodeSessionStream(ctx, out, updates)
+	}()
+	return updates, nil
+}
+
+// decodeSessionStream decodes a stream of JSON values, each a session or a
+// list of sessions as `sync list` prints, and sends the sessions to out
+// until the stream ends, a value can't be decoded, or ctx is canceled.
+func decodeSessionStream(ctx context.Context, r io.Reader, out chan<- SyncSession) error {
+	dec := json.NewDecoder(r)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		var sessions []SyncSession
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+			if err := json.Unmarshal(trimmed, &sessions); err != nil {
+				return err
+			}
+		} else {
+			var session SyncSession
+			if err := json.Unmarshal(trimmed, &session); err != nil {
+				return err
+			}
+			sessions = []SyncSession{session}
+		}
+
+		for _, session := range sessions {
+			select {
+			case out <- session:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// listSessions runs `mutagen sync list` for the given session selectors
+// (all sessions if none) and parses the JSON output.
+func (c *Client) listSessions(ctx context.Context, selectors ...string) ([]SyncSession, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.List)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "mutagen", "sync", "list", "--template", "{{json .}}")
-	output, err := cmd.Output()
+	args := append([]string{"sync", "list"}, selectors...)
+	args = append(args, "--template", "{{json .}}")
+	output, err := c.runner.Output(ctx, c.binary, args...)
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
-			return nil, fmt.Errorf("mutagen sync list failed: %s", string(exitErr.Stderr))
+			err := fmt.Errorf("mutagen sync list failed: %s", string(exitErr.Stderr))
+			if ctx.Err() != nil {
+				return nil, err // Killed for taking too long or canceled
+			}
+			return nil, transientError{err}
 		}
 		return nil, fmt.Errorf("mutagen sync list failed: %w", err)
 	}
@@ -139,12 +361,89 @@ type SessionOptions struct {
 	Ignore      []string // Paths to ignore
 	IgnoreVCS   *bool    // Whether to ignore VCS directories
 	SymlinkMode string   // Symlink mode (ignore, portable, posix-raw)
+	Labels      map[string]string
+
+	WatchMode            string // Watch mode (portable, force-poll, no-watch)
+	WatchPollingInterval uint32 // Seconds between polls
+	PermissionsMode      string // Permissions mode (portable, manual)
+	DefaultFileMode      string // Octal mode for new files, e.g. "0644"
+	DefaultDirectoryMode string // Octal mode for new directories, e.g. "0755"
+	DefaultOwner         string
+	DefaultGroup         string
+}
+
+// Config returns the configuration a session created with these options
+// runs with. IgnoreVCS only changes it when false, since CreateSession only
+// passes a flag to propagate VCS directories.
+func (o *SessionOptions) Config() SessionConfig {
+	config := SessionConfig{
+		Mode:            o.Mode,
+		IgnorePaths:     o.Ignore,
+		SymlinkMode:     o.SymlinkMode,
+		WatchMode:       o.WatchMode,
+		PermissionsMode: o.PermissionsMode,
+	}
+	if o.IgnoreVCS != nil && !*o.IgnoreVCS {
+		config.IgnoreVCS = "propagate"
+	}
+	return config
+}
+
+// args returns the `mutagen sync create` flags for all options but the mode,
+// which push sessions override.
+func (o *SessionOptions) args() []string {
+	var args []string
+	for _, pattern := range o.Ignore {
+		args = append(args, "--ignore", pattern)
+	}
+	if o.IgnoreVCS != nil && !*o.IgnoreVCS {
+		args = append(args, "--no-ignore-vcs")
+	}
+	if o.SymlinkMode != "" {
+		args = append(args, "--symlink-mode", o.SymlinkMode)
+	}
+	if o.WatchMode != "" {
+		args = append(args, "--watch-mode", o.WatchMode)
+	}
+	if o.WatchPollingInterval != 0 {
+		args = append(args, "--watch-polling-interval", strconv.FormatUint(uint64(o.WatchPollingInterval), 10))
+	}
+	if o.PermissionsMode != "" {
+		args = append(args, "--permissions-mode", o.PermissionsMode)
+	}
+	if o.DefaultFileMode != "" {
+		args = append(args, "--default-file-mode", o.DefaultFileMode)
+	}
+	if o.DefaultDirectoryMode != "" {
+		args = append(args, "--default-directory-mode", o.DefaultDirectoryMode)
+	}
+	if o.DefaultOwner != "" {
+		args = append(args, "--default-owner", o.DefaultOwner)
+	}
+	if o.DefaultGroup != "" {
+		args = append(args, "--default-group", o.DefaultGroup)
+	}
+	return append(args, labelArgs(o.Labels)...)
+}
+
+// labelArgs returns --label arguments for the given labels, sorted by key.
+func labelArgs(labels map[string]string) []string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	args := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		args = append(args, "--label", k+"="+labels[k])
+	}
+	return args
 }
 
 // CreateSession creates a new sync session with the given name and endpoints.
 // This is used for starting individual specs (not whole projects).
 func (c *Client) CreateSession(ctx context.Context, name, alpha, beta string, opts *SessionOptions) error {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Create)
 	defer cancel()
 
 	args := []string{"sync", "create", alpha, beta, "--name", name}
@@ -154,70 +453,89 @@ func (c *Client) CreateSession(ctx context.Context, name, alpha, beta string, op
 		if opts.Mode != "" {
 			args = append(args, "--sync-mode", opts.Mode)
 		}
-		for _, pattern := range opts.Ignore {
-			args = append(args, "--ignore", pattern)
-		}
-		if opts.IgnoreVCS != nil && !*opts.IgnoreVCS {
-			args = append(args, "--no-ignore-vcs")
-		}
-		if opts.SymlinkMode != "" {
-			args = append(args, "--symlink-mode", opts.SymlinkMode)
-		}
+		args = append(args, opts.args()...)
 	}
 
-	cmd := exec.CommandContext(ctx, "mutagen", args...)
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return wrapConnectionError("mutagen sync create failed", string(output))
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, args...); err != nil {
+		return WrapConnectionError("mutagen sync create failed", string(output))
 	}
 	return nil
 }
 
 // CreatePushSession creates a one-way sync session (alpha to beta).
 func (c *Client) CreatePushSession(ctx context.Context, name, alpha, beta string, opts *SessionOptions) error {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Create)
 	defer cancel()
 
 	args := []string{"sync", "create", alpha, beta, "--name", name, "--sync-mode", "one-way-replica"}
 
 	// Apply session options (except mode, which is always one-way-replica for push)
 	if opts != nil {
-		for _, pattern := range opts.Ignore {
-			args = append(args, "--ignore", pattern)
-		}
-		if opts.IgnoreVCS != nil && !*opts.IgnoreVCS {
-			args = append(args, "--no-ignore-vcs")
-		}
-		if opts.SymlinkMode != "" {
-			args = append(args, "--symlink-mode", opts.SymlinkMode)
-		}
+		args = append(args, opts.args()...)
 	}
 
-	cmd := exec.CommandContext(ctx, "mutagen", args...)
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return wrapConnectionError("mutagen sync create (push) failed", string(output))
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, args...); err != nil {
+		return WrapConnectionError("mutagen sync create (push) failed", string(output))
 	}
 	return nil
 }
 
 // TerminateSession terminates a sync session by name.
 func (c *Client) TerminateSession(ctx context.Context, name string) error {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutate)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "mutagen", "sync", "terminate", name)
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, "sync", "terminate", name); err != nil {
 		return fmt.Errorf("mutagen sync terminate failed: %s", string(output))
 	}
 	return nil
 }
 
+// Polling settings for WaitForTerminated; variables so tests can shorten them.
+var (
+	terminatePollInterval = 100 * time.Millisecond
+	terminateWaitTimeout  = 5 * time.Second
+)
+
+// WaitForTerminated polls until no session with the given name is listed.
+// The daemon may still list a session briefly after terminate returns, and
+// creating a replacement in that window fails with "already exists".
+// A failure to list the sessions ends the wait without an error: the
+// terminate itself succeeded, and a create that still collides reports it.
+func (c *Client) WaitForTerminated(ctx context.Context, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, terminateWaitTimeout)
+	defer cancel()
+
+	for {
+		sessions, err := c.listSessions(ctx)
+		if err != nil {
+			return nil
+		}
+		found := false
+		for _, session := range sessions {
+			if session.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("session %s still exists after terminate", name)
+		case <-time.After(terminatePollInterval):
+		}
+	}
+}
+
 // PauseSession pauses a sync session by name.
 func (c *Client) PauseSession(ctx context.Context, name string) error {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutate)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "mutagen", "sync", "pause", name)
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, "sync", "pause", name); err != nil {
 		return fmt.Errorf("mutagen sync pause failed: %s", string(output))
 	}
 	return nil
@@ -225,11 +543,10 @@ func (c *Client) PauseSession(ctx context.Context, name string) error {
 
 // ResumeSession resumes a paused sync session by name.
 func (c *Client) ResumeSession(ctx context.Context, name string) error {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutate)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "mutagen", "sync", "resume", name)
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, "sync", "resume", name); err != nil {
 		return fmt.Errorf("mutagen sync resume failed: %s", string(output))
 	}
 	return nil
@@ -237,11 +554,10 @@ func (c *Client) ResumeSession(ctx context.Context, name string) error {
 
 // FlushSession forces a sync cycle on a session by name.
 func (c *Client) FlushSession(ctx context.Context, name string) error {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Create)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "mutagen", "sync", "flush", name)
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, "sync", "flush", name); err != nil {
 		return fmt.Errorf("mutagen sync flush failed: %s", string(output))
 	}
 	return nil
@@ -249,11 +565,10 @@ func (c *Client) FlushSession(ctx context.Context, name string) error {
 
 // ResetSession resets a sync session by name to resolve conflicts.
 func (c *Client) ResetSession(ctx context.Context, name string) error {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutate)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "mutagen", "sync", "reset", name)
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, "sync", "reset", name); err != nil {
 		return fmt.Errorf("mutagen sync reset failed: %s", string(output))
 	}
 	return nil
@@ -261,23 +576,21 @@ func (c *Client) ResetSession(ctx context.Context, name string) error {
 
 // ProjectStart starts all sessions defined in a mutagen project file.
 func (c *Client) ProjectStart(ctx context.Context, projectFilePath string) error {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Create)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "mutagen", "project", "start", "-f", projectFilePath)
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return wrapConnectionError("mutagen project start failed", string(output))
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, "project", "start", "-f", projectFilePath); err != nil {
+		return WrapConnectionError("mutagen project start failed", string(output))
 	}
 	return nil
 }
 
 // ProjectTerminate terminates all sessions for a mutagen project file.
 func (c *Client) ProjectTerminate(ctx context.Context, projectFilePath string) error {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutate)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "mutagen", "project", "terminate", "-f", projectFilePath)
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, "project", "terminate", "-f", projectFilePath); err != nil {
 		return fmt.Errorf("mutagen project terminate failed: %s", string(output))
 	}
 	return nil
@@ -285,11 +598,10 @@ func (c *Client) ProjectTerminate(ctx context.Context, projectFilePath string) e
 
 // ProjectPause pauses all sessions for a mutagen project file.
 func (c *Client) ProjectPause(ctx context.Context, projectFilePath string) error {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutate)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "mutagen", "project", "pause", "-f", projectFilePath)
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, "project", "pause", "-f", projectFilePath); err != nil {
 		return fmt.Errorf("mutagen project pause failed: %s", string(output))
 	}
 	return nil
@@ -297,11 +609,10 @@ func (c *Client) ProjectPause(ctx context.Context, projectFilePath string) error
 
 // ProjectResume resumes all sessions for a mutagen project file.
 func (c *Client) ProjectResume(ctx context.Context, projectFilePath string) error {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutate)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "mutagen", "project", "resume", "-f", projectFilePath)
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, "project", "resume", "-f", projectFilePath); err != nil {
 		return fmt.Errorf("mutagen project resume failed: %s", string(output))
 	}
 	return nil
@@ -309,11 +620,10 @@ func (c *Client) ProjectResume(ctx context.Context, projectFilePath string) erro
 
 // ProjectFlush flushes all sessions for a mutagen project file.
 func (c *Client) ProjectFlush(ctx context.Context, projectFilePath string) error {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Create)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "mutagen", "project", "flush", "-f", projectFilePath)
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, "project", "flush", "-f", projectFilePath); err != nil {
 		return fmt.Errorf("mutagen project flush failed: %s", string(output))
 	}
 	return nil
@@ -321,14 +631,13 @@ func (c *Client) ProjectFlush(ctx context.Context, projectFilePath string) error
 
 // IsInstalled checks if the mutagen CLI is installed and accessible.
 func (c *Client) IsInstalled() bool {
-	cmd := exec.Command("mutagen", "version")
-	return cmd.Run() == nil
+	_, err := c.runner.Output(context.Background(), c.binary, "version")
+	return err == nil
 }
 
 // GetVersion returns the installed mutagen version.
 func (c *Client) GetVersion() (string, error) {
-	cmd := exec.Command("mutagen", "version")
-	output, err := cmd.Output()
+	output, err := c.runner.Output(context.Background(), c.binary, "version")
 	if err != nil {
 		return "", fmt.Errorf("failed to get mutagen version: %w", err)
 	}
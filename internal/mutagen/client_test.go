@@ -3,6 +3,10 @@ package mutagen
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
 	"testing"
 	"time"
 )
@@ -38,6 +42,12 @@ func TestWrapConnectionError(t *testing.T) {
 			output:      "connection timed out",
 			wantContain: "unreachable",
 		},
+		{
+			name:        "unknown host",
+			baseErr:     "failed",
+			output:      "ssh: Could not resolve hostname studio: Name or service not known",
+			wantContain: "~/.ssh/config",
+		},
 		{
 			name:        "permission denied",
 			baseErr:     "failed",
@@ -84,18 +94,28 @@ func TestWrapConnectionError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := wrapConnectionError(tt.baseErr, tt.output)
+			err := WrapConnectionError(tt.baseErr, tt.output)
 			if err == nil {
-				t.Fatal("wrapConnectionError() returned nil")
+				t.Fatal("WrapConnectionError() returned nil")
 			}
 			errMsg := err.Error()
 			if !contains(errMsg, tt.wantContain) {
-				t.Errorf("wrapConnectionError() = %q, want to contain %q", errMsg, tt.wantContain)
+				t.Errorf("WrapConnectionError() = %q, want to contain %q", errMsg, tt.wantContain)
 			}
 		})
 	}
 }
 
+func TestWrapConnectionError_CrossDeviceLinkIsDetectable(t *testing.T) {
+	err := WrapConnectionError("failed to create session", "rename: invalid cross-device link")
+	if !errors.Is(err, ErrCrossDeviceLink) {
+		t.Errorf("errors.Is(%v, ErrCrossDeviceLink) = false", err)
+	}
+	if errors.Is(WrapConnectionError("failed", "connection refused"), ErrCrossDeviceLink) {
+		t.Error("other errors should not match ErrCrossDeviceLink")
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
 		(len(s) > 0 && len(substr) > 0 && searchSubstring(s, substr)))
@@ -111,13 +131,100 @@ func searchSubstring(s, substr string) bool {
 }
 
 func TestNewClient(t *testing.T) {
-	timeout := 5 * time.Second
-	client := NewClient(timeout)
+	timeouts := Timeouts{List: time.Second, Create: time.Minute, Mutate: 5 * time.Second}
+	client := NewClient(timeouts)
 	if client == nil {
 		t.Fatal("NewClient() returned nil")
 	}
-	if client.timeout != timeout {
-		t.Errorf("client.timeout = %v, want %v", client.timeout, timeout)
+	if client.timeouts != timeouts {
+		t.Errorf("client.timeouts = %v, want %v", client.timeouts, timeouts)
+	}
+}
+
+func TestClient_Binary(t *testing.T) {
+	ctx := context.Background()
+	runner := &mockCommandRunner{output: []byte(`[]`)}
+	client := NewClientWithRunner(Timeouts{}, runner)
+	if client.Binary() != "mutagen" {
+		t.Errorf("Binary() = %q, want mutagen by default", client.Binary())
+	}
+
+	client.SetBinary("/nix/store/abc-mutagen/bin/mutagen")
+	calls := []struct {
+		name string
+		call func() error
+	}{
+		{"list", func() error { _, err := client.ListSessions(ctx); return err }},
+		{"pause", func() error { return client.PauseSession(ctx, "s") }},
+		{"project start", func() error { return client.ProjectStart(ctx, "mutagen.yml") }},
+		{"list forwards", func() error { _, err := client.ListForwards(ctx); return err }},
+		{"daemon stop", func() error { return client.StopDaemon(ctx) }},
+		{"version", func() error { _, err := client.GetVersion(); return err }},
+	}
+	for _, c := range calls {
+		runner.lastName = ""
+		if err := c.call(); err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if runner.lastName != "/nix/store/abc-mutagen/bin/mutagen" {
+			t.Errorf("%s ran %q, want the configured binary", c.name, runner.lastName)
+		}
+	}
+
+	client.SetBinary("")
+	if client.Binary() != DefaultBinary {
+		t.Errorf("Binary() = %q after SetBinary(\"\"), want %q", client.Binary(), DefaultBinary)
+	}
+}
+
+// deadlineRunner records how long each command was given to run.
+type deadlineRunner struct {
+	mockCommandRunner
+	budget time.Duration
+}
+
+func (r *deadlineRunner) Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		r.budget = time.Until(deadline)
+	}
+	return r.mockCommandRunner.Output(ctx, name, args...)
+}
+
+func (r *deadlineRunner) CombinedOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return r.Output(ctx, name, args...)
+}
+
+func TestClient_TimeoutPerCallType(t *testing.T) {
+	timeouts := Timeouts{List: time.Hour, Create: 2 * time.Hour, Mutate: 3 * time.Hour}
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func(c *Client) error
+		want time.Duration
+	}{
+		{"list", func(c *Client) error { _, err := c.ListSessions(ctx); return err }, timeouts.List},
+		{"get", func(c *Client) error { _, err := c.GetSession(ctx, "s"); return err }, timeouts.List},
+		{"list forwards", func(c *Client) error { _, err := c.ListForwards(ctx); return err }, timeouts.List},
+		{"create", func(c *Client) error { return c.CreateSession(ctx, "s", "/a", "/b", nil) }, timeouts.Create},
+		{"create push", func(c *Client) error { return c.CreatePushSession(ctx, "s", "/a", "/b", nil) }, timeouts.Create},
+		{"flush", func(c *Client) error { return c.FlushSession(ctx, "s") }, timeouts.Create},
+		{"project start", func(c *Client) error { return c.ProjectStart(ctx, "mutagen.yml") }, timeouts.Create},
+		{"pause", func(c *Client) error { return c.PauseSession(ctx, "s") }, timeouts.Mutate},
+		{"resume", func(c *Client) error { return c.ResumeSession(ctx, "s") }, timeouts.Mutate},
+		{"terminate", func(c *Client) error { return c.TerminateSession(ctx, "s") }, timeouts.Mutate},
+		{"project pause", func(c *Client) error { return c.ProjectPause(ctx, "mutagen.yml") }, timeouts.Mutate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &deadlineRunner{mockCommandRunner: mockCommandRunner{output: []byte(`[{"name":"s"}]`)}}
+			if err := tt.call(NewClientWithRunner(timeouts, runner)); err != nil {
+				t.Fatal(err)
+			}
+			// The budget is measured a moment after the deadline is set
+			if runner.budget > tt.want || runner.budget < tt.want-time.Minute {
+				t.Errorf("timeout = %v, want %v", runner.budget.Round(time.Second), tt.want)
+			}
+		})
 	}
 }
 
@@ -209,39 +316,26 @@ func buildSessionArgs(opts *SessionOptions) []string {
 	return args
 }
 
-// mockCommandRunner is used for testing command execution
+// mockCommandRunner records the commands it is asked to run and returns canned output.
 type mockCommandRunner struct {
+	lastName string
 	lastArgs []string
 	output   []byte
 	err      error
 }
 
-func (m *mockCommandRunner) Run(_ context.Context, args ...string) ([]byte, error) {
+func (m *mockCommandRunner) Output(_ context.Context, name string, args ...string) ([]byte, error) {
+	m.lastName = name
 	m.lastArgs = args
 	return m.output, m.err
 }
 
-// CommandRunner interface allows mocking exec.Command in tests
-type CommandRunner interface {
-	Run(ctx context.Context, args ...string) ([]byte, error)
-}
-
-// TestableClient wraps Client with an injectable command runner
-type TestableClient struct {
-	*Client
-	runner CommandRunner
+func (m *mockCommandRunner) CombinedOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return m.Output(ctx, name, args...)
 }
 
 func TestListSessions_EmptyOutput(t *testing.T) {
 	// Test that empty output returns empty slice
-	mock := &mockCommandRunner{output: []byte("")}
-	client := &TestableClient{
-		Client: NewClient(time.Second),
-		runner: mock,
-	}
-
-	// Since we can't easily inject the runner into the existing Client,
-	// we'll test the parsing logic separately
 	testCases := []struct {
 		name   string
 		output string
@@ -254,17 +348,169 @@ func TestListSessions_EmptyOutput(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Simulate the parsing logic from ListSessions
-			trimmed := trimSpace(tc.output)
-			if trimmed == "" || trimmed == "null" {
-				// Would return empty slice
-				return
+			mock := &mockCommandRunner{output: []byte(tc.output)}
+			client := NewClientWithRunner(UniformTimeouts(time.Second), mock)
+
+			sessions, err := client.ListSessions(context.Background())
+			if err != nil {
+				t.Fatalf("ListSessions() error = %v", err)
+			}
+			if len(sessions) != tc.want {
+				t.Errorf("len(sessions) = %d, want %d", len(sessions), tc.want)
+			}
+			if trimSpace(tc.output) != "" && trimSpace(tc.output) != "null" {
+				t.Errorf("expected empty result handling for %q", tc.output)
 			}
-			t.Errorf("expected empty result handling for %q", tc.output)
 		})
 	}
+}
+
+func TestListSessions_UsesRunner(t *testing.T) {
+	mock := &mockCommandRunner{output: []byte(`[{"name":"a"},{"name":"b"}]`)}
+	client := NewClientWithRunner(UniformTimeouts(time.Second), mock)
+
+	sessions, err := client.ListSessions(context.Background())
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Errorf("len(sessions) = %d, want 2", len(sessions))
+	}
+	wantArgs := []string{"sync", "list", "--template", "{{json .}}"}
+	if mock.lastName != "mutagen" || !equalArgs(mock.lastArgs, wantArgs) {
+		t.Errorf("ran %s %v, want mutagen %v", mock.lastName, mock.lastArgs, wantArgs)
+	}
+}
+
+func TestGetSession_ListsOnlyThatSession(t *testing.T) {
+	mock := &mockCommandRunner{output: []byte(`[{"name":"my-sync","status":"watching"}]`)}
+	client := NewClientWithRunner(UniformTimeouts(time.Second), mock)
+
+	session, err := client.GetSession(context.Background(), "my-sync")
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+	if session.Name != "my-sync" {
+		t.Errorf("session.Name = %q, want my-sync", session.Name)
+	}
+	wantArgs := []string{"sync", "list", "my-sync", "--template", "{{json .}}"}
+	if !equalArgs(mock.lastArgs, wantArgs) {
+		t.Errorf("args = %v, want %v", mock.lastArgs, wantArgs)
+	}
+
+	mock.output = []byte("[]")
+	if _, err := client.GetSession(context.Background(), "missing"); err == nil {
+		t.Error("GetSession() should fail when no session is returned")
+	}
+}
+
+func TestTerminateSession_UsesRunner(t *testing.T) {
+	mock := &mockCommandRunner{}
+	client := NewClientWithRunner(UniformTimeouts(time.Second), mock)
+
+	if err := client.TerminateSession(context.Background(), "my-sync"); err != nil {
+		t.Fatalf("TerminateSession() error = %v", err)
+	}
+	wantArgs := []string{"sync", "terminate", "my-sync"}
+	if !equalArgs(mock.lastArgs, wantArgs) {
+		t.Errorf("args = %v, want %v", mock.lastArgs, wantArgs)
+	}
+}
+
+func TestCreateSession_PassesLabels(t *testing.T) {
+	mock := &mockCommandRunner{}
+	client := NewClientWithRunner(UniformTimeouts(time.Second), mock)
+
+	opts := &SessionOptions{Labels: map[string]string{ProjectLabel: "apollo", "env": "dev"}}
+	if err := client.CreateSession(context.Background(), "my-sync", "/a", "host:/b", opts); err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	wantArgs := []string{"sync", "create", "/a", "host:/b", "--name", "my-sync",
+		"--label", "env=dev", "--label", "project=apollo"}
+	if !equalArgs(mock.lastArgs, wantArgs) {
+		t.Errorf("args = %v, want %v", mock.lastArgs, wantArgs)
+	}
+}
+
+func TestCreatePushSession_PassesWatchAndPermissions(t *testing.T) {
+	mock := &mockCommandRunner{}
+	client := NewClientWithRunner(UniformTimeouts(time.Second), mock)
+
+	opts := &SessionOptions{
+		Mode:                 "two-way-resolved", // Push sessions keep their own mode
+		SymlinkMode:          "posix-raw",
+		WatchMode:            "force-poll",
+		WatchPollingInterval: 20,
+		PermissionsMode:      "manual",
+		DefaultFileMode:      "0644",
+		DefaultDirectoryMode: "0755",
+		DefaultOwner:         "deploy",
+		DefaultGroup:         "www",
+	}
+	if err := client.CreatePushSession(context.Background(), "my-sync", "/a", "host:/b", opts); err != nil {
+		t.Fatalf("CreatePushSession() error = %v", err)
+	}
+	wantArgs := []string{"sync", "create", "/a", "host:/b", "--name", "my-sync", "--sync-mode", "one-way-replica",
+		"--symlink-mode", "posix-raw", "--watch-mode", "force-poll", "--watch-polling-interval", "20",
+		"--permissions-mode", "manual", "--default-file-mode", "0644", "--default-directory-mode", "0755",
+		"--default-owner", "deploy", "--default-group", "www"}
+	if !equalArgs(mock.lastArgs, wantArgs) {
+		t.Errorf("args = %v, want %v", mock.lastArgs, wantArgs)
+	}
+}
+
+// sequenceRunner returns each of its outputs in turn, repeating the last.
+type sequenceRunner struct {
+	outputs []string
+	calls   int
+}
+
+func (r *sequenceRunner) Output(_ context.Context, _ string, _ ...string) ([]byte, error) {
+	i := min(r.calls, len(r.outputs)-1)
+	r.calls++
+	return []byte(r.outputs[i]), nil
+}
+
+func (r *sequenceRunner) CombinedOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return r.Output(ctx, name, args...)
+}
+
+func TestWaitForTerminated(t *testing.T) {
+	interval, timeout := terminatePollInterval, terminateWaitTimeout
+	terminatePollInterval, terminateWaitTimeout = time.Millisecond, 50*time.Millisecond
+	t.Cleanup(func() { terminatePollInterval, terminateWaitTimeout = interval, timeout })
+
+	runner := &sequenceRunner{outputs: []string{`[{"name":"my-sync"}]`, `[{"name":"my-sync"}]`, `[{"name":"other"}]`}}
+	client := NewClientWithRunner(UniformTimeouts(time.Second), runner)
+	if err := client.WaitForTerminated(context.Background(), "my-sync"); err != nil {
+		t.Fatalf("WaitForTerminated() error = %v", err)
+	}
+	if runner.calls != 3 {
+		t.Errorf("listed sessions %d times, want 3", runner.calls)
+	}
+
+	stuck := &sequenceRunner{outputs: []string{`[{"name":"my-sync"}]`}}
+	client = NewClientWithRunner(UniformTimeouts(time.Second), stuck)
+	if err := client.WaitForTerminated(context.Background(), "my-sync"); err == nil {
+		t.Error("WaitForTerminated() should time out while the session is still listed")
+	}
 
-	_ = client // silence unused warning
+	client = NewClientWithRunner(UniformTimeouts(time.Second), &mockCommandRunner{err: errors.New("daemon unavailable")})
+	if err := client.WaitForTerminated(context.Background(), "my-sync"); err != nil {
+		t.Errorf("WaitForTerminated() error = %v, want a list failure to end the wait", err)
+	}
+}
+
+func equalArgs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 func trimSpace(s string) string {
@@ -300,3 +546,191 @@ func TestListSessions_ParseJSON(t *testing.T) {
 func jsonUnmarshal(data []byte, v interface{}) error {
 	return json.Unmarshal(data, v)
 }
+
+// streamRunner is a mockCommandRunner that also streams canned output.
+type streamRunner struct {
+	mockCommandRunner
+	stream string
+}
+
+func (s *streamRunner) Stream(_ context.Context, name string, args ...string) (io.ReadCloser, error) {
+	s.lastName = name
+	s.lastArgs = args
+	return io.NopCloser(strings.NewReader(s.stream)), s.err
+}
+
+func TestDecodeSessionStream(t *testing.T) {
+	lines := strings.Join([]string{
+		`{"name":"a","status":"scanning"}`,
+		`[{"name":"a","status":"staging-beta"}]`,
+		``,
+		`{"name":"a","status":"watching"}`,
+	}, "\n")
+
+	out := make(chan SyncSession, 3)
+	if err := decodeSessionStream(context.Background(), strings.NewReader(lines), out); err != nil {
+		t.Fatalf("decodeSessionStream() error = %v", err)
+	}
+	close(out)
+
+	var statuses []string
+	for session := range out {
+		statuses = append(statuses, session.Status)
+	}
+	want := []string{"scanning", "staging-beta", "watching"}
+	if strings.Join(statuses, ",") != strings.Join(want, ",") {
+		t.Errorf("statuses = %v, want %v", statuses, want)
+	}
+}
+
+func TestDecodeSessionStream_BadJSON(t *testing.T) {
+	out := make(chan SyncSession, 1)
+	err := decodeSessionStream(context.Background(), strings.NewReader(`{"name":"a"}`+"\nnot json"), out)
+	if err == nil {
+		t.Error("decodeSessionStream() should fail on malformed output")
+	}
+	if len(out) != 1 {
+		t.Errorf("got %d sessions before the bad line, want 1", len(out))
+	}
+}
+
+func TestDecodeSessionStream_StopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Nobody reads from out, so only the canceled context can end the loop
+	out := make(chan SyncSession)
+	done := make(chan error)
+	go func() { done <- decodeSessionStream(ctx, strings.NewReader(`{"name":"a"}`), out) }()
+
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("decodeSessionStream() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("decodeSessionStream() did not stop after cancel")
+	}
+}
+
+func TestMonitorSession(t *testing.T) {
+	runner := &streamRunner{stream: `{"name":"my-sync","status":"watching"}` + "\n"}
+	client := NewClientWithRunner(UniformTimeouts(time.Second), runner)
+
+	updates, err := client.MonitorSession(context.Background(), "my-sync")
+	if err != nil {
+		t.Fatalf("MonitorSession() error = %v", err)
+	}
+	wantArgs := []string{"sync", "monitor", "my-sync", "--template", "{{json .}}"}
+	if !equalArgs(runner.lastArgs, wantArgs) {
+		t.Errorf("args = %v, want %v", runner.lastArgs, wantArgs)
+	}
+
+	var got []SyncSession
+	for session := range updates {
+		got = append(got, session)
+	}
+	if len(got) != 1 || got[0].Name != "my-sync" || got[0].Status != "watching" {
+		t.Errorf("updates = %+v, want one watching session", got)
+	}
+}
+
+func TestMonitorSession_RequiresStreamRunner(t *testing.T) {
+	client := NewClientWithRunner(UniformTimeouts(time.Second), &mockCommandRunner{})
+	if _, err := client.MonitorSession(context.Background(), "my-sync"); err == nil {
+		t.Error("MonitorSession() should fail when the runner can't stream")
+	}
+}
+
+func TestDryRunRunner_StreamsMonitor(t *testing.T) {
+	next := &streamRunner{stream: `{"name":"a"}`}
+	runner := NewDryRunRunner(next, func(string) { t.Error("monitor should not be reported") })
+	client := NewClientWithRunner(UniformTimeouts(time.Second), runner)
+
+	updates, err := client.MonitorSession(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("MonitorSession() error = %v", err)
+	}
+	for range updates {
+	}
+	if next.lastArgs == nil {
+		t.Error("monitor should reach the real runner")
+	}
+}
+
+// flakyRunner fails the first failures calls as a command exiting with an
+// error would, then prints output.
+type flakyRunner struct {
+	failures int
+	calls    int
+	output   []byte
+	err      error // Returned instead of an exit error, if set
+}
+
+func (r *flakyRunner) Output(_ context.Context, _ string, _ ...string) ([]byte, error) {
+	r.calls++
+	if r.calls <= r.failures {
+		if r.err != nil {
+			return nil, r.err
+		}
+		return nil, &exec.ExitError{Stderr: []byte("unable to connect to daemon")}
+	}
+	return r.output, nil
+}
+
+func (r *flakyRunner) CombinedOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return r.Output(ctx, name, args...)
+}
+
+func shortenListRetries(t *testing.T) {
+	t.Helper()
+	delay, maxDelay := listRetryDelay, maxListRetryDelay
+	listRetryDelay, maxListRetryDelay = time.Millisecond, 2*time.Millisecond
+	t.Cleanup(func() { listRetryDelay, maxListRetryDelay = delay, maxDelay })
+}
+
+func TestListSessions_Retries(t *testing.T) {
+	shortenListRetries(t)
+	ctx := context.Background()
+	timeouts := UniformTimeouts(time.Minute)
+
+	runner := &flakyRunner{failures: 2, output: []byte(`[{"name":"web"}]`)}
+	sessions, err := NewClientWithRunner(timeouts, runner).ListSessions(ctx)
+	if err != nil || len(sessions) != 1 || runner.calls != 3 {
+		t.Errorf("ListSessions() = %v, %v after %d calls; want the list on the third", sessions, err, runner.calls)
+	}
+
+	runner = &flakyRunner{failures: 5, output: []byte(`[]`)}
+	client := NewClientWithRunner(timeouts, runner)
+	client.SetListAttempts(4)
+	if _, err := client.ListSessions(ctx); err == nil || !strings.Contains(err.Error(), "unable to connect to daemon") || runner.calls != 4 {
+		t.Errorf("ListSessions() = %v after %d calls; want the last failure after 4", err, runner.calls)
+	}
+}
+
+func TestListSessions_DoesNotRetry(t *testing.T) {
+	shortenListRetries(t)
+	timeouts := UniformTimeouts(time.Minute)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		runner *flakyRunner
+	}{
+		{"not installed", context.Background(), &flakyRunner{failures: 1, err: errors.New(`exec: "mutagen": executable file not found in $PATH`)}},
+		{"unparsable output", context.Background(), &flakyRunner{output: []byte(`not json`)}},
+		{"canceled", canceled, &flakyRunner{failures: 1, output: []byte(`[]`)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewClientWithRunner(timeouts, tt.runner).ListSessions(tt.ctx); err == nil {
+				t.Error("ListSessions() succeeded, want an error")
+			}
+			if tt.runner.calls != 1 {
+				t.Errorf("mutagen ran %d times, want 1", tt.runner.calls)
+			}
+		})
+	}
+}
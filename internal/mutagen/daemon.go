@@ -0,0 +1,65 @@
+package mutagen
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DaemonSocketPath returns the path of the Unix socket the Mutagen daemon
+// listens on, in $MUTAGEN_DATA_DIRECTORY or ~/.mutagen.
+func DaemonSocketPath() string {
+	dir := os.Getenv("MUTAGEN_DATA_DIRECTORY")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".mutagen")
+	}
+	return filepath.Join(dir, "daemon", "daemon.sock")
+}
+
+// PingDaemon checks that the daemon is accepting connections. Unlike other
+// commands it doesn't start the daemon if it isn't running.
+func (c *Client) PingDaemon(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.List)
+	defer cancel()
+
+	path := DaemonSocketPath()
+	if path == "" {
+		return fmt.Errorf("can't locate the daemon socket: no home directory")
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", path)
+	if err != nil {
+		return fmt.Errorf("daemon is not responding at %s", path)
+	}
+	return conn.Close()
+}
+
+// StopDaemon stops the daemon, disconnecting every session until it is
+// started again.
+func (c *Client) StopDaemon(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutate)
+	defer cancel()
+
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, "daemon", "stop"); err != nil {
+		return fmt.Errorf("mutagen daemon stop failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// StartDaemon starts the daemon. It does nothing if the daemon is running.
+func (c *Client) StartDaemon(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutate)
+	defer cancel()
+
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, "daemon", "start"); err != nil {
+		return fmt.Errorf("mutagen daemon start failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
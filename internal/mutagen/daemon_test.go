@@ -0,0 +1,70 @@
+package mutagen
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPingDaemon(t *testing.T) {
+	// Unix socket paths are limited in length, so avoid the long t.TempDir()
+	dir, err := os.MkdirTemp("", "mg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", dir)
+	client := NewClientWithRunner(UniformTimeouts(time.Second), &mockCommandRunner{})
+
+	if err := client.PingDaemon(context.Background()); err == nil {
+		t.Error("PingDaemon() succeeded with no daemon listening")
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "daemon"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("unix", DaemonSocketPath())
+	if err != nil {
+		t.Skipf("can't listen on a Unix socket: %v", err)
+	}
+	defer listener.Close()
+	if err := client.PingDaemon(context.Background()); err != nil {
+		t.Errorf("PingDaemon() error = %v with a daemon listening", err)
+	}
+}
+
+func TestStopDaemon(t *testing.T) {
+	runner := &mockCommandRunner{}
+	client := NewClientWithRunner(UniformTimeouts(time.Second), runner)
+	if err := client.StopDaemon(context.Background()); err != nil {
+		t.Fatalf("StopDaemon() error = %v", err)
+	}
+	if !equalArgs(runner.lastArgs, []string{"daemon", "stop"}) {
+		t.Errorf("args = %v, want daemon stop", runner.lastArgs)
+	}
+}
+
+func TestStopDaemon_ReportsOutput(t *testing.T) {
+	runner := &mockCommandRunner{output: []byte("Error: unable to connect to daemon\n"), err: errors.New("exit status 1")}
+	client := NewClientWithRunner(UniformTimeouts(time.Second), runner)
+	err := client.StopDaemon(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "unable to connect to daemon") {
+		t.Errorf("StopDaemon() error = %v, want the command output", err)
+	}
+}
+
+func TestStartDaemon(t *testing.T) {
+	runner := &mockCommandRunner{}
+	client := NewClientWithRunner(UniformTimeouts(time.Second), runner)
+	if err := client.StartDaemon(context.Background()); err != nil {
+		t.Fatalf("StartDaemon() error = %v", err)
+	}
+	if !equalArgs(runner.lastArgs, []string{"daemon", "start"}) {
+		t.Errorf("args = %v, want daemon start", runner.lastArgs)
+	}
+}
@@ -0,0 +1,151 @@
+package mutagen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ForwardEndpoint is the source or destination of a forward session.
+type ForwardEndpoint struct {
+	Protocol  string  `json:"protocol"`
+	User      string  `json:"user,omitempty"`
+	Host      *string `json:"host,omitempty"`
+	Port      uint32  `json:"port,omitempty"`
+	Path      string  `json:"path"` // Network endpoint, such as tcp:localhost:8080
+	Connected bool    `json:"connected"`
+}
+
+// DisplayPath returns the network endpoint, prefixed by its host if remote.
+func (e *ForwardEndpoint) DisplayPath() string {
+	if e.Host == nil {
+		return e.Path
+	}
+	host := *e.Host
+	if e.User != "" {
+		host = e.User + "@" + host
+	}
+	if e.Port != 0 {
+		host += ":" + strconv.FormatUint(uint64(e.Port), 10)
+	}
+	return host + ":" + e.Path
+}
+
+// ForwardSession represents a Mutagen network forwarding session.
+type ForwardSession struct {
+	Name            string            `json:"name"`
+	Identifier      string            `json:"identifier"`
+	Labels          map[string]string `json:"labels"`
+	Source          ForwardEndpoint   `json:"source"`
+	Destination     ForwardEndpoint   `json:"destination"`
+	Status          string            `json:"status"`
+	Paused          bool              `json:"paused"`
+	LastError       string            `json:"lastError,omitempty"`
+	OpenConnections uint64            `json:"openConnections"`
+}
+
+// DisplayName returns the session name, or its identifier if it is unnamed.
+func (f *ForwardSession) DisplayName() string {
+	if f.Name != "" {
+		return f.Name
+	}
+	return f.Identifier
+}
+
+// StatusIcon returns a compact icon representing the forward status.
+func (f *ForwardSession) StatusIcon() string {
+	if f.Paused {
+		return Icon("⏸", "|")
+	}
+	status := strings.ToLower(f.Status)
+	switch {
+	case strings.Contains(status, "forwarding"):
+		return Icon("⇄", "=")
+	case strings.Contains(status, "disconnected"):
+		return Icon("⊗", "x")
+	case strings.Contains(status, "connect"):
+		return Icon("🔌", "c")
+	case strings.Contains(status, "halt"):
+		return Icon("⛔", "!")
+	default:
+		return Icon("•", ".")
+	}
+}
+
+// StatusText returns a human-readable status description.
+func (f *ForwardSession) StatusText() string {
+	if f.Paused {
+		return "Paused"
+	}
+	status := strings.ToLower(f.Status)
+	switch {
+	case strings.Contains(status, "forwarding"):
+		return "Forwarding"
+	case strings.Contains(status, "disconnected"):
+		return "Disconnected"
+	case strings.Contains(status, "source"):
+		return "Connecting source"
+	case strings.Contains(status, "destination"):
+		return "Connecting destination"
+	case strings.Contains(status, "connect"):
+		return "Connecting"
+	case strings.Contains(status, "halt"):
+		return "Halted"
+	default:
+		return "Unknown"
+	}
+}
+
+// ListForwards returns all network forwarding sessions.
+func (c *Client) ListForwards(ctx context.Context) ([]ForwardSession, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.List)
+	defer cancel()
+
+	output, err := c.runner.Output(ctx, c.binary, "forward", "list", "--template", "{{json .}}")
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("mutagen forward list failed: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("mutagen forward list failed: %w", err)
+	}
+
+	trimmed := strings.TrimSpace(string(output))
+	if trimmed == "" || trimmed == "null" {
+		return []ForwardSession{}, nil
+	}
+
+	var forwards []ForwardSession
+	if err := json.Unmarshal(output, &forwards); err != nil {
+		return nil, fmt.Errorf("failed to parse mutagen output: %w", err)
+	}
+	return forwards, nil
+}
+
+// PauseForward pauses a forward session by name or identifier.
+func (c *Client) PauseForward(ctx context.Context, name string) error {
+	return c.forwardCommand(ctx, "pause", name)
+}
+
+// ResumeForward resumes a paused forward session by name or identifier.
+func (c *Client) ResumeForward(ctx context.Context, name string) error {
+	return c.forwardCommand(ctx, "resume", name)
+}
+
+// TerminateForward terminates a forward session by name or identifier.
+func (c *Client) TerminateForward(ctx context.Context, name string) error {
+	return c.forwardCommand(ctx, "terminate", name)
+}
+
+// forwardCommand runs `mutagen forward <command> <name>`.
+func (c *Client) forwardCommand(ctx context.Context, command, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutate)
+	defer cancel()
+
+	if output, err := c.runner.CombinedOutput(ctx, c.binary, "forward", command, name); err != nil {
+		return fmt.Errorf("mutagen forward %s failed: %s", command, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package mutagen
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// forwardListOutput is representative `mutagen forward list --template '{{json .}}'` output.
+const forwardListOutput = `[
+	{
+		"identifier": "forward_3rVpnHCuXGSwvbdCeQ2OyNVDTvOtj8UmBUF7xVV2bRy",
+		"version": 1,
+		"creationTime": "2024-05-01T10:00:00.123456Z",
+		"creatingVersion": "0.18.0",
+		"source": {"protocol": "local", "path": "tcp:localhost:8080", "connected": true},
+		"destination": {
+			"protocol": "ssh",
+			"user": "me",
+			"host": "server.example.com",
+			"path": "tcp:localhost:80",
+			"connected": true
+		},
+		"socket": {},
+		"name": "web",
+		"labels": {"env": "dev"},
+		"paused": false,
+		"status": "forwarding",
+		"openConnections": 2,
+		"totalConnections": 10,
+		"totalOutboundData": 1234,
+		"totalInboundData": 5678
+	},
+	{
+		"identifier": "forward_kT0zJ1c7GxG0JVvE8uAXQm9a2bGdbN3v0R6J9v0m4lA",
+		"version": 1,
+		"creationTime": "2024-05-01T10:05:00Z",
+		"creatingVersion": "0.18.0",
+		"source": {"protocol": "local", "path": "tcp:localhost:5432", "connected": false},
+		"destination": {
+			"protocol": "docker",
+			"host": "db",
+			"path": "tcp:localhost:5432",
+			"connected": false
+		},
+		"socket": {},
+		"paused": true,
+		"status": "disconnected",
+		"lastError": "unable to connect to destination",
+		"openConnections": 0,
+		"totalConnections": 0,
+		"totalOutboundData": 0,
+		"totalInboundData": 0
+	}
+]`
+
+func TestParseForwardsJSON(t *testing.T) {
+	var forwards []ForwardSession
+	if err := json.Unmarshal([]byte(forwardListOutput), &forwards); err != nil {
+		t.Fatalf("Failed to parse forwards: %v", err)
+	}
+	if len(forwards) != 2 {
+		t.Fatalf("Expected 2 forwards, got %d", len(forwards))
+	}
+
+	web := forwards[0]
+	if web.Name != "web" || web.DisplayName() != "web" {
+		t.Errorf("Name = %q, DisplayName() = %q, want web", web.Name, web.DisplayName())
+	}
+	if web.Source.DisplayPath() != "tcp:localhost:8080" {
+		t.Errorf("Source.DisplayPath() = %q", web.Source.DisplayPath())
+	}
+	if got, want := web.Destination.DisplayPath(), "me@server.example.com:tcp:localhost:80"; got != want {
+		t.Errorf("Destination.DisplayPath() = %q, want %q", got, want)
+	}
+	if web.Paused || web.StatusText() != "Forwarding" || web.OpenConnections != 2 {
+		t.Errorf("web = paused %v, status %q, %d open; want forwarding with 2 open", web.Paused, web.StatusText(), web.OpenConnections)
+	}
+	if web.Labels["env"] != "dev" {
+		t.Errorf("Labels = %v", web.Labels)
+	}
+
+	db := forwards[1]
+	if db.DisplayName() != db.Identifier {
+		t.Errorf("DisplayName() = %q, want the identifier for an unnamed forward", db.DisplayName())
+	}
+	if !db.Paused || db.StatusText() != "Paused" {
+		t.Errorf("StatusText() = %q, want Paused", db.StatusText())
+	}
+	if db.LastError != "unable to connect to destination" {
+		t.Errorf("LastError = %q", db.LastError)
+	}
+	if db.Destination.Connected {
+		t.Error("Destination.Connected = true, want false")
+	}
+}
+
+func TestForwardStatusText(t *testing.T) {
+	tests := []struct {
+		status string
+		want   string
+	}{
+		{"forwarding", "Forwarding"},
+		{"disconnected", "Disconnected"},
+		{"connecting-source", "Connecting source"},
+		{"connecting-destination", "Connecting destination"},
+		{"halted", "Halted"},
+		{"", "Unknown"},
+	}
+	for _, tt := range tests {
+		fwd := ForwardSession{Status: tt.status}
+		if got := fwd.StatusText(); got != tt.want {
+			t.Errorf("StatusText() for %q = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestListForwards(t *testing.T) {
+	mock := &mockCommandRunner{output: []byte(forwardListOutput)}
+	client := NewClientWithRunner(UniformTimeouts(time.Second), mock)
+
+	forwards, err := client.ListForwards(context.Background())
+	if err != nil {
+		t.Fatalf("ListForwards() error = %v", err)
+	}
+	if len(forwards) != 2 {
+		t.Errorf("got %d forwards, want 2", len(forwards))
+	}
+	wantArgs := []string{"forward", "list", "--template", "{{json .}}"}
+	if !equalArgs(mock.lastArgs, wantArgs) {
+		t.Errorf("args = %v, want %v", mock.lastArgs, wantArgs)
+	}
+}
+
+func TestListForwards_EmptyOutput(t *testing.T) {
+	for _, output := range []string{"", "null", "  \n"} {
+		client := NewClientWithRunner(UniformTimeouts(time.Second), &mockCommandRunner{output: []byte(output)})
+		forwards, err := client.ListForwards(context.Background())
+		if err != nil {
+			t.Fatalf("ListForwards() for %q error = %v", output, err)
+		}
+		if forwards == nil || len(forwards) != 0 {
+			t.Errorf("ListForwards() for %q = %v, want an empty slice", output, forwards)
+		}
+	}
+}
+
+func TestForwardCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(c *Client) error
+		want []string
+	}{
+		{"pause", func(c *Client) error { return c.PauseForward(context.Background(), "web") }, []string{"forward", "pause", "web"}},
+		{"resume", func(c *Client) error { return c.ResumeForward(context.Background(), "web") }, []string{"forward", "resume", "web"}},
+		{"terminate", func(c *Client) error { return c.TerminateForward(context.Background(), "web") }, []string{"forward", "terminate", "web"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockCommandRunner{}
+			if err := tt.run(NewClientWithRunner(UniformTimeouts(time.Second), mock)); err != nil {
+				t.Fatal(err)
+			}
+			if !equalArgs(mock.lastArgs, tt.want) {
+				t.Errorf("args = %v, want %v", mock.lastArgs, tt.want)
+			}
+		})
+	}
+}
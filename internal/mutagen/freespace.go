@@ -0,0 +1,70 @@
+package mutagen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// EndpointFreeSpace returns the bytes available to unprivileged users on
+// the filesystem holding a session's endpoint. A local endpoint is checked
+// with statfs; an ssh endpoint runs df on its host, using POSIX output so
+// that GNU and BSD df agree.
+func (c *Client) EndpointFreeSpace(ctx context.Context, e Endpoint) (uint64, error) {
+	switch {
+	case e.Protocol != "ssh" && !e.IsLocal():
+		return 0, fmt.Errorf("can't check free space on %s endpoints", e.Protocol)
+	case e.Protocol == "ssh" && e.Host == nil:
+		return 0, errors.New("ssh endpoint has no host")
+	}
+	if e.IsLocal() {
+		free, err := localFreeSpace(e.Path)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check free space on %s: %w", e.Path, err)
+		}
+		return free, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.List)
+	defer cancel()
+	output, err := c.runner.Output(ctx, "ssh", freeSpaceCommand(&e)...)
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			stderr := strings.TrimSpace(string(exitErr.Stderr))
+			return 0, WrapConnectionError(fmt.Sprintf("failed to check free space on %s: %s", *e.Host, stderr), stderr)
+		}
+		return 0, fmt.Errorf("failed to check free space on %s: %w", *e.Host, err)
+	}
+	return parseDFAvailable(output)
+}
+
+// freeSpaceCommand returns the ssh arguments that run df on an ssh
+// endpoint's path.
+func freeSpaceCommand(e *Endpoint) []string {
+	return sshArgs(e, "df -Pk -- "+remotePath(e.Path))
+}
+
+// parseDFAvailable returns the available bytes from the output of df -Pk:
+// a header, then a line whose fourth number is the available 1024-byte
+// blocks, followed by the capacity percentage. The filesystem name and
+// mount point may contain spaces, so the count is found by the capacity
+// that follows it.
+func parseDFAvailable(output []byte) (uint64, error) {
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) < 2 {
+		return 0, errors.New("df printed no filesystem")
+	}
+	fields := strings.Fields(lines[len(lines)-1])
+	for i := 4; i < len(fields); i++ {
+		if !strings.HasSuffix(fields[i], "%") {
+			continue
+		}
+		if blocks, err := strconv.ParseUint(fields[i-1], 10, 64); err == nil {
+			return blocks * 1024, nil
+		}
+	}
+	return 0, fmt.Errorf("can't read df output %q", lines[len(lines)-1])
+}
@@ -0,0 +1,10 @@
+//go:build !(linux || darwin)
+
+package mutagen
+
+import "errors"
+
+// localFreeSpace isn't supported on this platform.
+func localFreeSpace(string) (uint64, error) {
+	return 0, errors.New("checking free space isn't supported on this platform")
+}
@@ -0,0 +1,92 @@
+package mutagen
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestParseDFAvailable(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   uint64
+	}{
+		{"linux", "Filesystem     1024-blocks     Used Available Capacity Mounted on\n" +
+			"/dev/sda1        102400000 50000000  52400000      49% /\n", 52400000 * 1024},
+		{"macos", "Filesystem    1024-blocks      Used Available Capacity  Mounted on\n" +
+			"/dev/disk3s5   971350180 612345678 343210987    65%    /System/Volumes/Data\n", 343210987 * 1024},
+		{"spaces in the filesystem and mount point", "Filesystem 1024-blocks Used Available Capacity Mounted on\n" +
+			"//server/my share 2000 500 1500 25% /mnt/my share\n", 1500 * 1024},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDFAvailable([]byte(tt.output))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("parseDFAvailable() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDFAvailable_Malformed(t *testing.T) {
+	for _, output := range []string{"", "Filesystem 1024-blocks Used Available Capacity Mounted on\n", "header\nnot df output\n"} {
+		if _, err := parseDFAvailable([]byte(output)); err == nil {
+			t.Errorf("parseDFAvailable(%q) succeeded, want an error", output)
+		}
+	}
+}
+
+func TestEndpointFreeSpace_SSH(t *testing.T) {
+	runner := &mockCommandRunner{output: []byte("Filesystem 1024-blocks Used Available Capacity Mounted on\n/dev/sda1 4000 1000 3000 25% /\n")}
+	client := NewClientWithRunner(UniformTimeouts(time.Second), runner)
+
+	host := "dev"
+	free, err := client.EndpointFreeSpace(context.Background(), Endpoint{Protocol: "ssh", User: "me", Host: &host, Port: 2222, Path: "~/web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if free != 3000*1024 {
+		t.Errorf("free = %d, want %d", free, 3000*1024)
+	}
+	want := []string{"-o", "ConnectTimeout=5", "-o", "BatchMode=yes", "-p", "2222", "me@dev", "df -Pk -- ~/'web'"}
+	if runner.lastName != "ssh" || !reflect.DeepEqual(runner.lastArgs, want) {
+		t.Errorf("ran %s %q, want ssh %q", runner.lastName, runner.lastArgs, want)
+	}
+}
+
+func TestEndpointFreeSpace_Local(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("statfs isn't supported on " + runtime.GOOS)
+	}
+	runner := &mockCommandRunner{}
+	client := NewClientWithRunner(UniformTimeouts(time.Second), runner)
+
+	free, err := client.EndpointFreeSpace(context.Background(), Endpoint{Protocol: "local", Path: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if free == 0 {
+		t.Error("free = 0, want the temp directory's free space")
+	}
+	if runner.lastName != "" {
+		t.Errorf("ran %s for a local endpoint", runner.lastName)
+	}
+
+	if _, err := client.EndpointFreeSpace(context.Background(), Endpoint{Protocol: "local", Path: "/no/such/dir"}); err == nil {
+		t.Error("EndpointFreeSpace(/no/such/dir) succeeded, want an error")
+	}
+}
+
+func TestEndpointFreeSpace_Docker(t *testing.T) {
+	client := NewClientWithRunner(UniformTimeouts(time.Second), &mockCommandRunner{})
+	host := "box"
+	if _, err := client.EndpointFreeSpace(context.Background(), Endpoint{Protocol: "docker", Host: &host, Path: "/app"}); err == nil {
+		t.Error("EndpointFreeSpace(docker://box/app) succeeded, want an error")
+	}
+}
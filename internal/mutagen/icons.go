@@ -0,0 +1,25 @@
+package mutagen
+
+import "sync/atomic"
+
+// asciiIcons is set when status icons should be drawn in plain ASCII.
+var asciiIcons atomic.Bool
+
+// SetASCIIIcons chooses ASCII status icons, for terminals that draw emoji
+// and other symbols as boxes, or the usual Unicode ones.
+func SetASCIIIcons(on bool) {
+	asciiIcons.Store(on)
+}
+
+// ASCIIIcons reports whether status icons are drawn in ASCII.
+func ASCIIIcons() bool {
+	return asciiIcons.Load()
+}
+
+// Icon returns symbol, or its ASCII stand-in when ASCII icons are on.
+func Icon(symbol, ascii string) string {
+	if asciiIcons.Load() {
+		return ascii
+	}
+	return symbol
+}
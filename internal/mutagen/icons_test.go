@@ -0,0 +1,62 @@
+package mutagen
+
+import "testing"
+
+// sessionStatuses has one status for each session icon.
+var sessionStatuses = []string{
+	"watching", "scanning", "staging-beta", "reconciling", "saving",
+	"connecting-alpha", "transitioning", "halted-on-root-emptied", "",
+}
+
+func withASCIIIcons(t *testing.T, on bool) {
+	t.Helper()
+	SetASCIIIcons(on)
+	t.Cleanup(func() { SetASCIIIcons(false) })
+}
+
+func TestStatusIcon_ASCII(t *testing.T) {
+	withASCIIIcons(t, true)
+	for _, status := range sessionStatuses {
+		icon := (&SyncSession{Status: status}).StatusIcon()
+		if len(icon) != 1 || icon[0] < ' ' || icon[0] > '~' {
+			t.Errorf("status %q: icon %q isn't one ASCII character", status, icon)
+		}
+	}
+	endpoints := []Endpoint{{}, {Connected: true}, {Connected: true, Scanned: true}}
+	for _, e := range endpoints {
+		if icon := e.StatusIcon(); len(icon) != 1 {
+			t.Errorf("endpoint %+v: icon %q isn't one ASCII character", e, icon)
+		}
+	}
+	if icon := (&ForwardSession{Paused: true}).StatusIcon(); icon != "|" {
+		t.Errorf("paused forward icon = %q, want |", icon)
+	}
+}
+
+func TestStatusIcon_Distinct(t *testing.T) {
+	for _, ascii := range []bool{false, true} {
+		withASCIIIcons(t, ascii)
+		seen := make(map[string]string)
+		for _, status := range sessionStatuses {
+			icon := (&SyncSession{Status: status}).StatusIcon()
+			if other, ok := seen[icon]; ok {
+				t.Errorf("ascii=%v: %q and %q share the icon %q", ascii, other, status, icon)
+			}
+			seen[icon] = status
+		}
+
+		seen = make(map[string]string)
+		endpoints := map[string]Endpoint{
+			"disconnected": {},
+			"scanning":     {Connected: true},
+			"scanned":      {Connected: true, Scanned: true},
+		}
+		for state, e := range endpoints {
+			icon := e.StatusIcon()
+			if other, ok := seen[icon]; ok {
+				t.Errorf("ascii=%v: endpoints %s and %s share the icon %q", ascii, other, state, icon)
+			}
+			seen[icon] = state
+		}
+	}
+}
@@ -2,6 +2,9 @@ package mutagen
 
 import (
 	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -198,6 +201,56 @@ func TestParseSessionsJSON_WithEndpointStats(t *testing.T) {
 	}
 }
 
+func TestParseSessionsJSON_HaltedSession(t *testing.T) {
+	input := `[{
+		"name": "halted",
+		"identifier": "id",
+		"alpha": {"protocol": "local", "path": "/src", "connected": true, "scanned": true},
+		"beta": {
+			"protocol": "ssh",
+			"path": "/dst",
+			"host": "server",
+			"connected": true,
+			"scanned": true,
+			"transitionProblems": [
+				{"path": "logs/app.log", "error": "unable to replace file: permission denied"},
+				{"path": "tmp", "error": "unable to remove directory: directory not empty"}
+			]
+		},
+		"status": "halted-on-root-deletion",
+		"lastError": "beta root deleted; halting to avoid propagating deletion",
+		"paused": false,
+		"conflicts": []
+	}]`
+
+	var sessions []SyncSession
+	if err := json.Unmarshal([]byte(input), &sessions); err != nil {
+		t.Fatalf("Failed to parse halted session: %v", err)
+	}
+
+	s := sessions[0]
+	if !s.IsHalted() {
+		t.Errorf("IsHalted() = false for status %q", s.Status)
+	}
+	if s.StatusText() != "Halted" {
+		t.Errorf("StatusText() = %q, want Halted", s.StatusText())
+	}
+	if len(s.Beta.TransitionProblems) != 2 || s.Beta.TransitionProblems[1].Path != "tmp" {
+		t.Errorf("Beta.TransitionProblems = %+v", s.Beta.TransitionProblems)
+	}
+	want := []string{
+		"beta root deleted; halting to avoid propagating deletion",
+		"β logs/app.log: unable to replace file: permission denied",
+		"β tmp: unable to remove directory: directory not empty",
+	}
+	if got := s.Problems(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Problems() = %q, want %q", got, want)
+	}
+	if got := s.HaltReason(); got != want[0] {
+		t.Errorf("HaltReason() = %q, want %q", got, want[0])
+	}
+}
+
 func TestParseSessionsJSON_WithStagingProgress(t *testing.T) {
 	input := `[{
 		"name": "test",
@@ -435,6 +488,14 @@ func TestParseSessionsJSON_RealMutagenOutput(t *testing.T) {
 		t.Error("Paused = true, want false")
 	}
 
+	// Check versions
+	if s.Version != 1 {
+		t.Errorf("Version = %d, want 1", s.Version)
+	}
+	if s.CreatingVersion != "0.18.1" {
+		t.Errorf("CreatingVersion = %q, want 0.18.1", s.CreatingVersion)
+	}
+
 	// Check mode
 	if s.Mode == nil || *s.Mode != "one-way-replica" {
 		t.Errorf("Mode = %v, want one-way-replica", s.Mode)
@@ -471,6 +532,21 @@ func TestParseSessionsJSON_RealMutagenOutput(t *testing.T) {
 		t.Errorf("Beta.Path = %q, want ~/code/research", s.Beta.Path)
 	}
 
+	// Check scan problems
+	if len(s.Alpha.ScanProblems) != 1 {
+		t.Fatalf("Alpha.ScanProblems = %v, want one problem", s.Alpha.ScanProblems)
+	}
+	if p := s.Alpha.ScanProblems[0]; p.Path != "bucket-ans-gpu/.direnv/python-3.11/bin/python3" ||
+		p.Error != "invalid symbolic link: target is absolute" {
+		t.Errorf("Alpha.ScanProblems[0] = %+v", p)
+	}
+	if s.Beta.HasScanProblems() {
+		t.Errorf("Beta.ScanProblems = %v, want none", s.Beta.ScanProblems)
+	}
+	if !s.HasScanProblems() {
+		t.Error("HasScanProblems() = false, want true")
+	}
+
 	// Check successful cycles
 	if s.SuccessfulCycles == nil || *s.SuccessfulCycles != 323 {
 		t.Errorf("SuccessfulCycles = %v, want 323", s.SuccessfulCycles)
@@ -480,6 +556,52 @@ func TestParseSessionsJSON_RealMutagenOutput(t *testing.T) {
 	if s.CreationTime == nil || *s.CreationTime != "2025-12-17T05:24:51.866018Z" {
 		t.Errorf("CreationTime = %v, want 2025-12-17T05:24:51.866018Z", s.CreationTime)
 	}
+
+	// Check the configuration; the empty objects are mutagen's defaults
+	config := s.Config()
+	if config.Mode != "one-way-replica" {
+		t.Errorf("Config().Mode = %q, want one-way-replica", config.Mode)
+	}
+	if want := []string{".env", ".venv", "node_modules", "__pycache__"}; !slices.Equal(config.IgnorePaths, want) {
+		t.Errorf("Config().IgnorePaths = %v, want %v", config.IgnorePaths, want)
+	}
+	if config.IgnoreVCS != "" || config.SymlinkMode != "" || config.WatchMode != "" ||
+		config.PermissionsMode != "" || config.Compression != "" {
+		t.Errorf("Config() = %+v, want defaults for the empty objects", config)
+	}
+}
+
+func TestParseSessionsJSON_SessionConfig(t *testing.T) {
+	input := `{
+		"name": "configured",
+		"mode": "two-way-resolved",
+		"ignore": {"paths": ["build"], "vcs": "propagate"},
+		"symlink": {"mode": "posix-raw"},
+		"watch": {"mode": "force-poll", "pollingInterval": 20},
+		"permissions": {"mode": "manual"},
+		"compression": {"algorithm": "zstandard"}
+	}`
+
+	var s SyncSession
+	if err := json.Unmarshal([]byte(input), &s); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if s.Watch == nil || s.Watch.PollingInterval != 20 {
+		t.Errorf("Watch = %+v, want a polling interval of 20", s.Watch)
+	}
+
+	want := SessionConfig{
+		Mode:            "two-way-resolved",
+		IgnorePaths:     []string{"build"},
+		IgnoreVCS:       "propagate",
+		SymlinkMode:     "posix-raw",
+		WatchMode:       "force-poll",
+		PermissionsMode: "manual",
+		Compression:     "zstandard",
+	}
+	if got := s.Config(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Config() = %+v, want %+v", got, want)
+	}
 }
 
 func TestParseSessionsJSON_MultipleSessions(t *testing.T) {
@@ -569,3 +691,28 @@ func TestParseSessionsJSON_WithConflicts(t *testing.T) {
 		t.Errorf("Conflicts[0].Root = %q, want file1.txt", s.Conflicts[0].Root)
 	}
 }
+
+func TestCompression(t *testing.T) {
+	var sessions []SyncSession
+	input := `[
+		{"name": "a", "compression": {}},
+		{"name": "b", "compression": {"algorithm": "zstandard", "bytesOnWire": 1000, "bytesTransferred": 3100}}
+	]`
+	if err := json.Unmarshal([]byte(input), &sessions); err != nil {
+		t.Fatal(err)
+	}
+
+	if !sessions[0].Compression.IsEmpty() {
+		t.Error("an empty compression object should be empty")
+	}
+	if _, ok := sessions[0].Compression.Ratio(); ok {
+		t.Error("Ratio() should be unknown without byte counts")
+	}
+	c := sessions[1].Compression
+	if c.IsEmpty() || c.Algorithm != "zstandard" {
+		t.Errorf("Compression = %+v", c)
+	}
+	if ratio, ok := c.Ratio(); !ok || ratio != 3.1 {
+		t.Errorf("Ratio() = %v, %v, want 3.1", ratio, ok)
+	}
+}
@@ -0,0 +1,100 @@
+package mutagen
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Conflict winners for ResolveConflict.
+const (
+	WinnerAlpha = "alpha"
+	WinnerBeta  = "beta"
+)
+
+// ResolveConflict resolves a single conflict in favor of winner (WinnerAlpha
+// or WinnerBeta) by removing the conflicting path from the losing endpoint,
+// then flushing the session. In two-way-safe mode Mutagen lets a
+// modification override a deletion, so the next cycle copies the winner's
+// version over.
+func (c *Client) ResolveConflict(ctx context.Context, session *SyncSession, conflict Conflict, winner string) error {
+	name, args, err := conflictRemovalCommand(session, conflict, winner)
+	if err != nil {
+		return err
+	}
+
+	rmCtx, cancel := context.WithTimeout(ctx, c.timeouts.Mutate)
+	defer cancel()
+	if output, err := c.runner.CombinedOutput(rmCtx, name, args...); err != nil {
+		return WrapConnectionError(fmt.Sprintf("failed to remove %s: %s", conflict.Root, strings.TrimSpace(string(output))), string(output))
+	}
+	return c.FlushSession(ctx, session.Name)
+}
+
+// conflictRemovalCommand returns the command that removes a conflict's path
+// from the endpoint that loses to winner: rm for a local endpoint, or rm run
+// over ssh for a remote one.
+func conflictRemovalCommand(session *SyncSession, conflict Conflict, winner string) (string, []string, error) {
+	var loser *Endpoint
+	switch winner {
+	case WinnerAlpha:
+		loser = &session.Beta
+	case WinnerBeta:
+		loser = &session.Alpha
+	default:
+		return "", nil, fmt.Errorf("unknown conflict winner %q", winner)
+	}
+
+	root := path.Clean(conflict.Root)
+	if root == "." || root == "/" || root == ".." || strings.HasPrefix(root, "../") || path.IsAbs(root) {
+		return "", nil, fmt.Errorf("can't resolve a conflict at %q file by file", conflict.Root)
+	}
+	target := path.Join(loser.Path, root)
+
+	switch {
+	case loser.IsLocal():
+		return "rm", []string{"-rf", "--", target}, nil
+	case loser.Protocol == "ssh" && loser.Host != nil:
+		return "ssh", sshArgs(loser, "rm -rf -- "+remotePath(target)), nil
+	default:
+		return "", nil, fmt.Errorf("can't remove files on %s endpoints", loser.Protocol)
+	}
+}
+
+// SSHConnectTimeout is how long an SSH endpoint's host has to answer
+// before it is reported unreachable.
+const SSHConnectTimeout = 5 * time.Second
+
+// SSHOptions returns the options mutagui runs ssh with: a host gets
+// SSHConnectTimeout to answer, and BatchMode keeps ssh from prompting,
+// which it can't do under the TUI.
+func SSHOptions() []string {
+	return []string{"-o", fmt.Sprintf("ConnectTimeout=%d", int(SSHConnectTimeout.Seconds())), "-o", "BatchMode=yes"}
+}
+
+// sshArgs returns the ssh arguments that run command on an ssh endpoint's
+// host, as its user and on its port if it names them.
+func sshArgs(e *Endpoint, command string) []string {
+	args := SSHOptions()
+	if e.Port != 0 {
+		args = append(args, "-p", strconv.FormatUint(uint64(e.Port), 10))
+	}
+	host := *e.Host
+	if e.User != "" {
+		host = e.User + "@" + host
+	}
+	return append(args, host, command)
+}
+
+// remotePath quotes p for the remote shell, leaving a leading ~/ unquoted
+// so the shell expands it.
+func remotePath(p string) string {
+	quoted := func(s string) string { return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'" }
+	if rest, ok := strings.CutPrefix(p, "~/"); ok {
+		return "~/" + quoted(rest)
+	}
+	return quoted(p)
+}
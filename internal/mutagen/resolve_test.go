@@ -0,0 +1,114 @@
+package mutagen
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func conflictSession() *SyncSession {
+	return &SyncSession{
+		Name:  "web",
+		Alpha: Endpoint{Protocol: "local", Path: "/Users/me/web"},
+		Beta:  Endpoint{Protocol: "ssh", Host: strPtr("dev"), User: "me", Path: "~/web"},
+	}
+}
+
+func TestConflictRemovalCommand(t *testing.T) {
+	session := conflictSession()
+	tests := []struct {
+		name     string
+		root     string
+		winner   string
+		wantName string
+		wantArgs []string
+	}{
+		{"alpha wins removes from beta over ssh", "src/app.go", WinnerAlpha,
+			"ssh", []string{"-o", "ConnectTimeout=5", "-o", "BatchMode=yes", "me@dev", "rm -rf -- ~/'web/src/app.go'"}},
+		{"beta wins removes from local alpha", "src/app.go", WinnerBeta,
+			"rm", []string{"-rf", "--", "/Users/me/web/src/app.go"}},
+		{"path is cleaned", "./src//it's.txt", WinnerAlpha,
+			"ssh", []string{"-o", "ConnectTimeout=5", "-o", "BatchMode=yes", "me@dev", `rm -rf -- ~/'web/src/it'\''s.txt'`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, args, err := conflictRemovalCommand(session, Conflict{Root: tt.root}, tt.winner)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name != tt.wantName || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("command = %s %q, want %s %q", name, args, tt.wantName, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestConflictRemovalCommand_SSHPort(t *testing.T) {
+	session := conflictSession()
+	session.Beta.Port = 2222
+	session.Beta.Path = "/srv/web"
+	_, args, err := conflictRemovalCommand(session, Conflict{Root: "a"}, WinnerAlpha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"-o", "ConnectTimeout=5", "-o", "BatchMode=yes", "-p", "2222", "me@dev", "rm -rf -- '/srv/web/a'"}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %q, want %q", args, want)
+	}
+}
+
+func TestConflictRemovalCommand_Refuses(t *testing.T) {
+	session := conflictSession()
+	for _, root := range []string{"", ".", "/", "../web", "/etc/passwd"} {
+		if _, _, err := conflictRemovalCommand(session, Conflict{Root: root}, WinnerBeta); err == nil {
+			t.Errorf("root %q: expected an error", root)
+		}
+	}
+	if _, _, err := conflictRemovalCommand(session, Conflict{Root: "a"}, "both"); err == nil {
+		t.Error("unknown winner: expected an error")
+	}
+	session.Beta = Endpoint{Protocol: "docker", Path: "/app"}
+	if _, _, err := conflictRemovalCommand(session, Conflict{Root: "a"}, WinnerAlpha); err == nil {
+		t.Error("docker endpoint: expected an error")
+	}
+}
+
+// recordingRunner records each command line it runs.
+type recordingRunner struct {
+	commands []string
+	err      error
+}
+
+func (r *recordingRunner) Output(_ context.Context, name string, args ...string) ([]byte, error) {
+	r.commands = append(r.commands, name+" "+strings.Join(args, " "))
+	return nil, r.err
+}
+
+func (r *recordingRunner) CombinedOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return r.Output(ctx, name, args...)
+}
+
+func TestClient_ResolveConflict(t *testing.T) {
+	runner := &recordingRunner{}
+	client := NewClientWithRunner(UniformTimeouts(time.Minute), runner)
+	if err := client.ResolveConflict(context.Background(), conflictSession(), Conflict{Root: "notes.md"}, WinnerBeta); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"rm -rf -- /Users/me/web/notes.md", "mutagen sync flush web"}
+	if !reflect.DeepEqual(runner.commands, want) {
+		t.Errorf("commands = %q, want %q", runner.commands, want)
+	}
+
+	// A failed removal doesn't flush
+	runner = &recordingRunner{err: errors.New("exit status 1")}
+	client = NewClientWithRunner(UniformTimeouts(time.Minute), runner)
+	if err := client.ResolveConflict(context.Background(), conflictSession(), Conflict{Root: "notes.md"}, WinnerAlpha); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(runner.commands) != 1 {
+		t.Errorf("commands = %q, want only the removal", runner.commands)
+	}
+}
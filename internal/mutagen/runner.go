@@ -0,0 +1,145 @@
+package mutagen
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// CommandRunner executes external commands on behalf of the Client.
+// Substituting a runner lets tests capture invocations and lets dry-run
+// mode skip commands that would change sync state.
+type CommandRunner interface {
+	// Output runs the command and returns its standard output.
+	// On a non-zero exit, the error is an *exec.ExitError carrying stderr.
+	Output(ctx context.Context, name string, args ...string) ([]byte, error)
+	// CombinedOutput runs the command and returns its combined stdout and stderr.
+	CombinedOutput(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// StreamRunner is implemented by runners that can stream a long-running
+// command's output, such as `mutagen sync monitor`.
+type StreamRunner interface {
+	// Stream starts the command and returns its standard output, which ends
+	// when the command exits. Canceling ctx kills the command; closing the
+	// reader waits for it to exit.
+	Stream(ctx context.Context, name string, args ...string) (io.ReadCloser, error)
+}
+
+// ExecRunner runs commands with os/exec.
+type ExecRunner struct{}
+
+// Output runs the command and returns its standard output.
+func (ExecRunner) Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output()
+}
+
+// CombinedOutput runs the command and returns its combined stdout and stderr.
+func (ExecRunner) CombinedOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).CombinedOutput()
+}
+
+// Stream starts the command and returns its standard output.
+func (ExecRunner) Stream(ctx context.Context, name string, args ...string) (io.ReadCloser, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &commandStream{ReadCloser: stdout, cmd: cmd}, nil
+}
+
+// commandStream is a running command's standard output.
+type commandStream struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+// Close closes the output and waits for the command to exit. An exit
+// caused by the closed output or a canceled context is not an error.
+func (s *commandStream) Close() error {
+	s.ReadCloser.Close()
+	s.cmd.Wait()
+	return nil
+}
+
+// errNoStreaming is returned when the runner can't stream command output.
+var errNoStreaming = errors.New("command runner can't stream output")
+
+// DryRunRunner passes read-only commands (listing, version queries) through to
+// another runner, and reports every other command instead of running it.
+type DryRunRunner struct {
+	next   CommandRunner
+	report func(cmdline string)
+}
+
+// NewDryRunRunner creates a DryRunRunner. Read-only commands run via next;
+// mutating commands are passed to report as a shell-like command line.
+func NewDryRunRunner(next CommandRunner, report func(cmdline string)) *DryRunRunner {
+	return &DryRunRunner{next: next, report: report}
+}
+
+// Output runs read-only commands and reports mutating ones.
+func (r *DryRunRunner) Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	if isReadOnlyCommand(name, args) {
+		return r.next.Output(ctx, name, args...)
+	}
+	r.report(formatCommandLine(name, args))
+	return nil, nil
+}
+
+// CombinedOutput runs read-only commands and reports mutating ones.
+func (r *DryRunRunner) CombinedOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	if isReadOnlyCommand(name, args) {
+		return r.next.CombinedOutput(ctx, name, args...)
+	}
+	r.report(formatCommandLine(name, args))
+	return nil, nil
+}
+
+// Stream streams read-only commands via next; mutating commands can't be
+// streamed, since they would have to be reported rather than run.
+func (r *DryRunRunner) Stream(ctx context.Context, name string, args ...string) (io.ReadCloser, error) {
+	next, ok := r.next.(StreamRunner)
+	if !ok || !isReadOnlyCommand(name, args) {
+		return nil, errNoStreaming
+	}
+	return next.Stream(ctx, name, args...)
+}
+
+// isReadOnlyCommand returns true for mutagen subcommands that only query
+// state, and for the df that checks an ssh endpoint's free space.
+func isReadOnlyCommand(name string, args []string) bool {
+	if name == "ssh" {
+		return len(args) > 0 && strings.HasPrefix(args[len(args)-1], "df ")
+	}
+	if len(args) == 0 {
+		return true
+	}
+	switch args[0] {
+	case "version":
+		return true
+	case "sync", "forward":
+		return len(args) > 1 && (args[1] == "list" || args[1] == "monitor")
+	}
+	return false
+}
+
+// formatCommandLine renders a command and its arguments for display,
+// quoting arguments that contain spaces.
+func formatCommandLine(name string, args []string) string {
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, name)
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t'\"") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
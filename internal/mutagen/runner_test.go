@@ -0,0 +1,71 @@
+package mutagen
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestIsReadOnlyCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{"mutagen", []string{"version"}, true},
+		{"mutagen", []string{"sync", "list", "--template", "{{json .}}"}, true},
+		{"mutagen", []string{"sync", "monitor", "name"}, true},
+		{"mutagen", []string{"forward", "list"}, true},
+		{"mutagen", []string{"sync", "create", "a", "b"}, false},
+		{"mutagen", []string{"sync", "terminate", "name"}, false},
+		{"mutagen", []string{"project", "start", "-f", "mutagen.yml"}, false},
+		{"mutagen", []string{"daemon", "stop"}, false},
+		{"ssh", []string{"-p", "2222", "me@dev", "df -Pk -- '/srv'"}, true},
+		{"ssh", []string{"me@dev", "rm -rf -- '/srv/a'"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := isReadOnlyCommand(tt.name, tt.args); got != tt.want {
+			t.Errorf("isReadOnlyCommand(%s, %v) = %v, want %v", tt.name, tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCommandLine(t *testing.T) {
+	got := formatCommandLine("mutagen", []string{"sync", "create", "/a b", "host:/c", "--name", "x"})
+	want := "mutagen sync create '/a b' host:/c --name x"
+	if got != want {
+		t.Errorf("formatCommandLine() = %q, want %q", got, want)
+	}
+}
+
+func TestDryRunRunner_ReportsMutations(t *testing.T) {
+	next := &mockCommandRunner{}
+	var reported []string
+	runner := NewDryRunRunner(next, func(cmdline string) { reported = append(reported, cmdline) })
+	client := NewClientWithRunner(UniformTimeouts(time.Second), runner)
+
+	if err := client.TerminateSession(context.Background(), "my-sync"); err != nil {
+		t.Fatalf("TerminateSession() error = %v", err)
+	}
+	if next.lastArgs != nil {
+		t.Errorf("mutating command reached the real runner: %v", next.lastArgs)
+	}
+	if len(reported) != 1 || reported[0] != "mutagen sync terminate my-sync" {
+		t.Errorf("reported = %v, want the terminate command", reported)
+	}
+}
+
+func TestDryRunRunner_PassesThroughQueries(t *testing.T) {
+	next := &mockCommandRunner{output: []byte(`[{"name":"a"}]`)}
+	runner := NewDryRunRunner(next, func(string) { t.Error("query should not be reported") })
+	client := NewClientWithRunner(UniformTimeouts(time.Second), runner)
+
+	sessions, err := client.ListSessions(context.Background())
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Errorf("len(sessions) = %d, want 1", len(sessions))
+	}
+}
@@ -3,6 +3,8 @@ package mutagen
 
 import (
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -48,11 +50,66 @@ type StagingProgress struct {
 	TotalReceivedSize *uint64 `json:"totalReceivedSize,omitempty"`
 }
 
+// Compression describes compression on a session's network transport. The
+// algorithm is session configuration; the byte counts are only present if
+// the installed mutagen reports them.
+type Compression struct {
+	Algorithm        string  `json:"algorithm,omitempty"`
+	BytesOnWire      *uint64 `json:"bytesOnWire,omitempty"`
+	BytesTransferred *uint64 `json:"bytesTransferred,omitempty"` // Uncompressed size of the same data
+}
+
+// IsEmpty returns true if there is nothing to report, as for the empty
+// object that mutagen writes when compression is left at its default.
+func (c *Compression) IsEmpty() bool {
+	return c == nil || (c.Algorithm == "" && c.BytesOnWire == nil && c.BytesTransferred == nil)
+}
+
+// Ratio returns how many bytes were transferred per byte sent over the
+// network, or false if the byte counts aren't known.
+func (c *Compression) Ratio() (float64, bool) {
+	if c == nil || c.BytesOnWire == nil || c.BytesTransferred == nil || *c.BytesOnWire == 0 {
+		return 0, false
+	}
+	return float64(*c.BytesTransferred) / float64(*c.BytesOnWire), true
+}
+
+// ScanProblem is a file an endpoint could not scan, such as a broken
+// symbolic link or an unreadable directory.
+type ScanProblem struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// IgnoreConfiguration is a session's ignore settings.
+type IgnoreConfiguration struct {
+	Paths []string `json:"paths,omitempty"`
+	VCS   string   `json:"vcs,omitempty"` // VCS ignore mode: "ignore", "propagate", or empty for the default
+}
+
+// SymlinkConfiguration is a session's symbolic link settings.
+type SymlinkConfiguration struct {
+	Mode string `json:"mode,omitempty"` // Empty for the default
+}
+
+// WatchConfiguration is a session's filesystem watching settings.
+type WatchConfiguration struct {
+	Mode            string `json:"mode,omitempty"` // Empty for the default
+	PollingInterval uint32 `json:"pollingInterval,omitempty"`
+}
+
+// PermissionsConfiguration is a session's permission propagation settings.
+type PermissionsConfiguration struct {
+	Mode string `json:"mode,omitempty"` // Empty for the default
+}
+
 // Endpoint represents a sync endpoint (local or remote).
 type Endpoint struct {
 	Protocol        string           `json:"protocol"`
 	Path            string           `json:"path"`
 	Host            *string          `json:"host,omitempty"`
+	User            string           `json:"user,omitempty"`
+	Port            uint32           `json:"port,omitempty"`
 	Connected       bool             `json:"connected"`
 	Scanned         bool             `json:"scanned"`
 	Directories     *uint64          `json:"directories,omitempty"`
@@ -60,6 +117,17 @@ type Endpoint struct {
 	SymbolicLinks   *uint64          `json:"symbolicLinks,omitempty"`
 	TotalFileSize   *uint64          `json:"totalFileSize,omitempty"`
 	StagingProgress *StagingProgress `json:"stagingProgress,omitempty"`
+	Compression     *Compression     `json:"compression,omitempty"`
+	ScanProblems    []ScanProblem    `json:"scanProblems,omitempty"`
+
+	// TransitionProblems are files the endpoint could not write while
+	// applying changes, reported in the same form as scan problems
+	TransitionProblems []ScanProblem `json:"transitionProblems,omitempty"`
+}
+
+// IsLocal returns true if the endpoint is on the local filesystem.
+func (e *Endpoint) IsLocal() bool {
+	return e.Protocol == "local"
 }
 
 // DisplayPath returns the endpoint path with host prefix if remote.
@@ -71,24 +139,51 @@ func (e *Endpoint) DisplayPath() string {
 	return path
 }
 
-// PathWithTilde replaces the home directory prefix with ~ for display.
-func (e *Endpoint) PathWithTilde() string {
-	home := homeDir()
-	if home != "" && len(e.Path) >= len(home) && e.Path[:len(home)] == home {
-		return "~" + e.Path[len(home):]
+// URL returns the endpoint in the form the mutagen command line takes: a
+// local path, [user@]host[:port]:path for ssh, or protocol://host/path for
+// the other transports.
+func (e *Endpoint) URL() string {
+	host := ""
+	if e.Host != nil {
+		host = *e.Host
+	}
+	switch {
+	case e.IsLocal():
+		return e.Path
+	case e.Protocol == "ssh":
+		if e.User != "" {
+			host = e.User + "@" + host
+		}
+		if e.Port != 0 {
+			host += ":" + strconv.FormatUint(uint64(e.Port), 10)
+		}
+		return host + ":" + e.Path
+	default:
+		return e.Protocol + "://" + host + e.Path
 	}
-	return e.Path
+}
+
+// PathWithTilde replaces the home directory prefix with ~ for display, or
+// a longer prefix with its replacement from the path abbreviations.
+func (e *Endpoint) PathWithTilde() string {
+	path, _ := AbbreviatePath(e.Path)
+	return path
+}
+
+// HasScanProblems returns true if the endpoint reported files it could not scan.
+func (e *Endpoint) HasScanProblems() bool {
+	return len(e.ScanProblems) > 0
 }
 
 // StatusIcon returns a visual indicator for the endpoint connection status.
 func (e *Endpoint) StatusIcon() string {
 	if !e.Connected {
-		return "⊗"
+		return Icon("⊗", "x")
 	}
 	if !e.Scanned {
-		return "⟳"
+		return Icon("⟳", "~")
 	}
-	return "✓"
+	return Icon("✓", "+")
 }
 
 // SyncSession represents a Mutagen sync session.
@@ -101,10 +196,184 @@ type SyncSession struct {
 	Status           string            `json:"status"`
 	Paused           bool              `json:"paused"`
 	Mode             *string           `json:"mode,omitempty"`
+	Version          int               `json:"version"` // Session format version
 	CreationTime     *string           `json:"creationTime,omitempty"`
+	CreatingVersion  string            `json:"creatingVersion"` // Mutagen release that created the session
 	SuccessfulCycles *uint64           `json:"successfulCycles,omitempty"`
 	Conflicts        []Conflict        `json:"conflicts"`
+	Compression      *Compression      `json:"compression,omitempty"`
 	SyncTime         SyncTime          `json:"-"` // Not from JSON, tracked internally
+
+	// LastError is the most recent synchronization error, such as why the
+	// session halted
+	LastError string `json:"lastError,omitempty"`
+
+	// Settings the session was created with
+	Ignore      *IgnoreConfiguration      `json:"ignore,omitempty"`
+	Symlink     *SymlinkConfiguration     `json:"symlink,omitempty"`
+	Watch       *WatchConfiguration       `json:"watch,omitempty"`
+	Permissions *PermissionsConfiguration `json:"permissions,omitempty"`
+}
+
+// SessionConfig is the configuration of a session: how a running one was
+// created, or how a project file would create one. Empty values stand for
+// mutagen's defaults.
+type SessionConfig struct {
+	Mode            string
+	IgnorePaths     []string
+	IgnoreVCS       string // "ignore", "propagate", or empty for the default
+	SymlinkMode     string
+	WatchMode       string
+	PermissionsMode string
+	Compression     string // Algorithm
+}
+
+// ConfigSetting is one setting of a SessionConfig, for display.
+type ConfigSetting struct {
+	Name  string
+	Value string
+}
+
+// Config returns the configuration the session is running with, as mutagen
+// reports it. Endpoint-specific overrides aren't included.
+func (s *SyncSession) Config() SessionConfig {
+	var config SessionConfig
+	if s.Mode != nil {
+		config.Mode = *s.Mode
+	}
+	if s.Ignore != nil {
+		config.IgnorePaths = s.Ignore.Paths
+		if s.Ignore.VCS != "default" {
+			config.IgnoreVCS = s.Ignore.VCS
+		}
+	}
+	if s.Symlink != nil {
+		config.SymlinkMode = s.Symlink.Mode
+	}
+	if s.Watch != nil {
+		config.WatchMode = s.Watch.Mode
+	}
+	if s.Permissions != nil {
+		config.PermissionsMode = s.Permissions.Mode
+	}
+	if s.Compression != nil {
+		config.Compression = s.Compression.Algorithm
+	}
+	return config
+}
+
+// Settings lists the configuration in display order, with "default" for
+// unset values. Ignore patterns keep their order, which mutagen applies
+// them in.
+func (c SessionConfig) Settings() []ConfigSetting {
+	value := func(v string) string {
+		if v == "" {
+			return "default"
+		}
+		return v
+	}
+	ignores := "(none)"
+	if len(c.IgnorePaths) > 0 {
+		ignores = strings.Join(c.IgnorePaths, ", ")
+	}
+	return []ConfigSetting{
+		{"Mode", value(c.Mode)},
+		{"Ignores", ignores},
+		{"VCS", value(c.IgnoreVCS)},
+		{"Symlinks", value(c.SymlinkMode)},
+		{"Watch", value(c.WatchMode)},
+		{"Permissions", value(c.PermissionsMode)},
+		{"Compression", value(c.Compression)},
+	}
+}
+
+// FileSettings lists the settings that a project file can give, as
+// Settings does. Compression is left out: mutagen chooses it for the
+// transport, so the configuration of a file never has one.
+func (c SessionConfig) FileSettings() []ConfigSetting {
+	return slices.DeleteFunc(c.Settings(), func(s ConfigSetting) bool { return s.Name == "Compression" })
+}
+
+// HasScanProblems returns true if either endpoint reported files it could not scan.
+func (s *SyncSession) HasScanProblems() bool {
+	return s.Alpha.HasScanProblems() || s.Beta.HasScanProblems()
+}
+
+// IsHalted returns true if Mutagen has stopped syncing the session because
+// of an error it won't retry.
+func (s *SyncSession) IsHalted() bool {
+	return strings.HasPrefix(strings.ToLower(s.Status), "halted")
+}
+
+// Problems lists why the session can't sync: its last error, then the files
+// each endpoint could not write, as in "α dir/file: permission denied".
+func (s *SyncSession) Problems() []string {
+	var problems []string
+	if s.LastError != "" {
+		problems = append(problems, s.LastError)
+	}
+	for _, e := range []struct {
+		name     string
+		endpoint *Endpoint
+	}{{"α", &s.Alpha}, {"β", &s.Beta}} {
+		for _, p := range e.endpoint.TransitionProblems {
+			problems = append(problems, e.name+" "+p.Path+": "+p.Error)
+		}
+	}
+	return problems
+}
+
+// HaltReason returns the first problem of a halted session, or an empty
+// string if it isn't halted or Mutagen gave no reason.
+func (s *SyncSession) HaltReason() string {
+	if !s.IsHalted() {
+		return ""
+	}
+	if problems := s.Problems(); len(problems) > 0 {
+		return problems[0]
+	}
+	return ""
+}
+
+// ReplicatesToLocal returns true if alpha is remote and beta is local. For a
+// one-way session this means remote files overwrite local ones, which is
+// easy to set up backwards.
+func (s *SyncSession) ReplicatesToLocal() bool {
+	return s.Alpha.Protocol != "" && !s.Alpha.IsLocal() && s.Beta.IsLocal()
+}
+
+// ProjectLabel is the label mutagui puts on sessions it creates, naming the
+// project they belong to.
+const ProjectLabel = "project"
+
+// IsManaged returns true if the session carries the project label, meaning
+// it was created by mutagui (or `mutagen project start`) rather than by hand.
+func (s *SyncSession) IsManaged() bool {
+	_, ok := s.Labels[ProjectLabel]
+	return ok
+}
+
+// LabelValue converts s into a valid Mutagen label value: at most 63
+// characters, starting and ending with a letter or digit, with only
+// letters, digits, '-', '_', and '.' in between.
+func LabelValue(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	value := b.String()
+	if len(value) > 63 {
+		value = value[:63]
+	}
+	isAlnum := func(r rune) bool {
+		return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+	}
+	return strings.TrimFunc(value, func(r rune) bool { return !isAlnum(r) })
 }
 
 // GetLabel returns the value of a label, or empty string if not found.
@@ -135,31 +404,37 @@ func (s *SyncSession) BetaDisplay() string {
 	return s.Beta.DisplayPath()
 }
 
-// StatusIcon returns a compact icon representing the session status.
+// StatusIcon returns a compact icon representing the session status. The
+// Unicode icons are one or two cells wide; the ASCII ones are one.
 func (s *SyncSession) StatusIcon() string {
 	status := strings.ToLower(s.Status)
 	switch {
 	case strings.Contains(status, "watching"):
-		return "👁"
+		return Icon("👁", "w")
 	case strings.Contains(status, "scanning"):
-		return "🔍"
+		return Icon("🔍", "s")
 	case strings.Contains(status, "staging"):
-		return "📦"
+		return Icon("📦", "+")
 	case strings.Contains(status, "reconcil"):
-		return "⚖"
+		return Icon("⚖", "=")
 	case strings.Contains(status, "saving"):
-		return "💾"
+		return Icon("💾", "*")
 	case strings.Contains(status, "connect"):
-		return "🔌"
+		return Icon("🔌", "c")
 	case strings.Contains(status, "transition"):
-		return "⏳"
+		return Icon("⏳", "a")
 	case strings.Contains(status, "halt"):
-		return "⛔"
+		return Icon("⛔", "!")
 	default:
-		return "•"
+		return Icon("•", ".")
 	}
 }
 
+// IsStaging returns true if the session is staging files on an endpoint.
+func (s *SyncSession) IsStaging() bool {
+	return strings.Contains(strings.ToLower(s.Status), "staging")
+}
+
 // StatusText returns a human-readable status description.
 func (s *SyncSession) StatusText() string {
 	status := strings.ToLower(s.Status)
@@ -213,20 +488,17 @@ func (s *SyncSession) scanningStatusText() string {
 }
 
 // stagingStatusText returns a detailed staging status including progress if available.
+// When both endpoints are staging, as can happen in a two-way sync, both
+// percentages are shown: ↓ for files staged on alpha and ↑ for files staged on beta.
 func (s *SyncSession) stagingStatusText() string {
-	status := strings.ToLower(s.Status)
-
-	// Determine which endpoint is staging
-	endpoint := ""
-	var ep *Endpoint
-	if strings.Contains(status, "alpha") {
-		endpoint = "α"
-		ep = &s.Alpha
-	} else if strings.Contains(status, "beta") {
-		endpoint = "β"
-		ep = &s.Beta
+	alphaPct, alphaOK := s.Alpha.stagingPercent()
+	betaPct, betaOK := s.Beta.stagingPercent()
+	if alphaOK && betaOK {
+		return "Staging ↓" + formatNumber(alphaPct) + "% / ↑" + formatNumber(betaPct) + "%"
 	}
 
+	endpoint, ep := s.stagingEndpoint()
+
 	// Check for staging progress
 	if ep != nil && ep.StagingProgress != nil {
 		prog := ep.StagingProgress
@@ -240,6 +512,47 @@ func (s *SyncSession) stagingStatusText() string {
 	return "Staging"
 }
 
+// stagingEndpoint returns the endpoint the status says is staging, as "α"
+// or "β", or "" and nil if it doesn't say.
+func (s *SyncSession) stagingEndpoint() (string, *Endpoint) {
+	status := strings.ToLower(s.Status)
+	switch {
+	case strings.Contains(status, "alpha"):
+		return "α", &s.Alpha
+	case strings.Contains(status, "beta"):
+		return "β", &s.Beta
+	}
+	return "", nil
+}
+
+// StagingPercent returns the endpoint that is staging, as "α" or "β", and
+// the percentage of its expected files staged. It returns false unless the
+// session is staging on one endpoint that reports its progress; when both
+// report progress, StatusText shows the two percentages.
+func (s *SyncSession) StagingPercent() (string, int, bool) {
+	_, alphaOK := s.Alpha.stagingPercent()
+	_, betaOK := s.Beta.stagingPercent()
+	if !s.IsStaging() || (alphaOK && betaOK) {
+		return "", 0, false
+	}
+	endpoint, ep := s.stagingEndpoint()
+	if ep == nil {
+		return "", 0, false
+	}
+	pct, ok := ep.stagingPercent()
+	return endpoint, int(pct), ok
+}
+
+// stagingPercent returns the percentage of expected files staged on the
+// endpoint, or false if it isn't reporting staging progress.
+func (e *Endpoint) stagingPercent() (uint64, bool) {
+	prog := e.StagingProgress
+	if prog == nil || prog.ReceivedFiles == nil || prog.ExpectedFiles == nil || *prog.ExpectedFiles == 0 {
+		return 0, false
+	}
+	return min(*prog.ReceivedFiles*100 / *prog.ExpectedFiles, 100), true
+}
+
 // formatScanProgress formats a scanning progress message.
 func formatScanProgress(endpoint string, files uint64) string {
 	if files >= 1000 {
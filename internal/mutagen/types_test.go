@@ -1,6 +1,8 @@
 package mutagen
 
 import (
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -241,6 +243,53 @@ func TestSyncSession_StatusText_StagingProgress(t *testing.T) {
 	}
 }
 
+func TestSyncSession_StatusText_StagingBothEndpoints(t *testing.T) {
+	progress := func(received, expected uint64) *StagingProgress {
+		return &StagingProgress{ReceivedFiles: &received, ExpectedFiles: &expected}
+	}
+	session := SyncSession{
+		Status: "Staging beta",
+		Alpha:  Endpoint{StagingProgress: progress(45, 100)},
+		Beta:   Endpoint{StagingProgress: progress(3, 25)},
+	}
+	got := session.StatusText()
+	want := "Staging ↓45% / ↑12%"
+	if got != want {
+		t.Errorf("StatusText() with both endpoints staging = %q, want %q", got, want)
+	}
+
+	// Without alpha's progress, only beta is shown as before
+	session.Alpha.StagingProgress = nil
+	if got := session.StatusText(); got != "Staging β (3/25 12%)" {
+		t.Errorf("StatusText() with beta staging = %q", got)
+	}
+}
+
+func TestSyncSession_StagingPercent(t *testing.T) {
+	progress := func(received, expected uint64) *StagingProgress {
+		return &StagingProgress{ReceivedFiles: &received, ExpectedFiles: &expected}
+	}
+	session := SyncSession{
+		Status: "Staging alpha",
+		Alpha:  Endpoint{StagingProgress: progress(45, 100)},
+	}
+	if endpoint, pct, ok := session.StagingPercent(); !ok || endpoint != "α" || pct != 45 {
+		t.Errorf("StagingPercent() = %q, %d, %v, want α, 45", endpoint, pct, ok)
+	}
+
+	// Both endpoints' percentages don't fit one bar
+	session.Beta.StagingProgress = progress(3, 25)
+	if _, _, ok := session.StagingPercent(); ok {
+		t.Error("StagingPercent() should be false with both endpoints staging")
+	}
+
+	session.Beta.StagingProgress = nil
+	session.Status = "Watching for changes"
+	if _, _, ok := session.StagingPercent(); ok {
+		t.Error("StagingPercent() should be false for a session that isn't staging")
+	}
+}
+
 func TestFormatNumber(t *testing.T) {
 	tests := []struct {
 		n    uint64
@@ -295,3 +344,109 @@ func TestSyncSession_BetaDisplay(t *testing.T) {
 func strPtr(s string) *string {
 	return &s
 }
+
+func TestSyncSession_ReplicatesToLocal(t *testing.T) {
+	tests := []struct {
+		name  string
+		alpha string
+		beta  string
+		want  bool
+	}{
+		{"remote to local", "ssh", "local", true},
+		{"local to remote", "local", "ssh", false},
+		{"local to local", "local", "local", false},
+		{"unknown alpha", "", "local", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SyncSession{
+				Alpha: Endpoint{Protocol: tt.alpha},
+				Beta:  Endpoint{Protocol: tt.beta},
+			}
+			if got := s.ReplicatesToLocal(); got != tt.want {
+				t.Errorf("ReplicatesToLocal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncSession_IsManaged(t *testing.T) {
+	if (&SyncSession{}).IsManaged() {
+		t.Error("session without labels should not be managed")
+	}
+	s := &SyncSession{Labels: map[string]string{"environment": "production"}}
+	if s.IsManaged() {
+		t.Error("session without project label should not be managed")
+	}
+	s.Labels[ProjectLabel] = "mutagen-apollo"
+	if !s.IsManaged() {
+		t.Error("session with project label should be managed")
+	}
+}
+
+func TestLabelValue(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"mutagen", "mutagen"},
+		{"mutagen-apollo", "mutagen-apollo"},
+		{".mutagen", "mutagen"},
+		{"my project/with spaces", "my-project-with-spaces"},
+		{"--", ""},
+		{strings.Repeat("a", 70), strings.Repeat("a", 63)},
+	}
+	for _, tt := range tests {
+		if got := LabelValue(tt.in); got != tt.want {
+			t.Errorf("LabelValue(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSyncSession_HaltReason(t *testing.T) {
+	tests := []struct {
+		name    string
+		session SyncSession
+		want    string
+	}{
+		{"watching with an old error", SyncSession{Status: "watching", LastError: "connection lost"}, ""},
+		{"halted with an error", SyncSession{Status: "halted-on-root-emptied", LastError: "root emptied"}, "root emptied"},
+		{"halted on a transition problem", SyncSession{
+			Status: "halted-on-root-type-change",
+			Alpha:  Endpoint{TransitionProblems: []ScanProblem{{Path: "a", Error: "denied"}}},
+		}, "α a: denied"},
+		{"halted without a reason", SyncSession{Status: "halted-on-root-deletion"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.session.HaltReason(); got != tt.want {
+				t.Errorf("HaltReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionConfig_Settings(t *testing.T) {
+	no := false
+	opts := SessionOptions{Ignore: []string{"b", "a"}, IgnoreVCS: &no, WatchMode: "no-watch"}
+	var got []string
+	for _, setting := range opts.Config().Settings() {
+		got = append(got, setting.Name+": "+setting.Value)
+	}
+	want := []string{
+		"Mode: default",
+		"Ignores: b, a",
+		"VCS: propagate",
+		"Symlinks: default",
+		"Watch: no-watch",
+		"Permissions: default",
+		"Compression: default",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Settings() = %q, want %q", got, want)
+	}
+
+	// Files can't set compression, so it isn't compared with one
+	if slices.ContainsFunc(opts.Config().FileSettings(), func(s ConfigSetting) bool { return s.Name == "Compression" }) {
+		t.Error("FileSettings() should leave out compression")
+	}
+}
@@ -0,0 +1,45 @@
+package mutagen
+
+import (
+	"strconv"
+	"strings"
+)
+
+// majorMinor extracts the major and minor components of a version string
+// such as "0.18.1", "v0.18.1", or "0.19.0-dev".
+func majorMinor(version string) (major, minor int, ok bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "-")
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+// VersionsDiffer returns true if two Mutagen versions differ in their major or
+// minor component. Patch releases are compatible, but sessions created by a
+// different minor release are a common cause of agent mismatch errors.
+// Versions that cannot be parsed are never considered different.
+func VersionsDiffer(a, b string) bool {
+	aMajor, aMinor, aOK := majorMinor(a)
+	bMajor, bMinor, bOK := majorMinor(b)
+	if !aOK || !bOK {
+		return false
+	}
+	return aMajor != bMajor || aMinor != bMinor
+}
+
+// CreatedByDifferentVersion returns true if the session was created by a
+// Mutagen release whose major or minor version differs from current.
+func (s *SyncSession) CreatedByDifferentVersion(current string) bool {
+	return s.CreatingVersion != "" && VersionsDiffer(s.CreatingVersion, current)
+}
@@ -0,0 +1,36 @@
+package mutagen
+
+import "testing"
+
+func TestVersionsDiffer(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"0.18.1", "0.18.1", false},
+		{"0.18.0", "0.18.1", false},
+		{"0.17.5", "0.18.1", true},
+		{"1.0.0", "0.18.1", true},
+		{"v0.18.1", "0.18.0\n", false},
+		{"0.19.0-dev", "0.18.1", true},
+		{"", "0.18.1", false},
+		{"unknown", "0.18.1", false},
+	}
+	for _, tt := range tests {
+		if got := VersionsDiffer(tt.a, tt.b); got != tt.want {
+			t.Errorf("VersionsDiffer(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSyncSession_CreatedByDifferentVersion(t *testing.T) {
+	session := &SyncSession{CreatingVersion: "0.17.2"}
+	if !session.CreatedByDifferentVersion("0.18.1") {
+		t.Error("CreatedByDifferentVersion() = false for 0.17.2 vs 0.18.1")
+	}
+
+	session.CreatingVersion = ""
+	if session.CreatedByDifferentVersion("0.18.1") {
+		t.Error("CreatedByDifferentVersion() = true without a creating version")
+	}
+}
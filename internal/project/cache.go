@@ -0,0 +1,87 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileCache holds parsed project files so repeated discovery only re-parses
+// files that changed. A file is re-read when its modification time or size,
+// or those of the hooks file beside it, differ from when it was parsed. The zero value is not usable; a nil
+// *FileCache parses every time.
+type FileCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedFile
+}
+
+type cachedFile struct {
+	modTime time.Time
+	size    int64
+	hooks   fileStamp
+	file    *ProjectFile
+}
+
+// fileStamp identifies a version of a file that may not exist.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func stampFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
+
+// NewFileCache returns an empty FileCache.
+func NewFileCache() *FileCache {
+	return &FileCache{entries: make(map[string]cachedFile)}
+}
+
+// Load returns the parsed project file at path, from the cache if the file
+// is unchanged since it was last parsed. Callers must not modify the result,
+// since later calls may return it again.
+func (c *FileCache) Load(path string) (*ProjectFile, error) {
+	if c == nil {
+		return LoadProjectFile(path)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(absPath)
+	if err != nil {
+		c.forget(absPath)
+		return nil, err
+	}
+
+	hooks := stampFile(filepath.Join(filepath.Dir(absPath), HooksFileName))
+
+	c.mu.Lock()
+	entry, ok := c.entries[absPath]
+	c.mu.Unlock()
+	if ok && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() &&
+		entry.hooks.modTime.Equal(hooks.modTime) && entry.hooks.size == hooks.size {
+		return entry.file, nil
+	}
+
+	pf, err := LoadProjectFile(path)
+	if err != nil {
+		c.forget(absPath)
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[absPath] = cachedFile{modTime: info.ModTime(), size: info.Size(), hooks: hooks, file: pf}
+	c.mu.Unlock()
+	return pf, nil
+}
+
+func (c *FileCache) forget(absPath string) {
+	c.mu.Lock()
+	delete(c.entries, absPath)
+	c.mu.Unlock()
+}
@@ -0,0 +1,123 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeProjectFile(t *testing.T, path, session string) {
+	t.Helper()
+	content := "sync:\n  " + session + ":\n    alpha: /local\n    beta: host:/remote\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileCache_Load(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mutagen.yml")
+	writeProjectFile(t, path, "web")
+	cache := NewFileCache()
+
+	first, err := cache.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Unchanged: the cached parse is returned
+	second, err := cache.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second != first {
+		t.Error("unchanged file was parsed again")
+	}
+
+	// Same size, new modification time: parsed again
+	writeProjectFile(t, path, "api")
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	third, err := cache.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third == first {
+		t.Fatal("modified file was not parsed again")
+	}
+	if _, ok := third.Sessions["api"]; !ok {
+		t.Errorf("Sessions = %v, want the api session", third.Sessions)
+	}
+
+	// A removed file is an error, not a stale hit
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Load(path); err == nil {
+		t.Error("expected an error for a removed file")
+	}
+}
+
+func TestFileCache_Nil(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mutagen.yml")
+	writeProjectFile(t, path, "web")
+	var cache *FileCache
+
+	first, err := cache.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := cache.Load(path)
+	if first == second {
+		t.Error("a nil cache should parse every time")
+	}
+}
+
+func TestFindProjectsCached(t *testing.T) {
+	dir := t.TempDir()
+	writeProjectFile(t, filepath.Join(dir, "mutagen.yml"), "web")
+	cache := NewFileCache()
+
+	first, err := FindProjectsCached(cache, dir, nil, nil, false)
+	if err != nil || len(first) != 1 {
+		t.Fatalf("FindProjectsCached() = %v, %v; want one project", first, err)
+	}
+	second, err := FindProjectsCached(cache, dir, nil, nil, false)
+	if err != nil || len(second) != 1 {
+		t.Fatalf("FindProjectsCached() = %v, %v; want one project", second, err)
+	}
+	// Each call builds fresh projects around the same parsed file
+	if first[0] == second[0] {
+		t.Error("projects should not be shared between calls")
+	}
+	if first[0].File.Path != second[0].File.Path || len(second[0].Specs) != 1 {
+		t.Errorf("second project = %+v", second[0])
+	}
+}
+
+func TestFileCache_ReloadsOnHooksChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mutagen.yml")
+	writeProjectFile(t, path, "web")
+	cache := NewFileCache()
+
+	first, err := cache.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hooks := "[depends_on]\nweb = [\"config\"]\n"
+	if err := os.WriteFile(filepath.Join(dir, HooksFileName), []byte(hooks), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	second, err := cache.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second == first {
+		t.Fatal("project file was not parsed again after its hooks file changed")
+	}
+	if deps := second.Hooks.DependsOn["web"]; len(deps) != 1 || deps[0] != "config" {
+		t.Errorf("DependsOn[web] = %v, want [config]", deps)
+	}
+}
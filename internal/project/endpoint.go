@@ -0,0 +1,118 @@
+package project
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Endpoint is a Mutagen endpoint as a project file or the mutagen command
+// line gives it.
+type Endpoint struct {
+	// Scheme is the protocol of a URL-style endpoint such as
+	// docker://web/app, and "" for a local path or an SSH endpoint
+	Scheme string
+
+	// User, Host and Port are those of an SSH endpoint or a URL; Host is ""
+	// for a local path
+	User string
+	Host string
+	Port uint16
+
+	Path string
+}
+
+// ParseEndpoint parses an endpoint: a URL, [user@]host[:port]:path, or a
+// local path. Like Mutagen, it takes a path with a slash ahead of its first
+// colon, or with a Windows drive letter, as local.
+func ParseEndpoint(endpoint string) Endpoint {
+	if scheme, _, ok := strings.Cut(endpoint, "://"); ok {
+		e := Endpoint{Scheme: scheme}
+		if u, err := url.Parse(endpoint); err == nil {
+			e.User, e.Host, e.Path = u.User.Username(), u.Hostname(), u.Path
+		}
+		return e
+	}
+
+	colon := strings.IndexByte(endpoint, ':')
+	if slash := strings.IndexByte(endpoint, '/'); colon <= 1 || (slash >= 0 && slash < colon) {
+		return Endpoint{Path: endpoint}
+	}
+	e := Endpoint{Host: endpoint[:colon], Path: endpoint[colon+1:]}
+	if at := strings.LastIndexByte(e.Host, '@'); at >= 0 {
+		e.User, e.Host = e.Host[:at], e.Host[at+1:]
+	}
+	if port, rest, ok := strings.Cut(e.Path, ":"); ok {
+		if n, err := strconv.ParseUint(port, 10, 16); err == nil {
+			e.Port, e.Path = uint16(n), rest
+		}
+	}
+	return e
+}
+
+// IsLocal returns true if the endpoint is a local filesystem path.
+func (e Endpoint) IsLocal() bool {
+	return e.Scheme == "" && e.Host == ""
+}
+
+// IsSSH returns true if the endpoint is an SSH remote (host:path).
+func (e Endpoint) IsSSH() bool {
+	return e.Scheme == "" && e.Host != ""
+}
+
+// SSHDestination returns an SSH endpoint's host as the ssh command takes
+// it, with its user and port.
+func (e Endpoint) SSHDestination() string {
+	host := e.Host
+	if e.User != "" {
+		host = e.User + "@" + host
+	}
+	if e.Port != 0 {
+		return "ssh://" + host + ":" + strconv.FormatUint(uint64(e.Port), 10)
+	}
+	return host
+}
+
+// NormalizeEndpoint returns a canonical form of a Mutagen endpoint so that
+// equivalent spellings compare equal. Local paths have ~ and environment
+// variables expanded, are resolved against baseDir (the directory of the
+// project file, which is what Mutagen resolves them against), and are
+// cleaned, which strips trailing slashes. Remote (host:path) paths are only
+// cleaned, since their home directory isn't known here, and URL-style
+// endpoints only lose trailing slashes.
+func NormalizeEndpoint(endpoint, baseDir string) string {
+	switch e := ParseEndpoint(endpoint); {
+	case e.Scheme != "":
+		return strings.TrimRight(endpoint, "/")
+	case e.IsSSH():
+		if e.Path == "" {
+			return endpoint
+		}
+		return strings.TrimSuffix(endpoint, e.Path) + path.Clean(e.Path)
+	}
+
+	p := os.ExpandEnv(endpoint)
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			p = filepath.Join(home, p[1:])
+		}
+	}
+	if !filepath.IsAbs(p) && baseDir != "" {
+		p = filepath.Join(baseDir, p)
+	}
+	return filepath.Clean(p)
+}
+
+// SameEndpoint returns true if two endpoints refer to the same location.
+func SameEndpoint(a, b, baseDir string) bool {
+	return NormalizeEndpoint(a, baseDir) == NormalizeEndpoint(b, baseDir)
+}
+
+// Dir returns the directory containing the project file, which relative
+// local endpoints are resolved against.
+func (p *ProjectFile) Dir() string {
+	return filepath.Dir(p.Path)
+}
@@ -0,0 +1,100 @@
+package project
+
+import "testing"
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     Endpoint
+	}{
+		{"/home/user/project", Endpoint{Path: "/home/user/project"}},
+		{"project/src", Endpoint{Path: "project/src"}},
+		{"~/projects", Endpoint{Path: "~/projects"}},
+		{"./a:b", Endpoint{Path: "./a:b"}},
+		{"C:/Users/test", Endpoint{Path: "C:/Users/test"}},
+		{`C:\web`, Endpoint{Path: `C:\web`}},
+		{"server:/path/to/dir", Endpoint{Host: "server", Path: "/path/to/dir"}},
+		{"user@server:~/dir", Endpoint{User: "user", Host: "server", Path: "~/dir"}},
+		{"me@dev:2222:/srv/web", Endpoint{User: "me", Host: "dev", Port: 2222, Path: "/srv/web"}},
+		{"docker://container/path", Endpoint{Scheme: "docker", Host: "container", Path: "/path"}},
+		{"docker://user@container/path", Endpoint{Scheme: "docker", User: "user", Host: "container", Path: "/path"}},
+	}
+	for _, tt := range tests {
+		if got := ParseEndpoint(tt.endpoint); got != tt.want {
+			t.Errorf("ParseEndpoint(%q) = %+v, want %+v", tt.endpoint, got, tt.want)
+		}
+	}
+}
+
+func TestEndpoint_Kind(t *testing.T) {
+	tests := []struct {
+		endpoint   string
+		local, ssh bool
+	}{
+		{"/local/path", true, false},
+		{"relative/path", true, false},
+		{"server:/path", false, true},
+		{"user@server:/path", false, true},
+		{"docker://container", false, false},
+		{"C:/Windows/path", true, false},
+	}
+	for _, tt := range tests {
+		e := ParseEndpoint(tt.endpoint)
+		if e.IsLocal() != tt.local || e.IsSSH() != tt.ssh {
+			t.Errorf("%q: IsLocal() = %v, IsSSH() = %v, want %v, %v", tt.endpoint, e.IsLocal(), e.IsSSH(), tt.local, tt.ssh)
+		}
+	}
+}
+
+func TestEndpoint_SSHDestination(t *testing.T) {
+	tests := map[string]string{
+		"server:/path":         "server",
+		"me@server:/path":      "me@server",
+		"me@server:2222:/path": "ssh://me@server:2222",
+	}
+	for endpoint, want := range tests {
+		if got := ParseEndpoint(endpoint).SSHDestination(); got != want {
+			t.Errorf("ParseEndpoint(%q).SSHDestination() = %q, want %q", endpoint, got, want)
+		}
+	}
+}
+
+func TestNormalizeEndpoint(t *testing.T) {
+	t.Setenv("HOME", "/Users/me")
+	t.Setenv("CODE", "/Users/me/code")
+
+	tests := []struct {
+		name, endpoint, baseDir, want string
+	}{
+		{"tilde", "~/code", "", "/Users/me/code"},
+		{"trailing slash", "/Users/me/code/", "", "/Users/me/code"},
+		{"env var", "$CODE/app", "", "/Users/me/code/app"},
+		{"relative", "./app", "/Users/me/code", "/Users/me/code/app"},
+		{"dot", ".", "/Users/me/code", "/Users/me/code"},
+		{"remote trailing slash", "server:/srv/code/", "/base", "server:/srv/code"},
+		{"remote tilde kept", "server:~/code", "/base", "server:~/code"},
+		{"remote port kept", "me@server:2222:/srv/code/", "/base", "me@server:2222:/srv/code"},
+		{"url", "docker://container/app/", "/base", "docker://container/app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeEndpoint(tt.endpoint, tt.baseDir); got != tt.want {
+				t.Errorf("NormalizeEndpoint(%q, %q) = %q, want %q", tt.endpoint, tt.baseDir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSameEndpoint(t *testing.T) {
+	t.Setenv("HOME", "/Users/me")
+
+	if !SameEndpoint("~/code", "/Users/me/code/", "") {
+		t.Error("~/code and /Users/me/code/ should be the same")
+	}
+	if !SameEndpoint(".", "/Users/me/code", "/Users/me/code") {
+		t.Error(". should resolve against the project directory")
+	}
+	if SameEndpoint("/Users/me/code", "server:/Users/me/code", "") {
+		t.Error("local and remote endpoints should differ")
+	}
+}
@@ -0,0 +1,48 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// HooksFileName is the mutagui settings file read from a project file's
+// directory. It is kept separate from mutagen.yml, which Mutagen parses too.
+const HooksFileName = ".mutagui.toml"
+
+// Hooks contains commands mutagui runs around project operations, and the
+// settings for mutagui that Mutagen would reject in mutagen.yml.
+type Hooks struct {
+	// PreStart is a shell command run in the project directory before
+	// starting specs, e.g. to bring up a VPN or tunnel.
+	PreStart string `toml:"pre_start"`
+
+	// DependsOn maps a spec name to the specs that mutagui starts, and
+	// waits to be watching, before starting it when the whole project is
+	// started.
+	DependsOn map[string][]string `toml:"depends_on"`
+
+	// RemoteAlpha says that sessions whose alpha is remote and beta local
+	// are meant that way, so they aren't flagged as swapped
+	RemoteAlpha bool `toml:"remote_alpha"`
+}
+
+// LoadHooks reads the hooks file next to the given project file.
+// A missing hooks file yields empty Hooks and no error.
+func LoadHooks(projectFilePath string) (Hooks, error) {
+	var hooks Hooks
+	path := filepath.Join(filepath.Dir(projectFilePath), HooksFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return hooks, nil
+		}
+		return hooks, err
+	}
+	if err := toml.Unmarshal(data, &hooks); err != nil {
+		return hooks, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return hooks, nil
+}
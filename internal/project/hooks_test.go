@@ -0,0 +1,42 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadHooks(t *testing.T) {
+	dir := t.TempDir()
+	projectFile := filepath.Join(dir, "mutagen.yml")
+
+	hooks, err := LoadHooks(projectFile)
+	if err != nil {
+		t.Fatalf("LoadHooks() without file error = %v", err)
+	}
+	if hooks.PreStart != "" {
+		t.Errorf("PreStart = %q, want empty", hooks.PreStart)
+	}
+
+	content := `pre_start = "docker compose up -d"`
+	if err := os.WriteFile(filepath.Join(dir, HooksFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	hooks, err = LoadHooks(projectFile)
+	if err != nil {
+		t.Fatalf("LoadHooks() error = %v", err)
+	}
+	if hooks.PreStart != "docker compose up -d" {
+		t.Errorf("PreStart = %q, want docker compose up -d", hooks.PreStart)
+	}
+}
+
+func TestLoadHooks_InvalidTOML(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, HooksFileName), []byte("pre_start = "), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadHooks(filepath.Join(dir, "mutagen.yml")); err == nil {
+		t.Error("LoadHooks() should fail on invalid TOML")
+	}
+}
@@ -0,0 +1,69 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ValidateIgnorePatterns checks ignore patterns for mistakes that would
+// keep them from protecting the paths they name: empty entries, stray
+// spaces, negations of nothing, the sync root itself, and globs that don't
+// parse. It returns an error for each problem found.
+func ValidateIgnorePatterns(patterns []string) []error {
+	var errs []error
+	for _, pattern := range patterns {
+		if err := validateIgnorePattern(pattern); err != nil {
+			errs = append(errs, fmt.Errorf("ignore pattern %q: %w", pattern, err))
+		}
+	}
+	return errs
+}
+
+func validateIgnorePattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return errors.New("is empty")
+	}
+	if strings.TrimSpace(pattern) != pattern {
+		return errors.New("has leading or trailing spaces, which are part of the pattern")
+	}
+	glob := strings.TrimPrefix(pattern, "!")
+	switch glob {
+	case "":
+		return errors.New("negates nothing")
+	case "/", "/**":
+		return errors.New("matches the sync root, which can't be ignored")
+	}
+	for _, segment := range strings.Split(glob, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return errors.New("isn't a valid glob")
+		}
+	}
+	return nil
+}
+
+// ignoreWarnings describes the problems with the ignore patterns of a
+// project file's defaults and sessions, in session name order.
+func ignoreWarnings(pf *ProjectFile) []string {
+	var warnings []string
+	if pf.Defaults != nil && pf.Defaults.Ignore != nil {
+		for _, err := range ValidateIgnorePatterns(pf.Defaults.Ignore.Paths) {
+			warnings = append(warnings, "defaults: "+err.Error())
+		}
+	}
+	names := make([]string, 0, len(pf.Sessions))
+	for name := range pf.Sessions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if ignore := pf.Sessions[name].Ignore; ignore != nil {
+			for _, err := range ValidateIgnorePatterns(ignore.Paths) {
+				warnings = append(warnings, fmt.Sprintf("session %q: %s", name, err))
+			}
+		}
+	}
+	return warnings
+}
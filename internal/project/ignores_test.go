@@ -0,0 +1,77 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidateIgnorePatterns(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string // Empty if the pattern is fine
+	}{
+		{"node_modules/", ""},
+		{"**/*.pyc", ""},
+		{"/build", ""},
+		{"!keep.pyc", ""},
+		{"data/[0-9]*.csv", ""},
+		{"", "is empty"},
+		{"   ", "is empty"},
+		{"dist ", "has leading or trailing spaces"},
+		{"!", "negates nothing"},
+		{"/", "matches the sync root"},
+		{"src/[a-", "isn't a valid glob"},
+		{`cache\`, "isn't a valid glob"},
+	}
+	for _, tt := range tests {
+		errs := ValidateIgnorePatterns([]string{tt.pattern})
+		switch {
+		case tt.want == "" && len(errs) > 0:
+			t.Errorf("ValidateIgnorePatterns(%q) = %v, want no errors", tt.pattern, errs)
+		case tt.want != "" && (len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.want)):
+			t.Errorf("ValidateIgnorePatterns(%q) = %v, want an error that it %s", tt.pattern, errs, tt.want)
+		}
+	}
+}
+
+func TestLoadProjectFile_WarnsOfBadIgnores(t *testing.T) {
+	yamlPath := filepath.Join(t.TempDir(), "mutagen.yml")
+	content := `sync:
+  defaults:
+    ignore:
+      paths: ["", ".DS_Store"]
+  web:
+    alpha: .
+    beta: server:/web
+    ignore:
+      paths: ["build ", "src/[a-"]
+  api:
+    alpha: ./api
+    beta: server:/api
+    ignore:
+      paths: ["!"]
+`
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pf, err := LoadProjectFile(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadProjectFile() error = %v", err)
+	}
+	want := []string{
+		`defaults: ignore pattern "": is empty`,
+		`session "api": ignore pattern "!": negates nothing`,
+		`session "web": ignore pattern "build ": has leading or trailing spaces, which are part of the pattern`,
+		`session "web": ignore pattern "src/[a-": isn't a valid glob`,
+	}
+	if !slices.Equal(pf.Warnings, want) {
+		t.Errorf("Warnings = %q, want %q", pf.Warnings, want)
+	}
+	if len(pf.Sessions) != 2 {
+		t.Errorf("Sessions = %v, want both kept", pf.Sessions)
+	}
+}
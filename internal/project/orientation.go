@@ -0,0 +1,34 @@
+package project
+
+// IsRemoteEndpoint returns true if an endpoint is on another machine or in
+// a container: an SSH host:path, or a URL such as docker://web/app.
+func IsRemoteEndpoint(endpoint string) bool {
+	return endpointHost(endpoint) != "local"
+}
+
+// LooksSwapped returns true if a session's alpha is remote and a beta is
+// local. Alpha is usually the local copy, since it is what a push copies
+// from and what wins conflicts in two-way-safe mode; the other way round,
+// pushing to beta overwrites local files with the remote ones.
+func (d *SessionDefinition) LooksSwapped() bool {
+	if !IsRemoteEndpoint(d.Alpha) {
+		return false
+	}
+	for _, beta := range d.BetaEndpoints() {
+		if !IsRemoteEndpoint(beta) {
+			return true
+		}
+	}
+	return false
+}
+
+// LooksSwapped returns true if the named session's endpoints look swapped
+// (see SessionDefinition.LooksSwapped) and the hooks file doesn't say that
+// its alphas are remote on purpose.
+func (p *ProjectFile) LooksSwapped(name string) bool {
+	if p.Hooks.RemoteAlpha {
+		return false
+	}
+	def, ok := p.Sessions[name]
+	return ok && def.LooksSwapped()
+}
@@ -0,0 +1,90 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsRemoteEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     bool
+	}{
+		{"/home/me/site", false},
+		{"./site", false},
+		{"~/site", false},
+		{`C:\Users\me\site`, false},
+		{"server:/srv/site", true},
+		{"me@server:~/site", true},
+		{"me@server:22:/srv/site", true},
+		{"docker://web/app", true},
+		{"docker://me@web/app", true},
+	}
+	for _, tt := range tests {
+		if got := IsRemoteEndpoint(tt.endpoint); got != tt.want {
+			t.Errorf("IsRemoteEndpoint(%q) = %v, want %v", tt.endpoint, got, tt.want)
+		}
+	}
+}
+
+func TestSessionDefinition_LooksSwapped(t *testing.T) {
+	tests := []struct {
+		name string
+		def  SessionDefinition
+		want bool
+	}{
+		{"local to ssh", SessionDefinition{Alpha: "./site", Beta: "server:/srv/site"}, false},
+		{"local to docker", SessionDefinition{Alpha: "~/site", Beta: "docker://web/site"}, false},
+		{"local to local", SessionDefinition{Alpha: "/a", Beta: "/b"}, false},
+		{"ssh to ssh", SessionDefinition{Alpha: "east:/srv", Beta: "west:/srv"}, false},
+		{"ssh to local", SessionDefinition{Alpha: "server:/srv/site", Beta: "./site"}, true},
+		{"docker to local", SessionDefinition{Alpha: "docker://web/site", Beta: "/home/me/site"}, true},
+		{"ssh to a local beta among betas", SessionDefinition{Alpha: "server:/srv", Betas: []string{"west:/srv", "/backup/srv"}}, true},
+		{"ssh to remote betas", SessionDefinition{Alpha: "server:/srv", Betas: []string{"east:/srv", "west:/srv"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.def.LooksSwapped(); got != tt.want {
+				t.Errorf("LooksSwapped() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectFile_LooksSwapped_RemoteAlphaAnnotation(t *testing.T) {
+	content := `sync:
+  backup:
+    alpha: server:/srv/data
+    beta: ./data
+  site:
+    alpha: ./site
+    beta: server:/srv/site
+`
+	yamlPath := filepath.Join(t.TempDir(), "mutagen.yml")
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	pf, err := LoadProjectFile(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadProjectFile() error = %v", err)
+	}
+	if !pf.LooksSwapped("backup") || pf.LooksSwapped("site") || pf.LooksSwapped("missing") {
+		t.Errorf("LooksSwapped: backup %v, site %v, missing %v; want only backup",
+			pf.LooksSwapped("backup"), pf.LooksSwapped("site"), pf.LooksSwapped("missing"))
+	}
+
+	hooksPath := filepath.Join(filepath.Dir(yamlPath), HooksFileName)
+	if err := os.WriteFile(hooksPath, []byte("remote_alpha = true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if pf, err = LoadProjectFile(yamlPath); err != nil {
+		t.Fatalf("LoadProjectFile() error = %v", err)
+	}
+	if !pf.Hooks.RemoteAlpha || pf.LooksSwapped("backup") {
+		t.Errorf("remote_alpha should silence the warning: Hooks = %+v", pf.Hooks)
+	}
+	if len(pf.Warnings) != 0 {
+		t.Errorf("Warnings = %v, want none", pf.Warnings)
+	}
+}
@@ -2,6 +2,8 @@
 package project
 
 import (
+	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"sort"
@@ -25,11 +27,19 @@ const (
 
 // SessionDefinition represents a session defined in a mutagen.yml file.
 type SessionDefinition struct {
-	Alpha  string                 `yaml:"alpha"`
-	Beta   string                 `yaml:"beta"`
-	Mode   *string                `yaml:"mode,omitempty"`
-	Ignore *IgnoreConfig          `yaml:"ignore,omitempty"`
-	Extra  map[string]interface{} `yaml:",inline"`
+	Alpha  string        `yaml:"alpha"`
+	Beta   string        `yaml:"beta"`
+	Mode   *string       `yaml:"mode,omitempty"`
+	Ignore *IgnoreConfig `yaml:"ignore,omitempty"`
+
+	// Betas lists more endpoints that alpha is replicated to. A spec with
+	// betas runs one session for each beta (see Replicas).
+	Betas []string `yaml:"betas,omitempty"`
+
+	Symlink     *SymlinkConfig         `yaml:"symlink,omitempty"`
+	Watch       *WatchConfig           `yaml:"watch,omitempty"`
+	Permissions *PermissionsConfig     `yaml:"permissions,omitempty"`
+	Extra       map[string]interface{} `yaml:",inline"`
 }
 
 // IgnoreConfig represents ignore patterns for a session.
@@ -38,10 +48,45 @@ type IgnoreConfig struct {
 	VCS   *bool    `yaml:"vcs,omitempty"`
 }
 
+// SymlinkConfig represents how a session handles symbolic links.
+type SymlinkConfig struct {
+	Mode string `yaml:"mode,omitempty"` // ignore, portable, or posix-raw
+}
+
+// WatchConfig represents how a session watches for changes.
+type WatchConfig struct {
+	Mode            string `yaml:"mode,omitempty"`            // portable, force-poll, or no-watch
+	PollingInterval uint32 `yaml:"pollingInterval,omitempty"` // Seconds between polls
+}
+
+// PermissionsConfig represents how a session propagates permissions and
+// what it gives files it creates. Modes are octal strings such as "0644",
+// passed to Mutagen as written.
+type PermissionsConfig struct {
+	Mode                 string `yaml:"mode,omitempty"` // portable or manual
+	DefaultFileMode      string `yaml:"defaultFileMode,omitempty"`
+	DefaultDirectoryMode string `yaml:"defaultDirectoryMode,omitempty"`
+	DefaultOwner         string `yaml:"defaultOwner,omitempty"`
+	DefaultGroup         string `yaml:"defaultGroup,omitempty"`
+}
+
 // DefaultConfig represents default settings for sessions.
 type DefaultConfig struct {
-	Ignore *IgnoreConfig          `yaml:"ignore,omitempty"`
-	Extra  map[string]interface{} `yaml:",inline"`
+	Ignore *IgnoreConfig `yaml:"ignore,omitempty"`
+
+	Symlink     *SymlinkConfig     `yaml:"symlink,omitempty"`
+	Watch       *WatchConfig       `yaml:"watch,omitempty"`
+	Permissions *PermissionsConfig `yaml:"permissions,omitempty"`
+
+	Extra map[string]interface{} `yaml:",inline"`
+}
+
+// ForwardDefinition represents a network forwarding session in a project
+// file. mutagui lists these but doesn't start them.
+type ForwardDefinition struct {
+	Source      string                 `yaml:"source"`
+	Destination string                 `yaml:"destination"`
+	Extra       map[string]interface{} `yaml:",inline"`
 }
 
 // ProjectFile represents a parsed mutagen.yml file.
@@ -50,6 +95,26 @@ type ProjectFile struct {
 	TargetName *string                      `yaml:"targetName,omitempty"`
 	Sessions   map[string]SessionDefinition `yaml:"sync"`
 	Defaults   *DefaultConfig               `yaml:"defaults,omitempty"`
+
+	// Forwards are the file's forward definitions, without their defaults
+	Forwards map[string]ForwardDefinition `yaml:"forward,omitempty"`
+
+	// Hooks are the settings from the hooks file next to the project file
+	Hooks Hooks `yaml:"-"`
+
+	// Warnings describes parts of the file that could not be understood
+	// and were skipped, and ignore patterns that look like mistakes
+	Warnings []string `yaml:"-"`
+}
+
+// rawProjectFile is the on-disk form of a ProjectFile, with the sync
+// section left undecoded so that malformed entries can be reported
+// individually instead of failing the whole file.
+type rawProjectFile struct {
+	TargetName *string        `yaml:"targetName,omitempty"`
+	Sync       yaml.Node      `yaml:"sync"`
+	Forward    yaml.Node      `yaml:"forward"`
+	Defaults   *DefaultConfig `yaml:"defaults,omitempty"`
 }
 
 // DisplayName returns a user-friendly name for the project file.
@@ -73,6 +138,17 @@ type SyncSpec struct {
 	Name           string
 	State          SyncSpecState
 	RunningSession *mutagen.SyncSession
+	// EverStarted is true if the spec has been seen running before, as
+	// remembered across runs. It tells a stopped spec from a new one.
+	EverStarted bool
+	// Scheduled is true if the session is kept paused and flushed
+	// periodically instead of watching continuously.
+	Scheduled bool
+	// BetaSessions holds, for a spec with several betas, each replica's
+	// session in the order of SessionDefinition.Replicas, or nil where the
+	// replica isn't running. RunningSession is the first running one. It is
+	// empty for a spec with a single beta.
+	BetaSessions []*mutagen.SyncSession
 }
 
 // IsRunning returns true if the spec has a running session.
@@ -80,16 +156,94 @@ func (s *SyncSpec) IsRunning() bool {
 	return s.State != NotRunning
 }
 
+// Sessions returns the spec's running sessions: each running replica's, or
+// else its one running session.
+func (s *SyncSpec) Sessions() []*mutagen.SyncSession {
+	if len(s.BetaSessions) == 0 {
+		if s.RunningSession == nil {
+			return nil
+		}
+		return []*mutagen.SyncSession{s.RunningSession}
+	}
+	var sessions []*mutagen.SyncSession
+	for _, session := range s.BetaSessions {
+		if session != nil {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+// ReplicaCounts returns how many of a spec's replicas are running, and how
+// many it has. A spec with a single beta has one replica.
+func (s *SyncSpec) ReplicaCounts() (running, total int) {
+	if len(s.BetaSessions) == 0 {
+		if s.RunningSession != nil {
+			return 1, 1
+		}
+		return 0, 1
+	}
+	return len(s.Sessions()), len(s.BetaSessions)
+}
+
 // IsPaused returns true if the spec is running but paused.
 func (s *SyncSpec) IsPaused() bool {
 	return s.RunningSession != nil && s.RunningSession.Paused
 }
 
+// SetSession records the spec's running session. If the spec already holds
+// the same session, as told by its identifier, the sync time recorded for
+// it carries over. The new session replaces the old one rather than
+// overwriting it, since the UI may be drawing the old one.
+func (s *SyncSpec) SetSession(session *mutagen.SyncSession, state SyncSpecState) {
+	s.State = state
+	if s.RunningSession != nil && s.RunningSession != session &&
+		session.Identifier != "" && s.RunningSession.Identifier == session.Identifier {
+		updated := *session
+		updated.SyncTime = s.RunningSession.SyncTime
+		session = &updated
+	}
+	s.RunningSession = session
+}
+
 // Project represents a mutagen.yml file with its sync specs.
 type Project struct {
 	File   ProjectFile
 	Specs  []SyncSpec
 	Folded bool
+
+	// Orphaned marks the synthetic project that lists running sessions
+	// belonging to no project file. It has no file, and each spec is named
+	// after its session.
+	Orphaned bool
+}
+
+// HasWarnings returns true if parts of the project file were skipped
+// because they couldn't be parsed, or have ignore patterns that look wrong.
+func (p *Project) HasWarnings() bool {
+	return len(p.File.Warnings) > 0
+}
+
+// ConflictCount returns the number of conflicts across the project's
+// running sessions.
+func (p *Project) ConflictCount() int {
+	n := 0
+	for _, spec := range p.Specs {
+		if spec.RunningSession != nil {
+			n += spec.RunningSession.ConflictCount()
+		}
+	}
+	return n
+}
+
+// IsRunning returns true if any of the project's specs is running.
+func (p *Project) IsRunning() bool {
+	for i := range p.Specs {
+		if p.Specs[i].IsRunning() {
+			return true
+		}
+	}
+	return false
 }
 
 // LoadProjectFile loads and parses a mutagen.yml file.
@@ -99,26 +253,141 @@ func LoadProjectFile(path string) (*ProjectFile, error) {
 		return nil, err
 	}
 
-	var pf ProjectFile
-	if err := yaml.Unmarshal(data, &pf); err != nil {
+	var raw rawProjectFile
+	if err := yaml.Unmarshal(data, &raw); err != nil {
 		return nil, err
 	}
-	pf.Path = path
-
-	// Extract defaults from sessions map if present (mutagen.yml has sync.defaults)
+	pf := ProjectFile{
+		Path:       path,
+		TargetName: raw.TargetName,
+		Defaults:   raw.Defaults,
+	}
+	pf.Sessions, pf.Warnings = decodeSessions(&raw.Sync)
+	var forwardWarnings []string
+	pf.Forwards, forwardWarnings = decodeForwards(&raw.Forward)
+	pf.Warnings = append(pf.Warnings, forwardWarnings...)
+
+	// Extract defaults from sessions map if present (mutagen.yml has
+	// sync.defaults). A top-level defaults section is read too; where both
+	// configure the same thing, sync.defaults wins.
 	if pf.Sessions != nil {
 		if defaultSession, exists := pf.Sessions["defaults"]; exists {
-			pf.Defaults = &DefaultConfig{
-				Ignore: defaultSession.Ignore,
-				Extra:  defaultSession.Extra,
-			}
+			pf.Defaults = overlayDefaults(raw.Defaults, &DefaultConfig{
+				Ignore:      defaultSession.Ignore,
+				Symlink:     defaultSession.Symlink,
+				Watch:       defaultSession.Watch,
+				Permissions: defaultSession.Permissions,
+				Extra:       defaultSession.Extra,
+			})
 			delete(pf.Sessions, "defaults")
 		}
 	}
+	pf.Warnings = append(pf.Warnings, ignoreWarnings(&pf)...)
 
+	if pf.Hooks, err = LoadHooks(path); err != nil {
+		pf.Warnings = append(pf.Warnings, err.Error())
+	}
 	return &pf, nil
 }
 
+// decodeSessions decodes the sync section of a project file. Entries that
+// are not session definitions are skipped with a warning, as is the whole
+// section if it is not a map.
+func decodeSessions(node *yaml.Node) (map[string]SessionDefinition, []string) {
+	switch node.Kind {
+	case 0:
+		return nil, nil // No sync section
+	case yaml.MappingNode:
+	case yaml.SequenceNode:
+		return nil, []string{fmt.Sprintf(
+			"line %d: sync is a list, but should map session names to definitions (name: {alpha: ..., beta: ...})",
+			node.Line)}
+	default:
+		return nil, []string{fmt.Sprintf(
+			"line %d: sync should map session names to definitions", node.Line)}
+	}
+
+	sessions := make(map[string]SessionDefinition, len(node.Content)/2)
+	var warnings []string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		var def SessionDefinition
+		if err := valueNode.Decode(&def); err != nil {
+			warnings = append(warnings, fmt.Sprintf(
+				"line %d: skipped session %q: not a session definition", keyNode.Line, keyNode.Value))
+			continue
+		}
+		if dropped := def.dropDuplicateBetas(keyNode.Value); len(dropped) > 0 {
+			warnings = append(warnings, fmt.Sprintf(
+				"line %d: session %q: skipped betas on a host it already syncs to (%s)",
+				keyNode.Line, keyNode.Value, strings.Join(dropped, ", ")))
+		}
+		sessions[keyNode.Value] = def
+	}
+	return sessions, warnings
+}
+
+// decodeForwards decodes the forward section of a project file, leaving
+// out its defaults. Entries that are not forward definitions are skipped
+// with a warning, as is the whole section if it is not a map.
+func decodeForwards(node *yaml.Node) (map[string]ForwardDefinition, []string) {
+	switch node.Kind {
+	case 0:
+		return nil, nil // No forward section
+	case yaml.MappingNode:
+	default:
+		return nil, []string{fmt.Sprintf(
+			"line %d: forward should map forward names to definitions", node.Line)}
+	}
+
+	forwards := make(map[string]ForwardDefinition, len(node.Content)/2)
+	var warnings []string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		if keyNode.Value == "defaults" {
+			continue
+		}
+		var def ForwardDefinition
+		if err := valueNode.Decode(&def); err != nil || def.Source == "" || def.Destination == "" {
+			warnings = append(warnings, fmt.Sprintf(
+				"line %d: skipped forward %q: not a forward definition with a source and destination", keyNode.Line, keyNode.Value))
+			continue
+		}
+		forwards[keyNode.Value] = def
+	}
+	return forwards, warnings
+}
+
+// overlayDefaults returns base with each section that over sets replaced by
+// over's. Either may be nil.
+func overlayDefaults(base, over *DefaultConfig) *DefaultConfig {
+	if base == nil {
+		return over
+	}
+	if over == nil {
+		return base
+	}
+	merged := *base
+	if over.Ignore != nil {
+		merged.Ignore = over.Ignore
+	}
+	if over.Symlink != nil {
+		merged.Symlink = over.Symlink
+	}
+	if over.Watch != nil {
+		merged.Watch = over.Watch
+	}
+	if over.Permissions != nil {
+		merged.Permissions = over.Permissions
+	}
+	if len(over.Extra) > 0 {
+		merged.Extra = make(map[string]interface{}, len(base.Extra)+len(over.Extra))
+		maps.Copy(merged.Extra, base.Extra)
+		maps.Copy(merged.Extra, over.Extra)
+	}
+	return &merged
+}
+
 // NewProject creates a Project from a ProjectFile.
 func NewProject(file ProjectFile) *Project {
 	specs := make([]SyncSpec, 0, len(file.Sessions))
@@ -141,6 +410,35 @@ func NewProject(file ProjectFile) *Project {
 	}
 }
 
+// ReloadFile replaces the project's file with a newly read version of it.
+// Specs the new file still defines keep their session state, new specs
+// start out not running, and specs it no longer defines are dropped.
+// Returns the names of the added and removed specs.
+func (p *Project) ReloadFile(file ProjectFile) (added, removed []string) {
+	previous := make(map[string]SyncSpec, len(p.Specs))
+	for _, spec := range p.Specs {
+		previous[spec.Name] = spec
+	}
+
+	specs := NewProject(file).Specs
+	for i, spec := range specs {
+		if old, ok := previous[spec.Name]; ok {
+			specs[i] = old
+			delete(previous, spec.Name)
+		} else {
+			added = append(added, spec.Name)
+		}
+	}
+	for name := range previous {
+		removed = append(removed, name)
+	}
+	sort.Strings(removed)
+
+	p.File = file
+	p.Specs = specs
+	return added, removed
+}
+
 // UserConfigPaths returns paths that are always searched for mutagen project files.
 // These are the standard user configuration directories.
 func UserConfigPaths() []string {
@@ -155,16 +453,10 @@ func UserConfigPaths() []string {
 	}
 }
 
-// FindProjects searches for mutagen.yml files starting from baseDir and additional search paths.
-// Uses a limited depth search to avoid scanning the entire filesystem.
-// baseDir is searched first (like --project-dir), then additional config search paths,
-// and finally the user config directories (~/.config/mutagen/projects, ~/.mutagen/projects)
-// which are always searched.
-func FindProjects(baseDir string, configSearchPaths []string, excludePatterns []string) ([]*Project, error) {
-	var projects []*Project
-	seen := make(map[string]bool)
-
-	// Build the search paths list
+// SearchPaths returns the directories FindProjects searches, in order:
+// baseDir, then the config search paths, then the user config directories
+// if includeUserConfig is set. Paths may start with ~.
+func SearchPaths(baseDir string, configSearchPaths []string, includeUserConfig bool) []string {
 	var searchPaths []string
 
 	// Start with base directory (current dir or --project-dir)
@@ -175,11 +467,34 @@ func FindProjects(baseDir string, configSearchPaths []string, excludePatterns []
 	// Add config search paths from config file
 	searchPaths = append(searchPaths, configSearchPaths...)
 
-	// User config directories where any .yml file is a project
-	userConfigDirs := UserConfigPaths()
+	if includeUserConfig {
+		searchPaths = append(searchPaths, UserConfigPaths()...)
+	}
+	return searchPaths
+}
 
-	// Always add user config directories (these are always searched)
-	searchPaths = append(searchPaths, userConfigDirs...)
+// FindProjects searches for mutagen.yml files starting from baseDir and additional search paths.
+// Uses a limited depth search to avoid scanning the entire filesystem.
+// baseDir is searched first (like --project-dir), then additional config search paths,
+// and finally, if includeUserConfig is set, the user config directories
+// (~/.config/mutagen/projects, ~/.mutagen/projects).
+func FindProjects(baseDir string, configSearchPaths []string, excludePatterns []string, includeUserConfig bool) ([]*Project, error) {
+	return FindProjectsCached(nil, baseDir, configSearchPaths, excludePatterns, includeUserConfig)
+}
+
+// FindProjectsCached is FindProjects, taking unchanged project files from
+// cache rather than parsing them again. A nil cache parses every file.
+func FindProjectsCached(cache *FileCache, baseDir string, configSearchPaths []string, excludePatterns []string, includeUserConfig bool) ([]*Project, error) {
+	var projects []*Project
+	seen := make(map[string]bool)
+
+	searchPaths := SearchPaths(baseDir, configSearchPaths, includeUserConfig)
+
+	// User config directories where any .yml file is a project
+	var userConfigDirs []string
+	if includeUserConfig {
+		userConfigDirs = UserConfigPaths()
+	}
 
 	// Build set of expanded user config directories for special handling
 	userConfigSet := make(map[string]bool)
@@ -206,7 +521,7 @@ func FindProjects(baseDir string, configSearchPaths []string, excludePatterns []
 		acceptAnyYml := userConfigSet[searchPath]
 
 		// Search with limited depth (max 4 levels)
-		findProjectsInDir(searchPath, excludePatterns, &projects, seen, 0, 4, acceptAnyYml)
+		findProjectsInDir(cache, searchPath, excludePatterns, &projects, seen, 0, 4, acceptAnyYml)
 	}
 
 	return projects, nil
@@ -232,7 +547,7 @@ func expandPath(path string) string {
 
 // findProjectsInDir searches for mutagen.yml files with depth limiting.
 // If acceptAnyYml is true, any .yml file is considered a project file.
-func findProjectsInDir(dir string, excludePatterns []string, projects *[]*Project, seen map[string]bool, depth, maxDepth int, acceptAnyYml bool) {
+func findProjectsInDir(cache *FileCache, dir string, excludePatterns []string, projects *[]*Project, seen map[string]bool, depth, maxDepth int, acceptAnyYml bool) {
 	if depth > maxDepth {
 		return
 	}
@@ -261,7 +576,7 @@ func findProjectsInDir(dir string, excludePatterns []string, projects *[]*Projec
 				continue
 			}
 			// Recurse into subdirectories (don't propagate acceptAnyYml to subdirs)
-			findProjectsInDir(path, excludePatterns, projects, seen, depth+1, maxDepth, false)
+			findProjectsInDir(cache, path, excludePatterns, projects, seen, depth+1, maxDepth, false)
 		} else {
 			// Skip lock files
 			if strings.HasSuffix(name, ".lock") {
@@ -290,7 +605,7 @@ func findProjectsInDir(dir string, excludePatterns []string, projects *[]*Projec
 				}
 				seen[absPath] = true
 
-				pf, err := LoadProjectFile(path)
+				pf, err := cache.Load(path)
 				if err != nil {
 					continue // Skip invalid files
 				}
@@ -301,7 +616,8 @@ func findProjectsInDir(dir string, excludePatterns []string, projects *[]*Projec
 }
 
 // UpdateFromSessions updates the project's spec states based on running sessions.
-// Matches sessions by name (spec name for two-way, spec-name-push for push sessions).
+// Matches sessions by name (spec name for two-way, spec-name-push for push
+// sessions, and spec@host for each replica of a spec with several betas).
 func (p *Project) UpdateFromSessions(sessions []mutagen.SyncSession) {
 	// Create maps of session names to sessions
 	sessionByName := make(map[string]*mutagen.SyncSession)
@@ -314,11 +630,17 @@ func (p *Project) UpdateFromSessions(sessions []mutagen.SyncSession) {
 	for i := range p.Specs {
 		spec := &p.Specs[i]
 
+		// A spec with several betas runs a session per beta
+		if def, ok := p.File.Sessions[spec.Name]; ok && def.IsMultiBeta() {
+			spec.updateReplicas(def.Replicas(spec.Name), sessionByName)
+			continue
+		}
+		spec.BetaSessions = nil
+
 		// Look for two-way session (exact name match, not one-way-replica mode)
 		if session, exists := sessionByName[spec.Name]; exists {
 			if session.Mode == nil || *session.Mode != "one-way-replica" {
-				spec.RunningSession = session
-				spec.State = RunningTwoWay
+				spec.SetSession(session, RunningTwoWay)
 				continue
 			}
 		}
@@ -327,8 +649,7 @@ func (p *Project) UpdateFromSessions(sessions []mutagen.SyncSession) {
 		pushName := spec.Name + "-push"
 		if session, exists := sessionByName[pushName]; exists {
 			if session.Mode != nil && *session.Mode == "one-way-replica" {
-				spec.RunningSession = session
-				spec.State = RunningPush
+				spec.SetSession(session, RunningPush)
 				continue
 			}
 		}
@@ -336,8 +657,7 @@ func (p *Project) UpdateFromSessions(sessions []mutagen.SyncSession) {
 		// Also check if the exact name is a one-way-replica (legacy push format)
 		if session, exists := sessionByName[spec.Name]; exists {
 			if session.Mode != nil && *session.Mode == "one-way-replica" {
-				spec.RunningSession = session
-				spec.State = RunningPush
+				spec.SetSession(session, RunningPush)
 				continue
 			}
 		}
@@ -3,6 +3,8 @@ package project
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/osteele/mutagui/internal/mutagen"
@@ -182,6 +184,32 @@ func TestProject_UpdateFromSessions(t *testing.T) {
 	}
 }
 
+func TestProject_UpdateFromSessions_ReplacesSession(t *testing.T) {
+	proj := &Project{Specs: []SyncSpec{{Name: "web"}}}
+
+	proj.UpdateFromSessions([]mutagen.SyncSession{{Name: "web", Identifier: "sync_1", Status: "scanning"}})
+	first := proj.Specs[0].RunningSession
+	first.SyncTime = mutagen.SyncTimeAt
+
+	proj.UpdateFromSessions([]mutagen.SyncSession{{Name: "web", Identifier: "sync_1", Status: "watching"}})
+	refreshed := proj.Specs[0].RunningSession
+	if refreshed.Status != "watching" {
+		t.Errorf("Status = %q, want the refreshed status", refreshed.Status)
+	}
+	if refreshed.SyncTime != mutagen.SyncTimeAt {
+		t.Errorf("SyncTime = %v, want it kept across refreshes", refreshed.SyncTime)
+	}
+	if first.Status != "scanning" {
+		t.Error("the session the UI may be drawing should not be overwritten")
+	}
+
+	// A recreated session is a different session
+	proj.UpdateFromSessions([]mutagen.SyncSession{{Name: "web", Identifier: "sync_2", Status: "connecting"}})
+	if second := proj.Specs[0].RunningSession; second.Identifier != "sync_2" || second.SyncTime == mutagen.SyncTimeAt {
+		t.Errorf("RunningSession = %+v, want a new session without the old sync time", second)
+	}
+}
+
 func TestProject_UpdateFromSessions_MatchesByName(t *testing.T) {
 	proj := &Project{
 		File: ProjectFile{Path: "/test/project1/mutagen.yml"},
@@ -324,6 +352,148 @@ sync:
 	}
 }
 
+func TestLoadProjectFile_SubConfigs(t *testing.T) {
+	yamlPath := filepath.Join(t.TempDir(), "mutagen.yml")
+	content := `sync:
+  defaults:
+    watch:
+      mode: no-watch
+  web:
+    alpha: "/local/path"
+    beta: "server:/remote/path"
+    symlink:
+      mode: posix-raw
+    watch:
+      mode: force-poll
+      pollingInterval: 20
+    permissions:
+      mode: manual
+      defaultFileMode: 0644
+      defaultDirectoryMode: 0755
+      defaultOwner: deploy
+      defaultGroup: www
+    stageMode: neighboring
+`
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	pf, err := LoadProjectFile(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadProjectFile() error = %v", err)
+	}
+	web := pf.Sessions["web"]
+	if web.Symlink == nil || web.Symlink.Mode != "posix-raw" {
+		t.Errorf("Symlink = %+v, want posix-raw", web.Symlink)
+	}
+	if web.Watch == nil || *web.Watch != (WatchConfig{Mode: "force-poll", PollingInterval: 20}) {
+		t.Errorf("Watch = %+v, want force-poll every 20s", web.Watch)
+	}
+	wantPermissions := PermissionsConfig{
+		Mode:                 "manual",
+		DefaultFileMode:      "0644",
+		DefaultDirectoryMode: "0755",
+		DefaultOwner:         "deploy",
+		DefaultGroup:         "www",
+	}
+	if web.Permissions == nil || *web.Permissions != wantPermissions {
+		t.Errorf("Permissions = %+v, want %+v", web.Permissions, wantPermissions)
+	}
+
+	// Known sub-configs are typed; only unknown keys are left in Extra
+	if len(web.Extra) != 1 || web.Extra["stageMode"] != "neighboring" {
+		t.Errorf("Extra = %v, want only stageMode", web.Extra)
+	}
+
+	if pf.Defaults == nil || pf.Defaults.Watch == nil || pf.Defaults.Watch.Mode != "no-watch" {
+		t.Errorf("Defaults = %+v, want watch mode no-watch", pf.Defaults)
+	}
+}
+
+func TestLoadProjectFile_TopLevelDefaults(t *testing.T) {
+	yamlPath := filepath.Join(t.TempDir(), "mutagen.yml")
+	content := `defaults:
+  ignore:
+    paths: [".DS_Store"]
+  watch:
+    mode: no-watch
+sync:
+  web:
+    alpha: "/local/path"
+    beta: "server:/remote/path"
+`
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	pf, err := LoadProjectFile(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadProjectFile() error = %v", err)
+	}
+	if pf.Defaults == nil || pf.Defaults.Ignore == nil || len(pf.Defaults.Ignore.Paths) != 1 {
+		t.Fatalf("Defaults = %+v, want the top-level ignores", pf.Defaults)
+	}
+	if len(pf.Sessions) != 1 {
+		t.Errorf("Sessions = %v, want only web", pf.Sessions)
+	}
+
+	// With sync.defaults too, its sections win and the others are kept
+	content += `  defaults:
+    ignore:
+      paths: ["node_modules"]
+`
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	pf, err = LoadProjectFile(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadProjectFile() error = %v", err)
+	}
+	if pf.Defaults == nil || pf.Defaults.Ignore == nil || len(pf.Defaults.Ignore.Paths) != 1 || pf.Defaults.Ignore.Paths[0] != "node_modules" {
+		t.Errorf("Defaults.Ignore = %+v, want sync.defaults' ignores", pf.Defaults.Ignore)
+	}
+	if pf.Defaults.Watch == nil || pf.Defaults.Watch.Mode != "no-watch" {
+		t.Errorf("Defaults.Watch = %+v, want the top-level watch mode", pf.Defaults.Watch)
+	}
+}
+
+func TestLoadProjectFile_Forwards(t *testing.T) {
+	yamlPath := filepath.Join(t.TempDir(), "mutagen.yml")
+	content := `sync:
+  web:
+    alpha: "/local/path"
+    beta: "server:/remote/path"
+forward:
+  defaults:
+    socket:
+      overwriteMode: overwrite
+  api:
+    source: "tcp:localhost:8080"
+    destination: "server:tcp:localhost:80"
+  broken:
+    source: "tcp:localhost:9090"
+`
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	pf, err := LoadProjectFile(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadProjectFile() error = %v", err)
+	}
+
+	want := map[string]ForwardDefinition{
+		"api": {Source: "tcp:localhost:8080", Destination: "server:tcp:localhost:80"},
+	}
+	if !reflect.DeepEqual(pf.Forwards, want) {
+		t.Errorf("Forwards = %+v, want %+v", pf.Forwards, want)
+	}
+	if len(pf.Sessions) != 1 {
+		t.Errorf("Sessions = %v, want only web", pf.Sessions)
+	}
+	if len(pf.Warnings) != 1 || !strings.Contains(pf.Warnings[0], `skipped forward "broken"`) {
+		t.Errorf("Warnings = %q, want one for the forward without a destination", pf.Warnings)
+	}
+}
+
 func TestLoadProjectFile_NotFound(t *testing.T) {
 	_, err := LoadProjectFile("/nonexistent/path/mutagen.yml")
 	if err == nil {
@@ -346,6 +516,57 @@ func TestLoadProjectFile_InvalidYAML(t *testing.T) {
 	}
 }
 
+func TestLoadProjectFile_SyncList(t *testing.T) {
+	tmpDir := t.TempDir()
+	yamlPath := filepath.Join(tmpDir, "mutagen.yml")
+
+	content := `sync:
+  - name: code
+    alpha: .
+    beta: server:/code
+`
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	pf, err := LoadProjectFile(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadProjectFile() error = %v, want the file loaded with a warning", err)
+	}
+	if len(pf.Sessions) != 0 {
+		t.Errorf("Sessions = %v, want none", pf.Sessions)
+	}
+	if len(pf.Warnings) != 1 || !strings.Contains(pf.Warnings[0], "sync is a list") {
+		t.Errorf("Warnings = %v, want one about sync being a list", pf.Warnings)
+	}
+}
+
+func TestLoadProjectFile_SkipsMalformedSession(t *testing.T) {
+	tmpDir := t.TempDir()
+	yamlPath := filepath.Join(tmpDir, "mutagen.yml")
+
+	content := `sync:
+  good:
+    alpha: .
+    beta: server:/code
+  bad: [., server:/other]
+`
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	pf, err := LoadProjectFile(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadProjectFile() error = %v", err)
+	}
+	if _, ok := pf.Sessions["good"]; !ok || len(pf.Sessions) != 1 {
+		t.Errorf("Sessions = %v, want only good", pf.Sessions)
+	}
+	if len(pf.Warnings) != 1 || !strings.Contains(pf.Warnings[0], `"bad"`) {
+		t.Errorf("Warnings = %v, want one naming bad", pf.Warnings)
+	}
+}
+
 func TestFindProjects(t *testing.T) {
 	// Isolate from real user config by setting HOME to temp dir
 	origHome := os.Getenv("HOME")
@@ -378,7 +599,7 @@ func TestFindProjects(t *testing.T) {
 		t.Fatalf("Failed to write file: %v", err)
 	}
 
-	projects, err := FindProjects(tmpDir, nil, nil)
+	projects, err := FindProjects(tmpDir, nil, nil, true)
 	if err != nil {
 		t.Fatalf("FindProjects() error = %v", err)
 	}
@@ -419,7 +640,7 @@ func TestFindProjects_ExcludePatterns(t *testing.T) {
 		t.Fatalf("Failed to write file: %v", err)
 	}
 
-	projects, err := FindProjects(tmpDir, nil, []string{"node_modules"})
+	projects, err := FindProjects(tmpDir, nil, []string{"node_modules"}, true)
 	if err != nil {
 		t.Fatalf("FindProjects() error = %v", err)
 	}
@@ -452,7 +673,7 @@ func TestFindProjects_DepthLimit(t *testing.T) {
 		t.Fatalf("Failed to write file: %v", err)
 	}
 
-	projects, err := FindProjects(tmpDir, nil, nil)
+	projects, err := FindProjects(tmpDir, nil, nil, true)
 	if err != nil {
 		t.Fatalf("FindProjects() error = %v", err)
 	}
@@ -499,3 +720,49 @@ func TestUserConfigPaths(t *testing.T) {
 func strPtr(s string) *string {
 	return &s
 }
+
+func TestFindProjects_IncludeUserConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	userDir := filepath.Join(home, ".config", "mutagen", "projects")
+	if err := os.MkdirAll(userDir, 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	yaml := `sync:
+  session1:
+    alpha: "/local"
+    beta: "server:/remote"
+`
+	if err := os.WriteFile(filepath.Join(userDir, "global.yml"), []byte(yaml), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	baseDir := t.TempDir()
+
+	projects, err := FindProjects(baseDir, nil, nil, true)
+	if err != nil {
+		t.Fatalf("FindProjects() error = %v", err)
+	}
+	if len(projects) != 1 {
+		t.Errorf("FindProjects() with user config found %d projects, want 1", len(projects))
+	}
+
+	projects, err = FindProjects(baseDir, nil, nil, false)
+	if err != nil {
+		t.Fatalf("FindProjects() error = %v", err)
+	}
+	if len(projects) != 0 {
+		t.Errorf("FindProjects() without user config found %d projects, want 0", len(projects))
+	}
+}
+
+func TestSearchPaths(t *testing.T) {
+	got := SearchPaths("/work", []string{"~/code"}, false)
+	if strings.Join(got, ",") != "/work,~/code" {
+		t.Errorf("SearchPaths() = %v", got)
+	}
+	got = SearchPaths("", nil, true)
+	if len(got) != len(UserConfigPaths()) {
+		t.Errorf("SearchPaths() = %v, want the user config paths", got)
+	}
+}
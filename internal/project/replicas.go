@@ -0,0 +1,117 @@
+package project
+
+import "github.com/osteele/mutagui/internal/mutagen"
+
+// Replica is one of the sessions a spec runs: its alpha synced with one of
+// its betas.
+type Replica struct {
+	Name string // Session name
+	Beta string
+}
+
+// IsMultiBeta returns true if the definition lists betas, so that its spec
+// runs a session for each.
+func (d *SessionDefinition) IsMultiBeta() bool {
+	return len(d.Betas) > 0
+}
+
+// BetaEndpoints returns the definition's betas: beta, if set, followed by
+// the betas list.
+func (d *SessionDefinition) BetaEndpoints() []string {
+	var betas []string
+	if d.Beta != "" {
+		betas = append(betas, d.Beta)
+	}
+	return append(betas, d.Betas...)
+}
+
+// Replicas returns the sessions that the spec named name runs. A spec with
+// a single beta runs one session, named after the spec. A spec with betas
+// runs one for each beta, named by ReplicaName.
+func (d *SessionDefinition) Replicas(name string) []Replica {
+	if !d.IsMultiBeta() {
+		return []Replica{{Name: name, Beta: d.Beta}}
+	}
+	var replicas []Replica
+	for _, beta := range d.BetaEndpoints() {
+		replicas = append(replicas, Replica{Name: ReplicaName(name, beta), Beta: beta})
+	}
+	return replicas
+}
+
+// WithBeta returns a copy of the definition that syncs alpha with beta
+// alone, for creating one of its replicas.
+func (d SessionDefinition) WithBeta(beta string) SessionDefinition {
+	d.Beta = beta
+	d.Betas = nil
+	return d
+}
+
+// ReplicaName returns the session name of a spec's replica on beta:
+// "<spec>@<host>", with "local" as the host of a local path.
+func ReplicaName(spec, beta string) string {
+	return spec + "@" + endpointHost(beta)
+}
+
+// endpointHost returns the host of an endpoint, without any user or port.
+func endpointHost(endpoint string) string {
+	if host := ParseEndpoint(endpoint).Host; host != "" {
+		return host
+	}
+	return "local"
+}
+
+// dropDuplicateBetas removes betas whose replicas would have the same
+// session name as an earlier one, since only one of them could run, and
+// returns the betas it removed.
+func (d *SessionDefinition) dropDuplicateBetas(name string) (dropped []string) {
+	if !d.IsMultiBeta() {
+		return nil
+	}
+	seen := map[string]bool{}
+	if d.Beta != "" {
+		seen[ReplicaName(name, d.Beta)] = true
+	}
+	var betas []string
+	for _, beta := range d.Betas {
+		replica := ReplicaName(name, beta)
+		if seen[replica] {
+			dropped = append(dropped, beta)
+			continue
+		}
+		seen[replica] = true
+		betas = append(betas, beta)
+	}
+	d.Betas = betas
+	return dropped
+}
+
+// updateReplicas matches the sessions of a spec's replicas by name. The
+// spec is running if any replica is, with the first running one as its
+// RunningSession.
+func (s *SyncSpec) updateReplicas(replicas []Replica, sessionByName map[string]*mutagen.SyncSession) {
+	sessions := make([]*mutagen.SyncSession, len(replicas))
+	found := false
+	for i, replica := range replicas {
+		session, exists := sessionByName[replica.Name]
+		if !exists {
+			continue
+		}
+		if !found {
+			state := RunningTwoWay
+			if session.Mode != nil && *session.Mode == "one-way-replica" {
+				state = RunningPush
+			}
+			// Keep the pointer that anything showing the spec holds
+			s.SetSession(session, state)
+			session = s.RunningSession
+			found = true
+		}
+		sessions[i] = session
+	}
+	s.BetaSessions = sessions
+	if !found {
+		s.State = NotRunning
+		s.RunningSession = nil
+	}
+}
@@ -0,0 +1,106 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+func TestLoadProjectFile_Betas(t *testing.T) {
+	yamlPath := filepath.Join(t.TempDir(), "mutagen.yml")
+	content := `sync:
+  web:
+    alpha: ./web
+    beta: deploy@east:/srv/web
+    betas: [west:/srv/web, ssh://deploy@north:2222/srv/web, east:/srv/copy]
+  api:
+    alpha: ./api
+    beta: east:/srv/api
+`
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	pf, err := LoadProjectFile(yamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	web := pf.Sessions["web"]
+	var names, betas []string
+	for _, replica := range web.Replicas("web") {
+		names = append(names, replica.Name)
+		betas = append(betas, replica.Beta)
+	}
+	// The second beta on east would reuse web@east, so it is skipped
+	if want := []string{"web@east", "web@west", "web@north"}; !slices.Equal(names, want) {
+		t.Errorf("replica names = %v, want %v", names, want)
+	}
+	if want := []string{"deploy@east:/srv/web", "west:/srv/web", "ssh://deploy@north:2222/srv/web"}; !slices.Equal(betas, want) {
+		t.Errorf("replica betas = %v, want %v", betas, want)
+	}
+	if len(pf.Warnings) != 1 || !strings.Contains(pf.Warnings[0], "east:/srv/copy") {
+		t.Errorf("Warnings = %v, want one naming the skipped beta", pf.Warnings)
+	}
+
+	// A spec with only a beta runs one session with its own name
+	api := pf.Sessions["api"]
+	if got := api.Replicas("api"); len(got) != 1 || got[0] != (Replica{Name: "api", Beta: "east:/srv/api"}) {
+		t.Errorf("api replicas = %v", got)
+	}
+}
+
+func TestReplicaName(t *testing.T) {
+	tests := []struct{ beta, want string }{
+		{"server:/srv", "web@server"},
+		{"user@server:/srv", "web@server"},
+		{"docker://container/srv", "web@container"},
+		{"/local/copy", "web@local"},
+		{`C:\copy`, "web@local"},
+	}
+	for _, tt := range tests {
+		if got := ReplicaName("web", tt.beta); got != tt.want {
+			t.Errorf("ReplicaName(web, %q) = %q, want %q", tt.beta, got, tt.want)
+		}
+	}
+}
+
+func TestProject_UpdateFromSessions_Replicas(t *testing.T) {
+	proj := NewProject(ProjectFile{Sessions: map[string]SessionDefinition{
+		"web": {Alpha: "./web", Betas: []string{"east:/web", "west:/web", "north:/web"}},
+	}})
+	spec := &proj.Specs[0]
+
+	proj.UpdateFromSessions([]mutagen.SyncSession{
+		{Name: "web@west", Identifier: "sync_w"},
+		{Name: "web@north", Identifier: "sync_n"},
+		{Name: "web", Identifier: "sync_other"}, // Not one of its replicas
+	})
+	if spec.State != RunningTwoWay || spec.RunningSession == nil || spec.RunningSession.Name != "web@west" {
+		t.Fatalf("spec = %v %+v, want running with web@west", spec.State, spec.RunningSession)
+	}
+	if running, total := spec.ReplicaCounts(); running != 2 || total != 3 {
+		t.Errorf("ReplicaCounts() = %d/%d, want 2/3", running, total)
+	}
+	if spec.BetaSessions[0] != nil || spec.BetaSessions[2].Name != "web@north" {
+		t.Errorf("BetaSessions = %v, want each replica's session in place", spec.BetaSessions)
+	}
+	var names []string
+	for _, session := range spec.Sessions() {
+		names = append(names, session.Name)
+	}
+	if !slices.Equal(names, []string{"web@west", "web@north"}) {
+		t.Errorf("Sessions() = %v", names)
+	}
+
+	proj.UpdateFromSessions(nil)
+	if spec.State != NotRunning || spec.RunningSession != nil {
+		t.Errorf("spec = %v %+v, want not running", spec.State, spec.RunningSession)
+	}
+	if running, total := spec.ReplicaCounts(); running != 0 || total != 3 {
+		t.Errorf("ReplicaCounts() = %d/%d, want 0/3", running, total)
+	}
+}
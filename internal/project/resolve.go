@@ -0,0 +1,165 @@
+package project
+
+import "strconv"
+
+// Source says which part of a project file a session setting comes from.
+type Source int
+
+const (
+	// FromDefaults is the project file's defaults section.
+	FromDefaults Source = iota
+	// FromSpec is the spec's own definition.
+	FromSpec
+)
+
+func (s Source) String() string {
+	if s == FromSpec {
+		return "spec"
+	}
+	return "defaults"
+}
+
+// ResolvedIgnore is an ignore pattern and the part of the file it is from.
+type ResolvedIgnore struct {
+	Pattern string
+	Source  Source
+}
+
+// ResolvedSetting is a setting the project file gives a session, named by
+// its key in the file, as in "watch.mode".
+type ResolvedSetting struct {
+	Name   string
+	Value  string
+	Source Source
+
+	// Overrides is true if the spec's value replaces one in the defaults
+	Overrides bool
+}
+
+// ResolvedConfig is the configuration a project file gives one of its
+// sessions: the spec's definition merged over the project defaults.
+// Settings that neither sets are left empty, for Mutagen's defaults.
+type ResolvedConfig struct {
+	Mode        string
+	Ignores     []ResolvedIgnore // The defaults' patterns, then the spec's
+	IgnoreVCS   *bool
+	Symlink     SymlinkConfig
+	Watch       WatchConfig
+	Permissions PermissionsConfig
+
+	// Settings lists where each setting but the ignore patterns came from,
+	// in the order of the fields above
+	Settings []ResolvedSetting
+}
+
+// ResolveSessionConfig merges a session definition with the project
+// defaults. Ignore patterns accumulate, the defaults' first, in the order
+// Mutagen applies them; any other setting in the definition replaces the
+// same setting in the defaults. Defaults can't set a mode.
+func ResolveSessionConfig(def *SessionDefinition, defaults *DefaultConfig) ResolvedConfig {
+	var r ResolvedConfig
+	var base DefaultConfig
+	if defaults != nil {
+		base = *defaults
+	}
+
+	if def.Mode != nil && *def.Mode != "" {
+		r.Mode = r.pick("mode", "", *def.Mode)
+	}
+
+	var baseVCS, specVCS *bool
+	if base.Ignore != nil {
+		for _, pattern := range base.Ignore.Paths {
+			r.Ignores = append(r.Ignores, ResolvedIgnore{Pattern: pattern, Source: FromDefaults})
+		}
+		baseVCS = base.Ignore.VCS
+	}
+	if def.Ignore != nil {
+		for _, pattern := range def.Ignore.Paths {
+			r.Ignores = append(r.Ignores, ResolvedIgnore{Pattern: pattern, Source: FromSpec})
+		}
+		specVCS = def.Ignore.VCS
+	}
+	switch {
+	case specVCS != nil:
+		r.IgnoreVCS = specVCS
+		r.record("ignore.vcs", strconv.FormatBool(*specVCS), FromSpec, baseVCS != nil)
+	case baseVCS != nil:
+		r.IgnoreVCS = baseVCS
+		r.record("ignore.vcs", strconv.FormatBool(*baseVCS), FromDefaults, false)
+	}
+
+	var baseSymlink, specSymlink SymlinkConfig
+	if base.Symlink != nil {
+		baseSymlink = *base.Symlink
+	}
+	if def.Symlink != nil {
+		specSymlink = *def.Symlink
+	}
+	r.Symlink.Mode = r.pick("symlink.mode", baseSymlink.Mode, specSymlink.Mode)
+
+	var baseWatch, specWatch WatchConfig
+	if base.Watch != nil {
+		baseWatch = *base.Watch
+	}
+	if def.Watch != nil {
+		specWatch = *def.Watch
+	}
+	r.Watch.Mode = r.pick("watch.mode", baseWatch.Mode, specWatch.Mode)
+	interval := r.pick("watch.pollingInterval", formatInterval(baseWatch.PollingInterval), formatInterval(specWatch.PollingInterval))
+	if n, err := strconv.ParseUint(interval, 10, 32); err == nil {
+		r.Watch.PollingInterval = uint32(n)
+	}
+
+	var basePerms, specPerms PermissionsConfig
+	if base.Permissions != nil {
+		basePerms = *base.Permissions
+	}
+	if def.Permissions != nil {
+		specPerms = *def.Permissions
+	}
+	r.Permissions = PermissionsConfig{
+		Mode:                 r.pick("permissions.mode", basePerms.Mode, specPerms.Mode),
+		DefaultFileMode:      r.pick("permissions.defaultFileMode", basePerms.DefaultFileMode, specPerms.DefaultFileMode),
+		DefaultDirectoryMode: r.pick("permissions.defaultDirectoryMode", basePerms.DefaultDirectoryMode, specPerms.DefaultDirectoryMode),
+		DefaultOwner:         r.pick("permissions.defaultOwner", basePerms.DefaultOwner, specPerms.DefaultOwner),
+		DefaultGroup:         r.pick("permissions.defaultGroup", basePerms.DefaultGroup, specPerms.DefaultGroup),
+	}
+	return r
+}
+
+// IgnorePaths returns the ignore patterns without their sources, or nil if
+// there are none.
+func (r *ResolvedConfig) IgnorePaths() []string {
+	var patterns []string
+	for _, ignore := range r.Ignores {
+		patterns = append(patterns, ignore.Pattern)
+	}
+	return patterns
+}
+
+// pick returns the spec's value of a setting if it sets one, or else the
+// defaults' value, and records where it came from. Empty values are unset.
+func (r *ResolvedConfig) pick(name, fromDefaults, fromSpec string) string {
+	switch {
+	case fromSpec != "":
+		r.record(name, fromSpec, FromSpec, fromDefaults != "")
+		return fromSpec
+	case fromDefaults != "":
+		r.record(name, fromDefaults, FromDefaults, false)
+		return fromDefaults
+	}
+	return ""
+}
+
+func (r *ResolvedConfig) record(name, value string, source Source, overrides bool) {
+	r.Settings = append(r.Settings, ResolvedSetting{Name: name, Value: value, Source: source, Overrides: overrides})
+}
+
+// formatInterval formats a polling interval, or returns "" if it is unset.
+func formatInterval(seconds uint32) string {
+	if seconds == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(seconds), 10)
+}
@@ -0,0 +1,72 @@
+package project
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestResolveSessionConfig_Ignores(t *testing.T) {
+	def := &SessionDefinition{Ignore: &IgnoreConfig{Paths: []string{"*.log", "!keep.log"}}}
+	defaults := &DefaultConfig{Ignore: &IgnoreConfig{Paths: []string{".DS_Store", "node_modules/"}}}
+
+	resolved := ResolveSessionConfig(def, defaults)
+	want := []ResolvedIgnore{
+		{".DS_Store", FromDefaults},
+		{"node_modules/", FromDefaults},
+		{"*.log", FromSpec},
+		{"!keep.log", FromSpec},
+	}
+	if !slices.Equal(resolved.Ignores, want) {
+		t.Errorf("Ignores = %v, want the defaults' first: %v", resolved.Ignores, want)
+	}
+	if got := resolved.IgnorePaths(); !slices.Equal(got, []string{".DS_Store", "node_modules/", "*.log", "!keep.log"}) {
+		t.Errorf("IgnorePaths() = %v", got)
+	}
+
+	resolved = ResolveSessionConfig(def, nil)
+	if !slices.Equal(resolved.Ignores, want[2:]) {
+		t.Errorf("Ignores without defaults = %v, want %v", resolved.Ignores, want[2:])
+	}
+	resolved = ResolveSessionConfig(&SessionDefinition{}, nil)
+	if got := resolved.IgnorePaths(); got != nil {
+		t.Errorf("IgnorePaths() = %v, want none", got)
+	}
+}
+
+func TestResolveSessionConfig_Provenance(t *testing.T) {
+	yes, no := true, false
+	mode := "two-way-resolved"
+	defaults := &DefaultConfig{
+		Ignore:      &IgnoreConfig{VCS: &yes},
+		Symlink:     &SymlinkConfig{Mode: "ignore"},
+		Watch:       &WatchConfig{Mode: "no-watch", PollingInterval: 60},
+		Permissions: &PermissionsConfig{DefaultOwner: "root"},
+	}
+	def := &SessionDefinition{
+		Mode:        &mode,
+		Ignore:      &IgnoreConfig{VCS: &no},
+		Watch:       &WatchConfig{Mode: "force-poll"},
+		Permissions: &PermissionsConfig{DefaultOwner: "deploy", DefaultGroup: "staff"},
+	}
+
+	resolved := ResolveSessionConfig(def, defaults)
+	want := []ResolvedSetting{
+		{Name: "mode", Value: "two-way-resolved", Source: FromSpec},
+		{Name: "ignore.vcs", Value: "false", Source: FromSpec, Overrides: true},
+		{Name: "symlink.mode", Value: "ignore", Source: FromDefaults},
+		{Name: "watch.mode", Value: "force-poll", Source: FromSpec, Overrides: true},
+		{Name: "watch.pollingInterval", Value: "60", Source: FromDefaults},
+		{Name: "permissions.defaultOwner", Value: "deploy", Source: FromSpec, Overrides: true},
+		{Name: "permissions.defaultGroup", Value: "staff", Source: FromSpec},
+	}
+	if !reflect.DeepEqual(resolved.Settings, want) {
+		t.Errorf("Settings =\n%+v\nwant\n%+v", resolved.Settings, want)
+	}
+	if resolved.IgnoreVCS == nil || *resolved.IgnoreVCS {
+		t.Errorf("IgnoreVCS = %v, want the spec's false", resolved.IgnoreVCS)
+	}
+	if resolved.Watch != (WatchConfig{Mode: "force-poll", PollingInterval: 60}) {
+		t.Errorf("Watch = %+v", resolved.Watch)
+	}
+}
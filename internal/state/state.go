@@ -0,0 +1,168 @@
+// Package state persists what mutagui remembers between runs, such as which
+// specs have been started before.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// data is the JSON form of the state file.
+type data struct {
+	// StartedSpecs maps a spec key to when it was first seen running
+	StartedSpecs map[string]time.Time `json:"startedSpecs,omitempty"`
+	// Folded maps a project file path to whether it was last folded
+	Folded map[string]bool `json:"folded,omitempty"`
+	// Scheduled holds the keys of specs kept paused and flushed on a schedule
+	Scheduled map[string]bool `json:"scheduled,omitempty"`
+	// ConfigDeclined is set once the offer of a starter config is declined
+	ConfigDeclined bool `json:"configDeclined,omitempty"`
+}
+
+// Store holds persisted state. A Store with an empty path keeps its state
+// in memory only.
+type Store struct {
+	path string
+	data data
+}
+
+// DefaultPath returns the state file location:
+// $XDG_STATE_HOME/mutagui/state.json, or ~/.local/state/mutagui/state.json.
+// Returns "" if the home directory can't be determined.
+func DefaultPath() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "mutagui", "state.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".local", "state", "mutagui", "state.json")
+}
+
+// NewMemoryStore returns a Store that is never written to disk.
+func NewMemoryStore() *Store {
+	return &Store{}
+}
+
+// Open loads the state file at path. A missing file yields an empty store
+// that will be created on the first Save.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(contents, &s.data); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the state file, replacing it atomically.
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	contents, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, contents, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// SpecKey identifies a spec across runs by its project file and name.
+func SpecKey(projectFilePath, specName string) string {
+	return projectFilePath + "#" + specName
+}
+
+// HasStarted returns true if the spec has been seen running before.
+func (s *Store) HasStarted(key string) bool {
+	_, ok := s.data.StartedSpecs[key]
+	return ok
+}
+
+// MarkStarted records that the spec has been seen running. Returns true if
+// this is the first time, meaning the store needs saving.
+func (s *Store) MarkStarted(key string, at time.Time) bool {
+	if s.HasStarted(key) {
+		return false
+	}
+	if s.data.StartedSpecs == nil {
+		s.data.StartedSpecs = make(map[string]time.Time)
+	}
+	s.data.StartedSpecs[key] = at
+	return true
+}
+
+// Folded returns whether the project was last folded, and false for ok if
+// its fold state was never recorded.
+func (s *Store) Folded(projectFilePath string) (folded, ok bool) {
+	folded, ok = s.data.Folded[projectFilePath]
+	return folded, ok
+}
+
+// SetFolded records whether the project is folded. Returns true if this
+// changed the stored value, meaning the store needs saving.
+func (s *Store) SetFolded(projectFilePath string, folded bool) bool {
+	if old, ok := s.data.Folded[projectFilePath]; ok && old == folded {
+		return false
+	}
+	if s.data.Folded == nil {
+		s.data.Folded = make(map[string]bool)
+	}
+	s.data.Folded[projectFilePath] = folded
+	return true
+}
+
+// Scheduled returns true if the spec is kept paused and flushed on a schedule.
+func (s *Store) Scheduled(key string) bool {
+	return s.data.Scheduled[key]
+}
+
+// SetScheduled records whether the spec is flushed on a schedule. Returns
+// true if this changed the stored value, meaning the store needs saving.
+func (s *Store) SetScheduled(key string, scheduled bool) bool {
+	if s.data.Scheduled[key] == scheduled {
+		return false
+	}
+	if scheduled {
+		if s.data.Scheduled == nil {
+			s.data.Scheduled = make(map[string]bool)
+		}
+		s.data.Scheduled[key] = true
+	} else {
+		delete(s.data.Scheduled, key)
+	}
+	return true
+}
+
+// ConfigDeclined returns true if the offer to write a starter config was
+// declined, so it shouldn't be made again.
+func (s *Store) ConfigDeclined() bool {
+	return s.data.ConfigDeclined
+}
+
+// DeclineConfig records that the offer of a starter config was declined.
+// Returns true if this is the first time, meaning the store needs saving.
+func (s *Store) DeclineConfig() bool {
+	if s.data.ConfigDeclined {
+		return false
+	}
+	s.data.ConfigDeclined = true
+	return true
+}
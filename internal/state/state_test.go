@@ -0,0 +1,148 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOpen_MissingFile(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if s.HasStarted(SpecKey("/p/mutagen.yml", "code")) {
+		t.Error("empty store reports a started spec")
+	}
+}
+
+func TestStore_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mutagui", "state.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	key := SpecKey("/p/mutagen.yml", "code")
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	if !s.MarkStarted(key, now) {
+		t.Error("MarkStarted() = false on first mark")
+	}
+	if s.MarkStarted(key, now.Add(time.Hour)) {
+		t.Error("MarkStarted() = true on second mark")
+	}
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if !reopened.HasStarted(key) {
+		t.Error("started spec not persisted")
+	}
+	if reopened.HasStarted(SpecKey("/p/mutagen.yml", "other")) {
+		t.Error("unstarted spec reported as started")
+	}
+}
+
+func TestOpen_InvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Error("Open() should fail on invalid JSON")
+	}
+}
+
+func TestDefaultPath_XDGStateHome(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/tmp/xdg-state")
+	if got := DefaultPath(); got != "/tmp/xdg-state/mutagui/state.json" {
+		t.Errorf("DefaultPath() = %q", got)
+	}
+}
+
+func TestStore_Folded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Folded("/p/mutagen.yml"); ok {
+		t.Error("Folded() ok for a project never recorded")
+	}
+	if !s.SetFolded("/p/mutagen.yml", false) {
+		t.Error("SetFolded() = false on first record")
+	}
+	if s.SetFolded("/p/mutagen.yml", false) {
+		t.Error("SetFolded() = true without a change")
+	}
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if folded, ok := reopened.Folded("/p/mutagen.yml"); !ok || folded {
+		t.Errorf("Folded() = %v, %v after reopening, want false, true", folded, ok)
+	}
+}
+
+func TestStore_Scheduled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := SpecKey("/p/mutagen.yml", "code")
+	if !s.SetScheduled(key, true) {
+		t.Error("SetScheduled() = false on first record")
+	}
+	if s.SetScheduled(key, true) {
+		t.Error("SetScheduled() = true without a change")
+	}
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reopened.Scheduled(key) {
+		t.Error("Scheduled() = false after reopening")
+	}
+	if !reopened.SetScheduled(key, false) || reopened.Scheduled(key) {
+		t.Error("SetScheduled(false) should clear the spec")
+	}
+}
+
+func TestStore_ConfigDeclined(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ConfigDeclined() {
+		t.Error("ConfigDeclined() = true in an empty store")
+	}
+	if !s.DeclineConfig() || s.DeclineConfig() {
+		t.Error("DeclineConfig() should report only the first decline as a change")
+	}
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reopened.ConfigDeclined() {
+		t.Error("ConfigDeclined() = false after reopening")
+	}
+}
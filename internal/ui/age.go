@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+// formatAge formats how long ago something happened in its largest whole
+// unit, as in 45s, 3h, or 12d.
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", max(int(d/time.Second), 0))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+}
+
+// sessionStarted describes when a session was created, as in
+// "2025-12-17 05:24 (started 3h ago)", or returns an empty string if
+// Mutagen didn't report a creation time that parses.
+func (m Model) sessionStarted(session *mutagen.SyncSession) string {
+	if session.CreationTime == nil {
+		return ""
+	}
+	created, err := time.Parse(time.RFC3339Nano, *session.CreationTime)
+	if err != nil {
+		return ""
+	}
+	return created.Local().Format("2006-01-02 15:04") + " (started " + formatAge(m.Clock.Now().Sub(created)) + " ago)"
+}
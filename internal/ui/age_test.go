@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/osteele/mutagui/internal/clock"
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{-2 * time.Second, "0s"}, // A clock slightly behind the daemon's
+		{45 * time.Second, "45s"},
+		{3*time.Minute + 59*time.Second, "3m"},
+		{3*time.Hour + 20*time.Minute, "3h"},
+		{50 * time.Hour, "2d"},
+	}
+	for _, tt := range tests {
+		if got := formatAge(tt.d); got != tt.want {
+			t.Errorf("formatAge(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestRenderSyncStatusModal_IdentifierAndAge(t *testing.T) {
+	m := newTestModel()
+	m.Clock = clock.NewFake(time.Date(2025, 12, 17, 8, 30, 0, 0, time.UTC))
+	created := "2025-12-17T05:24:51.866018Z"
+	session := &mutagen.SyncSession{Name: "s", Identifier: "sync_abc123", CreationTime: &created}
+	m.GetSelectedSession = func() *mutagen.SyncSession { return session }
+
+	if got := m.sessionStarted(session); !strings.HasSuffix(got, "(started 3h ago)") {
+		t.Errorf("sessionStarted() = %q, want it started 3h ago", got)
+	}
+	out := m.renderSyncStatusModal()
+	for _, want := range []string{"Identifier: sync_abc123", "started 3h ago", "Press y to copy the identifier"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("modal should contain %q:\n%s", want, out)
+		}
+	}
+
+	bad := "yesterday"
+	session.CreationTime = &bad
+	if out := m.renderSyncStatusModal(); strings.Contains(out, "Created:") {
+		t.Errorf("modal shouldn't show an unparsable creation time:\n%s", out)
+	}
+}
+
+func TestSyncStatusModal_CopiesIdentifier(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	copies := 0
+	m.OnCopyIdentifier = func() *StatusMessage {
+		copies++
+		return &StatusMessage{Type: StatusInfo, Text: "Copied identifier of s: sync_abc123"}
+	}
+	m.ActiveModal = ModalSyncStatus
+	m = press(m, "y")
+	if copies != 1 || m.ActiveModal != ModalSyncStatus {
+		t.Errorf("y copied %d time(s), modal = %v; want one copy with the modal still open", copies, m.ActiveModal)
+	}
+}
@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// tableColumns are the columns a table of specs can show, in the order of
+// config.ListColumns, with their header labels.
+var tableColumns = []struct{ name, title string }{
+	{"name", "Name"},
+	{"status", "Status"},
+	{"alpha", "Alpha"},
+	{"beta", "Beta"},
+	{"cycles", "Cycles"},
+	{"conflicts", "Conflicts"},
+}
+
+// columnGap is the space between the columns of a table.
+const columnGap = 2
+
+// columnTitle returns the header label of a column.
+func columnTitle(name string) string {
+	for _, column := range tableColumns {
+		if column.name == name {
+			return column.title
+		}
+	}
+	return name
+}
+
+// columnWidths returns the width of each column of a table: the widest of
+// its header and its cells, so that the columns line up. If the columns and
+// the gaps between them are wider than width, the widest column gives up a
+// cell at a time until they fit, but no column gets narrower than its
+// header.
+func columnWidths(headers []string, rows [][]string, width int) []int {
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = lipgloss.Width(header)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	total := columnGap * max(len(widths)-1, 0)
+	for _, w := range widths {
+		total += w
+	}
+	for total > width {
+		widest := -1
+		for i, w := range widths {
+			if w > lipgloss.Width(headers[i]) && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// tablePrefixWidth is the width of the indent and status icon that start
+// each row of the table, ahead of its first column.
+func (m Model) tablePrefixWidth() int {
+	return lipgloss.Width(m.specIndent(nil)) + 2
+}
+
+// tableLayout returns the widths of the table's columns, fitted to the
+// specs in the list and to maxWidth.
+func (m Model) tableLayout(maxWidth int) []int {
+	headers := make([]string, len(m.Columns))
+	for i, name := range m.Columns {
+		headers[i] = columnTitle(name)
+	}
+	var rows [][]string
+	for _, item := range m.Selection.Items() {
+		if item.Type != SelectableSpec || item.ProjectIndex >= len(m.Projects) {
+			continue
+		}
+		proj := m.Projects[item.ProjectIndex]
+		cells := m.specCells(proj, &proj.Specs[item.SpecIndex])
+		row := make([]string, len(m.Columns))
+		for i, name := range m.Columns {
+			row[i] = cells[name]
+		}
+		rows = append(rows, row)
+	}
+	return columnWidths(headers, rows, maxWidth-m.tablePrefixWidth())
+}
+
+// specCells returns the text of each table column of a spec's row, by
+// column name. Columns that don't apply to the spec are empty.
+func (m Model) specCells(proj *project.Project, spec *project.SyncSpec) map[string]string {
+	mark := swapMark(proj, spec)
+	cells := map[string]string{"name": spec.Name + mark}
+	session := spec.RunningSession
+	if spec.State == project.NotRunning || session == nil {
+		switch {
+		case spec.State != project.NotRunning:
+			// Started, but its session isn't listed yet
+		case spec.EverStarted:
+			cells["status"] = "Stopped"
+		default:
+			cells["status"] = "New"
+		}
+		if def, ok := proj.File.Sessions[spec.Name]; ok {
+			cells["alpha"] = applyTilde(project.NormalizeEndpoint(def.Alpha, proj.File.Dir()))
+			if betas := def.BetaEndpoints(); len(betas) > 0 {
+				cells["beta"] = applyTilde(project.NormalizeEndpoint(betas[0], proj.File.Dir()))
+				if len(betas) > 1 {
+					cells["beta"] += fmt.Sprintf(" +%d", len(betas)-1)
+				}
+			}
+		}
+		return cells
+	}
+
+	name := spec.Name
+	if m.ShowSessions {
+		name = session.Name
+	}
+	if spec.State == project.RunningPush {
+		name += " (one-way)"
+	}
+	cells["name"] = name + mark
+	cells["status"] = session.StatusText()
+	cells["alpha"] = session.AlphaDisplay()
+	cells["beta"] = session.BetaDisplay()
+	if running, total := spec.ReplicaCounts(); total > 1 {
+		cells["status"] = fmt.Sprintf("%d/%d running", running, total)
+		cells["beta"] += fmt.Sprintf(" +%d", total-1)
+	}
+	if session.SuccessfulCycles != nil && *session.SuccessfulCycles > 0 {
+		cells["cycles"] = strconv.FormatUint(*session.SuccessfulCycles, 10)
+	}
+	if session.HasConflicts() {
+		cells["conflicts"] = strconv.Itoa(session.ConflictCount())
+	}
+	return cells
+}
+
+// renderColumnHeader renders the header row that labels the table's
+// columns.
+func (m Model) renderColumnHeader(widths []int, maxWidth int) string {
+	cells := make([]string, len(m.Columns))
+	for i, name := range m.Columns {
+		cells[i] = m.fitCell(name, columnTitle(name), widths[i], i == len(m.Columns)-1)
+	}
+	line := strings.Repeat(" ", m.tablePrefixWidth()) + strings.Join(cells, strings.Repeat(" ", columnGap))
+	return m.Theme.ModalHelp.Render(truncateLine(line, maxWidth))
+}
+
+// renderColumnRow renders a spec's row of the table, in place of the row
+// renderSpecRow draws.
+func (m Model) renderColumnRow(proj *project.Project, spec *project.SyncSpec, widths []int, maxWidth int, selected bool) string {
+	icon, iconStyle := mutagen.Icon("○", "o"), m.Theme.StatusNotRunning
+	switch {
+	case spec.State == project.NotRunning:
+	case spec.RunningSession == nil:
+		icon, iconStyle = mutagen.Icon("▶", ">"), m.Theme.StatusRunning
+	default:
+		icon, iconStyle = m.runningIcon(spec, spec.RunningSession)
+	}
+	styles := map[string]lipgloss.Style{
+		"name":      m.Theme.SessionName,
+		"alpha":     m.Theme.SessionAlpha,
+		"beta":      m.Theme.SessionBeta,
+		"conflicts": m.Theme.StatusPaused.Bold(true),
+	}
+	if spec.State == project.NotRunning {
+		// Specs that have never run are dimmed, as in the usual rows
+		styles["status"] = m.Theme.ModalHelp
+		if spec.EverStarted {
+			styles["status"] = m.Theme.StatusNotRunning
+		}
+	}
+
+	values := m.specCells(proj, spec)
+	cells := make([]string, len(m.Columns))
+	for i, name := range m.Columns {
+		cells[i] = m.fitCell(name, values[name], widths[i], i == len(m.Columns)-1)
+		if style, ok := styles[name]; ok && !selected {
+			cells[i] = style.Render(cells[i])
+		}
+	}
+	if !selected {
+		icon = iconStyle.Render(icon)
+	}
+	line := m.specIndent(spec) + icon + " " + strings.Join(cells, strings.Repeat(" ", columnGap))
+	return truncateLine(line, maxWidth)
+}
+
+// fitCell shortens a cell's text to the column's width, abbreviating
+// endpoint paths as the usual rows do, and pads it to line up the next
+// column.
+func (m Model) fitCell(column, text string, width int, last bool) string {
+	if (column == "alpha" || column == "beta") && !m.TruncatePathEnds {
+		text = abbreviatePath(text, width)
+	}
+	text = truncateLine(text, width)
+	if last {
+		return text
+	}
+	return padRight(text, width)
+}
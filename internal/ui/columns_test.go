@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/osteele/mutagui/internal/config"
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestTableColumns_MatchConfig(t *testing.T) {
+	var names []string
+	for _, column := range tableColumns {
+		names = append(names, column.name)
+	}
+	if !slices.Equal(names, config.ListColumns) {
+		t.Errorf("table columns = %v, want config.ListColumns %v", names, config.ListColumns)
+	}
+}
+
+func TestColumnWidths(t *testing.T) {
+	headers := []string{"Name", "Status", "Beta"}
+	rows := [][]string{
+		{"web", "Watching for changes", "server:/srv/web"},
+		{"documentation", "Paused", ""},
+	}
+	tests := []struct {
+		name  string
+		width int
+		want  []int
+	}{
+		{"widest value", 100, []int{13, 20, 15}},
+		{"widest column shrinks first", 50, []int{13, 18, 15}},
+		{"columns shrink evenly", 40, []int{12, 12, 12}},
+		{"not below the headers", 10, []int{4, 6, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := columnWidths(headers, rows, tt.width); !slices.Equal(got, tt.want) {
+				t.Errorf("columnWidths(%d) = %v, want %v", tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderList_Columns(t *testing.T) {
+	cycles, host := uint64(12), "server"
+	proj := makeTestProject("proj", 2, false)
+	proj.Specs[1].State = project.RunningTwoWay
+	proj.Specs[1].RunningSession = &mutagen.SyncSession{
+		Name:             "spec-b",
+		Status:           "watching",
+		Alpha:            mutagen.Endpoint{Path: "/local/b", Connected: true},
+		Beta:             mutagen.Endpoint{Host: &host, Path: "/remote/b", Connected: true},
+		SuccessfulCycles: &cycles,
+	}
+	m := newTestModel(proj)
+	m.Width, m.Height = 120, 30
+	m.Columns = []string{"name", "beta", "cycles"}
+
+	lines := strings.Split(m.renderList(20), "\n")
+	header := slices.IndexFunc(lines, func(line string) bool { return strings.Contains(line, "Name") })
+	if header < 0 {
+		t.Fatalf("no header row in:\n%s", strings.Join(lines, "\n"))
+	}
+	for _, title := range []string{"Beta", "Cycles"} {
+		if !strings.Contains(lines[header], title) {
+			t.Errorf("header %q lacks %s", lines[header], title)
+		}
+	}
+	if strings.Contains(lines[header], "Alpha") || strings.Contains(lines[header], "Status") {
+		t.Errorf("header %q shows columns that weren't chosen", lines[header])
+	}
+
+	// The chosen columns line up under their labels
+	row := lines[slices.IndexFunc(lines, func(line string) bool { return strings.Contains(line, "spec-b") })]
+	for _, cell := range []struct{ title, value string }{{"Beta", "server:/remote/b"}, {"Cycles", "12"}} {
+		got := lipgloss.Width(row[:strings.Index(row, cell.value)])
+		if want := lipgloss.Width(lines[header][:strings.Index(lines[header], cell.title)]); got != want {
+			t.Errorf("%s at column %d, want %d under its label:\n%s\n%s", cell.value, got, want, lines[header], row)
+		}
+	}
+	if strings.Contains(row, "/local/b") || strings.Contains(row, "Watching") {
+		t.Errorf("row %q shows columns that weren't chosen", row)
+	}
+}
+
+func TestRenderList_NoColumnsKeepsUsualRows(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	m.Width, m.Height = 120, 30
+	if out := m.renderList(20); strings.Contains(out, "Name") {
+		t.Errorf("list without columns has a header:\n%s", out)
+	}
+}
@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+// Confirmation is an action waiting for the user to confirm it in ModalConfirm.
+type Confirmation struct {
+	Title       string
+	Lines       []string // Plain-language description of what will happen
+	LoadingText string   // Shown while the action runs
+	Run         tea.Cmd
+}
+
+func (m Model) renderConfirmModal() string {
+	c := m.confirmation
+	if c == nil {
+		return m.Theme.ModalBorder.Render("Nothing to confirm")
+	}
+
+	var content strings.Builder
+	content.WriteString(m.Theme.ConfirmWarning.Render(mutagen.Icon("⚠", "!")+" "+c.Title) + "\n\n")
+	for _, line := range c.Lines {
+		content.WriteString(line + "\n")
+	}
+	content.WriteString("\n" + m.Theme.ConflictAlpha.Bold(true).Render("'"+keys.ConfirmYes.Help().Key+"'") + " Confirm  " + m.Theme.ModalHelp.Render("'"+keys.ConfirmNo.Help().Key+"'/Esc") + " Cancel\n")
+
+	return m.Theme.ConfirmPushBorder.Render(content.String())
+}
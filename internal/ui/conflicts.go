@@ -1,6 +1,12 @@
 package ui
 
-import "github.com/osteele/mutagui/internal/mutagen"
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/osteele/mutagui/internal/mutagen"
+)
 
 // SessionConflicts represents the conflicts associated with a spec/session.
 type SessionConflicts struct {
@@ -8,3 +14,160 @@ type SessionConflicts struct {
 	Session   *mutagen.SyncSession
 	Conflicts []mutagen.Conflict
 }
+
+// ConflictDirections counts conflicts by which side has more changes.
+type ConflictDirections struct {
+	FavorAlpha int
+	FavorBeta  int
+	Equal      int
+}
+
+// Total returns the total number of conflicts counted.
+func (d ConflictDirections) Total() int {
+	return d.FavorAlpha + d.FavorBeta + d.Equal
+}
+
+// String formats the counts as a one-line summary,
+// e.g. "5 conflicts: 3 favor α, 2 favor β, 0 equal".
+func (d ConflictDirections) String() string {
+	noun := "conflicts"
+	if d.Total() == 1 {
+		noun = "conflict"
+	}
+	return fmt.Sprintf("%d %s: %d favor α, %d favor β, %d equal",
+		d.Total(), noun, d.FavorAlpha, d.FavorBeta, d.Equal)
+}
+
+// CountConflictDirections classifies each conflict by comparing the number of
+// alpha and beta changes, giving a hint whether pushing or pulling is the
+// better bulk resolution.
+func CountConflictDirections(sessions []SessionConflicts) ConflictDirections {
+	var d ConflictDirections
+	for _, sc := range sessions {
+		for _, c := range sc.Conflicts {
+			switch {
+			case len(c.AlphaChanges) > len(c.BetaChanges):
+				d.FavorAlpha++
+			case len(c.BetaChanges) > len(c.AlphaChanges):
+				d.FavorBeta++
+			default:
+				d.Equal++
+			}
+		}
+	}
+	return d
+}
+
+// countConflicts returns the number of conflicts across sessions.
+func countConflicts(sessions []SessionConflicts) int {
+	n := 0
+	for _, sc := range sessions {
+		n += len(sc.Conflicts)
+	}
+	return n
+}
+
+// conflictAt returns the i'th conflict across sessions, in the order the
+// conflicts dialog lists them, with the session it belongs to.
+func conflictAt(sessions []SessionConflicts, i int) (SessionConflicts, mutagen.Conflict, bool) {
+	for _, sc := range sessions {
+		if i < len(sc.Conflicts) {
+			return sc, sc.Conflicts[i], sc.Session != nil
+		}
+		i -= len(sc.Conflicts)
+	}
+	return SessionConflicts{}, mutagen.Conflict{}, false
+}
+
+// filterConflicts returns the conflicts whose root path contains query,
+// ignoring case, leaving out sessions with none. An empty query keeps
+// them all.
+func filterConflicts(sessions []SessionConflicts, query string) []SessionConflicts {
+	if query == "" {
+		return sessions
+	}
+	query = strings.ToLower(query)
+	var filtered []SessionConflicts
+	for _, sc := range sessions {
+		var matches []mutagen.Conflict
+		for _, conflict := range sc.Conflicts {
+			if strings.Contains(strings.ToLower(conflict.Root), query) {
+				matches = append(matches, conflict)
+			}
+		}
+		if len(matches) > 0 {
+			sc.Conflicts = matches
+			filtered = append(filtered, sc)
+		}
+	}
+	return filtered
+}
+
+// conflictKey identifies a conflict across refreshes, which replace the
+// sessions and their conflicts.
+func conflictKey(sc SessionConflicts, conflict mutagen.Conflict) string {
+	return sc.SpecName + "\x00" + conflict.Root
+}
+
+// visibleConflicts returns the conflicts the conflicts dialog lists: those
+// matching its query.
+func (m Model) visibleConflicts() []SessionConflicts {
+	if m.GetConflicts == nil {
+		return nil
+	}
+	return filterConflicts(m.GetConflicts(), m.conflictQuery)
+}
+
+// setConflictExpanded shows or hides the changes of the highlighted
+// conflict, below its path. With toggle, it flips whichever is showing.
+func (m *Model) setConflictExpanded(expanded, toggle bool) {
+	sessions := m.visibleConflicts()
+	if m.conflictCursor >= countConflicts(sessions) {
+		return
+	}
+	sc, conflict, _ := conflictAt(sessions, m.conflictCursor)
+	k := conflictKey(sc, conflict)
+	if toggle {
+		expanded = !m.expandedConflicts[k]
+	}
+	if m.expandedConflicts == nil {
+		m.expandedConflicts = make(map[string]bool)
+	}
+	if expanded {
+		m.expandedConflicts[k] = true
+	} else {
+		delete(m.expandedConflicts, k)
+	}
+	m.followConflictCursor()
+}
+
+// setConflictQuery filters the conflicts dialog, returning to the top of
+// the list.
+func (m *Model) setConflictQuery(query string) {
+	m.conflictQuery = query
+	m.conflictCursor = 0
+	m.modalOffset = 0
+}
+
+// handleConflictQueryKeyPress edits the conflicts dialog's query. The list
+// narrows as it is typed; Enter keeps the query and Esc clears it.
+func (m Model) handleConflictQueryKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.editingConflictQuery = false
+		m.setConflictQuery("")
+	case tea.KeyEnter:
+		m.editingConflictQuery = false
+	case tea.KeyBackspace:
+		if runes := []rune(m.conflictQuery); len(runes) > 0 {
+			m.setConflictQuery(string(runes[:len(runes)-1]))
+		}
+	case tea.KeySpace:
+		m.setConflictQuery(m.conflictQuery + " ")
+	case tea.KeyRunes:
+		m.setConflictQuery(m.conflictQuery + string(msg.Runes))
+	}
+	return m, nil
+}
@@ -0,0 +1,299 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+func changes(n int) []mutagen.Change {
+	result := make([]mutagen.Change, n)
+	for i := range result {
+		result[i] = mutagen.Change{Path: "file"}
+	}
+	return result
+}
+
+func TestCountConflictDirections(t *testing.T) {
+	sessions := []SessionConflicts{
+		{
+			SpecName: "a",
+			Conflicts: []mutagen.Conflict{
+				{Root: "x", AlphaChanges: changes(3), BetaChanges: changes(1)},
+				{Root: "y", AlphaChanges: changes(1), BetaChanges: changes(2)},
+			},
+		},
+		{
+			SpecName: "b",
+			Conflicts: []mutagen.Conflict{
+				{Root: "z", AlphaChanges: changes(2), BetaChanges: changes(1)},
+				{Root: "w", AlphaChanges: changes(1), BetaChanges: changes(1)},
+			},
+		},
+	}
+
+	got := CountConflictDirections(sessions)
+	want := ConflictDirections{FavorAlpha: 2, FavorBeta: 1, Equal: 1}
+	if got != want {
+		t.Errorf("CountConflictDirections() = %+v, want %+v", got, want)
+	}
+	if got.String() != "4 conflicts: 2 favor α, 1 favor β, 1 equal" {
+		t.Errorf("String() = %q", got.String())
+	}
+}
+
+func TestConflictDirections_StringSingular(t *testing.T) {
+	d := ConflictDirections{FavorBeta: 1}
+	if got := d.String(); got != "1 conflict: 0 favor α, 1 favor β, 0 equal" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestConflictsModal_PullHonorsConfirmation(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	pulled := 0
+	m.OnPullConflicts = func(ctx context.Context) *StatusMessage {
+		pulled++
+		return nil
+	}
+	m.ActiveModal = ModalConflicts
+	m.ConfirmPullToAlpha = true
+
+	m = press(m, "a")
+	if m.ActiveModal != ModalConfirmPull {
+		t.Fatalf("ActiveModal = %v, want ModalConfirmPull", m.ActiveModal)
+	}
+	m = press(m, "n")
+	if m.ActiveModal != ModalConflicts {
+		t.Errorf("n should return to the conflicts dialog; ActiveModal = %v", m.ActiveModal)
+	}
+
+	// Without confirmation, a pulls right away
+	m.ConfirmPullToAlpha = false
+	updated, cmd := m.handleKeyPress(keyPress("a"))
+	if updated.(Model).ActiveModal != ModalNone || cmd == nil {
+		t.Fatal("a should pull without confirmation")
+	}
+	cmd()
+	if pulled != 1 {
+		t.Errorf("pulled = %d, want 1", pulled)
+	}
+}
+
+func TestConflictsModal_ResolveSelectedConflict(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	web := &mutagen.SyncSession{Name: "web", Beta: mutagen.Endpoint{Protocol: "local", Path: "/srv/web"}}
+	api := &mutagen.SyncSession{Name: "api"}
+	m.GetConflicts = func() []SessionConflicts {
+		return []SessionConflicts{
+			{SpecName: "web", Session: web, Conflicts: []mutagen.Conflict{{Root: "a"}, {Root: "b"}}},
+			{SpecName: "api", Session: api, Conflicts: []mutagen.Conflict{{Root: "c"}}},
+		}
+	}
+	var resolved []string
+	m.OnResolveConflict = func(ctx context.Context, session *mutagen.SyncSession, conflict mutagen.Conflict, winner string) *StatusMessage {
+		resolved = append(resolved, session.Name+" "+conflict.Root+" "+winner)
+		return nil
+	}
+	m = press(m, "c")
+
+	// The cursor runs across sessions and stops at the last conflict
+	for range 3 {
+		m = press(m, "down")
+	}
+	if m.conflictCursor != 2 {
+		t.Fatalf("conflictCursor = %d, want 2", m.conflictCursor)
+	}
+	updated, cmd := m.handleKeyPress(keyPress("<"))
+	if updated.(Model).ActiveModal != ModalNone || cmd == nil {
+		t.Fatal("< should resolve without confirmation")
+	}
+	cmd()
+
+	// Keeping alpha asks first, like pushing
+	m = press(m, "up")
+	m.ConfirmPushToBeta = true
+	m = press(m, ">")
+	if m.ActiveModal != ModalConfirm || m.confirmation == nil {
+		t.Fatalf("ActiveModal = %v, want ModalConfirm", m.ActiveModal)
+	}
+	if got := m.confirmation.Lines[0]; got != "Delete beta's copy of b?" {
+		t.Errorf("confirmation = %q", got)
+	}
+	m.confirmation.Run()
+
+	want := []string{"api c beta", "web b alpha"}
+	if len(resolved) != 2 || resolved[0] != want[0] || resolved[1] != want[1] {
+		t.Errorf("resolved = %v, want %v", resolved, want)
+	}
+}
+
+func TestConflictAt(t *testing.T) {
+	sessions := []SessionConflicts{
+		{SpecName: "a", Session: &mutagen.SyncSession{}, Conflicts: []mutagen.Conflict{{Root: "x"}}},
+		{SpecName: "b", Session: &mutagen.SyncSession{}, Conflicts: []mutagen.Conflict{{Root: "y"}, {Root: "z"}}},
+	}
+	sc, c, ok := conflictAt(sessions, 2)
+	if !ok || sc.SpecName != "b" || c.Root != "z" {
+		t.Errorf("conflictAt(2) = %s %s %v, want b z", sc.SpecName, c.Root, ok)
+	}
+	if _, _, ok := conflictAt(sessions, 3); ok {
+		t.Error("conflictAt(3) should be out of range")
+	}
+}
+
+func TestFilterConflicts(t *testing.T) {
+	sessions := []SessionConflicts{
+		{SpecName: "web", Conflicts: []mutagen.Conflict{{Root: "src/App.go"}, {Root: "docs/readme.md"}}},
+		{SpecName: "api", Conflicts: []mutagen.Conflict{{Root: "cmd/main.go"}}},
+	}
+
+	got := filterConflicts(sessions, "APP")
+	if len(got) != 1 || got[0].SpecName != "web" || len(got[0].Conflicts) != 1 || got[0].Conflicts[0].Root != "src/App.go" {
+		t.Errorf("filterConflicts(APP) = %+v, want only web's src/App.go", got)
+	}
+	if got := filterConflicts(sessions, ".go"); countConflicts(got) != 2 || len(got) != 2 {
+		t.Errorf("filterConflicts(.go) = %+v, want a conflict from each session", got)
+	}
+	if got := filterConflicts(sessions, "nothing"); len(got) != 0 {
+		t.Errorf("filterConflicts(nothing) = %+v, want no sessions", got)
+	}
+	if got := filterConflicts(sessions, ""); countConflicts(got) != 3 {
+		t.Errorf("an empty query should keep all 3 conflicts, got %d", countConflicts(got))
+	}
+	if len(sessions[0].Conflicts) != 2 {
+		t.Error("filterConflicts changed its argument")
+	}
+}
+
+func conflictListModel() Model {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.Width, m.Height = 120, 40
+	web := &mutagen.SyncSession{Name: "web", Alpha: mutagen.Endpoint{Protocol: "local", Path: "/local/web"}}
+	api := &mutagen.SyncSession{Name: "api", Alpha: mutagen.Endpoint{Protocol: "local", Path: "/local/api"}}
+	m.GetConflicts = func() []SessionConflicts {
+		// A new list each time, as refreshes make
+		return []SessionConflicts{
+			{SpecName: "web", Session: web, Conflicts: []mutagen.Conflict{
+				{Root: "src/app.go", AlphaChanges: []mutagen.Change{{Path: "src/app.go"}}},
+				{Root: "docs/guide.md"},
+			}},
+			{SpecName: "api", Session: api, Conflicts: []mutagen.Conflict{{Root: "src/server.go"}}},
+		}
+	}
+	return press(m, "c")
+}
+
+func typeConflictQuery(m Model, query string) Model {
+	m = press(m, "/")
+	for _, r := range query {
+		updated, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	return m
+}
+
+func TestConflictsModal_FilterByPath(t *testing.T) {
+	m := conflictListModel()
+	m = press(m, "down")
+	m = typeConflictQuery(m, "SRC/")
+	if !m.editingConflictQuery || m.conflictCursor != 0 {
+		t.Fatalf("typing should edit the query from the top; editing = %v, cursor = %d", m.editingConflictQuery, m.conflictCursor)
+	}
+	out := m.renderConflictModal()
+	if strings.Contains(out, "docs/guide.md") || !strings.Contains(out, "src/app.go") || !strings.Contains(out, "src/server.go") {
+		t.Errorf("only the src/ conflicts should be listed:\n%s", out)
+	}
+	if !strings.Contains(out, "/SRC/█") || !strings.Contains(out, "2 of 3 paths match") {
+		t.Errorf("the header should show the query and the count of matches:\n%s", out)
+	}
+
+	// Enter keeps the filter, and the cursor moves among the matches
+	updated, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	m = press(m, "down")
+	m = press(m, "down")
+	if m.editingConflictQuery || m.conflictQuery != "SRC/" || m.conflictCursor != 1 {
+		t.Fatalf("editing = %v, query = %q, cursor = %d; want the kept query with the cursor on the last match",
+			m.editingConflictQuery, m.conflictQuery, m.conflictCursor)
+	}
+	var resolved string
+	m.OnResolveConflict = func(ctx context.Context, session *mutagen.SyncSession, conflict mutagen.Conflict, winner string) *StatusMessage {
+		resolved = session.Name + " " + conflict.Root
+		return nil
+	}
+	if _, cmd := m.handleKeyPress(keyPress("<")); cmd != nil {
+		cmd()
+	}
+	if resolved != "api src/server.go" {
+		t.Errorf("resolved %q, want the highlighted match api src/server.go", resolved)
+	}
+
+	// Esc clears the filter before it closes the dialog
+	m = press(m, "esc")
+	if m.ActiveModal != ModalConflicts || m.conflictQuery != "" {
+		t.Fatalf("Esc should clear the query first; modal = %v, query = %q", m.ActiveModal, m.conflictQuery)
+	}
+	if out := m.renderConflictModal(); !strings.Contains(out, "docs/guide.md") {
+		t.Errorf("clearing the query should list every conflict:\n%s", out)
+	}
+	if m = press(m, "esc"); m.ActiveModal != ModalNone {
+		t.Errorf("a second Esc should close the dialog; modal = %v", m.ActiveModal)
+	}
+}
+
+func TestConflictsModal_NoMatches(t *testing.T) {
+	m := typeConflictQuery(conflictListModel(), "zzz")
+	if out := m.renderConflictModal(); !strings.Contains(out, "No conflict paths match") || !strings.Contains(out, "0 of 3 paths match") {
+		t.Errorf("the dialog should say nothing matches:\n%s", out)
+	}
+	updated, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(Model); m.editingConflictQuery || m.conflictQuery != "" || m.ActiveModal != ModalConflicts {
+		t.Errorf("Esc while typing should clear the query and leave the dialog open")
+	}
+}
+
+func TestConflictsModal_ExpandCollapse(t *testing.T) {
+	m := conflictListModel()
+	if out := m.renderConflictModal(); strings.Contains(out, "/local/web/src/app.go") {
+		t.Errorf("conflicts should start collapsed:\n%s", out)
+	}
+
+	m = press(m, "enter")
+	out := m.renderConflictModal()
+	if !strings.Contains(out, "/local/web/src/app.go") || !strings.Contains(out, "α src/app.go") {
+		t.Errorf("enter should show the conflict's paths and changes:\n%s", out)
+	}
+	if strings.Contains(out, "/local/web/docs/guide.md") {
+		t.Errorf("only the selected conflict should expand:\n%s", out)
+	}
+
+	// Expansion is kept by path, across refreshes and moves of the cursor
+	m = press(m, "down")
+	m = press(m, "right")
+	if !m.expandedConflicts[conflictKey(SessionConflicts{SpecName: "web"}, mutagen.Conflict{Root: "src/app.go"})] ||
+		!m.expandedConflicts[conflictKey(SessionConflicts{SpecName: "web"}, mutagen.Conflict{Root: "docs/guide.md"})] {
+		t.Errorf("expandedConflicts = %v, want both of web's conflicts", m.expandedConflicts)
+	}
+	m = press(m, "right")
+	m = press(m, "left")
+	if out := m.renderConflictModal(); strings.Contains(out, "/local/web/docs/guide.md") || !strings.Contains(out, "/local/web/src/app.go") {
+		t.Errorf("left should collapse only the selected conflict:\n%s", out)
+	}
+	m = press(m, "up")
+	m = press(m, "enter")
+	if len(m.expandedConflicts) != 0 {
+		t.Errorf("enter should collapse an expanded conflict; expanded = %v", m.expandedConflicts)
+	}
+
+	// Reopening the dialog starts collapsed
+	m = press(m, "enter")
+	m = press(m, "c")
+	m = press(m, "c")
+	if len(m.expandedConflicts) != 0 {
+		t.Errorf("reopened dialog kept expanded conflicts %v", m.expandedConflicts)
+	}
+}
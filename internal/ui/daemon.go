@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+// DaemonSessionsMsg carries the daemon's full session list for the daemon
+// sessions dialog, and the status of an action taken from it.
+type DaemonSessionsMsg struct {
+	Sessions []mutagen.SyncSession
+	Err      error
+	Status   *StatusMessage
+}
+
+// daemonSessionsState is the state of the daemon sessions dialog.
+type daemonSessionsState struct {
+	sessions []mutagen.SyncSession
+	err      error
+	loading  bool
+	index    int
+	// confirming is set while asking whether to terminate the selected row
+	confirming bool
+}
+
+// openDaemonSessions shows the daemon sessions dialog and loads the list.
+func (m Model) openDaemonSessions() (tea.Model, tea.Cmd) {
+	m.ActiveModal = ModalDaemonSessions
+	m.daemon = daemonSessionsState{loading: true}
+	return m, m.daemonSessionsCmd(nil)
+}
+
+// daemonSessionsCmd lists every session the daemon knows about, after
+// running action if it is non-nil.
+func (m Model) daemonSessionsCmd(action func(ctx context.Context) *StatusMessage) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var status *StatusMessage
+		if action != nil {
+			status = action(ctx)
+		}
+		sessions, err := m.ListDaemonSessions(ctx)
+		return DaemonSessionsMsg{Sessions: sessions, Err: err, Status: status}
+	}
+}
+
+func (m Model) handleDaemonSessionsMsg(msg DaemonSessionsMsg) (tea.Model, tea.Cmd) {
+	m.daemon.loading = false
+	m.daemon.sessions = msg.Sessions
+	m.daemon.err = msg.Err
+	if m.daemon.index >= len(msg.Sessions) {
+		m.daemon.index = max(len(msg.Sessions)-1, 0)
+	}
+	if msg.Status != nil {
+		m.StatusMessage = msg.Status
+		if m.OnRefresh != nil {
+			return m, m.refreshCmd(true)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleDaemonSessionsKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := &m.daemon
+	if d.confirming {
+		switch {
+		case key.Matches(msg, keys.ConfirmYes) && m.OnTerminateSession != nil:
+			session := d.sessions[d.index]
+			d.confirming = false
+			d.loading = true
+			return m, m.daemonSessionsCmd(func(ctx context.Context) *StatusMessage {
+				return m.OnTerminateSession(ctx, session.Identifier, session.Name)
+			})
+		case key.Matches(msg, keys.ConfirmNo), key.Matches(msg, keys.Escape):
+			d.confirming = false
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, keys.DaemonList), key.Matches(msg, keys.Escape):
+		m.ActiveModal = ModalNone
+	case key.Matches(msg, keys.Up):
+		if d.index > 0 {
+			d.index--
+		}
+	case key.Matches(msg, keys.Down):
+		if d.index < len(d.sessions)-1 {
+			d.index++
+		}
+	case key.Matches(msg, keys.Refresh):
+		d.loading = true
+		return m, m.daemonSessionsCmd(nil)
+	case key.Matches(msg, keys.Terminate):
+		if !d.loading && d.index < len(d.sessions) {
+			d.confirming = true
+		}
+	}
+	return m, nil
+}
+
+// projectForSession returns the name of the project whose spec is running
+// the session, or "" if it isn't matched to any project.
+func (m Model) projectForSession(session *mutagen.SyncSession) string {
+	for _, proj := range m.Projects {
+		for i := range proj.Specs {
+			running := proj.Specs[i].RunningSession
+			if running != nil && running.Identifier == session.Identifier && running.Name == session.Name {
+				return proj.File.DisplayName()
+			}
+		}
+	}
+	return ""
+}
+
+// daemonSessionsWindow returns the range of daemon sessions listed in the
+// dialog, scrolled to keep the selected row visible.
+func (m Model) daemonSessionsWindow() (start, end int) {
+	d := m.daemon
+	visible := max(m.Height-14, 3)
+	if d.index >= visible {
+		start = d.index - visible + 1
+	}
+	return start, min(start+visible, len(d.sessions))
+}
+
+func (m Model) renderDaemonSessionsModal() string {
+	d := m.daemon
+	var content strings.Builder
+
+	switch {
+	case d.err != nil:
+		content.WriteString(m.Theme.StatusError.Render("Failed to list sessions: "+d.err.Error()) + "\n")
+	case d.loading && d.sessions == nil:
+		content.WriteString("Loading...\n")
+	case len(d.sessions) == 0:
+		content.WriteString("The daemon has no sync sessions.\n")
+	default:
+		start, end := m.daemonSessionsWindow()
+		if start > 0 {
+			content.WriteString(m.Theme.ModalHelp.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+		}
+		for i := start; i < end; i++ {
+			session := &d.sessions[i]
+			owner := m.projectForSession(session)
+			if owner == "" {
+				owner = "not in any project"
+			}
+			line := fmt.Sprintf("%s %-24s %-14s %s → %s  (%s)",
+				session.StatusIcon(),
+				truncateString(session.Name, 24),
+				truncateString(session.StatusText(), 14),
+				session.AlphaDisplay(), session.BetaDisplay(), owner)
+			if i == d.index {
+				line = m.Theme.SelectedItem.Render(line)
+			}
+			content.WriteString(line + "\n")
+		}
+		if end < len(d.sessions) {
+			content.WriteString(m.Theme.ModalHelp.Render(fmt.Sprintf("  ↓ %d more", len(d.sessions)-end)) + "\n")
+		}
+	}
+
+	content.WriteString("\n")
+	if d.confirming {
+		content.WriteString(m.Theme.ConfirmWarning.Render(fmt.Sprintf("Terminate %s? ", d.sessions[d.index].Name)) +
+			m.Theme.HelpKey.Render(keys.ConfirmYes.Help().Key) + " Yes  " + m.Theme.HelpKey.Render(keys.ConfirmNo.Help().Key) + " No")
+	} else {
+		content.WriteString(m.Theme.ModalHelp.Render(fmt.Sprintf("↑/↓ select  %s terminate  %s reload  Esc or '%s' close",
+			keys.Terminate.Help().Key, keys.Refresh.Help().Key, keys.DaemonList.Help().Key)))
+	}
+
+	title := fmt.Sprintf(" Daemon Sessions (%d) ", len(d.sessions))
+	if d.loading && d.sessions != nil {
+		title += "Working... "
+	}
+	return m.Theme.ModalBorder.Render(m.Theme.ModalTitle.Render(title) + "\n\n" + content.String())
+}
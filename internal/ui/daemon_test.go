@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+func TestDaemonSessions_ListAndTerminate(t *testing.T) {
+	proj := makeTestProject("proj", 1, false)
+	proj.Specs[0].RunningSession = &mutagen.SyncSession{Name: "spec-a", Identifier: "sync_a"}
+	m := newTestModel(proj)
+	m.Width, m.Height = 120, 40
+
+	sessions := []mutagen.SyncSession{
+		{Name: "spec-a", Identifier: "sync_a", Status: "Watching for changes"},
+		{Name: "orphan", Identifier: "sync_b", Status: "Watching for changes"},
+	}
+	m.ListDaemonSessions = func(ctx context.Context) ([]mutagen.SyncSession, error) { return sessions, nil }
+	var terminated []string
+	m.OnTerminateSession = func(ctx context.Context, identifier, name string) *StatusMessage {
+		terminated = append(terminated, identifier)
+		sessions = sessions[:1]
+		return &StatusMessage{Text: "Terminated session: " + name}
+	}
+
+	updated, cmd := m.handleKeyPress(keyPress("Z"))
+	m = updated.(Model)
+	if m.ActiveModal != ModalDaemonSessions || cmd == nil {
+		t.Fatalf("Z should open the daemon sessions dialog and load it")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+
+	out := m.renderDaemonSessionsModal()
+	if !strings.Contains(out, "orphan") || !strings.Contains(out, "not in any project") {
+		t.Errorf("dialog should list the unmatched session:\n%s", out)
+	}
+	if strings.Count(out, "not in any project") != 1 {
+		t.Errorf("the project's session should be matched to it:\n%s", out)
+	}
+
+	// Terminate the second row after confirming
+	m = press(m, "j")
+	m = press(m, "t")
+	if !strings.Contains(m.renderDaemonSessionsModal(), "Terminate orphan?") {
+		t.Fatal("t should ask for confirmation")
+	}
+	updated, cmd = m.handleKeyPress(keyPress("y"))
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+
+	if len(terminated) != 1 || terminated[0] != "sync_b" {
+		t.Errorf("terminated %v, want [sync_b]", terminated)
+	}
+	if m.ActiveModal != ModalDaemonSessions || len(m.daemon.sessions) != 1 || m.daemon.index != 0 {
+		t.Errorf("dialog should stay open with the reloaded list (modal %v, %d sessions, index %d)",
+			m.ActiveModal, len(m.daemon.sessions), m.daemon.index)
+	}
+	if m.StatusMessage == nil || m.StatusMessage.Text != "Terminated session: orphan" {
+		t.Errorf("StatusMessage = %+v", m.StatusMessage)
+	}
+
+	m = press(m, "Z")
+	if m.ActiveModal != ModalNone {
+		t.Error("Z should close the dialog")
+	}
+}
+
+func TestDaemonSessions_CancelTerminate(t *testing.T) {
+	m := newTestModel()
+	m.ActiveModal = ModalDaemonSessions
+	m.daemon = daemonSessionsState{sessions: []mutagen.SyncSession{{Name: "x", Identifier: "sync_x"}}}
+	m.OnTerminateSession = func(ctx context.Context, identifier, name string) *StatusMessage {
+		t.Error("terminate should not run")
+		return nil
+	}
+
+	m = press(m, "t")
+	m = press(m, "n")
+	if m.daemon.confirming || m.ActiveModal != ModalDaemonSessions {
+		t.Errorf("n should cancel and keep the dialog open")
+	}
+}
+
+// press sends a key to the model and returns the updated model.
+func press(m Model, k string) Model {
+	updated, _ := m.handleKeyPress(keyPress(k))
+	return updated.(Model)
+}
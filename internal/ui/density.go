@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// nameColumn pads a project or spec name to width, cutting it short if it
+// is longer, so that what follows lines up. Compact rows leave the name as
+// it is.
+func (m Model) nameColumn(name string, width int) string {
+	if m.Compact {
+		return name
+	}
+	return fmt.Sprintf("%-*s", width, truncateString(name, width))
+}
+
+// padColumn pads s to width, except in compact rows.
+func (m Model) padColumn(s string, width int) string {
+	if m.Compact {
+		return s
+	}
+	return padRight(s, width)
+}
+
+// specIndent returns the indent of a spec row, which shows whether the
+// spec is marked.
+func (m Model) specIndent(spec *project.SyncSpec) string {
+	switch {
+	case m.Compact && m.marks[spec]:
+		return mutagen.Icon("✓", "*") + " "
+	case m.Compact:
+		return "  "
+	case m.marks[spec]:
+		return markIndent()
+	default:
+		return "    "
+	}
+}
+
+// sessionIcon returns a session's status icon followed by a space. Compact
+// rows leave it out, since it is two columns wide and the status text or
+// endpoint icons say the same. Icons are padded to the widest, so the
+// status column lines up whichever icon a row has.
+func (m Model) sessionIcon(session *mutagen.SyncSession) string {
+	if m.Compact {
+		return ""
+	}
+	width := 2
+	if mutagen.ASCIIIcons() {
+		width = 1
+	}
+	return padRight(session.StatusIcon(), width) + " "
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestRenderSpecRow_Compact(t *testing.T) {
+	cycles := uint64(12)
+	proj := makeTestProject("proj", 1, false)
+	spec := &proj.Specs[0]
+	spec.State = project.RunningTwoWay
+	spec.RunningSession = &mutagen.SyncSession{
+		Name:             "spec-a",
+		Status:           "watching",
+		SuccessfulCycles: &cycles,
+		Alpha:            mutagen.Endpoint{Connected: true, Scanned: true, Path: "/Users/me/code"},
+		Beta:             mutagen.Endpoint{Connected: true, Scanned: true, Path: "/srv/code"},
+	}
+	m := newTestModel(proj)
+
+	for _, showPaths := range []bool{false, true} {
+		m.ShowPaths = showPaths
+		m.Compact = false
+		normal := m.renderSpecRow(proj, spec, 200, true)
+		m.Compact = true
+		compact := m.renderSpecRow(proj, spec, 200, true)
+		if lipgloss.Width(compact) >= lipgloss.Width(normal) {
+			t.Errorf("paths=%v: compact row %q is no narrower than %q", showPaths, compact, normal)
+		}
+		if strings.Contains(compact, "👁") {
+			t.Errorf("paths=%v: compact row %q has the session icon", showPaths, compact)
+		}
+	}
+
+	m.ShowPaths = false
+	if row := m.renderSpecRow(proj, spec, 200, true); strings.Contains(row, "cycles") || !strings.Contains(row, "spec-a Watching") {
+		t.Errorf("compact row = %q, want the status right after the name, without cycles", row)
+	}
+}
+
+func TestRenderProjectHeader_Compact(t *testing.T) {
+	proj := makeTestProject("proj", 2, false)
+	m := newTestModel(proj)
+	normal := m.renderProjectHeader(proj, 200, true)
+	m.Compact = true
+	compact := m.renderProjectHeader(proj, 200, true)
+	if lipgloss.Width(compact) >= lipgloss.Width(normal) {
+		t.Errorf("compact header %q is no narrower than %q", compact, normal)
+	}
+}
+
+func TestDensityKey(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	m = press(m, "d")
+	if !m.Compact {
+		t.Error("d should switch to compact rows")
+	}
+	m = press(m, "d")
+	if m.Compact {
+		t.Error("d again should switch back")
+	}
+}
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+// Event is a timestamped entry in the event log.
+type Event struct {
+	Time time.Time
+	Type StatusMessageType
+	Text string
+}
+
+// EventLog is a fixed-capacity ring buffer of recent events.
+// When full, adding an event discards the oldest one. It is safe for
+// concurrent use, since project-wide actions log from several goroutines.
+type EventLog struct {
+	mu     sync.Mutex
+	events []Event
+	start  int
+	count  int
+}
+
+// NewEventLog creates an EventLog that retains up to capacity events.
+func NewEventLog(capacity int) *EventLog {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &EventLog{events: make([]Event, capacity)}
+}
+
+// Add appends an event, evicting the oldest event if the log is full.
+func (l *EventLog) Add(e Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	capacity := len(l.events)
+	if l.count < capacity {
+		l.events[(l.start+l.count)%capacity] = e
+		l.count++
+		return
+	}
+	l.events[l.start] = e
+	l.start = (l.start + 1) % capacity
+}
+
+// Len returns the number of events currently retained.
+func (l *EventLog) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.count
+}
+
+// Events returns the retained events, oldest first.
+func (l *EventLog) Events() []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	result := make([]Event, 0, l.count)
+	for i := 0; i < l.count; i++ {
+		result = append(result, l.events[(l.start+i)%len(l.events)])
+	}
+	return result
+}
+
+// Last returns the most recent event, or false if there are none.
+func (l *EventLog) Last() (Event, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.count == 0 {
+		return Event{}, false
+	}
+	return l.events[(l.start+l.count-1)%len(l.events)], true
+}
+
+// recordStatus adds a status message to the event log, so that it can be
+// read after the status bar has moved on, unless the latest event already
+// says the same thing.
+func (m Model) recordStatus(status *StatusMessage) {
+	if m.Events == nil {
+		return
+	}
+	if last, ok := m.Events.Last(); ok && last.Text == status.Text {
+		return
+	}
+	m.Events.Add(Event{Time: m.Clock.Now(), Type: status.Type, Text: status.Text})
+}
+
+// eventLogLines returns the lines of the event log dialog, oldest first.
+func (m Model) eventLogLines() []string {
+	var events []Event
+	if m.Events != nil {
+		events = m.Events.Events()
+	}
+	if len(events) == 0 {
+		return []string{"Nothing has happened yet."}
+	}
+
+	width := max(m.Width-16, 20)
+	lines := make([]string, len(events))
+	for i, e := range events {
+		text := truncateLine(e.Text, width)
+		switch e.Type {
+		case StatusError:
+			text = m.Theme.StatusError.Render("✗ " + text)
+		case StatusWarning:
+			text = m.Theme.StatusWarning.Render(mutagen.Icon("⚠", "!") + " " + text)
+		default:
+			text = "  " + text
+		}
+		lines[i] = m.Theme.ModalHelp.Render(e.Time.Format("15:04:05")) + " " + text
+	}
+	return lines
+}
+
+// openEventLog shows the event log dialog, scrolled to the newest events.
+func (m *Model) openEventLog() {
+	m.ActiveModal = ModalEventLog
+	n := len(m.eventLogLines())
+	m.modalOffset = clampOffset(n, n, m.modalBodyHeight(0))
+}
+
+func (m Model) handleEventLogKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, keys.EventLog) {
+		m.ActiveModal = ModalNone
+	} else if offset, ok := scrollOffset(msg, m.modalOffset, len(m.eventLogLines()), m.modalBodyHeight(0)); ok {
+		m.modalOffset = offset
+	}
+	return m, nil
+}
+
+func (m Model) renderEventLogModal() string {
+	var content strings.Builder
+	content.WriteString(m.renderScrollWindow(m.eventLogLines(), m.modalOffset, m.modalBodyHeight(0)))
+	content.WriteString("\n" + m.Theme.ModalHelp.Render(fmt.Sprintf("↑/↓ PgUp/PgDn scroll  Esc or '%s' close", keys.EventLog.Help().Key)))
+
+	return m.Theme.ModalBorder.Render(
+		m.Theme.ModalTitle.Render(" Event Log ") + "\n\n" + content.String(),
+	)
+}
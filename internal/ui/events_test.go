@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEventLog_Ordering(t *testing.T) {
+	log := NewEventLog(3)
+	log.Add(Event{Text: "one"})
+	log.Add(Event{Text: "two"})
+
+	events := log.Events()
+	if len(events) != 2 {
+		t.Fatalf("len = %d, want 2", len(events))
+	}
+	if events[0].Text != "one" || events[1].Text != "two" {
+		t.Errorf("events = %v, want oldest first", events)
+	}
+}
+
+func TestEventLog_EvictsOldest(t *testing.T) {
+	log := NewEventLog(3)
+	for _, text := range []string{"a", "b", "c", "d", "e"} {
+		log.Add(Event{Text: text})
+	}
+
+	if log.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", log.Len())
+	}
+	events := log.Events()
+	want := []string{"c", "d", "e"}
+	for i, e := range events {
+		if e.Text != want[i] {
+			t.Errorf("events[%d] = %q, want %q", i, e.Text, want[i])
+		}
+	}
+}
+
+func TestEventLog_Last(t *testing.T) {
+	log := NewEventLog(2)
+	if _, ok := log.Last(); ok {
+		t.Error("an empty log has no last event")
+	}
+	for _, text := range []string{"a", "b", "c"} {
+		log.Add(Event{Text: text})
+	}
+	if last, ok := log.Last(); !ok || last.Text != "c" {
+		t.Errorf("Last() = %v, %v; want c after wrapping", last, ok)
+	}
+}
+
+func TestUpdate_RecordsStatusMessages(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.Events = NewEventLog(10)
+	m.Events.Add(Event{Type: StatusInfo, Text: "Started session: web"})
+
+	// The app logged this status as an event already
+	updated, _ := m.Update(OperationDoneMsg{Status: &StatusMessage{Type: StatusInfo, Text: "Started session: web"}})
+	m = updated.(Model)
+	updated, _ = m.Update(OperationDoneMsg{Status: &StatusMessage{Type: StatusError, Text: "Failed to push: timeout"}})
+	m = updated.(Model)
+	// The error stays in the log after the flash clears
+	updated, _ = m.Update(ClearFlashMsg{})
+	m = updated.(Model)
+
+	events := m.Events.Events()
+	if len(events) != 2 || events[1].Type != StatusError || events[1].Text != "Failed to push: timeout" {
+		t.Errorf("events = %+v, want the error recorded once", events)
+	}
+}
+
+func TestEventLogModal(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.Height = 20
+	m.Events = NewEventLog(100)
+	for i := range 30 {
+		m.Events.Add(Event{Type: StatusInfo, Text: fmt.Sprintf("event %d", i)})
+	}
+
+	m = press(m, "g")
+	if m.ActiveModal != ModalEventLog {
+		t.Fatalf("ActiveModal = %v, want the event log", m.ActiveModal)
+	}
+	// It opens on the newest events
+	out := m.renderEventLogModal()
+	if !strings.Contains(out, "event 29") || strings.Contains(out, "event 0\n") || strings.Contains(out, "more below") {
+		t.Errorf("the log should open at its end:\n%s", out)
+	}
+	m = press(m, "pgup")
+	if !strings.Contains(m.renderEventLogModal(), "more below") {
+		t.Error("page up should scroll back")
+	}
+	m = press(m, "g")
+	if m.ActiveModal != ModalNone {
+		t.Error("g should close the event log")
+	}
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// labelPrefix introduces a label term in a filter query.
+const labelPrefix = "label:"
+
+// filterTerm is a single whitespace-separated term of a filter query.
+type filterTerm struct {
+	// text is matched case-insensitively against project and spec names.
+	text string
+	// labelKey and labelValue match a running session's labels.
+	// An empty labelValue matches any session that has the key.
+	labelKey   string
+	labelValue string
+	isLabel    bool
+}
+
+// Filter narrows the project list to specs matching a query.
+// Terms are separated by whitespace and must all match. A term of the form
+// label:key=value matches sessions carrying that label; label:key matches
+// sessions that have the label at all; any other term matches project,
+// spec, and session names.
+type Filter struct {
+	Query string
+	terms []filterTerm
+}
+
+// ParseFilter parses a filter query.
+func ParseFilter(query string) Filter {
+	f := Filter{Query: query}
+	for _, field := range strings.Fields(query) {
+		if rest, ok := strings.CutPrefix(field, labelPrefix); ok && rest != "" {
+			key, value, _ := strings.Cut(rest, "=")
+			f.terms = append(f.terms, filterTerm{isLabel: true, labelKey: key, labelValue: value})
+			continue
+		}
+		f.terms = append(f.terms, filterTerm{text: strings.ToLower(field)})
+	}
+	return f
+}
+
+// IsEmpty returns true if the filter has no terms and matches everything.
+func (f Filter) IsEmpty() bool {
+	return len(f.terms) == 0
+}
+
+// MatchesSpec returns true if the spec satisfies every term of the filter.
+func (f Filter) MatchesSpec(proj *project.Project, spec *project.SyncSpec) bool {
+	for _, term := range f.terms {
+		if !term.matchesSpec(proj, spec) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesProject returns true if any of the project's specs match the filter.
+// A project without specs matches when its name satisfies every text term.
+func (f Filter) MatchesProject(proj *project.Project) bool {
+	if len(proj.Specs) == 0 {
+		name := strings.ToLower(proj.File.DisplayName())
+		for _, term := range f.terms {
+			if term.isLabel || !strings.Contains(name, term.text) {
+				return false
+			}
+		}
+		return true
+	}
+	for i := range proj.Specs {
+		if f.MatchesSpec(proj, &proj.Specs[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t filterTerm) matchesSpec(proj *project.Project, spec *project.SyncSpec) bool {
+	session := spec.RunningSession
+	if t.isLabel {
+		if session == nil || session.Labels == nil {
+			return false
+		}
+		value, ok := session.Labels[t.labelKey]
+		return ok && (t.labelValue == "" || value == t.labelValue)
+	}
+
+	if strings.Contains(strings.ToLower(proj.File.DisplayName()), t.text) ||
+		strings.Contains(strings.ToLower(spec.Name), t.text) {
+		return true
+	}
+	return session != nil && strings.Contains(strings.ToLower(session.Name), t.text)
+}
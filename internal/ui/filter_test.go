@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// labelProject returns a project whose first spec runs a session with the given labels.
+func labelProject(name string, labels map[string]string) *project.Project {
+	proj := makeTestProject(name, 2, true)
+	proj.Specs[0].State = project.RunningTwoWay
+	proj.Specs[0].RunningSession = &mutagen.SyncSession{Name: name + "-spec-a", Labels: labels}
+	return proj
+}
+
+func TestParseFilter(t *testing.T) {
+	f := ParseFilter("  web label:environment=production label:owner ")
+	if len(f.terms) != 3 {
+		t.Fatalf("len(terms) = %d, want 3", len(f.terms))
+	}
+	if f.terms[0].isLabel || f.terms[0].text != "web" {
+		t.Errorf("terms[0] = %+v, want text term 'web'", f.terms[0])
+	}
+	if !f.terms[1].isLabel || f.terms[1].labelKey != "environment" || f.terms[1].labelValue != "production" {
+		t.Errorf("terms[1] = %+v, want label environment=production", f.terms[1])
+	}
+	if !f.terms[2].isLabel || f.terms[2].labelKey != "owner" || f.terms[2].labelValue != "" {
+		t.Errorf("terms[2] = %+v, want label owner", f.terms[2])
+	}
+	if !ParseFilter("   ").IsEmpty() {
+		t.Error("whitespace-only query should be empty")
+	}
+}
+
+func TestFilter_MatchesSpecByLabel(t *testing.T) {
+	proj := labelProject("app", map[string]string{"environment": "production"})
+
+	tests := []struct {
+		query string
+		spec  int
+		want  bool
+	}{
+		{"label:environment=production", 0, true},
+		{"label:environment=staging", 0, false},
+		{"label:environment", 0, true},
+		{"label:owner", 0, false},
+		{"label:environment=production", 1, false}, // not running
+		{"APP", 1, true},
+		{"spec-b", 0, false},
+		{"app label:environment=production", 0, true},
+	}
+	for _, tt := range tests {
+		f := ParseFilter(tt.query)
+		if got := f.MatchesSpec(proj, &proj.Specs[tt.spec]); got != tt.want {
+			t.Errorf("ParseFilter(%q).MatchesSpec(spec %d) = %v, want %v", tt.query, tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestSelectionManager_RebuildWithFilter(t *testing.T) {
+	projects := []*project.Project{
+		labelProject("prod", map[string]string{"environment": "production"}),
+		labelProject("stage", map[string]string{"environment": "staging"}),
+	}
+	sm := NewSelectionManager()
+	sm.SetFilter(ParseFilter("label:environment=production"))
+	sm.RebuildFromProjects(projects)
+
+	// Only the prod header and its matching spec, even though it is folded
+	if sm.TotalItems() != 2 {
+		t.Fatalf("TotalItems() = %d, want 2", sm.TotalItems())
+	}
+	if item := sm.ItemAt(1); item.Type != SelectableSpec || item.ProjectIndex != 0 || item.SpecIndex != 0 {
+		t.Errorf("ItemAt(1) = %+v, want spec 0 of project 0", item)
+	}
+
+	sm.SetFilter(Filter{})
+	sm.RebuildFromProjects(projects)
+	if sm.TotalItems() != 2 {
+		t.Errorf("TotalItems() without filter = %d, want 2 folded headers", sm.TotalItems())
+	}
+}
+
+func TestSelectionManager_ApplyFilterKeepsSelection(t *testing.T) {
+	projects := []*project.Project{
+		makeTestProject("alpha", 2, false),
+		makeTestProject("beta", 2, false),
+	}
+	projects[0].File.Path = "/test/web.yml"
+	projects[1].File.Path = "/test/api.yml"
+	sm := NewSelectionManager()
+	sm.RebuildFromProjects(projects)
+	sm.SetIndex(5) // api/spec-b
+
+	// The selected spec still matches, so it stays selected at its new index
+	sm.ApplyFilter(ParseFilter("api"), projects)
+	if spec := sm.ItemAt(sm.RawIndex()); spec.ProjectIndex != 1 || spec.SpecIndex != 1 {
+		t.Errorf("selection = %+v, want spec 1 of project 1", spec)
+	}
+
+	// The spec is filtered out but its project isn't: select the project
+	sm.ApplyFilter(ParseFilter("api spec-a"), projects)
+	if !sm.IsProjectSelected() || sm.SelectedProjectIndex() != 1 {
+		t.Errorf("selection = %+v, want the api project header", sm.SelectedItem())
+	}
+
+	// Nothing selected matches: select the first match
+	sm.ApplyFilter(ParseFilter("web"), projects)
+	if sm.RawIndex() != 0 {
+		t.Errorf("RawIndex() = %d, want 0", sm.RawIndex())
+	}
+
+	// Clearing the filter keeps the selection
+	sm.SetIndex(2) // web/spec-b
+	sm.ApplyFilter(Filter{}, projects)
+	if spec := sm.ItemAt(sm.RawIndex()); sm.RawIndex() != 2 || spec.SpecIndex != 1 {
+		t.Errorf("RawIndex() = %d, want 2", sm.RawIndex())
+	}
+}
@@ -0,0 +1,228 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+// ForwardsMsg carries the forward session list for the forwards view, and
+// the status of an action taken on a forward.
+type ForwardsMsg struct {
+	Forwards []mutagen.ForwardSession
+	Err      error
+	Status   *StatusMessage
+}
+
+// forwardsState is the state of the forwards view, which replaces the
+// project list while shown.
+type forwardsState struct {
+	shown     bool
+	sessions  []mutagen.ForwardSession
+	err       error
+	loading   bool
+	selection *SelectionManager
+}
+
+// toggleForwards switches the list between sync projects and forwards,
+// loading the forwards when they are shown.
+func (m Model) toggleForwards() (tea.Model, tea.Cmd) {
+	if m.forwards.shown || m.ListForwards == nil {
+		m.forwards.shown = false
+		return m, nil
+	}
+	m.forwards.shown = true
+	m.forwards.loading = true
+	if m.forwards.selection == nil {
+		m.forwards.selection = NewSelectionManager()
+	}
+	return m, m.forwardsCmd(nil)
+}
+
+// forwardsCmd lists the forward sessions, after running action if it is
+// non-nil.
+func (m Model) forwardsCmd(action func(ctx context.Context) *StatusMessage) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var status *StatusMessage
+		if action != nil {
+			status = action(ctx)
+		}
+		forwards, err := m.ListForwards(ctx)
+		return ForwardsMsg{Forwards: forwards, Err: err, Status: status}
+	}
+}
+
+func (m Model) handleForwardsMsg(msg ForwardsMsg) (tea.Model, tea.Cmd) {
+	m.forwards.loading = false
+	m.forwards.sessions = msg.Forwards
+	m.forwards.err = msg.Err
+	if m.forwards.selection == nil {
+		m.forwards.selection = NewSelectionManager()
+	}
+	m.forwards.selection.RebuildFromForwards(msg.Forwards)
+	if msg.Status == nil {
+		return m, nil
+	}
+	m.endOperation(nil)
+	m.StatusMessage = msg.Status
+	return m, m.flashCmd()
+}
+
+// selectedForward returns the selected forward session, or nil if there is none.
+func (m Model) selectedForward() *mutagen.ForwardSession {
+	if m.forwards.selection == nil {
+		return nil
+	}
+	if i := m.forwards.selection.SelectedForward(); i >= 0 && i < len(m.forwards.sessions) {
+		return &m.forwards.sessions[i]
+	}
+	return nil
+}
+
+func (m Model) handleForwardsKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, keys.Suspend):
+		return m, tea.Suspend
+	case key.Matches(msg, keys.Help):
+		m.ActiveModal = ModalHelp
+	case key.Matches(msg, keys.Forwards), key.Matches(msg, keys.Escape):
+		m.forwards.shown = false
+	case key.Matches(msg, keys.Up):
+		m.forwards.selection.SelectPrevious()
+	case key.Matches(msg, keys.Down):
+		m.forwards.selection.SelectNext()
+	case key.Matches(msg, keys.Refresh):
+		m.forwards.loading = true
+		return m, m.forwardsCmd(nil)
+	case key.Matches(msg, keys.Pause):
+		if fwd := m.selectedForward(); fwd != nil {
+			if fwd.Paused {
+				return m.runForwardAction("Resuming "+fwd.DisplayName()+"...", *fwd, m.OnResumeForward)
+			}
+			return m.runForwardAction("Pausing "+fwd.DisplayName()+"...", *fwd, m.OnPauseForward)
+		}
+	case key.Matches(msg, keys.Resume):
+		if fwd := m.selectedForward(); fwd != nil {
+			return m.runForwardAction("Resuming "+fwd.DisplayName()+"...", *fwd, m.OnResumeForward)
+		}
+	case key.Matches(msg, keys.Terminate):
+		fwd := m.selectedForward()
+		if fwd == nil || m.OnTerminateForward == nil {
+			return m, nil
+		}
+		session := *fwd
+		m.confirmation = &Confirmation{
+			Title: "TERMINATE FORWARD",
+			Lines: []string{
+				"Terminate " + session.DisplayName() + "?",
+				"",
+				session.Source.DisplayPath() + " → " + session.Destination.DisplayPath(),
+				"Open connections through it are closed.",
+			},
+			LoadingText: "Terminating " + session.DisplayName() + "...",
+			Run: m.forwardsCmd(func(ctx context.Context) *StatusMessage {
+				return m.OnTerminateForward(ctx, session.Identifier, session.DisplayName())
+			}),
+		}
+		m.ActiveModal = ModalConfirm
+	}
+	return m, nil
+}
+
+// runForwardAction runs action on fwd, then reloads the forwards.
+func (m Model) runForwardAction(loadingText string, fwd mutagen.ForwardSession,
+	action func(ctx context.Context, identifier, name string) *StatusMessage) (tea.Model, tea.Cmd) {
+	if action == nil {
+		return m, nil
+	}
+	m.IsLoading = true
+	m.LoadingText = loadingText
+	return m, m.forwardsCmd(func(ctx context.Context) *StatusMessage {
+		return action(ctx, fwd.Identifier, fwd.DisplayName())
+	})
+}
+
+// definedForwards describes the forwards defined in the project files that
+// no running forward has the name of, by project and then name.
+func (m Model) definedForwards() []string {
+	running := make(map[string]bool, len(m.forwards.sessions))
+	for _, fwd := range m.forwards.sessions {
+		running[fwd.Name] = true
+	}
+	var lines []string
+	for _, proj := range m.Projects {
+		names := slices.Sorted(maps.Keys(proj.File.Forwards))
+		for _, name := range names {
+			if running[name] {
+				continue
+			}
+			def := proj.File.Forwards[name]
+			lines = append(lines, fmt.Sprintf("%s %-24s %-22s %s → %s", mutagen.Icon("○", "o"),
+				truncateString(name, 24), truncateString(proj.File.DisplayName(), 22), def.Source, def.Destination))
+		}
+	}
+	return lines
+}
+
+func (m Model) renderForwardList(height int) string {
+	f := m.forwards
+	contentWidth := max(m.Width-4-m.borderSize(), 40)
+
+	var lines []string
+	switch {
+	case f.err != nil:
+		lines = append(lines, m.Theme.StatusError.Render("Failed to list forwards: "+f.err.Error()))
+	case f.loading && f.sessions == nil:
+		lines = append(lines, "Loading...")
+	case len(f.sessions) == 0:
+		lines = append(lines, "No forward sessions.", "",
+			m.Theme.ModalHelp.Render("Create one with mutagen forward create, or press "+keys.Forwards.Help().Key+" to return to sync projects"))
+	default:
+		for i := range f.sessions {
+			fwd := &f.sessions[i]
+			line := fmt.Sprintf("%s %-24s %-22s %s → %s",
+				fwd.StatusIcon(),
+				truncateString(fwd.DisplayName(), 24),
+				truncateString(fwd.StatusText(), 22),
+				fwd.Source.DisplayPath(), fwd.Destination.DisplayPath())
+			if fwd.OpenConnections > 0 {
+				line += fmt.Sprintf("  (%d open)", fwd.OpenConnections)
+			}
+			line = truncateLine(line, contentWidth)
+			if i == f.selection.RawIndex() {
+				line = m.Theme.SelectedItem.Width(contentWidth).Render(line)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	if defined := m.definedForwards(); len(defined) > 0 && f.err == nil {
+		lines = append(lines, "", m.Theme.ModalHelp.Render("In project files, not running (mutagui doesn't start forwards):"))
+		for _, line := range defined {
+			lines = append(lines, truncateLine(line, contentWidth))
+		}
+	}
+
+	innerHeight := height - m.borderSize() - 1 // Account for border and title
+	for len(lines) < innerHeight {
+		lines = append(lines, "")
+	}
+	if len(lines) > innerHeight {
+		lines = lines[:innerHeight]
+	}
+
+	title := fmt.Sprintf(" Forwards (%d) ", len(f.sessions))
+	return m.section(m.Theme.ListBorder).
+		Width(m.Width - m.borderSize()).
+		Height(height - m.borderSize()).
+		Render(m.Theme.ListTitle.Render(title) + "\n" + strings.Join(lines, "\n"))
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestForwards_ToggleListAndAct(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	m.Width, m.Height = 120, 30
+
+	forwards := []mutagen.ForwardSession{
+		{Name: "web", Identifier: "forward_a", Status: "forwarding",
+			Source:      mutagen.ForwardEndpoint{Path: "tcp:localhost:8080"},
+			Destination: mutagen.ForwardEndpoint{Path: "tcp:localhost:80"}},
+		{Identifier: "forward_b", Status: "disconnected", Paused: true},
+	}
+	m.ListForwards = func(ctx context.Context) ([]mutagen.ForwardSession, error) { return forwards, nil }
+	var calls []string
+	record := func(verb string) func(ctx context.Context, identifier, name string) *StatusMessage {
+		return func(ctx context.Context, identifier, name string) *StatusMessage {
+			calls = append(calls, verb+" "+identifier)
+			return &StatusMessage{Text: verb + " " + name}
+		}
+	}
+	m.OnPauseForward = record("pause")
+	m.OnResumeForward = record("resume")
+	m.OnTerminateForward = record("terminate")
+
+	updated, cmd := m.handleKeyPress(keyPress("F"))
+	m = updated.(Model)
+	if !m.forwards.shown || cmd == nil {
+		t.Fatal("F should show the forwards and load them")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+
+	view := m.View()
+	if !strings.Contains(view, "Forwards (2)") || !strings.Contains(view, "tcp:localhost:8080 → tcp:localhost:80") {
+		t.Errorf("view should list the forwards:\n%s", view)
+	}
+	if strings.Contains(view, "spec-a") {
+		t.Errorf("forwards view should replace the project list:\n%s", view)
+	}
+
+	// p pauses a running forward and resumes a paused one
+	updated, cmd = m.handleKeyPress(keyPress("p"))
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	m = press(m, "j")
+	updated, cmd = m.handleKeyPress(keyPress("p"))
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+
+	// t terminates after confirming
+	m = press(m, "t")
+	if m.ActiveModal != ModalConfirm {
+		t.Fatal("t should ask for confirmation")
+	}
+	updated, cmd = m.handleKeyPress(keyPress("y"))
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+
+	want := []string{"pause forward_a", "resume forward_b", "terminate forward_b"}
+	if strings.Join(calls, ", ") != strings.Join(want, ", ") {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if m.IsLoading || m.StatusMessage == nil || m.StatusMessage.Text != "terminate forward_b" {
+		t.Errorf("loading %v, status %+v; want the terminate status", m.IsLoading, m.StatusMessage)
+	}
+
+	m = press(m, "F")
+	if m.forwards.shown || !strings.Contains(m.View(), "proj") {
+		t.Error("F should return to the sync projects")
+	}
+}
+
+func TestForwards_KeysDontReachProjects(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	m.ListForwards = func(ctx context.Context) ([]mutagen.ForwardSession, error) { return nil, nil }
+	started := false
+	m.OnStart = func(ctx context.Context) *StatusMessage {
+		started = true
+		return nil
+	}
+
+	m = press(m, "F")
+	updated, cmd := m.handleKeyPress(keyPress("s"))
+	if cmd != nil {
+		updated.(Model).Update(cmd())
+	}
+	if started {
+		t.Error("s in the forwards view should not start the selected project")
+	}
+}
+
+func TestForwards_ListsProjectDefinitions(t *testing.T) {
+	proj := makeTestProject("proj", 1, false)
+	proj.File.Forwards = map[string]project.ForwardDefinition{
+		"api": {Source: "tcp:localhost:8080", Destination: "server:tcp:localhost:80"},
+		"web": {Source: "tcp:localhost:3000", Destination: "server:tcp:localhost:3000"},
+	}
+	m := newTestModel(proj)
+	m.Width, m.Height = 120, 30
+	m.ListForwards = func(ctx context.Context) ([]mutagen.ForwardSession, error) {
+		return []mutagen.ForwardSession{{Name: "web", Identifier: "forward_a", Status: "forwarding"}}, nil
+	}
+
+	updated, cmd := m.handleKeyPress(keyPress("F"))
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+
+	view := m.View()
+	if !strings.Contains(view, "In project files, not running") || !strings.Contains(view, "tcp:localhost:8080 → server:tcp:localhost:80") {
+		t.Errorf("view should list the defined forward that isn't running:\n%s", view)
+	}
+	if strings.Contains(view, "tcp:localhost:3000 →") {
+		t.Errorf("view shouldn't list a defined forward that is running:\n%s", view)
+	}
+}
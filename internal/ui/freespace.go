@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+// freeSpaceCheck is the check of the free space on the beta of the session
+// in the sync status dialog.
+type freeSpaceCheck struct {
+	gen      int    // The dialog's pollGen when the check started
+	endpoint string // The beta's URL
+	done     bool
+	free     uint64
+	err      error
+}
+
+// freeSpaceMsg carries the result of a freeSpaceCheck.
+type freeSpaceMsg struct {
+	gen      int
+	endpoint string
+	free     uint64
+	err      error
+}
+
+// checkFreeSpace starts checking the free space on the selected session's
+// beta, which can take a round trip over SSH, for the sync status dialog.
+func (m *Model) checkFreeSpace() tea.Cmd {
+	m.freeSpace = freeSpaceCheck{}
+	if m.FreeSpace == nil || m.GetSelectedSession == nil {
+		return nil
+	}
+	session := m.GetSelectedSession()
+	if session == nil {
+		return nil
+	}
+	gen, beta, check := m.pollGen, session.Beta, m.FreeSpace
+	endpoint := beta.URL()
+	m.freeSpace = freeSpaceCheck{gen: gen, endpoint: endpoint}
+	return func() tea.Msg {
+		free, err := check(context.Background(), beta)
+		return freeSpaceMsg{gen: gen, endpoint: endpoint, free: free, err: err}
+	}
+}
+
+// freeSpaceReadout describes the free space on an endpoint, to follow its
+// total size, with a warning if the files still to arrive from the alpha
+// wouldn't fit in it. It is empty for endpoints that weren't checked.
+func (m Model) freeSpaceReadout(e *mutagen.Endpoint) string {
+	check := m.freeSpace
+	if check.endpoint == "" || check.gen != m.pollGen || check.endpoint != e.URL() {
+		return ""
+	}
+	switch {
+	case !check.done:
+		return m.Theme.ModalHelp.Render("Free: checking…")
+	case check.err != nil:
+		return m.Theme.ModalHelp.Render("Free: unknown (" + check.err.Error() + ")")
+	}
+	readout := "Free: " + formatBytes(check.free)
+	if toArrive := m.bytesToArrive(); check.free < toArrive {
+		readout += m.Theme.StatusWarning.Render(" " + mutagen.Icon("⚠", "!") + " less than the " + formatBytes(toArrive) + " still to sync")
+	}
+	return readout
+}
+
+// bytesToArrive returns how much more the selected session's beta needs to
+// hold to match its alpha, from their total sizes, or 0 if either isn't
+// known yet.
+func (m Model) bytesToArrive() uint64 {
+	if m.GetSelectedSession == nil {
+		return 0
+	}
+	session := m.GetSelectedSession()
+	if session == nil || session.Alpha.TotalFileSize == nil || session.Beta.TotalFileSize == nil {
+		return 0
+	}
+	alpha, beta := *session.Alpha.TotalFileSize, *session.Beta.TotalFileSize
+	if alpha <= beta {
+		return 0
+	}
+	return alpha - beta
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+// freeSpaceTestModel shows the sync status dialog for a session whose alpha
+// holds 3 GB and whose beta has 1 GB of it so far.
+func freeSpaceTestModel(free uint64, err error) (Model, *[]string) {
+	m := newTestModel()
+	alphaTotal, betaTotal := uint64(3<<30), uint64(1<<30)
+	host := "dev"
+	session := &mutagen.SyncSession{
+		Name:  "s",
+		Alpha: mutagen.Endpoint{Protocol: "local", Path: "/local", TotalFileSize: &alphaTotal},
+		Beta:  mutagen.Endpoint{Protocol: "ssh", Host: &host, Path: "/srv", TotalFileSize: &betaTotal},
+	}
+	m.GetSelectedSession = func() *mutagen.SyncSession { return session }
+	var checked []string
+	m.FreeSpace = func(_ context.Context, endpoint mutagen.Endpoint) (uint64, error) {
+		checked = append(checked, endpoint.URL())
+		return free, err
+	}
+	m.ActiveModal = ModalSyncStatus
+	return m, &checked
+}
+
+func TestSyncStatusModal_FreeSpace(t *testing.T) {
+	m, checked := freeSpaceTestModel(1<<30, nil)
+	msg := m.checkFreeSpace()()
+	if out := m.renderSyncStatusModal(); !strings.Contains(out, "Free: checking") {
+		t.Errorf("modal should say the check is underway:\n%s", out)
+	}
+
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	if len(*checked) != 1 || (*checked)[0] != "dev:/srv" {
+		t.Errorf("checked %q, want only the beta dev:/srv", *checked)
+	}
+	out := m.renderSyncStatusModal()
+	if !strings.Contains(out, "Total Size: 1.0 GB  Free: 1.0 GB") || !strings.Contains(out, "less than the 2.0 GB still to sync") {
+		t.Errorf("modal should show the beta's free space, flagged as too little:\n%s", out)
+	}
+	if strings.Count(out, "Free:") != 1 {
+		t.Errorf("only the beta should show its free space:\n%s", out)
+	}
+}
+
+func TestSyncStatusModal_FreeSpaceRoomy(t *testing.T) {
+	// More than is still to sync, though less than the alpha's total
+	m, _ := freeSpaceTestModel(5<<29, nil)
+	updated, _ := m.Update(m.checkFreeSpace()())
+	out := updated.(Model).renderSyncStatusModal()
+	if !strings.Contains(out, "Free: 2.5 GB") || strings.Contains(out, "still to sync") {
+		t.Errorf("modal should show the free space without a warning:\n%s", out)
+	}
+}
+
+func TestSyncStatusModal_FreeSpaceFailure(t *testing.T) {
+	m, _ := freeSpaceTestModel(0, errors.New("ssh: connection refused"))
+	updated, _ := m.Update(m.checkFreeSpace()())
+	if out := updated.(Model).renderSyncStatusModal(); !strings.Contains(out, "Free: unknown (ssh: connection refused)") {
+		t.Errorf("modal should say why the free space is unknown:\n%s", out)
+	}
+}
+
+func TestSyncStatusModal_StaleFreeSpaceIgnored(t *testing.T) {
+	m, _ := freeSpaceTestModel(1<<30, nil)
+	msg := m.checkFreeSpace()()
+
+	// The dialog was closed and reopened before the first check finished
+	m.pollGen++
+	m.checkFreeSpace()
+	updated, _ := m.Update(msg)
+	if out := updated.(Model).renderSyncStatusModal(); !strings.Contains(out, "Free: checking") {
+		t.Errorf("an earlier dialog's check shouldn't be shown:\n%s", out)
+	}
+}
@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"os"
+	"strings"
+)
+
+// TerminalLacksUnicode guesses from the environment whether the terminal
+// draws Unicode symbols as boxes: the Linux console and dumb terminals do,
+// and so does any terminal whose locale isn't UTF-8. An unset locale is
+// taken to be UTF-8, as it is on most systems.
+func TerminalLacksUnicode() bool {
+	switch os.Getenv("TERM") {
+	case "linux", "dumb", "vt100", "vt220":
+		return true
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := strings.ToLower(os.Getenv(name)); locale != "" {
+			return !strings.Contains(locale, "utf-8") && !strings.Contains(locale, "utf8")
+		}
+	}
+	return false
+}
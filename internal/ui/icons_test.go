@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestTerminalLacksUnicode(t *testing.T) {
+	tests := []struct {
+		name, term, lcAll, lang string
+		want                    bool
+	}{
+		{"utf-8 locale", "xterm-256color", "", "en_US.UTF-8", false},
+		{"utf8 locale", "xterm-256color", "", "de_DE.utf8", false},
+		{"no locale", "xterm-256color", "", "", false},
+		{"C locale", "xterm-256color", "", "C", true},
+		{"LC_ALL wins", "xterm-256color", "POSIX", "en_US.UTF-8", true},
+		{"linux console", "linux", "", "en_US.UTF-8", true},
+		{"dumb terminal", "dumb", "", "en_US.UTF-8", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TERM", tt.term)
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_CTYPE", "")
+			t.Setenv("LANG", tt.lang)
+			if got := TerminalLacksUnicode(); got != tt.want {
+				t.Errorf("TerminalLacksUnicode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionIcon_PadsToWidestIcon(t *testing.T) {
+	m := newTestModel()
+	for _, ascii := range []bool{false, true} {
+		mutagen.SetASCIIIcons(ascii)
+		want := lipgloss.Width(m.sessionIcon(&mutagen.SyncSession{Status: "scanning"}))
+		for _, status := range []string{"watching", "reconciling", "halted", "disconnected", ""} {
+			if got := lipgloss.Width(m.sessionIcon(&mutagen.SyncSession{Status: status})); got != want {
+				t.Errorf("ascii=%v: %q icon is %d wide, want %d", ascii, status, got, want)
+			}
+		}
+	}
+	mutagen.SetASCIIIcons(false)
+}
+
+func TestASCIIIcons_SpecRowAndStatus(t *testing.T) {
+	mutagen.SetASCIIIcons(true)
+	defer mutagen.SetASCIIIcons(false)
+
+	proj := makeTestProject("proj", 1, false)
+	m := newTestModel(proj)
+	m.marks = map[*project.SyncSpec]bool{&proj.Specs[0]: true}
+	row := m.renderSpecRow(proj, &proj.Specs[0], 100, false)
+	for _, r := range row {
+		if r > unicode.MaxASCII {
+			t.Errorf("spec row has %q with ASCII icons on: %q", r, row)
+			break
+		}
+	}
+
+	m.IsLoading, m.LoadingText = true, "Starting..."
+	if out := m.renderStatus(); !strings.Contains(out, "* Starting...") {
+		t.Errorf("status should mark the operation in ASCII:\n%s", out)
+	}
+}
@@ -0,0 +1,23 @@
+package ui
+
+import "time"
+
+// refreshPacing is what the model tracks to decide which auto-refresh ticks
+// to act on.
+type refreshPacing struct {
+	unfocused   bool      // The terminal reported losing focus
+	lastInput   time.Time // The last key or mouse press
+	lastRefresh time.Time // The last tick that refreshed
+}
+
+// autoRefreshDue reports whether an auto-refresh tick at now should
+// refresh. While the terminal is unfocused, ticks are skipped if
+// PauseUnfocused is set; otherwise, as after IdleAfter without input, they
+// refresh only every IdleInterval.
+func (m Model) autoRefreshDue(now time.Time) bool {
+	if m.pacing.unfocused && m.PauseUnfocused {
+		return false
+	}
+	idle := m.pacing.unfocused || (m.IdleAfter > 0 && now.Sub(m.pacing.lastInput) >= m.IdleAfter)
+	return !idle || now.Sub(m.pacing.lastRefresh) >= m.IdleInterval
+}
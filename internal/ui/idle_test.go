@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newPacedModel returns a model that counts refreshes, with the default
+// refresh pacing.
+func newPacedModel(refreshes *int) Model {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	m.OnRefresh = func(ctx context.Context, force bool) error {
+		*refreshes++
+		return nil
+	}
+	m.PauseUnfocused = true
+	m.IdleAfter = 5 * time.Minute
+	m.IdleInterval = 30 * time.Second
+	return m
+}
+
+// tick sends an auto-refresh tick at t and runs any command it returns.
+func tick(m Model, t time.Time) Model {
+	updated, cmd := m.Update(TickMsg(t))
+	if cmd != nil {
+		cmd()
+	}
+	return updated.(Model)
+}
+
+func TestAutoRefresh_PausedWhileUnfocused(t *testing.T) {
+	var refreshes int
+	m := newPacedModel(&refreshes)
+	start := time.Now()
+
+	m = tick(m, start)
+	if refreshes != 1 {
+		t.Fatalf("refreshes = %d after a focused tick, want 1", refreshes)
+	}
+
+	updated, _ := m.Update(tea.BlurMsg{})
+	m = updated.(Model)
+	for i := 1; i <= 20; i++ {
+		m = tick(m, start.Add(time.Duration(i)*3*time.Second))
+	}
+	if refreshes != 1 {
+		t.Errorf("refreshes = %d while unfocused, want none", refreshes-1)
+	}
+
+	updated, cmd := m.Update(tea.FocusMsg{})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("regaining focus should refresh")
+	}
+	cmd()
+	m = tick(m, start.Add(63*time.Second))
+	if refreshes != 3 {
+		t.Errorf("refreshes = %d, want one on focus and one on the next tick", refreshes-1)
+	}
+}
+
+func TestAutoRefresh_ManualRefreshWhileUnfocused(t *testing.T) {
+	var refreshes int
+	m := newPacedModel(&refreshes)
+	updated, _ := m.Update(tea.BlurMsg{})
+	m = updated.(Model)
+
+	if _, cmd := m.Update(keyPress("r")); cmd == nil {
+		t.Error("r should refresh even while auto-refresh is paused")
+	}
+}
+
+func TestAutoRefresh_SlowsUnfocusedWhenNotPaused(t *testing.T) {
+	var refreshes int
+	m := newPacedModel(&refreshes)
+	m.PauseUnfocused = false
+	start := time.Now()
+	m = tick(m, start)
+
+	updated, _ := m.Update(tea.BlurMsg{})
+	m = updated.(Model)
+	for i := 1; i <= 20; i++ {
+		m = tick(m, start.Add(time.Duration(i)*3*time.Second))
+	}
+	// Ticks at 30s and 60s refresh
+	if refreshes != 3 {
+		t.Errorf("refreshes = %d over a minute unfocused, want 2 at the idle pace", refreshes-1)
+	}
+}
+
+func TestScheduledFlushes_IndependentOfRefreshPacing(t *testing.T) {
+	var refreshes, flushes int
+	m := newPacedModel(&refreshes)
+	m.OnScheduledFlush = func(context.Context) { flushes++ }
+	updated, _ := m.Update(tea.BlurMsg{})
+	m = updated.(Model)
+
+	updated, cmd := m.Update(ScheduleTickMsg(time.Now()))
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("a schedule tick while unfocused should still check for flushes")
+	}
+	cmd()
+	if flushes != 1 || refreshes != 0 {
+		t.Errorf("flushes, refreshes = %d, %d; want a flush check without a refresh", flushes, refreshes)
+	}
+}
+
+func TestAutoRefresh_SlowsWhenIdle(t *testing.T) {
+	var refreshes int
+	m := newPacedModel(&refreshes)
+	start := time.Now()
+	m = tick(m, start)
+
+	// Refreshes keep the usual pace until IdleAfter passes without input
+	for i := 1; i < 100; i++ {
+		m = tick(m, start.Add(time.Duration(i)*3*time.Second))
+	}
+	if refreshes != 100 {
+		t.Fatalf("refreshes = %d in the first 5 minutes, want 100", refreshes)
+	}
+	for i := 100; i <= 110; i++ {
+		m = tick(m, start.Add(time.Duration(i)*3*time.Second))
+	}
+	if refreshes != 101 {
+		t.Errorf("refreshes = %d, want one in the next 30 idle seconds", refreshes-100)
+	}
+
+	// A key press restores the usual pace
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(Model)
+	m = tick(m, time.Now().Add(time.Second))
+	if refreshes != 102 {
+		t.Errorf("refreshes = %d, want a refresh on the first tick after input", refreshes-101)
+	}
+}
@@ -0,0 +1,46 @@
+package ui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// ignoresCheck is the description of the selected spec's ignore patterns in
+// the sync status dialog, which walks the local endpoint to find patterns
+// that match nothing.
+type ignoresCheck struct {
+	gen   int // The dialog's pollGen when the check started
+	done  bool
+	lines []string
+}
+
+// ignoresMsg carries the result of an ignoresCheck.
+type ignoresMsg struct {
+	gen   int
+	lines []string
+}
+
+// checkIgnores starts describing the selected spec's ignore patterns for the
+// sync status dialog. The dialog renders the result rather than walking the
+// endpoint again on each render.
+func (m *Model) checkIgnores() tea.Cmd {
+	m.ignores = ignoresCheck{}
+	if m.DescribeIgnores == nil {
+		return nil
+	}
+	gen, describe := m.pollGen, m.DescribeIgnores
+	m.ignores = ignoresCheck{gen: gen}
+	return func() tea.Msg {
+		return ignoresMsg{gen: gen, lines: describe()}
+	}
+}
+
+// ignoresLines returns the lines to show under the dialog's "Ignores:"
+// heading, and nil if there are none.
+func (m Model) ignoresLines() []string {
+	check := m.ignores
+	if m.DescribeIgnores == nil || check.gen != m.pollGen {
+		return nil
+	}
+	if !check.done {
+		return []string{m.Theme.ModalHelp.Render("Checking…")}
+	}
+	return check.lines
+}
@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// Where key bindings are used. A key may only do one thing in each.
+const (
+	keyContextList      = "list"      // The session list
+	keyContextModal     = "modal"     // Scrolling dialogs
+	keyContextConflicts = "conflicts" // The conflicts dialog
+	keyContextConfirm   = "confirm"   // Confirmation dialogs
+)
+
+// keyAction is a key binding that the config file can rebind by name.
+type keyAction struct {
+	name     string
+	binding  func(*KeyMap) *key.Binding
+	contexts []string
+}
+
+var (
+	listOnly         = []string{keyContextList}
+	listAndConflicts = []string{keyContextList, keyContextConflicts}
+	navigation       = []string{keyContextList, keyContextModal, keyContextConflicts}
+	scrolling        = []string{keyContextModal, keyContextConflicts}
+	conflictOnly     = []string{keyContextConflicts}
+	confirmOnly      = []string{keyContextConfirm}
+)
+
+// keyActions are the bindings the [keys] config table can rebind, by name.
+// The config file's actions are checked against them.
+var keyActions = []keyAction{
+	{"up", func(k *KeyMap) *key.Binding { return &k.Up }, navigation},
+	{"down", func(k *KeyMap) *key.Binding { return &k.Down }, navigation},
+	{"page_up", func(k *KeyMap) *key.Binding { return &k.PageUp }, scrolling},
+	{"page_down", func(k *KeyMap) *key.Binding { return &k.PageDown }, scrolling},
+	{"fold", func(k *KeyMap) *key.Binding { return &k.Left }, listAndConflicts},
+	{"unfold", func(k *KeyMap) *key.Binding { return &k.Right }, listAndConflicts},
+	{"toggle_fold", func(k *KeyMap) *key.Binding { return &k.Enter }, listAndConflicts},
+	{"quit", func(k *KeyMap) *key.Binding { return &k.Quit }, listOnly},
+	{"suspend", func(k *KeyMap) *key.Binding { return &k.Suspend }, listOnly},
+	{"help", func(k *KeyMap) *key.Binding { return &k.Help }, listOnly},
+	{"refresh", func(k *KeyMap) *key.Binding { return &k.Refresh }, listOnly},
+	{"thorough_refresh", func(k *KeyMap) *key.Binding { return &k.Thorough }, listOnly},
+	{"restart_daemon", func(k *KeyMap) *key.Binding { return &k.Daemon }, listOnly},
+	{"start", func(k *KeyMap) *key.Binding { return &k.Start }, listOnly},
+	{"terminate", func(k *KeyMap) *key.Binding { return &k.Terminate }, listOnly},
+	{"start_all", func(k *KeyMap) *key.Binding { return &k.StartAll }, listOnly},
+	{"terminate_all", func(k *KeyMap) *key.Binding { return &k.TermAll }, listOnly},
+	{"flush", func(k *KeyMap) *key.Binding { return &k.Flush }, listOnly},
+	{"reconnect", func(k *KeyMap) *key.Binding { return &k.Reconnect }, listOnly},
+	{"reset", func(k *KeyMap) *key.Binding { return &k.Reset }, listOnly},
+	{"restart", func(k *KeyMap) *key.Binding { return &k.Restart }, listOnly},
+	{"pause", func(k *KeyMap) *key.Binding { return &k.Pause }, listOnly},
+	{"resume", func(k *KeyMap) *key.Binding { return &k.Resume }, listOnly},
+	{"pause_all", func(k *KeyMap) *key.Binding { return &k.PauseAll }, listOnly},
+	{"focus", func(k *KeyMap) *key.Binding { return &k.Focus }, listOnly},
+	{"mark", func(k *KeyMap) *key.Binding { return &k.Mark }, listOnly},
+	{"schedule", func(k *KeyMap) *key.Binding { return &k.Schedule }, listOnly},
+	{"undo", func(k *KeyMap) *key.Binding { return &k.Undo }, listOnly},
+	{"push", func(k *KeyMap) *key.Binding { return &k.Push }, listOnly},
+	{"conflicts", func(k *KeyMap) *key.Binding { return &k.Conflicts }, listOnly},
+	{"sync_status", func(k *KeyMap) *key.Binding { return &k.SyncStatus }, listOnly},
+	{"log", func(k *KeyMap) *key.Binding { return &k.Log }, listOnly},
+	{"event_log", func(k *KeyMap) *key.Binding { return &k.EventLog }, listOnly},
+	{"filter", func(k *KeyMap) *key.Binding { return &k.Filter }, listAndConflicts},
+	{"problems_only", func(k *KeyMap) *key.Binding { return &k.Problems }, listOnly},
+	{"edit", func(k *KeyMap) *key.Binding { return &k.Edit }, listOnly},
+	{"copy", func(k *KeyMap) *key.Binding { return &k.Copy }, listOnly},
+	{"open_beta", func(k *KeyMap) *key.Binding { return &k.OpenBeta }, listOnly},
+	{"toggle_mode", func(k *KeyMap) *key.Binding { return &k.ToggleMode }, listOnly},
+	{"toggle_host", func(k *KeyMap) *key.Binding { return &k.ToggleHost }, listOnly},
+	{"toggle_names", func(k *KeyMap) *key.Binding { return &k.ToggleNames }, listOnly},
+	{"sort", func(k *KeyMap) *key.Binding { return &k.Sort }, listOnly},
+	{"borderless", func(k *KeyMap) *key.Binding { return &k.Borderless }, listOnly},
+	{"density", func(k *KeyMap) *key.Binding { return &k.Density }, listOnly},
+	{"daemon_list", func(k *KeyMap) *key.Binding { return &k.DaemonList }, listOnly},
+	{"forwards", func(k *KeyMap) *key.Binding { return &k.Forwards }, listOnly},
+	{"push_to_beta", func(k *KeyMap) *key.Binding { return &k.PushToBeta }, conflictOnly},
+	{"pull_to_alpha", func(k *KeyMap) *key.Binding { return &k.PullToAlpha }, conflictOnly},
+	{"keep_alpha", func(k *KeyMap) *key.Binding { return &k.KeepAlpha }, conflictOnly},
+	{"keep_beta", func(k *KeyMap) *key.Binding { return &k.KeepBeta }, conflictOnly},
+	{"confirm_yes", func(k *KeyMap) *key.Binding { return &k.ConfirmYes }, confirmOnly},
+	{"confirm_no", func(k *KeyMap) *key.Binding { return &k.ConfirmNo }, confirmOnly},
+	{"close", func(k *KeyMap) *key.Binding { return &k.Escape }, []string{keyContextList, keyContextModal, keyContextConflicts, keyContextConfirm}},
+}
+
+// KeyMapFromConfig returns the default key bindings with those named in
+// bindings, from the config file's [keys] table, replaced. It fails for an
+// unknown action, or a key that would do two things in the same place.
+// Space may both pause and mark, since the space setting picks one.
+func KeyMapFromConfig(bindings map[string][]string) (KeyMap, error) {
+	km := DefaultKeyMap()
+	for _, name := range slices.Sorted(maps.Keys(bindings)) {
+		if !slices.ContainsFunc(keyActions, func(a keyAction) bool { return a.name == name }) {
+			return km, fmt.Errorf("unknown key action %q; the actions are %s", name, strings.Join(keyActionNames(), ", "))
+		}
+	}
+
+	var rebound []keyAction
+	for _, action := range keyActions {
+		bound, ok := bindings[action.name]
+		if !ok {
+			continue
+		}
+		binding := action.binding(&km)
+		binding.SetKeys(bound...)
+		binding.SetHelp(strings.Join(bound, "/"), binding.Help().Desc)
+		rebound = append(rebound, action)
+	}
+
+	for _, action := range rebound {
+		for _, k := range action.binding(&km).Keys() {
+			for _, other := range keyActions {
+				if other.name == action.name || !sharesKeyContext(action, other) || isPauseMark(action, other) {
+					continue
+				}
+				if slices.Contains(other.binding(&km).Keys(), k) {
+					return km, fmt.Errorf("key %q is bound to both %s and %s", k, action.name, other.name)
+				}
+			}
+		}
+	}
+	return km, nil
+}
+
+// keyActionNames returns the names of the actions, in order.
+func keyActionNames() []string {
+	names := make([]string, len(keyActions))
+	for i, action := range keyActions {
+		names[i] = action.name
+	}
+	return names
+}
+
+// UseKeyMap replaces the key bindings, as returned by KeyMapFromConfig.
+func UseKeyMap(km KeyMap) {
+	keys = km
+}
+
+// ActionKey returns the key of the named action as the help names it, for
+// messages that tell the user what to press. It follows the config file.
+func ActionKey(name string) string {
+	i := slices.IndexFunc(keyActions, func(a keyAction) bool { return a.name == name })
+	if i < 0 {
+		return name
+	}
+	return keyActions[i].binding(&keys).Help().Key
+}
+
+func sharesKeyContext(a, b keyAction) bool {
+	return slices.ContainsFunc(a.contexts, func(c string) bool { return slices.Contains(b.contexts, c) })
+}
+
+// isPauseMark returns true for the pause and mark actions, which share the
+// space bar by default.
+func isPauseMark(a, b keyAction) bool {
+	return a.name == "pause" && b.name == "mark" || a.name == "mark" && b.name == "pause"
+}
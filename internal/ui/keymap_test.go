@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKeyMapFromConfig(t *testing.T) {
+	km, err := KeyMapFromConfig(map[string][]string{"start": {"G"}, "sort": {"s"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Swapping two keys isn't a conflict, since both are rebound
+	if !slices.Equal(km.Start.Keys(), []string{"G"}) || km.Start.Help().Key != "G" {
+		t.Errorf("Start = %v (help %q), want G", km.Start.Keys(), km.Start.Help().Key)
+	}
+	if !slices.Equal(km.Sort.Keys(), []string{"s"}) {
+		t.Errorf("Sort = %v, want s", km.Sort.Keys())
+	}
+	if !slices.Equal(km.Terminate.Keys(), DefaultKeyMap().Terminate.Keys()) {
+		t.Error("actions that aren't rebound should keep their keys")
+	}
+}
+
+func TestKeyMapFromConfig_Conflicts(t *testing.T) {
+	tests := []struct {
+		bindings map[string][]string
+		want     string
+	}{
+		{map[string][]string{"start": {"t"}}, `key "t" is bound to both start and terminate`},
+		{map[string][]string{"keep_alpha": {"j"}}, `key "j" is bound to both keep_alpha and down`},
+		{map[string][]string{"strat": {"g"}}, `unknown key action "strat"`},
+	}
+	for _, tt := range tests {
+		if _, err := KeyMapFromConfig(tt.bindings); err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("KeyMapFromConfig(%v) error = %v, want %q", tt.bindings, err, tt.want)
+		}
+	}
+
+	// A key may do different things in different places: y confirms in
+	// dialogs and copies in the list
+	if _, err := KeyMapFromConfig(map[string][]string{"confirm_yes": {"y"}}); err != nil {
+		t.Errorf("confirm_yes = y: %v", err)
+	}
+}
+
+func TestKeyMapFromConfig_DefaultsDontConflict(t *testing.T) {
+	defaults := DefaultKeyMap()
+	bindings := map[string][]string{}
+	for _, action := range keyActions {
+		bindings[action.name] = action.binding(&defaults).Keys()
+	}
+	if _, err := KeyMapFromConfig(bindings); err != nil {
+		t.Errorf("rebinding every action to its default keys: %v", err)
+	}
+}
+
+func TestHelpModal_ShowsReboundKeys(t *testing.T) {
+	km, err := KeyMapFromConfig(map[string][]string{"start": {"G"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	UseKeyMap(km)
+	t.Cleanup(func() { UseKeyMap(DefaultKeyMap()) })
+
+	m := newTestModel(makeTestProject("p", 1, false))
+	help := strings.Join(m.helpLines(), "\n")
+	if !strings.Contains(help, "  G               Start this spec") || strings.Contains(help, "  s               Start") {
+		t.Errorf("help should show the rebound key in place of the default:\n%s", help)
+	}
+
+	// The rebound key starts; the default no longer does
+	m.Selection.SetIndex(1)
+	m.OnStart = func(ctx context.Context) *StatusMessage { return nil }
+	if _, cmd := m.handleKeyPress(keyPress("s")); cmd != nil {
+		t.Error("s should no longer start")
+	}
+	if _, cmd := m.handleKeyPress(keyPress("G")); cmd == nil {
+		t.Error("G should start")
+	}
+}
+
+func TestHelpBar_ShowsReboundKeys(t *testing.T) {
+	km, err := KeyMapFromConfig(map[string][]string{"start": {"G"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	UseKeyMap(km)
+	t.Cleanup(func() { UseKeyMap(DefaultKeyMap()) })
+
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.Selection.SetIndex(1)
+	bar := m.renderHelp()
+	if !strings.Contains(bar, "G Start") || strings.Contains(bar, "s Start") {
+		t.Errorf("help bar should show the rebound key in place of the default:\n%s", bar)
+	}
+}
+
+func TestActionKey_FollowsConfig(t *testing.T) {
+	if got := ActionKey("focus"); got != "z" {
+		t.Errorf("ActionKey(focus) = %q, want z", got)
+	}
+	km, err := KeyMapFromConfig(map[string][]string{"focus": {"ctrl+f"}, "reconnect": {"ctrl+e"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	UseKeyMap(km)
+	t.Cleanup(func() { UseKeyMap(DefaultKeyMap()) })
+
+	if got := ActionKey("focus"); got != "ctrl+f" {
+		t.Errorf("ActionKey(focus) = %q, want ctrl+f", got)
+	}
+	if got := formatDisconnection(Disconnection{For: time.Minute}); got != " disconnected 1m (ctrl+e reconnects)" {
+		t.Errorf("formatDisconnection() = %q, want the rebound key", got)
+	}
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// markIndent replaces a spec row's indent when the spec is marked.
+func markIndent() string {
+	return "  " + mutagen.Icon("✓", "*") + " "
+}
+
+// toggleMark marks or unmarks the selected spec. On a project row it marks
+// every spec in the project, or unmarks them if they are all marked already.
+func (m *Model) toggleMark() {
+	projIdx := m.Selection.SelectedProjectIndex()
+	if projIdx < 0 || projIdx >= len(m.Projects) {
+		return
+	}
+	if m.marks == nil {
+		m.marks = make(map[*project.SyncSpec]bool)
+	}
+	proj := m.Projects[projIdx]
+
+	if m.Selection.IsSpecSelected() {
+		if spec := m.selectedSpec(); spec != nil {
+			if m.marks[spec] {
+				delete(m.marks, spec)
+			} else {
+				m.marks[spec] = true
+			}
+		}
+		return
+	}
+
+	allMarked := true
+	for i := range proj.Specs {
+		if !m.marks[&proj.Specs[i]] {
+			allMarked = false
+			break
+		}
+	}
+	for i := range proj.Specs {
+		if allMarked {
+			delete(m.marks, &proj.Specs[i])
+		} else {
+			m.marks[&proj.Specs[i]] = true
+		}
+	}
+}
+
+// markedSpecs returns the marked specs in list order. Marks on specs that
+// are no longer listed, because their project was reloaded, are ignored.
+func (m Model) markedSpecs() []*project.SyncSpec {
+	if len(m.marks) == 0 {
+		return nil
+	}
+	var specs []*project.SyncSpec
+	for _, proj := range m.Projects {
+		for i := range proj.Specs {
+			if m.marks[&proj.Specs[i]] {
+				specs = append(specs, &proj.Specs[i])
+			}
+		}
+	}
+	return specs
+}
+
+func (m Model) pauseMarkedCmd(specs []*project.SyncSpec) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnPauseMarked(ctx, specs)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestSpace_PausesByDefault(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 2, false))
+	m.OnPause = func(ctx context.Context) *StatusMessage { return nil }
+
+	updated, cmd := m.handleKeyPress(keyPress(" "))
+	if cmd == nil || !updated.(Model).IsLoading {
+		t.Error("space should pause when it isn't configured to mark")
+	}
+	if len(updated.(Model).marks) != 0 {
+		t.Error("space marked a spec without SpaceMarks")
+	}
+}
+
+func TestSpace_MarksSpecs(t *testing.T) {
+	proj := makeTestProject("proj", 3, false)
+	m := newTestModel(proj)
+	m.SpaceMarks = true
+	m.OnPause = func(ctx context.Context) *StatusMessage {
+		t.Error("space paused while configured to mark")
+		return nil
+	}
+
+	m = press(m, "j") // spec-a
+	m = press(m, " ")
+	m = press(m, "j")
+	m = press(m, "j") // spec-c
+	m = press(m, " ")
+	specs := m.markedSpecs()
+	if len(specs) != 2 || specs[0].Name != "spec-a" || specs[1].Name != "spec-c" {
+		t.Fatalf("marked = %v, want spec-a and spec-c", specNames(specs))
+	}
+	if row := m.renderSpecRow(proj, &proj.Specs[0], 100, false); !strings.HasPrefix(row, markIndent()) {
+		t.Errorf("marked row = %q, want the mark", row)
+	}
+
+	// Marking again unmarks
+	m = press(m, " ")
+	if specs := m.markedSpecs(); len(specs) != 1 {
+		t.Errorf("marked = %v, want only spec-a", specNames(specs))
+	}
+}
+
+func TestSpace_MarksWholeProject(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 2, false))
+	m.SpaceMarks = true
+
+	m = press(m, " ")
+	if specs := m.markedSpecs(); len(specs) != 2 {
+		t.Fatalf("marked = %v, want every spec", specNames(specs))
+	}
+	m = press(m, " ")
+	if specs := m.markedSpecs(); len(specs) != 0 {
+		t.Errorf("marked = %v, want none after marking a fully marked project", specNames(specs))
+	}
+}
+
+func TestPause_ActsOnMarkedSpecs(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 3, false))
+	m.SpaceMarks = true
+	m.OnPause = func(ctx context.Context) *StatusMessage {
+		t.Error("p paused the selection while specs were marked")
+		return nil
+	}
+	var got []*project.SyncSpec
+	m.OnPauseMarked = func(ctx context.Context, specs []*project.SyncSpec) *StatusMessage {
+		got = specs
+		return nil
+	}
+
+	m = press(m, "j")
+	m = press(m, " ")
+	m = press(m, "j")
+	m = press(m, " ")
+	_, cmd := m.handleKeyPress(keyPress("p"))
+	if cmd == nil {
+		t.Fatal("p should start an operation")
+	}
+	cmd()
+	if len(got) != 2 {
+		t.Errorf("paused %v, want the two marked specs", specNames(got))
+	}
+
+	// Escape clears the marks
+	m = press(m, "esc")
+	if len(m.markedSpecs()) != 0 {
+		t.Error("esc should clear marks")
+	}
+}
+
+func specNames(specs []*project.SyncSpec) []string {
+	var names []string
+	for _, spec := range specs {
+		names = append(names, spec.Name)
+	}
+	return names
+}
@@ -2,14 +2,19 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/osteele/mutagui/internal/clock"
 	"github.com/osteele/mutagui/internal/mutagen"
 	"github.com/osteele/mutagui/internal/project"
 )
@@ -24,6 +29,11 @@ const (
 	ModalSyncStatus
 	ModalConfirmPush
 	ModalConfirmPull
+	ModalFirstRun
+	ModalConfirm
+	ModalDaemonSessions
+	ModalSessionLog
+	ModalEventLog
 )
 
 // StatusMessageType represents the type of status message.
@@ -35,6 +45,10 @@ const (
 	StatusError
 )
 
+// ErrTerminalEditor is returned by OnOpenEditor when the editor runs in the
+// terminal, so the TUI must be suspended while it runs.
+var ErrTerminalEditor = errors.New("terminal editor requested")
+
 // StatusMessage represents a status message to display.
 type StatusMessage struct {
 	Type StatusMessageType
@@ -45,6 +59,7 @@ type StatusMessage struct {
 type Model struct {
 	// UI state
 	Theme       Theme
+	Clock       clock.Clock
 	Width       int
 	Height      int
 	ActiveModal Modal
@@ -54,40 +69,233 @@ type Model struct {
 	Selection     *SelectionManager
 	StatusMessage *StatusMessage
 	LastRefresh   *time.Time
-	ShowPaths     bool
+	ShowPaths     bool // Display mode for projects without a display rule
+	ShowHost      bool // Show a remote host tag after spec names in status mode
+	ShowSessions  bool // Show mutagen session names instead of spec names
+	Borderless    bool // Draw the main sections without borders
+	Compact       bool // Drop column padding and wide icons to fit more on each row
+
+	// Columns lays spec rows out as a table of these columns under a header
+	// row, in place of the usual rows; see tableColumns
+	Columns []string
+
+	// TruncatePathEnds cuts long endpoint paths at the end of the row rather
+	// than abbreviating their middles
+	TruncatePathEnds bool
+
+	// PauseUnfocused skips auto-refreshes while the terminal is unfocused.
+	// After IdleAfter without a key or mouse press, and while unfocused if
+	// refreshes aren't paused, auto-refreshes come only every IdleInterval;
+	// a zero IdleAfter keeps the usual pace while focused.
+	PauseUnfocused bool
+	IdleAfter      time.Duration
+	IdleInterval   time.Duration
+
+	// SpaceMarks makes the space bar mark specs instead of pausing; p then
+	// pauses or resumes the marked specs together
+	SpaceMarks bool
+
+	// Events is the event log, shared with the app. Status messages are
+	// added to it too, so they can be read after they clear.
+	Events *EventLog
 
 	// Async operation state
 	IsLoading   bool
 	LoadingText string
 
+	// operations are the in-flight operations' loading texts, by the item
+	// each was started on. Only an operation's own OperationDoneMsg ends it.
+	operations map[SelectableItem]string
+
+	// confirmation is the action awaiting an answer in ModalConfirm
+	confirmation *Confirmation
+
+	// pollGen identifies the current sync status polling loop; reopening
+	// the dialog starts a new loop and orphans any previous one
+	pollGen int
+
+	// stopMonitor ends the stream of session updates feeding the sync
+	// status dialog, if one is running
+	stopMonitor    context.CancelFunc
+	monitorUpdates <-chan mutagen.SyncSession
+
+	// freeSpace is the sync status dialog's check of its beta's free space
+	freeSpace freeSpaceCheck
+
+	// ignores is the sync status dialog's description of the spec's ignores
+	ignores ignoresCheck
+
+	// logOffset is the first line shown in the session log dialog
+	logOffset int
+
+	// conflictCursor is the conflict highlighted in the conflicts dialog,
+	// counting across all of the listed sessions
+	conflictCursor int
+
+	// conflictQuery narrows the conflicts dialog to the conflicts whose
+	// path contains it; editingConflictQuery is true while it is typed
+	conflictQuery        string
+	editingConflictQuery bool
+
+	// expandedConflicts are the conflicts whose changes the conflicts
+	// dialog shows, by conflictKey
+	expandedConflicts map[string]bool
+	// modalOffset is the first line shown in the help, conflicts, or event
+	// log dialog when its content is taller than the screen
+	modalOffset int
+
+	// displayToggled is true while the display mode is flipped from its
+	// initial setting, including projects with a display rule
+	displayToggled bool
+
+	// editingFilter is true while the filter query is being typed
+	editingFilter bool
+
+	// marks are the specs marked with the space bar, when SpaceMarks is set
+	marks map[*project.SyncSpec]bool
+
+	// daemon is the state of the daemon sessions dialog
+	daemon daemonSessionsState
+
+	// forwards is the state of the forwards view
+	forwards forwardsState
+
+	// pacing decides which auto-refresh ticks refresh
+	pacing refreshPacing
+
 	// Callbacks for operations (set by main)
 	// Each callback returns a status message describing the result
-	OnRefresh          func(ctx context.Context) error
+	// OnRefresh lists sessions, unless force is false and they were listed
+	// moments ago
+	OnRefresh          func(ctx context.Context, force bool) error
+	OnThoroughRefresh  func(ctx context.Context) *StatusMessage // Checks the daemon, rescans, and refreshes
 	OnStart            func(ctx context.Context) *StatusMessage
 	OnTerminate        func(ctx context.Context) *StatusMessage
 	OnFlush            func(ctx context.Context) *StatusMessage
 	OnPause            func(ctx context.Context) *StatusMessage
 	OnResume           func(ctx context.Context) *StatusMessage
+	OnPauseAll         func(ctx context.Context) *StatusMessage // Pauses or resumes every project's sessions
+	OnFocus            func(ctx context.Context) *StatusMessage // Pauses every session but the selection's, or resumes them
+	OnStartAll         func(ctx context.Context) *StatusMessage // Starts every project's stopped specs
+	OnTerminateAll     func(ctx context.Context) *StatusMessage // Terminates every project's sessions
+	OnPauseMarked      func(ctx context.Context, specs []*project.SyncSpec) *StatusMessage
+	OnToggleSchedule   func(ctx context.Context) *StatusMessage // Switches a spec between continuous sync and scheduled flushes
+	OnScheduledFlush   func(ctx context.Context)                // Flushes the scheduled specs that are due
+	OnReconcile        func(ctx context.Context) *StatusMessage // Recreates the running spec with its project file settings
+	OnReset            func(ctx context.Context) *StatusMessage
+	OnRestart          func(ctx context.Context) *StatusMessage // Terminates the running spec and recreates it, as a push if it was one
+	OnRestartDaemon    func(ctx context.Context) *StatusMessage
 	OnPush             func(ctx context.Context) *StatusMessage
 	OnPushConflicts    func(ctx context.Context) *StatusMessage
 	OnPullConflicts    func(ctx context.Context) *StatusMessage
+	OnPollSession      func(ctx context.Context) error // Re-fetches the selected session only
+	OnUndo             func(ctx context.Context) *StatusMessage
+	OfferFix           func() []string // Describes a fix for the operation that just failed, if one is known
+	OnFix              func(ctx context.Context) *StatusMessage
+	ListDaemonSessions func(ctx context.Context) ([]mutagen.SyncSession, error)
+	OnTerminateSession func(ctx context.Context, identifier, name string) *StatusMessage
+	DescribeUndo       func() (description string, ok bool)
 	OnToggleFold       func(projIdx int)
-	OnOpenEditor       func(projIdx int) error
+	OnCycleSort        func() *StatusMessage   // Switches to the next project sort order
+	OnCopy             func() *StatusMessage   // Copies the selected spec's endpoint paths or the project file path
+	OnCopyIdentifier   func() *StatusMessage   // Copies the selected session's identifier
+	OnOpenEditor       func(projIdx int) error // Returns ErrTerminalEditor if the TUI must be suspended
 	GetConflicts       func() []SessionConflicts
 	GetSelectedSession func() *mutagen.SyncSession
+	GetProjects        func() []*project.Project // Picks up projects found by rescans
+	GetSummary         func() Summary            // Totals sessions across all projects for the header
+	GetFocus           func() (string, bool)     // Names the spec or project focus mode leaves running
+	DescribePush       func() []string           // Explains what a push would overwrite
+	DescribeReconcile  func() []string           // Lists the running spec's settings that differ from its project file
+	DescribeReset      func() []string           // Names the sessions a reset would affect
+	DescribeTerminate  func() []string           // Names the sessions a terminate would affect
+	DescribeTermAll    func() []string           // Counts the running sessions of each project
+	DisplayModeFor     func(proj *project.Project) (showPaths, ok bool)
+	DescribeIgnores    func() []string // Lists the selected spec's ignore patterns and those that match nothing
+	CycleHistory       func() []uint64 // Successful cycles of the selected session per sample, oldest first
+	SearchPaths        func() []string // Directories searched for project files
+	TransferRate       func(session *mutagen.SyncSession) (TransferRate, bool)
+
+	// FreeSpace returns the bytes free on the filesystem of an endpoint,
+	// for the sync status dialog
+	FreeSpace func(ctx context.Context, endpoint mutagen.Endpoint) (uint64, error)
+
+	// GetFileConfig returns the selected running spec's configuration from
+	// its project file, to compare with the one its session runs with
+	GetFileConfig func() (mutagen.SessionConfig, bool)
+
+	// DescribeFileSettings lists the settings the selected spec's project
+	// file gives it, other than ignores, with whether each is the spec's
+	// own or from the project defaults
+	DescribeFileSettings func() []string
+
+	// Live updates for the sync status dialog: OnMonitorSession streams the
+	// selected session until ctx is canceled and OnSessionUpdate applies each
+	// update. Without them, or if streaming fails, the dialog polls instead.
+	OnMonitorSession func(ctx context.Context) (<-chan mutagen.SyncSession, error)
+	OnSessionUpdate  func(session mutagen.SyncSession)
+
+	// Network forwarding sessions, listed in the forwards view. Forwards
+	// are addressed by identifier; name is for status messages.
+	ListForwards       func(ctx context.Context) ([]mutagen.ForwardSession, error)
+	OnPauseForward     func(ctx context.Context, identifier, name string) *StatusMessage
+	OnResumeForward    func(ctx context.Context, identifier, name string) *StatusMessage
+	OnTerminateForward func(ctx context.Context, identifier, name string) *StatusMessage
+
+	// First-run setup: offered when no config file exists, until declined
+	ConfigPath      string
+	OnWriteConfig   func() error
+	OnDeclineConfig func() // Remembers that the offer was declined
+
+	// MutagenVersion is the installed Mutagen version, used to flag sessions
+	// created by an incompatible release
+	MutagenVersion string
 
 	// Confirmation settings (from config)
 	ConfirmPushToBeta  bool
 	ConfirmPullToAlpha bool
+	ConfirmCreatePush  bool
+	ConfirmTerminate   bool
+
+	// ConfirmThresholds spare small terminates and resets their questions
+	ConfirmThresholds ConfirmThresholds
 
 	// For terminal editor support
 	SuspendAndRun func(func()) tea.Cmd
+
+	// OnOpenBeta opens the selected spec's beta endpoint, returning a shell
+	// to run in the terminal if it is remote
+	OnOpenBeta func() (*exec.Cmd, *StatusMessage)
+
+	// OnResolveConflict resolves one conflict in favor of winner,
+	// mutagen.WinnerAlpha or mutagen.WinnerBeta
+	OnResolveConflict func(ctx context.Context, session *mutagen.SyncSession, conflict mutagen.Conflict, winner string) *StatusMessage
+
+	// LoadProjectFile re-reads a project file that changed on disk, and
+	// OnReloadProjectFile updates its project with the result. Loading runs
+	// in a command; the update runs in Update, since the list shares the
+	// project.
+	LoadProjectFile     func(path string) (*project.ProjectFile, error)
+	OnReloadProjectFile func(path string, file *project.ProjectFile, err error) *StatusMessage
+
+	// OnReconnect pauses and resumes the selected spec's session, or the
+	// selected project's disconnected sessions, so Mutagen dials them again.
+	// Disconnection reports a session that has stayed disconnected.
+	OnReconnect   func(ctx context.Context) *StatusMessage
+	Disconnection func(session *mutagen.SyncSession) (Disconnection, bool)
+
+	// GetStagingTotal totals the staging of every running session for the
+	// status bar
+	GetStagingTotal func() StagingTotal
 }
 
 // KeyMap defines the key bindings.
 type KeyMap struct {
 	Up          key.Binding
 	Down        key.Binding
+	PageUp      key.Binding
+	PageDown    key.Binding
+	Reconnect   key.Binding
 	Left        key.Binding
 	Right       key.Binding
 	Enter       key.Binding
@@ -95,18 +303,44 @@ type KeyMap struct {
 	Suspend     key.Binding
 	Help        key.Binding
 	Refresh     key.Binding
+	Thorough    key.Binding
+	Daemon      key.Binding
 	Start       key.Binding
 	Terminate   key.Binding
+	StartAll    key.Binding
+	TermAll     key.Binding
 	Flush       key.Binding
+	Reset       key.Binding
+	Restart     key.Binding
 	Pause       key.Binding
 	Resume      key.Binding
+	PauseAll    key.Binding
+	Focus       key.Binding
+	Mark        key.Binding
+	Schedule    key.Binding
+	Undo        key.Binding
 	Push        key.Binding
 	Conflicts   key.Binding
 	SyncStatus  key.Binding
+	Log         key.Binding
+	EventLog    key.Binding
+	Filter      key.Binding
+	Problems    key.Binding
 	Edit        key.Binding
+	Copy        key.Binding
+	OpenBeta    key.Binding
 	ToggleMode  key.Binding
+	ToggleHost  key.Binding
+	ToggleNames key.Binding
+	Sort        key.Binding
+	Borderless  key.Binding
+	Density     key.Binding
+	DaemonList  key.Binding
+	Forwards    key.Binding
 	PushToBeta  key.Binding
 	PullToAlpha key.Binding
+	KeepAlpha   key.Binding
+	KeepBeta    key.Binding
 	ConfirmYes  key.Binding
 	ConfirmNo   key.Binding
 	Escape      key.Binding
@@ -123,6 +357,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("down", "j"),
 			key.WithHelp("↓/j", "down"),
 		),
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup"),
+			key.WithHelp("PgUp", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown"),
+			key.WithHelp("PgDn", "page down"),
+		),
 		Left: key.NewBinding(
 			key.WithKeys("left"),
 			key.WithHelp("←", "fold"),
@@ -151,6 +393,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
 		),
+		Thorough: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "check daemon & refresh"),
+		),
+		Daemon: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "restart daemon"),
+		),
 		Start: key.NewBinding(
 			key.WithKeys("s"),
 			key.WithHelp("s", "start"),
@@ -159,10 +409,30 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("t"),
 			key.WithHelp("t", "terminate"),
 		),
+		StartAll: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "start all"),
+		),
+		TermAll: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "terminate all"),
+		),
 		Flush: key.NewBinding(
 			key.WithKeys("f"),
 			key.WithHelp("f", "flush"),
 		),
+		Reconnect: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "reconnect"),
+		),
+		Reset: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "reset"),
+		),
+		Restart: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "restart"),
+		),
 		Pause: key.NewBinding(
 			key.WithKeys("p", " "),
 			key.WithHelp("p/space", "pause/resume"),
@@ -171,6 +441,26 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("u"),
 			key.WithHelp("u", "resume"),
 		),
+		PauseAll: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("^P", "pause/resume all"),
+		),
+		Focus: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "focus"),
+		),
+		Mark: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "mark"),
+		),
+		Schedule: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "scheduled flushes"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "undo"),
+		),
 		Push: key.NewBinding(
 			key.WithKeys("P"),
 			key.WithHelp("P", "push"),
@@ -183,14 +473,67 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("i"),
 			key.WithHelp("i", "sync status"),
 		),
+		Log: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "session log"),
+		),
+		EventLog: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("g", "event log"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		Problems: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "problems only"),
+		),
 		Edit: key.NewBinding(
 			key.WithKeys("e"),
 			key.WithHelp("e", "edit"),
 		),
+		Copy: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy paths"),
+		),
+		// Shifted, since o cycles the sort order
+		OpenBeta: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "open beta"),
+		),
 		ToggleMode: key.NewBinding(
 			key.WithKeys("m"),
 			key.WithHelp("m", "toggle mode"),
 		),
+		ToggleHost: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "toggle host"),
+		),
+		ToggleNames: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "spec/session names"),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "sort"),
+		),
+		Borderless: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "toggle borders"),
+		),
+		Density: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "compact rows"),
+		),
+		DaemonList: key.NewBinding(
+			key.WithKeys("Z"),
+			key.WithHelp("Z", "daemon sessions"),
+		),
+		Forwards: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "forwards"),
+		),
 		PushToBeta: key.NewBinding(
 			key.WithKeys("b"),
 			key.WithHelp("b", "push to beta"),
@@ -199,6 +542,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("a"),
 			key.WithHelp("a", "pull to alpha"),
 		),
+		KeepAlpha: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "keep alpha's file"),
+		),
+		KeepBeta: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "keep beta's file"),
+		),
 		ConfirmYes: key.NewBinding(
 			key.WithKeys("y", "Y"),
 			key.WithHelp("y", "confirm"),
@@ -220,6 +571,7 @@ var keys = DefaultKeyMap()
 func NewModel(theme Theme) Model {
 	return Model{
 		Theme:     theme,
+		Clock:     clock.Real{},
 		Selection: NewSelectionManager(),
 		Projects:  []*project.Project{},
 	}
@@ -235,44 +587,174 @@ type (
 	RefreshDoneMsg   struct{ Err error }
 	OperationDoneMsg struct {
 		Err    error
-		Status *StatusMessage // Optional status message to display
+		Status *StatusMessage  // Optional status message to display
+		Target *SelectableItem // The item the operation was started on, set by beginOperation
 	}
 	TickMsg          time.Time
+	ScheduleTickMsg  time.Time
 	EditorSuspendMsg struct{ ProjIdx int }
+	ShellClosedMsg   struct{ Err error }
+
+	// OpenBetaMsg carries the result of OnOpenBeta: a shell to run in the
+	// terminal, or the status of opening a local beta
+	OpenBetaMsg struct {
+		Cmd    *exec.Cmd
+		Status *StatusMessage
+	}
 	ClearFlashMsg    struct{}
+	SessionPollMsg   struct{ gen int }
+	SessionPolledMsg struct {
+		gen int
+		Err error
+	}
+	SessionUpdateMsg struct {
+		gen     int
+		session mutagen.SyncSession
+		ok      bool // false once the stream has ended
+	}
+
+	// ProjectFileChangedMsg reports that a loaded project file changed on disk
+	ProjectFileChangedMsg struct{ Path string }
+	// projectFileLoadedMsg carries a changed project file, read from disk
+	projectFileLoadedMsg struct {
+		path string
+		file *project.ProjectFile
+		err  error
+	}
+	// projectFileReloadedMsg carries the result of reloading a project
+	// file; unlike OperationDoneMsg it leaves a running operation alone
+	projectFileReloadedMsg struct{ Status *StatusMessage }
 )
 
+// sessionPollInterval is how often the session shown in the sync status
+// dialog is re-fetched, independently of the global refresh.
+const sessionPollInterval = 500 * time.Millisecond
+
 // Update implements tea.Model.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	next, ok := model.(Model)
+	if !ok {
+		return model, cmd
+	}
+	if next.StatusMessage != nil && next.StatusMessage != m.StatusMessage {
+		next.recordStatus(next.StatusMessage)
+	}
+	if next.stopMonitor != nil && next.ActiveModal != ModalSyncStatus {
+		// The sync status dialog closed; stop streaming its session
+		next.stopMonitor()
+		next.stopMonitor = nil
+	}
+	return next, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		m.pacing.lastInput = m.Clock.Now()
 		return m.handleKeyPress(msg)
 
 	case tea.MouseMsg:
+		m.pacing.lastInput = m.Clock.Now()
 		return m.handleMouseEvent(msg)
 
+	case tea.BlurMsg:
+		m.pacing.unfocused = true
+		return m, nil
+
+	case tea.FocusMsg:
+		wasUnfocused := m.pacing.unfocused
+		m.pacing.unfocused = false
+		m.pacing.lastInput = m.Clock.Now()
+		// Catch up on what changed while refreshes were paused or slowed
+		if wasUnfocused && m.OnRefresh != nil {
+			return m, m.refreshCmd(false)
+		}
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.Width = msg.Width
 		m.Height = msg.Height
 		return m, nil
 
 	case RefreshDoneMsg:
-		m.IsLoading = false
-		m.LoadingText = ""
+		// A refresh ends only itself; operations still running stay so
+		m.endOperation(nil)
+		if m.GetProjects != nil {
+			m.Projects = m.GetProjects()
+		}
 		if msg.Err != nil {
-			m.StatusMessage = &StatusMessage{Type: StatusError, Text: msg.Err.Error()}
+			if m.LastRefresh != nil {
+				m.StatusMessage = &StatusMessage{Type: StatusWarning, Text: StaleListText(*m.LastRefresh, msg.Err)}
+			} else {
+				m.StatusMessage = &StatusMessage{Type: StatusError, Text: msg.Err.Error()}
+			}
 			return m, m.flashCmd()
 		}
 		return m, nil
 
+	case OpenBetaMsg:
+		if msg.Cmd != nil {
+			// The shell takes over the terminal until it exits
+			return m, tea.ExecProcess(msg.Cmd, func(err error) tea.Msg { return ShellClosedMsg{Err: err} })
+		}
+		m.StatusMessage = msg.Status
+		return m, m.flashCmd()
+
+	case ShellClosedMsg:
+		if msg.Err != nil {
+			m.StatusMessage = &StatusMessage{Type: StatusError, Text: "Shell exited: " + msg.Err.Error()}
+		}
+		return m, nil
+
 	case OperationDoneMsg:
-		m.IsLoading = false
-		m.LoadingText = ""
+		m.endOperation(msg.Target)
+		if m.GetProjects != nil {
+			m.Projects = m.GetProjects() // The operation may have rescanned
+		}
 		if msg.Err != nil {
 			m.StatusMessage = &StatusMessage{Type: StatusError, Text: msg.Err.Error()}
 		} else if msg.Status != nil {
 			m.StatusMessage = msg.Status
 		}
+		if m.OfferFix != nil && m.OnFix != nil {
+			if lines := m.OfferFix(); len(lines) > 0 {
+				m.confirmation = &Confirmation{
+					Title:       "APPLY SUGGESTED FIX",
+					Lines:       lines,
+					LoadingText: "Applying fix...",
+					Run:         m.fixCmd(),
+				}
+				m.ActiveModal = ModalConfirm
+				return m, nil
+			}
+		}
+		return m, m.flashCmd()
+
+	case ProjectFileChangedMsg:
+		if m.LoadProjectFile == nil || m.OnReloadProjectFile == nil {
+			return m, nil
+		}
+		load, path := m.LoadProjectFile, msg.Path
+		return m, func() tea.Msg {
+			file, err := load(path)
+			return projectFileLoadedMsg{path: path, file: file, err: err}
+		}
+
+	case projectFileLoadedMsg:
+		m.StatusMessage = m.OnReloadProjectFile(msg.path, msg.file, msg.err)
+		if m.GetProjects != nil {
+			m.Projects = m.GetProjects()
+		}
+		return m, m.refreshAfterReloadCmd()
+
+	case projectFileReloadedMsg:
+		if m.GetProjects != nil {
+			m.Projects = m.GetProjects()
+		}
+		if msg.Status != nil {
+			m.StatusMessage = msg.Status
+		}
 		return m, m.flashCmd()
 
 	case ClearFlashMsg:
@@ -282,65 +764,121 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case TickMsg:
-		// Auto-refresh tick
-		if m.OnRefresh != nil {
-			return m, m.refreshCmd()
+	case DaemonSessionsMsg:
+		return m.handleDaemonSessionsMsg(msg)
+
+	case ForwardsMsg:
+		return m.handleForwardsMsg(msg)
+
+	case SessionPollMsg:
+		if m.ActiveModal != ModalSyncStatus || msg.gen != m.pollGen {
+			return m, nil
 		}
-		return m, nil
-	}
+		return m, m.pollSessionCmd(msg.gen)
 
-	return m, nil
-}
+	case SessionPolledMsg:
+		if msg.gen != m.pollGen {
+			return m, nil
+		}
+		if msg.Err != nil {
+			// Stop polling; the next global refresh will catch up
+			m.StatusMessage = &StatusMessage{Type: StatusWarning, Text: "Failed to poll session: " + msg.Err.Error()}
+			return m, nil
+		}
+		if m.ActiveModal != ModalSyncStatus {
+			return m, nil
+		}
+		return m, m.sessionPollTick()
 
-func (m Model) handleMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
-	// Only handle clicks when no modal is open
-	if m.ActiveModal != ModalNone {
+	case freeSpaceMsg:
+		if msg.gen == m.freeSpace.gen && msg.endpoint == m.freeSpace.endpoint {
+			m.freeSpace.done, m.freeSpace.free, m.freeSpace.err = true, msg.free, msg.err
+		}
 		return m, nil
-	}
 
-	// Only handle left click
-	if msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionRelease {
+	case ignoresMsg:
+		if msg.gen == m.ignores.gen {
+			m.ignores.done, m.ignores.lines = true, msg.lines
+		}
 		return m, nil
-	}
 
-	// Calculate list area (accounting for header and border)
-	// Header: 1 line (styled title)
-	// List border top: 1 line
-	// List title: 1 line
-	// First item starts at row 3 (0-indexed)
-	listTop := 3
-	listBottom := m.Height - 6 // Status (3) + Help (3)
-
-	// Check if click is in list area
-	if msg.Y >= listTop && msg.Y < listBottom {
-		clickedIndex := msg.Y - listTop
-		if clickedIndex >= 0 && clickedIndex < m.Selection.TotalItems() {
-			// Check if clicking on a project header to toggle fold
-			item := m.Selection.ItemAt(clickedIndex)
-			if item != nil && item.Type == SelectableProject {
-				// If clicking on already selected project, toggle fold
-				if clickedIndex == m.Selection.RawIndex() && m.OnToggleFold != nil {
-					m.OnToggleFold(item.ProjectIndex)
-					m.Selection.RebuildFromProjects(m.Projects)
-					return m, nil
-				}
+	case SessionUpdateMsg:
+		if msg.gen != m.pollGen {
+			return m, nil
+		}
+		if !msg.ok {
+			// The monitor exited; fall back to polling while the dialog is open
+			if m.stopMonitor != nil {
+				m.stopMonitor()
+				m.stopMonitor = nil
 			}
-			// Select the clicked item
-			m.Selection.SetIndex(clickedIndex)
+			if m.ActiveModal != ModalSyncStatus {
+				return m, nil
+			}
+			return m, m.sessionPollTick()
+		}
+		if m.OnSessionUpdate != nil {
+			m.OnSessionUpdate(msg.session)
+		}
+		return m, m.nextSessionUpdate(msg.gen)
+
+	case TickMsg:
+		// Auto-refresh tick, skipped while unfocused or idle
+		now := time.Time(msg)
+		if m.pacing.lastInput.IsZero() {
+			m.pacing.lastInput = now
+		}
+		if !m.autoRefreshDue(now) {
+			return m, nil
 		}
+		m.pacing.lastRefresh = now
+		var cmds []tea.Cmd
+		if m.OnRefresh != nil {
+			cmds = append(cmds, m.refreshCmd(false))
+		}
+		if m.forwards.shown && !m.forwards.loading {
+			cmds = append(cmds, m.forwardsCmd(nil))
+		}
+		return m, tea.Batch(cmds...)
+
+	case ScheduleTickMsg:
+		// Scheduled flushes keep their own time, whether auto-refresh is
+		// off, paced down, or skipped
+		if m.OnScheduledFlush == nil {
+			return m, nil
+		}
+		return m, m.scheduledFlushCmd()
 	}
 
 	return m, nil
 }
 
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Handle escape to close modals
+	if m.editingFilter {
+		return m.handleFilterKeyPress(msg)
+	}
+	if m.editingConflictQuery {
+		return m.handleConflictQueryKeyPress(msg)
+	}
+
+	// Handle escape to close modals, then to clear an active filter
 	if key.Matches(msg, keys.Escape) {
+		if m.ActiveModal == ModalConflicts && m.conflictQuery != "" {
+			m.setConflictQuery("")
+			return m, nil
+		}
 		if m.ActiveModal != ModalNone {
 			m.ActiveModal = ModalNone
 			return m, nil
 		}
+		if !m.Selection.Filter().IsEmpty() {
+			m.setFilter("")
+			return m, nil
+		}
+		if len(m.marks) > 0 {
+			m.marks = nil
+			return m, nil
+		}
 	}
 
 	// Handle modal-specific keys
@@ -348,6 +886,10 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleModalKeyPress(msg)
 	}
 
+	if m.forwards.shown {
+		return m.handleForwardsKeyPress(msg)
+	}
+
 	// Global keys
 	switch {
 	case key.Matches(msg, keys.Quit):
@@ -358,6 +900,11 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case key.Matches(msg, keys.Help):
 		m.ActiveModal = ModalHelp
+		m.modalOffset = 0
+		return m, nil
+
+	case key.Matches(msg, keys.Filter):
+		m.editingFilter = true
 		return m, nil
 
 	case key.Matches(msg, keys.Up):
@@ -373,6 +920,7 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if m.OnToggleFold != nil {
 				m.OnToggleFold(projIdx)
 				m.Selection.RebuildFromProjects(m.Projects)
+				m.Selection.SelectProject(projIdx)
 			}
 		}
 		return m, nil
@@ -381,64 +929,286 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.OnRefresh != nil {
 			m.IsLoading = true
 			m.LoadingText = "Refreshing..."
-			return m, m.refreshCmd()
+			return m, m.refreshCmd(false)
 		}
 		return m, nil
 
+	case key.Matches(msg, keys.Thorough):
+		if m.OnThoroughRefresh != nil {
+			cmd := m.beginOperation("Checking daemon...", m.thoroughRefreshCmd())
+			return m, cmd
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Daemon):
+		if m.OnRestartDaemon == nil {
+			return m, nil
+		}
+		m.confirmation = &Confirmation{
+			Title: "RESTART MUTAGEN DAEMON",
+			Lines: []string{
+				"Stop and restart the Mutagen daemon?",
+				"",
+				"Every session disconnects and reconnects, which also replaces",
+				"remote agents left over from another Mutagen release.",
+			},
+			LoadingText: "Restarting the daemon...",
+			Run:         m.restartDaemonCmd(),
+		}
+		m.ActiveModal = ModalConfirm
+		return m, nil
+
 	case key.Matches(msg, keys.Start):
+		// Starting a running spec offers to recreate it if its settings drifted
+		if m.Selection.IsSpecSelected() && m.OnReconcile != nil && m.DescribeReconcile != nil {
+			if changes := m.DescribeReconcile(); len(changes) > 0 {
+				lines := []string{"The running session differs from the project file:", ""}
+				for _, change := range changes {
+					lines = append(lines, "  "+change)
+				}
+				m.confirmation = &Confirmation{
+					Title:       "RESTART WITH PROJECT SETTINGS",
+					Lines:       append(lines, "", "The session is terminated and recreated; synced files are kept."),
+					LoadingText: "Restarting...",
+					Run:         m.reconcileCmd(),
+				}
+				m.ActiveModal = ModalConfirm
+				return m, nil
+			}
+		}
 		if m.OnStart != nil {
-			m.IsLoading = true
-			m.LoadingText = "Starting..."
-			return m, m.startCmd()
+			cmd := m.beginOperation("Starting...", m.startCmd())
+			return m, cmd
 		}
 		return m, nil
 
 	case key.Matches(msg, keys.Terminate):
 		if m.OnTerminate != nil {
-			m.IsLoading = true
-			m.LoadingText = "Terminating..."
-			return m, m.terminateCmd()
+			if m.ConfirmTerminate && m.DescribeTerminate != nil {
+				if names := m.DescribeTerminate(); m.ConfirmThresholds.confirmTerminate(len(names)) {
+					lines := []string{fmt.Sprintf("Terminate %d session(s)?", len(names)), ""}
+					for _, name := range names {
+						lines = append(lines, "  "+name)
+					}
+					m.confirmation = &Confirmation{
+						Title:       "TERMINATE SESSIONS",
+						Lines:       append(lines, "", "Syncing stops until the sessions are started again; files are kept."),
+						LoadingText: "Terminating...",
+						Run:         m.terminateCmd(),
+					}
+					m.ActiveModal = ModalConfirm
+					return m, nil
+				}
+			}
+			cmd := m.beginOperation("Terminating...", m.terminateCmd())
+			return m, cmd
 		}
 		return m, nil
 
+	case key.Matches(msg, keys.StartAll):
+		if m.OnStartAll != nil {
+			cmd := m.beginOperation("Starting all projects...", m.startAllCmd())
+			return m, cmd
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.TermAll):
+		if m.OnTerminateAll == nil {
+			return m, nil
+		}
+		var lines []string
+		if m.DescribeTermAll != nil {
+			lines = m.DescribeTermAll()
+		}
+		if len(lines) == 0 {
+			m.StatusMessage = &StatusMessage{Type: StatusWarning, Text: "No sessions running"}
+			return m, m.flashCmd()
+		}
+		for i, line := range lines {
+			lines[i] = "  " + line
+		}
+		m.confirmation = &Confirmation{
+			Title: "TERMINATE ALL PROJECTS",
+			Lines: append(append([]string{fmt.Sprintf("Terminate the sessions of %d project(s)?", len(lines)), ""}, lines...),
+				"", "Syncing stops everywhere until the projects are started again; files are kept."),
+			LoadingText: "Terminating all projects...",
+			Run:         m.terminateAllCmd(),
+		}
+		m.ActiveModal = ModalConfirm
+		return m, nil
+
 	case key.Matches(msg, keys.Flush):
 		if m.OnFlush != nil {
-			m.IsLoading = true
-			m.LoadingText = "Flushing..."
-			return m, m.flushCmd()
+			cmd := m.beginOperation("Flushing...", m.flushCmd())
+			return m, cmd
 		}
 		return m, nil
 
+	case key.Matches(msg, keys.Reconnect):
+		if m.OnReconnect != nil {
+			cmd := m.beginOperation("Reconnecting...", m.reconnectCmd())
+			return m, cmd
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Reset):
+		if m.OnReset == nil {
+			return m, nil
+		}
+		var names []string
+		if m.DescribeReset != nil {
+			names = m.DescribeReset()
+		}
+		if len(names) == 0 {
+			m.StatusMessage = &StatusMessage{Type: StatusWarning, Text: "No sessions running"}
+			return m, m.flashCmd()
+		}
+		if !m.ConfirmThresholds.confirmReset(m.selectedRunningSessions()) {
+			cmd := m.beginOperation("Resetting...", m.resetCmd())
+			return m, cmd
+		}
+		lines := []string{"Reset " + strings.Join(names, ", ") + "?", ""}
+		m.confirmation = &Confirmation{
+			Title: "RESET SYNC HISTORY",
+			Lines: append(lines,
+				"Mutagen forgets the last synced state and rescans both endpoints",
+				"from scratch, which can take a while for large trees. Changes made",
+				"on both sides since the last sync may show up as conflicts."),
+			LoadingText: "Resetting...",
+			Run:         m.resetCmd(),
+		}
+		m.ActiveModal = ModalConfirm
+		return m, nil
+
+	case key.Matches(msg, keys.Restart):
+		if m.OnRestart != nil && m.Selection.IsSpecSelected() {
+			// A restart terminates the sessions first, so it asks as a terminate would
+			if m.ConfirmTerminate && m.DescribeTerminate != nil {
+				if names := m.DescribeTerminate(); m.ConfirmThresholds.confirmTerminate(len(names)) {
+					lines := []string{fmt.Sprintf("Restart %d session(s)?", len(names)), ""}
+					for _, name := range names {
+						lines = append(lines, "  "+name)
+					}
+					m.confirmation = &Confirmation{
+						Title:       "RESTART SESSIONS",
+						Lines:       append(lines, "", "The sessions are terminated and created again from the project file."),
+						LoadingText: "Restarting...",
+						Run:         m.restartCmd(),
+					}
+					m.ActiveModal = ModalConfirm
+					return m, nil
+				}
+			}
+			cmd := m.beginOperation("Restarting...", m.restartCmd())
+			return m, cmd
+		}
+		return m, nil
+
+	// Space is bound to both Pause and Mark; SpaceMarks picks which one wins
+	case m.SpaceMarks && key.Matches(msg, keys.Mark):
+		m.toggleMark()
+		return m, nil
+
 	case key.Matches(msg, keys.Pause):
+		if specs := m.markedSpecs(); len(specs) > 0 && m.OnPauseMarked != nil {
+			cmd := m.beginOperation(fmt.Sprintf("Toggling pause for %d marked spec(s)...", len(specs)), m.pauseMarkedCmd(specs))
+			return m, cmd
+		}
 		if m.OnPause != nil {
-			m.IsLoading = true
-			m.LoadingText = "Toggling pause..."
-			return m, m.pauseCmd()
+			cmd := m.beginOperation("Toggling pause...", m.pauseCmd())
+			return m, cmd
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.PauseAll):
+		if m.OnPauseAll != nil {
+			cmd := m.beginOperation("Toggling pause for all sessions...", m.pauseAllCmd())
+			return m, cmd
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Focus):
+		if m.OnFocus != nil {
+			cmd := m.beginOperation("Toggling focus...", m.focusCmd())
+			return m, cmd
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Schedule):
+		if m.OnToggleSchedule != nil {
+			cmd := m.beginOperation("Switching sync mode...", m.toggleScheduleCmd())
+			return m, cmd
 		}
 		return m, nil
 
 	case key.Matches(msg, keys.Resume):
 		if m.OnResume != nil {
-			m.IsLoading = true
-			m.LoadingText = "Resuming..."
-			return m, m.resumeCmd()
+			cmd := m.beginOperation("Resuming...", m.resumeCmd())
+			return m, cmd
 		}
 		return m, nil
 
 	case key.Matches(msg, keys.Push):
 		if m.OnPush != nil {
-			m.IsLoading = true
-			m.LoadingText = "Creating push session..."
-			return m, m.pushCmd()
+			if m.ConfirmCreatePush && m.DescribePush != nil {
+				if lines := m.DescribePush(); len(lines) > 0 {
+					m.confirmation = &Confirmation{
+						Title:       "CONFIRM ONE-WAY PUSH",
+						Lines:       append(lines, "", "Any running session is replaced; files on the target are overwritten."),
+						LoadingText: "Creating push session...",
+						Run:         m.pushCmd(),
+					}
+					m.ActiveModal = ModalConfirm
+					return m, nil
+				}
+			}
+			cmd := m.beginOperation("Creating push session...", m.pushCmd())
+			return m, cmd
 		}
 		return m, nil
 
+	case key.Matches(msg, keys.Undo):
+		if m.OnUndo == nil || m.DescribeUndo == nil {
+			return m, nil
+		}
+		description, ok := m.DescribeUndo()
+		if !ok {
+			m.StatusMessage = &StatusMessage{Type: StatusWarning, Text: description}
+			return m, m.flashCmd()
+		}
+		m.confirmation = &Confirmation{
+			Title:       "UNDO LAST ACTION",
+			Lines:       []string{description + "?"},
+			LoadingText: "Undoing...",
+			Run:         m.undoCmd(),
+		}
+		m.ActiveModal = ModalConfirm
+		return m, nil
+
 	case key.Matches(msg, keys.Conflicts):
 		m.ActiveModal = ModalConflicts
+		m.conflictCursor = 0
+		m.modalOffset = 0
+		m.conflictQuery = ""
+		m.expandedConflicts = nil
 		return m, nil
 
 	case key.Matches(msg, keys.SyncStatus):
 		m.ActiveModal = ModalSyncStatus
+		m.pollGen++
+		checks := tea.Batch(m.checkFreeSpace(), m.checkIgnores())
+		if m.startMonitor() {
+			return m, tea.Batch(m.nextSessionUpdate(m.pollGen), checks)
+		}
+		return m, tea.Batch(m.sessionPollTick(), checks)
+
+	case key.Matches(msg, keys.Log):
+		m.ActiveModal = ModalSessionLog
+		m.logOffset = 0
+		return m, nil
+
+	case key.Matches(msg, keys.EventLog):
+		m.openEventLog()
 		return m, nil
 
 	case key.Matches(msg, keys.Edit):
@@ -446,11 +1216,15 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			projIdx := m.Selection.SelectedProjectIndex()
 			if projIdx >= 0 {
 				err := m.OnOpenEditor(projIdx)
-				if err != nil && m.SuspendAndRun != nil {
-					// Terminal editor - need to suspend
-					return m, m.SuspendAndRun(func() {
-						m.runTerminalEditor(projIdx)
-					})
+				if errors.Is(err, ErrTerminalEditor) {
+					if m.SuspendAndRun != nil {
+						// Terminal editor - need to suspend
+						return m, m.SuspendAndRun(func() {
+							m.runTerminalEditor(projIdx)
+						})
+					}
+				} else if err != nil {
+					m.StatusMessage = &StatusMessage{Type: StatusError, Text: err.Error()}
 				}
 			}
 		}
@@ -458,6 +1232,61 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case key.Matches(msg, keys.ToggleMode):
 		m.ShowPaths = !m.ShowPaths
+		m.displayToggled = !m.displayToggled
+		return m, nil
+
+	case key.Matches(msg, keys.ToggleHost):
+		m.ShowHost = !m.ShowHost
+		return m, nil
+
+	case key.Matches(msg, keys.ToggleNames):
+		m.ShowSessions = !m.ShowSessions
+		return m, nil
+
+	case key.Matches(msg, keys.OpenBeta):
+		if m.OnOpenBeta != nil && m.Selection.IsSpecSelected() {
+			open := m.OnOpenBeta
+			return m, func() tea.Msg {
+				cmd, status := open()
+				return OpenBetaMsg{Cmd: cmd, Status: status}
+			}
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Copy):
+		if m.OnCopy != nil && m.Selection.SelectedItem() != nil {
+			m.StatusMessage = m.OnCopy()
+			return m, m.flashCmd()
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Sort):
+		if m.OnCycleSort != nil {
+			m.StatusMessage = m.OnCycleSort()
+			if m.GetProjects != nil {
+				m.Projects = m.GetProjects()
+			}
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Borderless):
+		m.Borderless = !m.Borderless
+		return m, nil
+
+	case key.Matches(msg, keys.Problems):
+		return m.toggleProblemsOnly()
+
+	case key.Matches(msg, keys.Density):
+		m.Compact = !m.Compact
+		return m, nil
+
+	case key.Matches(msg, keys.Forwards):
+		return m.toggleForwards()
+
+	case key.Matches(msg, keys.DaemonList):
+		if m.ListDaemonSessions != nil {
+			return m.openDaemonSessions()
+		}
 		return m, nil
 	}
 
@@ -469,6 +1298,8 @@ func (m Model) handleModalKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case ModalHelp:
 		if key.Matches(msg, keys.Help) || key.Matches(msg, keys.Escape) {
 			m.ActiveModal = ModalNone
+		} else if offset, ok := scrollOffset(msg, m.modalOffset, len(m.helpLines()), m.modalBodyHeight(0)); ok {
+			m.modalOffset = offset
 		}
 		return m, nil
 
@@ -484,9 +1315,8 @@ func (m Model) handleModalKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			// No confirmation needed - execute directly
 			m.ActiveModal = ModalNone
-			m.IsLoading = true
-			m.LoadingText = "Pushing to beta..."
-			return m, m.pushConflictsCmd()
+			cmd := m.beginOperation("Pushing to beta...", m.pushConflictsCmd())
+			return m, cmd
 		}
 		if key.Matches(msg, keys.PullToAlpha) && m.OnPullConflicts != nil {
 			if m.ConfirmPullToAlpha {
@@ -495,9 +1325,33 @@ func (m Model) handleModalKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			// No confirmation needed - execute directly
 			m.ActiveModal = ModalNone
-			m.IsLoading = true
-			m.LoadingText = "Pulling to alpha..."
-			return m, m.pullConflictsCmd()
+			cmd := m.beginOperation("Pulling to alpha...", m.pullConflictsCmd())
+			return m, cmd
+		}
+		switch {
+		case key.Matches(msg, keys.Up):
+			m.conflictCursor = max(m.conflictCursor-1, 0)
+			m.followConflictCursor()
+		case key.Matches(msg, keys.Down):
+			if m.conflictCursor < countConflicts(m.visibleConflicts())-1 {
+				m.conflictCursor++
+			}
+			m.followConflictCursor()
+		case key.Matches(msg, keys.Enter):
+			m.setConflictExpanded(false, true)
+		case key.Matches(msg, keys.Right):
+			m.setConflictExpanded(true, false)
+		case key.Matches(msg, keys.Left):
+			m.setConflictExpanded(false, false)
+		case key.Matches(msg, keys.Filter):
+			m.editingConflictQuery = true
+		case key.Matches(msg, keys.PageUp), key.Matches(msg, keys.PageDown):
+			lines, _, _ := m.conflictListLines()
+			m.modalOffset, _ = scrollOffset(msg, m.modalOffset, len(lines), m.conflictListHeight())
+		case key.Matches(msg, keys.KeepAlpha):
+			return m.resolveConflict(mutagen.WinnerAlpha)
+		case key.Matches(msg, keys.KeepBeta):
+			return m.resolveConflict(mutagen.WinnerBeta)
 		}
 		return m, nil
 
@@ -508,9 +1362,8 @@ func (m Model) handleModalKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		if key.Matches(msg, keys.ConfirmYes) && m.OnPushConflicts != nil {
 			m.ActiveModal = ModalNone
-			m.IsLoading = true
-			m.LoadingText = "Pushing to beta..."
-			return m, m.pushConflictsCmd()
+			cmd := m.beginOperation("Pushing to beta...", m.pushConflictsCmd())
+			return m, cmd
 		}
 		return m, nil
 
@@ -521,9 +1374,8 @@ func (m Model) handleModalKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		if key.Matches(msg, keys.ConfirmYes) && m.OnPullConflicts != nil {
 			m.ActiveModal = ModalNone
-			m.IsLoading = true
-			m.LoadingText = "Pulling to alpha..."
-			return m, m.pullConflictsCmd()
+			cmd := m.beginOperation("Pulling to alpha...", m.pullConflictsCmd())
+			return m, cmd
 		}
 		return m, nil
 
@@ -531,27 +1383,229 @@ func (m Model) handleModalKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if key.Matches(msg, keys.SyncStatus) || key.Matches(msg, keys.Escape) {
 			m.ActiveModal = ModalNone
 		}
+		if key.Matches(msg, keys.Copy) && m.OnCopyIdentifier != nil {
+			m.StatusMessage = m.OnCopyIdentifier()
+			return m, m.flashCmd()
+		}
+		if key.Matches(msg, keys.Edit) && m.Selection.IsProjectSelected() {
+			// Fix config issues straight from their listing
+			m.ActiveModal = ModalNone
+			return m.handleKeyPress(msg)
+		}
+		return m, nil
+
+	case ModalConfirm:
+		if key.Matches(msg, keys.Escape) || key.Matches(msg, keys.ConfirmNo) {
+			m.ActiveModal = ModalNone
+			m.confirmation = nil
+			return m, nil
+		}
+		if key.Matches(msg, keys.ConfirmYes) && m.confirmation != nil {
+			c := m.confirmation
+			m.ActiveModal = ModalNone
+			m.confirmation = nil
+			cmd := m.beginOperation(c.LoadingText, c.Run)
+			return m, cmd
+		}
+		return m, nil
+
+	case ModalDaemonSessions:
+		return m.handleDaemonSessionsKeyPress(msg)
+
+	case ModalSessionLog:
+		return m.handleSessionLogKeyPress(msg)
+
+	case ModalEventLog:
+		return m.handleEventLogKeyPress(msg)
+
+	case ModalFirstRun:
+		if key.Matches(msg, keys.Escape) || key.Matches(msg, keys.ConfirmNo) {
+			m.ActiveModal = ModalNone
+			if m.OnDeclineConfig != nil {
+				m.OnDeclineConfig()
+			}
+			return m, nil
+		}
+		if key.Matches(msg, keys.ConfirmYes) && m.OnWriteConfig != nil {
+			m.ActiveModal = ModalNone
+			if err := m.OnWriteConfig(); err != nil {
+				m.StatusMessage = &StatusMessage{Type: StatusError, Text: "Failed to write config: " + err.Error()}
+			} else {
+				m.StatusMessage = &StatusMessage{Type: StatusInfo, Text: "Wrote starter config to " + m.ConfigPath}
+			}
+			return m, m.flashCmd()
+		}
 		return m, nil
 	}
 
 	return m, nil
 }
 
+// handleFilterKeyPress edits the filter query. The list updates as the query
+// is typed; Enter keeps the filter and Esc clears it.
+func (m Model) handleFilterKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	query := m.Selection.Filter().Query
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.editingFilter = false
+		m.setFilter("")
+	case tea.KeyEnter:
+		m.editingFilter = false
+	case tea.KeyBackspace:
+		if runes := []rune(query); len(runes) > 0 {
+			m.setFilter(string(runes[:len(runes)-1]))
+		}
+	case tea.KeySpace:
+		m.setFilter(query + " ")
+	case tea.KeyRunes:
+		m.setFilter(query + string(msg.Runes))
+	}
+	return m, nil
+}
+
+// setFilter applies a filter query to the project list.
+func (m *Model) setFilter(query string) {
+	m.Selection.ApplyFilter(ParseFilter(query), m.Projects)
+}
+
+// beginOperation marks an operation on the selected item as in progress and
+// returns run, which must end with an OperationDoneMsg, tagged so that the
+// message ends the operation. If an operation covering the same item is
+// still running, it sets an "operation in progress" status and returns a
+// flash instead, so the key press is ignored. Operations on forwards end
+// with their ForwardsMsg, so they aren't tracked.
+func (m *Model) beginOperation(loadingText string, run tea.Cmd) tea.Cmd {
+	target := m.Selection.SelectedItem()
+	if m.forwards.shown {
+		target = nil
+	}
+	if target != nil && m.operationCovers(*target) {
+		m.StatusMessage = &StatusMessage{Type: StatusWarning, Text: "Operation in progress"}
+		return m.flashCmd()
+	}
+
+	m.IsLoading = true
+	m.LoadingText = loadingText
+	if target == nil {
+		return run
+	}
+	t := *target
+	if m.operations == nil {
+		m.operations = make(map[SelectableItem]string)
+	}
+	m.operations[t] = loadingText
+	return func() tea.Msg {
+		msg := run()
+		if done, ok := msg.(OperationDoneMsg); ok {
+			done.Target = &t
+			return done
+		}
+		return msg
+	}
+}
+
+// operationCovers returns true if an in-flight operation was started on
+// item, or on the project of a spec item or a spec of a project item, since
+// a project operation acts on its specs.
+func (m Model) operationCovers(item SelectableItem) bool {
+	for running := range m.operations {
+		if running == item {
+			return true
+		}
+		if running.Type == SelectableForward || item.Type == SelectableForward {
+			continue
+		}
+		if running.ProjectIndex == item.ProjectIndex &&
+			(running.Type == SelectableProject || item.Type == SelectableProject) {
+			return true
+		}
+	}
+	return false
+}
+
+// endOperation ends the operation started on target, if any, and shows the
+// loading text of another still running, or clears it.
+func (m *Model) endOperation(target *SelectableItem) {
+	if target != nil {
+		delete(m.operations, *target)
+	}
+	m.IsLoading, m.LoadingText = false, ""
+	for _, text := range m.operations {
+		m.IsLoading, m.LoadingText = true, text
+		break
+	}
+}
+
 // Command functions
-func (m Model) refreshCmd() tea.Cmd {
+// refreshCmd refreshes the session list. Unless force is set, it may reuse
+// a list from moments before, so that a tick and a keypress together don't
+// run mutagen twice.
+func (m Model) refreshCmd(force bool) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		err := m.OnRefresh(ctx)
+		err := m.OnRefresh(ctx, force)
 		return RefreshDoneMsg{Err: err}
 	}
 }
 
+// StaleListText describes a failed refresh that left the sessions listed at
+// lastRefresh on screen.
+func StaleListText(lastRefresh time.Time, err error) string {
+	return "Failed to refresh sessions; showing them as of " + lastRefresh.Format("15:04:05") + ": " + err.Error()
+}
+
+func (m Model) undoCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnUndo(ctx)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
+
+func (m Model) reconcileCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnReconcile(ctx)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
+
+func (m Model) resetCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnReset(ctx)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
+
+func (m Model) restartCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnRestart(ctx)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
+
 func (m Model) startCmd() tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
 		status := m.OnStart(ctx)
 		if m.OnRefresh != nil {
-			m.OnRefresh(ctx)
+			m.OnRefresh(ctx, true)
 		}
 		return OperationDoneMsg{Status: status}
 	}
@@ -562,7 +1616,18 @@ func (m Model) terminateCmd() tea.Cmd {
 		ctx := context.Background()
 		status := m.OnTerminate(ctx)
 		if m.OnRefresh != nil {
-			m.OnRefresh(ctx)
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
+
+func (m Model) reconnectCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnReconnect(ctx)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
 		}
 		return OperationDoneMsg{Status: status}
 	}
@@ -573,7 +1638,7 @@ func (m Model) flushCmd() tea.Cmd {
 		ctx := context.Background()
 		status := m.OnFlush(ctx)
 		if m.OnRefresh != nil {
-			m.OnRefresh(ctx)
+			m.OnRefresh(ctx, true)
 		}
 		return OperationDoneMsg{Status: status}
 	}
@@ -584,7 +1649,69 @@ func (m Model) pauseCmd() tea.Cmd {
 		ctx := context.Background()
 		status := m.OnPause(ctx)
 		if m.OnRefresh != nil {
-			m.OnRefresh(ctx)
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
+
+func (m Model) toggleScheduleCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnToggleSchedule(ctx)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
+
+func (m Model) scheduledFlushCmd() tea.Cmd {
+	return func() tea.Msg {
+		m.OnScheduledFlush(context.Background())
+		return nil
+	}
+}
+
+func (m Model) startAllCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnStartAll(ctx)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
+
+func (m Model) terminateAllCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnTerminateAll(ctx)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
+
+func (m Model) pauseAllCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnPauseAll(ctx)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
+
+func (m Model) focusCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnFocus(ctx)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
 		}
 		return OperationDoneMsg{Status: status}
 	}
@@ -595,7 +1722,7 @@ func (m Model) resumeCmd() tea.Cmd {
 		ctx := context.Background()
 		status := m.OnResume(ctx)
 		if m.OnRefresh != nil {
-			m.OnRefresh(ctx)
+			m.OnRefresh(ctx, true)
 		}
 		return OperationDoneMsg{Status: status}
 	}
@@ -606,7 +1733,35 @@ func (m Model) pushCmd() tea.Cmd {
 		ctx := context.Background()
 		status := m.OnPush(ctx)
 		if m.OnRefresh != nil {
-			m.OnRefresh(ctx)
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
+
+func (m Model) thoroughRefreshCmd() tea.Cmd {
+	return func() tea.Msg {
+		return OperationDoneMsg{Status: m.OnThoroughRefresh(context.Background())}
+	}
+}
+
+func (m Model) restartDaemonCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnRestartDaemon(ctx)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
+
+func (m Model) fixCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnFix(ctx)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
 		}
 		return OperationDoneMsg{Status: status}
 	}
@@ -617,23 +1772,132 @@ func (m Model) pushConflictsCmd() tea.Cmd {
 		ctx := context.Background()
 		status := m.OnPushConflicts(ctx)
 		if m.OnRefresh != nil {
-			m.OnRefresh(ctx)
+			m.OnRefresh(ctx, true)
 		}
 		return OperationDoneMsg{Status: status}
 	}
 }
 
+// resolveConflict resolves the highlighted conflict in favor of winner,
+// asking first when the matching bulk action would.
+func (m Model) resolveConflict(winner string) (tea.Model, tea.Cmd) {
+	if m.OnResolveConflict == nil || m.GetConflicts == nil {
+		return m, nil
+	}
+	sc, conflict, ok := conflictAt(m.visibleConflicts(), m.conflictCursor)
+	if !ok {
+		return m, nil
+	}
+	keep, lose, loser := "alpha", "beta", sc.Session.Beta
+	confirm := m.ConfirmPushToBeta
+	if winner == mutagen.WinnerBeta {
+		keep, lose, loser = "beta", "alpha", sc.Session.Alpha
+		confirm = m.ConfirmPullToAlpha
+	}
+	run := m.resolveConflictCmd(sc.Session, conflict, winner)
+	loadingText := "Keeping " + keep + "'s " + conflict.Root + "..."
+	if !confirm {
+		m.ActiveModal = ModalNone
+		cmd := m.beginOperation(loadingText, run)
+		return m, cmd
+	}
+	m.confirmation = &Confirmation{
+		Title: "KEEP " + strings.ToUpper(keep) + " VERSION",
+		Lines: []string{
+			"Delete " + lose + "'s copy of " + conflict.Root + "?",
+			"  " + loser.DisplayPath(),
+			"",
+			"Mutagen then copies " + keep + "'s version over it. This can't be undone.",
+		},
+		LoadingText: loadingText,
+		Run:         run,
+	}
+	m.ActiveModal = ModalConfirm
+	return m, nil
+}
+
+func (m Model) resolveConflictCmd(session *mutagen.SyncSession, conflict mutagen.Conflict, winner string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		status := m.OnResolveConflict(ctx, session, conflict, winner)
+		if m.OnRefresh != nil {
+			m.OnRefresh(ctx, true)
+		}
+		return OperationDoneMsg{Status: status}
+	}
+}
+
+func (m Model) refreshAfterReloadCmd() tea.Cmd {
+	return func() tea.Msg {
+		if m.OnRefresh != nil {
+			// Pick up the sessions of added specs
+			m.OnRefresh(context.Background(), true)
+		}
+		return projectFileReloadedMsg{}
+	}
+}
+
 func (m Model) pullConflictsCmd() tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
 		status := m.OnPullConflicts(ctx)
 		if m.OnRefresh != nil {
-			m.OnRefresh(ctx)
+			m.OnRefresh(ctx, true)
 		}
 		return OperationDoneMsg{Status: status}
 	}
 }
 
+// sessionPollTick schedules the next poll of the session in the sync status dialog.
+func (m Model) sessionPollTick() tea.Cmd {
+	if m.OnPollSession == nil {
+		return nil
+	}
+	gen := m.pollGen
+	return tea.Tick(sessionPollInterval, func(time.Time) tea.Msg {
+		return SessionPollMsg{gen: gen}
+	})
+}
+
+// startMonitor starts streaming the selected session for the sync status
+// dialog, replacing any previous stream. It returns false if streaming
+// isn't available, in which case the dialog polls instead.
+func (m *Model) startMonitor() bool {
+	if m.stopMonitor != nil {
+		m.stopMonitor()
+		m.stopMonitor = nil
+	}
+	if m.OnMonitorSession == nil {
+		return false
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := m.OnMonitorSession(ctx)
+	if err != nil {
+		cancel()
+		return false
+	}
+	m.monitorUpdates = updates
+	m.stopMonitor = cancel
+	return true
+}
+
+// nextSessionUpdate waits for the next update from the session stream.
+func (m Model) nextSessionUpdate(gen int) tea.Cmd {
+	updates := m.monitorUpdates
+	return func() tea.Msg {
+		session, ok := <-updates
+		return SessionUpdateMsg{gen: gen, session: session, ok: ok}
+	}
+}
+
+func (m Model) pollSessionCmd(gen int) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		err := m.OnPollSession(ctx)
+		return SessionPolledMsg{gen: gen, Err: err}
+	}
+}
+
 // flashCmd returns a command that clears the status message after a delay.
 func (m Model) flashCmd() tea.Cmd {
 	return tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
@@ -668,17 +1932,20 @@ func (m Model) View() string {
 		return "Loading..."
 	}
 
-	// Calculate available height for list
-	headerHeight := 3
-	statusHeight := 3
-	helpHeight := 3
-	listHeight := m.Height - headerHeight - statusHeight - helpHeight
-
-	// Build sections
+	// Build sections; the list gets the height the others leave, measured
+	// since borders and a wrapped help bar change it
 	header := m.renderHeader()
-	list := m.renderList(listHeight)
 	status := m.renderStatus()
 	help := m.renderHelp()
+	listHeight := m.Height - lipgloss.Height(header) - lipgloss.Height(status) - lipgloss.Height(help)
+	var list string
+	if m.forwards.shown {
+		list = m.renderForwardList(listHeight)
+	} else if len(m.Projects) == 0 {
+		list = m.renderEmptyState(listHeight)
+	} else {
+		list = m.renderList(listHeight)
+	}
 
 	// Main view
 	mainView := lipgloss.JoinVertical(lipgloss.Left,
@@ -699,7 +1966,35 @@ func (m Model) View() string {
 
 func (m Model) renderHeader() string {
 	title := m.Theme.HeaderTitle.Render("Mutagen TUI")
-	return m.Theme.Header.Width(m.Width - 2).Render(title)
+	width := m.Width - m.borderSize()
+	if m.GetSummary != nil {
+		// The summary sits right of the title, and is cut short rather than
+		// wrapping onto a second line
+		room := width - m.Theme.Header.GetHorizontalPadding() - lipgloss.Width(title) - 2
+		if room > 1 {
+			summary := truncateLine(formatSummary(m.GetSummary()), room)
+			title += strings.Repeat(" ", room-lipgloss.Width(summary)+2) + summary
+		}
+	}
+	return m.section(m.Theme.Header).Width(width).Render(title)
+}
+
+// borderSize returns the rows or columns taken by a section's border on
+// both sides together, which is zero when borderless.
+func (m Model) borderSize() int {
+	if m.Borderless {
+		return 0
+	}
+	return 2
+}
+
+// section returns a main-view section style, with its border removed
+// when borderless.
+func (m Model) section(style lipgloss.Style) lipgloss.Style {
+	if m.Borderless {
+		return style.Border(lipgloss.Border{}, false)
+	}
+	return style
 }
 
 func (m Model) renderList(height int) string {
@@ -709,17 +2004,35 @@ func (m Model) renderList(height int) string {
 		totalSpecs += len(proj.Specs)
 	}
 
-	title := fmt.Sprintf(" Sync Projects (%d projects, %d specs) ", len(m.Projects), totalSpecs)
-
+	title := fmt.Sprintf(" Sync Projects (%d projects, %d specs) ", len(m.Projects), totalSpecs)
+	if filter := m.Selection.Filter(); !filter.IsEmpty() {
+		title += fmt.Sprintf("[filter: %s] ", strings.TrimSpace(filter.Query))
+	}
+	if m.Selection.ProblemsOnly() {
+		title += "[problems only] "
+	}
+	if m.GetFocus != nil {
+		if target, ok := m.GetFocus(); ok {
+			title += fmt.Sprintf("[focus: %s] ", target)
+		}
+	}
+
 	// Available width for content (account for border padding)
-	contentWidth := m.Width - 6
+	contentWidth := m.Width - 4 - m.borderSize()
 	if contentWidth < 40 {
 		contentWidth = 40
 	}
 
 	// Build list items
 	var items []string
+	var widths []int
+	if len(m.Columns) > 0 {
+		widths = m.tableLayout(contentWidth)
+	}
 	for i, item := range m.Selection.Items() {
+		if item.ProjectIndex >= len(m.Projects) {
+			continue // Selection rebuilt for a rescan the model has not picked up yet
+		}
 		selected := i == m.Selection.RawIndex()
 		var line string
 		switch item.Type {
@@ -729,7 +2042,11 @@ func (m Model) renderList(height int) string {
 		case SelectableSpec:
 			proj := m.Projects[item.ProjectIndex]
 			spec := &proj.Specs[item.SpecIndex]
-			line = m.renderSpecRow(proj, spec, contentWidth, selected)
+			if widths != nil {
+				line = m.renderColumnRow(proj, spec, widths, contentWidth, selected)
+			} else {
+				line = m.renderSpecRow(proj, spec, contentWidth, selected)
+			}
 		}
 
 		// Apply selection styling with full width
@@ -740,9 +2057,17 @@ func (m Model) renderList(height int) string {
 		items = append(items, line)
 	}
 
+	if widths != nil && len(items) > 0 {
+		items = append([]string{m.renderColumnHeader(widths, contentWidth)}, items...)
+	}
+	if len(items) == 0 && m.Selection.ProblemsOnly() {
+		items = append(items, m.Theme.StatusRunning.Render(mutagen.Icon("✓", "+")+" No problems: no conflicts, disconnections, scan problems, or halted sessions"),
+			m.Theme.ModalHelp.Render("Press "+keys.Problems.Help().Key+" to list every spec"))
+	}
+
 	// Join items and pad to fill height
 	content := strings.Join(items, "\n")
-	innerHeight := height - 2 // Account for border
+	innerHeight := height - m.borderSize() - 1 // Account for border and title
 	lines := strings.Split(content, "\n")
 	for len(lines) < innerHeight {
 		lines = append(lines, "")
@@ -752,16 +2077,53 @@ func (m Model) renderList(height int) string {
 	}
 	content = strings.Join(lines, "\n")
 
-	return m.Theme.ListBorder.
-		Width(m.Width - 2).
-		Height(height - 2).
+	return m.section(m.Theme.ListBorder).
+		Width(m.Width - m.borderSize()).
+		Height(height - m.borderSize()).
 		Render(m.Theme.ListTitle.Render(title) + "\n" + content)
 }
 
+// renderEmptyState fills the list box when no project files were found,
+// explaining where mutagui looked and how to point it elsewhere.
+func (m Model) renderEmptyState(height int) string {
+	var content strings.Builder
+	content.WriteString("No mutagen project files found.\n\n")
+	if m.SearchPaths != nil {
+		content.WriteString(m.Theme.HelpKey.Render("Searched:") + "\n")
+		for _, dir := range m.SearchPaths() {
+			content.WriteString("  " + dir + "\n")
+		}
+		content.WriteString("\n")
+	}
+	content.WriteString(m.Theme.HelpKey.Render("To add a project:") + "\n")
+	content.WriteString("  • Create a mutagen.yml (or mutagen-<name>.yml) in one of these directories\n")
+	content.WriteString("  • Put any .yml project file in ~/.config/mutagen/projects\n")
+	content.WriteString("  • Run mutagui -d <dir> to search another directory\n")
+	content.WriteString("  • Add directories to search_paths under [projects] in ~/.config/mutagui/config.toml\n")
+	content.WriteString("\n" + m.Theme.ModalHelp.Render(fmt.Sprintf("Press %s to search again, or %s to quit", keys.Thorough.Help().Key, keys.Quit.Help().Key)))
+
+	lines := strings.Split(content.String(), "\n")
+	for i, line := range lines {
+		lines[i] = truncateLine(line, m.Width-4-m.borderSize())
+	}
+	innerHeight := height - m.borderSize() - 1 // Account for border and title
+	for len(lines) < innerHeight {
+		lines = append(lines, "")
+	}
+	if len(lines) > innerHeight {
+		lines = lines[:innerHeight]
+	}
+
+	return m.section(m.Theme.ListBorder).
+		Width(m.Width - m.borderSize()).
+		Height(height - m.borderSize()).
+		Render(m.Theme.ListTitle.Render(" Sync Projects ") + "\n" + strings.Join(lines, "\n"))
+}
+
 func (m Model) renderProjectHeader(proj *project.Project, maxWidth int, selected bool) string {
-	foldIcon := "▼"
+	foldIcon := mutagen.Icon("▼", "v")
 	if proj.Folded {
-		foldIcon = "▶"
+		foldIcon = mutagen.Icon("▶", ">")
 	}
 
 	// Count running/paused specs
@@ -786,10 +2148,10 @@ func (m Model) renderProjectHeader(proj *project.Project, maxWidth int, selected
 	}
 
 	// Status icon
-	statusIcon := "○"
+	statusIcon := mutagen.Icon("○", "o")
 	statusStyle := m.Theme.StatusNotRunning
 	if runningCount > 0 {
-		statusIcon = "✓"
+		statusIcon = mutagen.Icon("✓", "+")
 		statusStyle = m.Theme.StatusRunning
 	}
 
@@ -808,79 +2170,145 @@ func (m Model) renderProjectHeader(proj *project.Project, maxWidth int, selected
 	if disconnectedCount > 0 {
 		statusText += fmt.Sprintf(", %d waiting", disconnectedCount)
 	}
+	if proj.Orphaned {
+		// Strays from deleted specs or other tools, listed so they can be terminated
+		statusIcon = "?"
+		statusStyle = m.Theme.StatusWarning
+		statusText = fmt.Sprintf("%d session(s) not in any project file", len(proj.Specs))
+	}
 
-	// Conflict suffix
-	conflictSuffix := ""
-	if conflictCount > 0 {
-		conflictText := "conflict"
-		if conflictCount > 1 {
-			conflictText = "conflicts"
+	configIssue := ""
+	if proj.HasWarnings() && m.Compact {
+		configIssue = " " + mutagen.Icon("⚠", "!")
+	} else if proj.HasWarnings() {
+		configIssue = "  " + mutagen.Icon("⚠", "!") + " config issue"
+		if !selected {
+			configIssue = m.Theme.StatusWarning.Render(configIssue)
 		}
-		conflictSuffix = fmt.Sprintf("  ⚠ %d %s", conflictCount, conflictText)
+	}
+
+	// Conflict badge
+	badge := ""
+	if conflictCount > 0 {
+		badge = mutagen.Icon("⚠", "!") + " " + conflictBadgeText(conflictCount)
 	}
 
 	// Build line with fixed-width name column
-	name := fmt.Sprintf("%-26s", truncateString(proj.File.DisplayName(), 26))
+	name := m.nameColumn(proj.File.DisplayName(), 26)
+	sep := "  "
+	if m.Compact {
+		sep = " "
+	}
 
 	// Compose line - use plain text when selected so background applies uniformly
 	var line string
 	if selected {
-		line = fmt.Sprintf("%s %s %s  %s%s",
+		line = fmt.Sprintf("%s %s %s%s%s%s",
 			foldIcon,
 			statusIcon,
 			name,
+			sep,
 			statusText,
-			conflictSuffix,
+			configIssue,
 		)
 	} else {
-		line = fmt.Sprintf("%s %s %s  %s%s",
+		line = fmt.Sprintf("%s %s %s%s%s%s",
 			foldIcon,
 			statusStyle.Render(statusIcon),
 			m.Theme.SessionName.Bold(true).Render(name),
+			sep,
 			statusText,
-			m.Theme.StatusPaused.Bold(true).Render(conflictSuffix),
+			configIssue,
 		)
+		if badge != "" {
+			badge = m.Theme.StatusPaused.Bold(true).Render(badge)
+		}
 	}
 
-	return truncateLine(line, maxWidth)
+	return withBadge(line, badge, maxWidth)
+}
+
+// selectedSpec returns the selected spec, or nil if a project is selected.
+func (m Model) selectedSpec() *project.SyncSpec {
+	projIdx, specIdx := m.Selection.SelectedSpec()
+	if projIdx < 0 || projIdx >= len(m.Projects) || specIdx >= len(m.Projects[projIdx].Specs) {
+		return nil
+	}
+	return &m.Projects[projIdx].Specs[specIdx]
+}
+
+// showPathsFor returns whether the project's specs are shown with paths.
+// A matching display rule overrides the global mode; toggling the mode
+// flips both.
+func (m Model) showPathsFor(proj *project.Project) bool {
+	if m.DisplayModeFor != nil {
+		if showPaths, ok := m.DisplayModeFor(proj); ok {
+			return showPaths != m.displayToggled
+		}
+	}
+	return m.ShowPaths
 }
 
 func (m Model) renderSpecRow(proj *project.Project, spec *project.SyncSpec, maxWidth int, selected bool) string {
-	indent := "    "
+	indent := m.specIndent(spec)
 
 	switch spec.State {
 	case project.NotRunning:
 		sessionDef, exists := proj.File.Sessions[spec.Name]
-		name := fmt.Sprintf("%-28s", truncateString(spec.Name, 28))
+		name := m.nameColumn(spec.Name+swapMark(proj, spec), 28)
+		icon := mutagen.Icon("○", "o")
 
-		if !exists || !m.ShowPaths {
+		if !exists || !m.showPathsFor(proj) {
 			var line string
+			host := m.hostColumn(proj, spec, selected)
+			// Specs that have never run are dimmed, to tell them from stopped ones
+			state, stateStyle := "New", m.Theme.ModalHelp
+			if spec.EverStarted {
+				state, stateStyle = "Stopped", m.Theme.StatusNotRunning
+			}
 			if selected {
-				line = fmt.Sprintf("%s%s %s Not running", indent, "○", name)
+				line = fmt.Sprintf("%s%s %s %s%s", indent, icon, name, host, state)
 			} else {
-				line = fmt.Sprintf("%s%s %s Not running",
+				line = fmt.Sprintf("%s%s %s %s%s",
 					indent,
-					m.Theme.StatusNotRunning.Render("○"),
+					m.Theme.StatusNotRunning.Render(icon),
 					m.Theme.SessionName.Render(name),
+					host,
+					stateStyle.Render(state),
 				)
 			}
 			return truncateLine(line, maxWidth)
 		}
 
+		// Show paths as running sessions do: absolute, without trailing slashes
+		betas := sessionDef.BetaEndpoints()
+		alpha := applyTilde(project.NormalizeEndpoint(sessionDef.Alpha, proj.File.Dir()))
+		beta := ""
+		if len(betas) > 0 {
+			beta = applyTilde(project.NormalizeEndpoint(betas[0], proj.File.Dir()))
+		}
+		if len(betas) > 1 {
+			beta += fmt.Sprintf(" +%d", len(betas)-1)
+		}
+		prefixWidth := lipgloss.Width(indent + icon + " " + name + " ")
+		arrow := mutagen.Icon("⇄", "<>")
+		alpha, beta = m.fitEndpoints(alpha, beta, maxWidth-prefixWidth-lipgloss.Width(" "+arrow+" "))
 		var line string
 		if selected {
-			line = fmt.Sprintf("%s%s %s %s ⇄ %s",
-				indent, "○", name,
-				applyTilde(sessionDef.Alpha),
-				applyTilde(sessionDef.Beta),
+			line = fmt.Sprintf("%s%s %s %s %s %s",
+				indent, icon, name,
+				alpha,
+				arrow,
+				beta,
 			)
 		} else {
-			line = fmt.Sprintf("%s%s %s %s ⇄ %s",
+			line = fmt.Sprintf("%s%s %s %s %s %s",
 				indent,
-				m.Theme.StatusNotRunning.Render("○"),
+				m.Theme.StatusNotRunning.Render(icon),
 				m.Theme.SessionName.Render(name),
-				m.Theme.SessionAlpha.Render(applyTilde(sessionDef.Alpha)),
-				m.Theme.SessionBeta.Render(applyTilde(sessionDef.Beta)),
+				m.Theme.SessionAlpha.Render(alpha),
+				arrow,
+				m.Theme.SessionBeta.Render(beta),
 			)
 		}
 		return truncateLine(line, maxWidth)
@@ -889,11 +2317,11 @@ func (m Model) renderSpecRow(proj *project.Project, spec *project.SyncSpec, maxW
 		if spec.RunningSession == nil {
 			var line string
 			if selected {
-				line = fmt.Sprintf("%s%s %s", indent, "▶", spec.Name)
+				line = fmt.Sprintf("%s%s %s", indent, mutagen.Icon("▶", ">"), spec.Name)
 			} else {
 				line = fmt.Sprintf("%s%s %s",
 					indent,
-					m.Theme.StatusRunning.Render("▶"),
+					m.Theme.StatusRunning.Render(mutagen.Icon("▶", ">")),
 					m.Theme.SessionName.Render(spec.Name),
 				)
 			}
@@ -902,99 +2330,188 @@ func (m Model) renderSpecRow(proj *project.Project, spec *project.SyncSpec, maxW
 
 		session := spec.RunningSession
 
-		// Use ▶ for running, ⚠ for conflicts (replaces status icon)
-		statusIcon := "▶"
-		statusStyle := m.Theme.StatusRunning
-		if session.HasConflicts() {
-			statusIcon = "⚠"
-			statusStyle = m.Theme.StatusPaused
-		} else if session.Paused {
-			statusIcon = "⏸"
-			statusStyle = m.Theme.StatusPaused
-		}
+		statusIcon, statusStyle := m.runningIcon(spec, session)
 
 		nameWithMode := spec.Name
+		if m.ShowSessions {
+			nameWithMode = session.Name
+		}
 		if spec.State == project.RunningPush {
-			nameWithMode = spec.Name + " (one-way)"
+			nameWithMode += " (one-way)"
+		}
+		name := m.nameColumn(nameWithMode+swapMark(proj, spec), 28)
+
+		// A spec with several betas shows how many of its replicas run
+		replicas := ""
+		if running, total := spec.ReplicaCounts(); total > 1 {
+			replicas = fmt.Sprintf("%d/%d running", running, total)
 		}
-		name := fmt.Sprintf("%-28s", truncateString(nameWithMode, 28))
 
 		var line string
-		if m.ShowPaths {
-			arrow := "⇄"
+		if m.showPathsFor(proj) {
+			arrow := mutagen.Icon("⇄", "<>")
 			if spec.State == project.RunningPush {
-				arrow = "⬆"
+				arrow = mutagen.Icon("⬆", "->")
 			}
 
-			alphaPath := session.Alpha.StatusIcon() + session.AlphaDisplay()
-			betaPath := session.Beta.StatusIcon() + session.BetaDisplay()
+			// Each path follows its endpoint's status icon
+			prefixWidth := lipgloss.Width(indent+statusIcon+" "+name+" "+m.sessionIcon(session)) + 2
+			available := maxWidth - prefixWidth - lipgloss.Width(" "+arrow+" ")
+			if session.HasConflicts() {
+				available -= max(badgeColumnWidth, lipgloss.Width(conflictBadgeText(session.ConflictCount()))+1)
+			}
+			alphaMark, betaMark := scanProblemMark(&session.Alpha), scanProblemMark(&session.Beta)
+			available -= lipgloss.Width(alphaMark + betaMark)
+			if replicas != "" {
+				available -= lipgloss.Width(" (" + replicas + ")")
+			}
+			alphaDisplay, betaDisplay := m.fitEndpoints(session.AlphaDisplay(), session.BetaDisplay(), available)
+			alphaPath := session.Alpha.StatusIcon() + alphaMark + alphaDisplay
+			betaPath := session.Beta.StatusIcon() + betaMark + betaDisplay
+			if replicas != "" {
+				betaPath += " (" + replicas + ")"
+			}
 
 			if selected {
-				line = fmt.Sprintf("%s%s %s %s %s %s %s",
+				line = fmt.Sprintf("%s%s %s %s%s %s %s",
 					indent, statusIcon, name,
-					session.StatusIcon(),
+					m.sessionIcon(session),
 					alphaPath, arrow, betaPath,
 				)
 			} else {
-				line = fmt.Sprintf("%s%s %s %s %s %s %s",
+				line = fmt.Sprintf("%s%s %s %s%s %s %s",
 					indent,
 					statusStyle.Render(statusIcon),
 					m.Theme.SessionName.Render(name),
-					session.StatusIcon(),
+					m.sessionIcon(session),
 					m.Theme.SessionAlpha.Render(alphaPath),
 					arrow,
 					m.Theme.SessionBeta.Render(betaPath),
 				)
 			}
 		} else {
-			statusText := session.StatusText()
+			status := session.StatusText()
+			if endpoint, pct, ok := session.StagingPercent(); ok {
+				status = m.stagingStatus(endpoint, pct, maxWidth, selected)
+			}
+			if replicas != "" {
+				status = replicas
+			}
+			statusText := m.padColumn(status+m.transferReadout(session), statusColumnWidth)
 			cyclesInfo := ""
-			if session.SuccessfulCycles != nil && *session.SuccessfulCycles > 0 {
+			if session.SuccessfulCycles != nil && *session.SuccessfulCycles > 0 && !m.Compact {
 				cyclesInfo = fmt.Sprintf(" (%d cycles)", *session.SuccessfulCycles)
 			}
+			host := m.hostColumn(proj, spec, selected)
+			problems := ""
+			if session.HasScanProblems() {
+				problems = " " + mutagen.Icon("⚠", "!") + " scan problems"
+				if m.Compact {
+					problems = " " + mutagen.Icon("⚠", "!")
+				}
+				if !selected {
+					problems = m.Theme.StatusWarning.Render(problems)
+				}
+			}
+			if reason := session.HaltReason(); reason != "" {
+				reason = " " + truncateLine(reason, maxHaltReasonWidth)
+				if !selected {
+					reason = m.Theme.StatusError.Render(reason)
+				}
+				problems += reason
+			} else if m.Disconnection != nil {
+				if d, ok := m.Disconnection(session); ok {
+					text := formatDisconnection(d)
+					if !selected {
+						text = m.Theme.StatusWarning.Render(text)
+					}
+					problems += text
+				}
+			}
 			if selected {
-				line = fmt.Sprintf("%s%s %s %s %s%s",
-					indent, statusIcon, name,
-					session.StatusIcon(),
-					statusText, cyclesInfo,
+				line = fmt.Sprintf("%s%s %s %s%s%s%s%s",
+					indent, statusIcon, name, host,
+					m.sessionIcon(session),
+					statusText, cyclesInfo, problems,
 				)
 			} else {
-				line = fmt.Sprintf("%s%s %s %s %s%s",
+				line = fmt.Sprintf("%s%s %s %s%s%s%s%s",
 					indent,
 					statusStyle.Render(statusIcon),
 					m.Theme.SessionName.Render(name),
-					session.StatusIcon(),
+					host,
+					m.sessionIcon(session),
 					statusText,
 					cyclesInfo,
+					problems,
 				)
 			}
 		}
 
-		// Add conflict count at end if present
-		if session.HasConflicts() {
-			conflictText := "conflict"
-			if session.ConflictCount() > 1 {
-				conflictText = "conflicts"
-			}
+		// Note one-way sessions that overwrite local files from a remote
+		if spec.State == project.RunningPush && session.ReplicatesToLocal() {
 			if selected {
-				line += fmt.Sprintf(" %d %s", session.ConflictCount(), conflictText)
+				line += " remote → local"
 			} else {
-				line += m.Theme.StatusPaused.Bold(true).Render(fmt.Sprintf(" %d %s", session.ConflictCount(), conflictText))
+				line += m.Theme.ModalHelp.Render(" remote → local")
 			}
 		}
 
-		return truncateLine(line, maxWidth)
+		// Conflict count goes in the right-aligned badge column
+		badge := ""
+		if session.HasConflicts() {
+			badge = conflictBadgeText(session.ConflictCount())
+			if !selected {
+				badge = m.Theme.StatusPaused.Bold(true).Render(badge)
+			}
+		}
+
+		return withBadge(line, badge, maxWidth)
 	}
 
 	return truncateLine(indent+spec.Name, maxWidth)
 }
 
+// swapMark returns a ⚠ to follow the name of a spec whose alpha is remote
+// and beta local, which the sync status overlay explains, or "".
+func swapMark(proj *project.Project, spec *project.SyncSpec) string {
+	if !proj.File.LooksSwapped(spec.Name) {
+		return ""
+	}
+	return " " + mutagen.Icon("⚠", "!")
+}
+
+// swapWarning explains the mark swapMark puts on a spec.
+func (m Model) swapWarning(spec string) string {
+	return m.Theme.StatusWarning.Render(fmt.Sprintf(
+		"%s %s's alpha is remote and its beta local, the reverse of the usual.\n"+
+			"  Pushing to beta would overwrite the local files with the remote ones.\n"+
+			"  If that's intended, set remote_alpha = true in .mutagui.toml next to the project file.", mutagen.Icon("⚠", "!"), spec)) + "\n"
+}
+
+// runningIcon returns the icon of a running spec's row and its style: ▶
+// for running, and in its place ⚠ for conflicts, ⏱ for a scheduled pause,
+// and ⏸ for paused.
+func (m Model) runningIcon(spec *project.SyncSpec, session *mutagen.SyncSession) (string, lipgloss.Style) {
+	switch {
+	case session.HasConflicts():
+		return mutagen.Icon("⚠", "!"), m.Theme.StatusPaused
+	case spec.Scheduled:
+		return mutagen.Icon("⏱", "t"), m.Theme.StatusPaused
+	case session.Paused:
+		return mutagen.Icon("⏸", "|"), m.Theme.StatusPaused
+	}
+	return mutagen.Icon("▶", ">"), m.Theme.StatusRunning
+}
+
 func (m Model) renderStatus() string {
 	var text string
 	style := m.Theme.StatusMessage
 
-	if m.IsLoading {
-		text = "⏳ " + m.LoadingText
+	if m.editingFilter {
+		text = "/" + m.Selection.Filter().Query + "█"
+	} else if m.IsLoading {
+		text = mutagen.Icon("⏳", "*") + " " + m.LoadingText
 	} else if m.StatusMessage != nil {
 		text = m.StatusMessage.Text
 		switch m.StatusMessage.Type {
@@ -1007,44 +2524,69 @@ func (m Model) renderStatus() string {
 		text = "Ready"
 	}
 
+	if m.GetStagingTotal != nil {
+		if staging := formatStagingTotal(m.GetStagingTotal()); staging != "" {
+			text += " | " + staging
+		}
+	}
 	if m.LastRefresh != nil {
 		text += fmt.Sprintf(" | Last refresh: %s", m.LastRefresh.Format("15:04:05"))
 	}
 
-	return m.Theme.StatusBar.Width(m.Width - 2).Render(style.Render(text))
+	return m.section(m.Theme.StatusBar).Width(m.Width - m.borderSize()).Render(style.Render(text))
 }
 
 func (m Model) renderHelp() string {
 	var items []string
+	sep := m.Theme.HelpSep.Render(" | ")
+	item := func(keyText, label string) string { return m.Theme.HelpKey.Render(keyText) + " " + label }
+	k := func(b key.Binding) string { return b.Help().Key }
+	nav := item(k(keys.Up)+" "+k(keys.Down), "Nav")
+
+	if m.forwards.shown {
+		items = append(items,
+			nav,
+			item(k(keys.Pause), "Pause/Resume"),
+			item(k(keys.Terminate), "Terminate"),
+			item(k(keys.Refresh), "Reload"),
+			item(k(keys.Forwards), "Sync Projects"),
+			item(k(keys.Help), "Help"),
+			item(k(keys.Quit), "Quit"),
+		)
+		return m.section(m.Theme.HelpBar).Width(m.Width - m.borderSize()).Render(strings.Join(items, sep))
+	}
 
 	items = append(items,
-		m.Theme.HelpKey.Render("↑/↓/j/k")+" Nav",
-		m.Theme.HelpKey.Render("h/l/↵")+" Fold",
-		m.Theme.HelpKey.Render("r")+" Refresh",
-		m.Theme.HelpKey.Render("?")+" Help",
+		nav,
+		item(k(keys.Left)+" "+k(keys.Right)+" "+k(keys.Enter), "Fold"),
+		item(k(keys.Refresh), "Refresh"),
+		item(k(keys.Filter), "Filter"),
+		item(k(keys.Help), "Help"),
 	)
 
 	if m.Selection.IsProjectSelected() {
 		items = append(items,
-			m.Theme.HelpKey.Render("e")+" Edit",
-			m.Theme.HelpKey.Render("s")+" Start",
-			m.Theme.HelpKey.Render("t")+" Terminate",
-			m.Theme.HelpKey.Render("p")+" Pause/Resume",
+			item(k(keys.Edit), "Edit"),
+			item(k(keys.Start), "Start"),
+			item(k(keys.Terminate), "Terminate"),
+			item(m.pauseKeys(), "Pause/Resume"),
 		)
 	} else if m.Selection.IsSpecSelected() {
 		items = append(items,
-			m.Theme.HelpKey.Render("s")+" Start",
-			m.Theme.HelpKey.Render("t")+" Terminate",
-			m.Theme.HelpKey.Render("f")+" Flush",
-			m.Theme.HelpKey.Render("p")+" Pause/Resume",
-			m.Theme.HelpKey.Render("c")+" Conflicts",
+			item(k(keys.Start), "Start"),
+			item(k(keys.Terminate), "Terminate"),
+			item(k(keys.Flush), "Flush"),
+			item(m.pauseKeys(), "Pause/Resume"),
+			item(k(keys.Conflicts), "Conflicts"),
 		)
 	}
+	if m.SpaceMarks {
+		items = append(items, item(k(keys.Mark), "Mark"))
+	}
 
-	items = append(items, m.Theme.HelpKey.Render("q")+" Quit")
+	items = append(items, item(k(keys.Quit), "Quit"))
 
-	sep := m.Theme.HelpSep.Render(" | ")
-	return m.Theme.HelpBar.Width(m.Width - 2).Render(strings.Join(items, sep))
+	return m.section(m.Theme.HelpBar).Width(m.Width - m.borderSize()).Render(strings.Join(items, sep))
 }
 
 func (m Model) renderModal() string {
@@ -1059,42 +2601,114 @@ func (m Model) renderModal() string {
 		return m.renderConfirmPushModal()
 	case ModalConfirmPull:
 		return m.renderConfirmPullModal()
+	case ModalFirstRun:
+		return m.renderFirstRunModal()
+	case ModalConfirm:
+		return m.renderConfirmModal()
+	case ModalDaemonSessions:
+		return m.renderDaemonSessionsModal()
+	case ModalSessionLog:
+		return m.renderSessionLogModal()
+	case ModalEventLog:
+		return m.renderEventLogModal()
 	}
 	return ""
 }
 
 func (m Model) renderHelpModal() string {
+	content := m.renderScrollWindow(m.helpLines(), m.modalOffset, m.modalBodyHeight(0))
+	content += "\n"
+	if len(m.helpLines()) > m.modalBodyHeight(0) {
+		content += m.Theme.ModalHelp.Render(fmt.Sprintf("↑/↓ PgUp/PgDn scroll  %s or Esc to close", keys.Help.Help().Key))
+	} else {
+		content += m.Theme.ModalHelp.Render(fmt.Sprintf("Press %s or Esc to close", keys.Help.Help().Key))
+	}
+
+	return m.Theme.ModalBorder.Render(
+		m.Theme.ModalTitle.Render(" Mutagen TUI - Keyboard Commands ") + "\n\n" + content,
+	)
+}
+
+// helpLines returns the lines of the help dialog. The keys are the
+// bindings' own, so keys rebound in the config file are shown in place.
+func (m Model) helpLines() []string {
+	k := func(b key.Binding) string { return b.Help().Key }
+	line := func(keyText, desc string) string { return fmt.Sprintf("  %-16s%s\n", keyText, desc) }
+
 	content := m.Theme.ModalTitle.Render("NAVIGATION") + "\n"
-	content += "  ↑/k, ↓/j        Move selection up/down\n"
-	content += "  ←, l/→/↵        Fold/unfold project\n"
+	content += line(k(keys.Up)+", "+k(keys.Down), "Move selection up/down")
+	content += line(k(keys.Left)+", "+k(keys.Right)+", "+k(keys.Enter), "Fold/unfold project")
 	content += "\n"
 	content += m.Theme.ModalTitle.Render("GLOBAL ACTIONS") + "\n"
-	content += "  r               Refresh session list\n"
-	content += "  m               Toggle display mode\n"
-	content += "  q, Ctrl-C       Quit application\n"
-	content += "  ?/h             Toggle this help screen\n"
+	content += line(k(keys.Refresh), "Refresh session list")
+	content += line(k(keys.Thorough), "Check the daemon, rescan, and refresh")
+	content += line(k(keys.Daemon), "Restart the Mutagen daemon")
+	content += line(k(keys.ToggleMode), "Toggle display mode")
+	content += line(k(keys.ToggleHost), "Toggle remote host tags")
+	content += line(k(keys.ToggleNames), "Toggle spec/session names")
+	content += line(k(keys.Sort), "Sort projects by name, status, conflicts, or as found")
+	content += line(k(keys.Borderless), "Toggle borders")
+	content += line(k(keys.Density), "Toggle compact rows (less padding, fewer icons)")
+	content += line(k(keys.DaemonList), "List all daemon sessions")
+	content += line(k(keys.Forwards), "Switch between sync projects and forwards")
+	content += line(k(keys.Filter), "Filter by name or label:key=value")
+	content += line(k(keys.Problems), "Only list specs with problems")
+	content += line(k(keys.EventLog), "Event log: recent status messages and events")
+	content += line(k(keys.StartAll), "Start all projects")
+	content += line(k(keys.TermAll), "Terminate all projects (asks first)")
+	content += line(k(keys.PauseAll), "Pause/resume all sessions")
+	content += line(k(keys.Focus), "Focus: pause all but the selection (again resumes them)")
+	content += line(k(keys.Undo), "Undo last terminate/pause/resume")
+	content += line(k(keys.Quit), "Quit application")
+	content += line(k(keys.Help), "Toggle this help screen")
 	content += "\n"
 	content += m.Theme.ModalTitle.Render("PROJECT ACTIONS") + "\n"
-	content += "  e               Edit project configuration\n"
-	content += "  s               Start all specs\n"
-	content += "  t               Terminate all specs\n"
-	content += "  f               Flush all specs\n"
-	content += "  P               Create push sessions\n"
-	content += "  p/Space         Pause/resume all specs\n"
+	content += line(k(keys.Edit), "Edit project configuration")
+	content += line(k(keys.Copy), "Copy the project file path")
+	content += line(k(keys.Start), "Start all specs")
+	content += line(k(keys.Terminate), "Terminate all specs")
+	content += line(k(keys.Flush), "Flush all specs")
+	content += line(k(keys.Reconnect), "Reconnect disconnected specs")
+	content += line(k(keys.Reset), "Reset all specs (rescan from scratch)")
+	content += line(k(keys.Push), "Create push sessions")
+	content += line(m.pauseKeys(), "Pause/resume all specs")
 	content += "\n"
 	content += m.Theme.ModalTitle.Render("SPEC ACTIONS") + "\n"
-	content += "  s               Start this spec\n"
-	content += "  t               Terminate this spec\n"
-	content += "  f               Flush this spec\n"
-	content += "  P               Create push session\n"
-	content += "  p/Space         Pause/resume spec\n"
-	content += "  c               View conflicts\n"
-	content += "\n"
-	content += m.Theme.ModalHelp.Render("Press ? or Esc to close")
+	content += line(k(keys.Start), "Start this spec")
+	content += line(k(keys.Terminate), "Terminate this spec")
+	content += line(k(keys.Flush), "Flush this spec")
+	content += line(k(keys.Reconnect), "Reconnect (pause and resume) this spec")
+	content += line(k(keys.Reset), "Reset this spec (rescan from scratch)")
+	content += line(k(keys.Restart), "Restart: terminate and recreate, keeping push or two-way")
+	content += line(k(keys.Copy), "Copy the alpha and beta paths")
+	content += line(k(keys.OpenBeta), "Open beta: a shell on its host, or the file manager")
+	content += line(k(keys.Push), "Create push session")
+	content += line(m.pauseKeys(), "Pause/resume spec")
+	content += line(k(keys.Conflicts), fmt.Sprintf("View conflicts (%s shows changes, %s filters paths, %s or %s keeps one side)",
+		k(keys.Enter), k(keys.Filter), k(keys.KeepAlpha), k(keys.KeepBeta)))
+	content += line(k(keys.Log), "View session log (full status, errors, scan problems)")
+	content += line(k(keys.Schedule), "Toggle scheduled flushes (paused between)")
+	if m.SpaceMarks {
+		content += line(k(keys.Mark), "Mark spec (on a project: all its specs)")
+		content += line(k(keys.Pause), "Pause/resume marked specs, if any")
+		content += line(k(keys.Escape), "Clear marks")
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
 
-	return m.Theme.ModalBorder.Render(
-		m.Theme.ModalTitle.Render(" Mutagen TUI - Keyboard Commands ") + "\n\n" + content,
-	)
+// pauseKeys returns the keys for pause/resume in the help dialog; space
+// pauses too unless it marks specs.
+func (m Model) pauseKeys() string {
+	if !m.SpaceMarks {
+		return keys.Pause.Help().Key
+	}
+	var bound []string
+	for _, k := range keys.Pause.Keys() {
+		if k != " " && !slices.Contains(keys.Mark.Keys(), k) {
+			bound = append(bound, k)
+		}
+	}
+	return strings.Join(bound, "/")
 }
 
 func (m Model) renderConflictModal() string {
@@ -1103,47 +2717,135 @@ func (m Model) renderConflictModal() string {
 	}
 
 	conflicts := m.GetConflicts()
-	totalConflicts := 0
-	for _, sc := range conflicts {
-		totalConflicts += len(sc.Conflicts)
-	}
-
-	if totalConflicts == 0 {
+	if countConflicts(conflicts) == 0 {
 		return m.Theme.ModalBorder.Render(
 			m.Theme.ModalTitle.Render(" Conflict Details ") + "\n\n" +
 				"No conflicts found\n\n" +
-				m.Theme.ModalHelp.Render("Press Esc or 'c' to close"),
+				m.Theme.ModalHelp.Render(fmt.Sprintf("Press Esc or '%s' to close", keys.Conflicts.Help().Key)),
 		)
 	}
 
-	var content strings.Builder
-	content.WriteString(m.Theme.ConflictAlpha.Render("'b'") + " " + m.Theme.ConflictAlpha.Render("α → β") + " push (overwrites beta)\n")
-	content.WriteString(m.Theme.ConflictBeta.Render("'a'") + " " + m.Theme.ConflictBeta.Render("α ← β") + " pull (overwrites alpha)\n")
-	content.WriteString(m.Theme.ModalHelp.Render("Esc/'c' to close") + "\n\n")
+	lines, _, _ := m.conflictListLines()
+	if len(lines) == 0 {
+		lines = []string{"No conflict paths match"}
+	}
+	content := m.conflictModalHeader(conflicts) +
+		m.renderScrollWindow(lines, m.modalOffset, m.conflictListHeight())
 
-	for _, sc := range conflicts {
-		if len(sc.Conflicts) == 0 {
-			continue
+	return m.Theme.ModalBorder.Render(
+		m.Theme.ModalTitle.Render(" Conflict Details ") + "\n\n" + content,
+	)
+}
+
+// conflictModalHeader returns the lines of the conflicts dialog that stay
+// in place above the scrolling list of conflicts.
+func (m Model) conflictModalHeader(conflicts []SessionConflicts) string {
+	var content strings.Builder
+	content.WriteString(m.Theme.SessionName.Bold(true).Render(CountConflictDirections(conflicts).String()) + "\n\n")
+	content.WriteString(m.Theme.ConflictAlpha.Render("'"+keys.PushToBeta.Help().Key+"'") + " " + m.Theme.ConflictAlpha.Render("α → β") + " push (overwrites beta)\n")
+	content.WriteString(m.Theme.ConflictBeta.Render("'"+keys.PullToAlpha.Help().Key+"'") + " " + m.Theme.ConflictBeta.Render("α ← β") + " pull (overwrites alpha)\n")
+	if m.OnResolveConflict != nil {
+		content.WriteString(m.Theme.ConflictAlpha.Render("'"+keys.KeepAlpha.Help().Key+"'") + "/" + m.Theme.ConflictBeta.Render("'"+keys.KeepBeta.Help().Key+"'") + " keep α's/β's version of the ▶ file only\n")
+	}
+	content.WriteString(m.Theme.ModalHelp.Render(fmt.Sprintf("↑/↓ select  %s show changes  %s filter  PgUp/PgDn scroll  Esc/'%s' to close",
+		keys.Enter.Help().Key, keys.Filter.Help().Key, keys.Conflicts.Help().Key)) + "\n")
+	if m.editingConflictQuery || m.conflictQuery != "" {
+		query := "/" + m.conflictQuery
+		if m.editingConflictQuery {
+			query += "█"
 		}
+		content.WriteString(m.Theme.HelpKey.Render(query) + m.Theme.ModalHelp.Render(fmt.Sprintf(
+			"  %d of %d paths match", countConflicts(m.visibleConflicts()), countConflicts(conflicts))) + "\n")
+	}
+	content.WriteString("\n")
+	return content.String()
+}
+
+// conflictListHeight returns how many lines of the list of conflicts fit
+// below the conflicts dialog's header.
+func (m Model) conflictListHeight() int {
+	var conflicts []SessionConflicts
+	if m.GetConflicts != nil {
+		conflicts = m.GetConflicts()
+	}
+	return m.modalBodyHeight(strings.Count(m.conflictModalHeader(conflicts), "\n"))
+}
+
+// conflictListLines returns the lines listing each conflict in the
+// conflicts dialog, and the first and last line of the highlighted one.
+func (m Model) conflictListLines() (lines []string, cursorFirst, cursorLast int) {
+	lines, _, cursorFirst, cursorLast = m.conflictListRows()
+	return lines, cursorFirst, cursorLast
+}
+
+// conflictListRows is conflictListLines, with the index of the conflict
+// each line describes, or -1 for session names and blank lines. Each
+// conflict is a row naming its path, followed by its changes if it is
+// expanded.
+func (m Model) conflictListRows() (lines []string, owners []int, cursorFirst, cursorLast int) {
+	index := 0
+	for _, sc := range m.visibleConflicts() {
 		if sc.SpecName != "" {
-			content.WriteString(m.Theme.SessionName.Bold(true).Render(sc.SpecName) + "\n")
+			// Scrolling to a session's first conflict shows its name too
+			if m.conflictCursor == index {
+				cursorFirst = len(lines)
+			}
+			lines = append(lines, m.Theme.SessionName.Bold(true).Render(sc.SpecName))
+			owners = append(owners, -1)
 		}
-		for _, conflict := range sc.Conflicts {
-			m.appendConflictDetails(&content, conflict, sc.Session)
-			content.WriteString("\n")
+		for i, conflict := range sc.Conflicts {
+			gutter := "  "
+			if index == m.conflictCursor {
+				gutter = m.Theme.SelectedItem.Render("▶") + " "
+				if i > 0 || sc.SpecName == "" {
+					cursorFirst = len(lines)
+				}
+			}
+			expanded := m.expandedConflicts[conflictKey(sc, conflict)]
+			fold := mutagen.Icon("▸", "+")
+			if expanded {
+				fold = mutagen.Icon("▾", "-")
+			}
+			root := conflict.Root
+			if root == "" {
+				root = "."
+			}
+			lines = append(lines, gutter+fold+" "+root+"  "+m.Theme.ModalHelp.Render(
+				fmt.Sprintf("α %d / β %d changes", len(conflict.AlphaChanges), len(conflict.BetaChanges))))
+			owners = append(owners, index)
+			if expanded {
+				var details strings.Builder
+				m.appendConflictDetails(&details, conflict, sc.Session)
+				for _, line := range strings.Split(strings.TrimSuffix(details.String(), "\n"), "\n") {
+					if line != "" {
+						lines = append(lines, "    "+line)
+						owners = append(owners, index)
+					}
+				}
+			}
+			if index == m.conflictCursor {
+				cursorLast = len(lines) - 1
+			}
+			index++
 		}
-		content.WriteString("\n")
+		lines = append(lines, "")
+		owners = append(owners, -1)
 	}
+	return lines, owners, cursorFirst, cursorLast
+}
 
-	return m.Theme.ModalBorder.Render(
-		m.Theme.ModalTitle.Render(" Conflict Details ") + "\n\n" + content.String(),
-	)
+// followConflictCursor scrolls the conflicts dialog to show the
+// highlighted conflict.
+func (m *Model) followConflictCursor() {
+	lines, first, last := m.conflictListLines()
+	height := m.conflictListHeight()
+	m.modalOffset = clampOffset(followOffset(m.modalOffset, first, last, height), len(lines), height)
 }
 
 func (m Model) renderConfirmPushModal() string {
 	var content strings.Builder
 
-	content.WriteString(m.Theme.ConfirmWarning.Render("⚠ CONFIRM PUSH TO BETA") + "\n\n")
+	content.WriteString(m.Theme.ConfirmWarning.Render(mutagen.Icon("⚠", "!")+" CONFIRM PUSH TO BETA") + "\n\n")
 
 	// Get session paths if available
 	if m.GetSelectedSession != nil {
@@ -1155,7 +2857,7 @@ func (m Model) renderConfirmPushModal() string {
 
 	content.WriteString("This will " + m.Theme.ConfirmWarning.Render("OVERWRITE") + " files on beta with alpha versions.\n")
 	content.WriteString("This action cannot be undone.\n\n")
-	content.WriteString(m.Theme.ConflictAlpha.Bold(true).Render("'y'") + " Confirm  " + m.Theme.ModalHelp.Render("'n'/Esc") + " Cancel\n")
+	content.WriteString(m.Theme.ConflictAlpha.Bold(true).Render("'"+keys.ConfirmYes.Help().Key+"'") + " Confirm  " + m.Theme.ModalHelp.Render("'"+keys.ConfirmNo.Help().Key+"'/Esc") + " Cancel\n")
 
 	return m.Theme.ConfirmPushBorder.Render(content.String())
 }
@@ -1163,7 +2865,7 @@ func (m Model) renderConfirmPushModal() string {
 func (m Model) renderConfirmPullModal() string {
 	var content strings.Builder
 
-	content.WriteString(m.Theme.ConfirmWarning.Render("⚠ CONFIRM PULL TO ALPHA") + "\n\n")
+	content.WriteString(m.Theme.ConfirmWarning.Render(mutagen.Icon("⚠", "!")+" CONFIRM PULL TO ALPHA") + "\n\n")
 
 	// Get session paths if available
 	if m.GetSelectedSession != nil {
@@ -1175,11 +2877,25 @@ func (m Model) renderConfirmPullModal() string {
 
 	content.WriteString("This will " + m.Theme.ConfirmWarning.Render("OVERWRITE") + " files on alpha with beta versions.\n")
 	content.WriteString("This action cannot be undone.\n\n")
-	content.WriteString(m.Theme.ConflictBeta.Bold(true).Render("'y'") + " Confirm  " + m.Theme.ModalHelp.Render("'n'/Esc") + " Cancel\n")
+	content.WriteString(m.Theme.ConflictBeta.Bold(true).Render("'"+keys.ConfirmYes.Help().Key+"'") + " Confirm  " + m.Theme.ModalHelp.Render("'"+keys.ConfirmNo.Help().Key+"'/Esc") + " Cancel\n")
 
 	return m.Theme.ConfirmPullBorder.Render(content.String())
 }
 
+func (m Model) renderFirstRunModal() string {
+	var content strings.Builder
+
+	content.WriteString(m.Theme.ModalTitle.Render(" Welcome to mutagui ") + "\n\n")
+	content.WriteString("No config file was found. Write a starter config to\n")
+	content.WriteString(m.Theme.ModalHelp.Render(m.ConfigPath) + "?\n\n")
+	content.WriteString("It lists every setting with a comment, using your\n")
+	content.WriteString("current theme and any common project directories.\n\n")
+	content.WriteString("Skip it, and you won't be asked again.\n\n")
+	content.WriteString(m.Theme.ModalTitle.Render("'"+keys.ConfirmYes.Help().Key+"'") + " Write config  " + m.Theme.ModalHelp.Render("'"+keys.ConfirmNo.Help().Key+"'/Esc") + " Skip\n")
+
+	return m.Theme.ModalBorder.Render(content.String())
+}
+
 func (m Model) appendConflictDetails(sb *strings.Builder, conflict mutagen.Conflict, session *mutagen.SyncSession) {
 	if session != nil {
 		alphaPath := session.AlphaDisplay()
@@ -1199,45 +2915,127 @@ func (m Model) appendConflictDetails(sb *strings.Builder, conflict mutagen.Confl
 			m.Theme.ConflictAlpha.Render(alphaPath) + "\n")
 		sb.WriteString(m.Theme.ConflictBeta.Bold(true).Render("Beta (β):  ") +
 			m.Theme.ConflictBeta.Render(betaPath) + "\n")
-	} else {
-		sb.WriteString(m.Theme.SessionName.Bold(true).Render("Root: ") +
-			m.Theme.ConflictAlpha.Render(conflict.Root) + "\n")
 	}
 
-	sb.WriteString(fmt.Sprintf("  α %d / β %d changes\n",
-		len(conflict.AlphaChanges), len(conflict.BetaChanges)))
-
 	if len(conflict.AlphaChanges) > 0 {
-		sb.WriteString(m.Theme.ConflictAlpha.Bold(true).Render("  α ") +
+		sb.WriteString(m.Theme.ConflictAlpha.Bold(true).Render("α ") +
 			summarizeChanges(conflict.AlphaChanges) + "\n")
 	}
 	if len(conflict.BetaChanges) > 0 {
-		sb.WriteString(m.Theme.ConflictBeta.Bold(true).Render("  β ") +
+		sb.WriteString(m.Theme.ConflictBeta.Bold(true).Render("β ") +
 			summarizeChanges(conflict.BetaChanges) + "\n")
 	}
 }
 
+// renderProjectWarnings lists the parts of a project file that were skipped
+// or look wrong.
+func (m Model) renderProjectWarnings(proj *project.Project) string {
+	var content strings.Builder
+	content.WriteString(m.Theme.HelpKey.Render("File: ") + applyTilde(proj.File.Path) + "\n\n")
+	content.WriteString("These parts of the file were skipped or look like mistakes:\n")
+	for _, warning := range proj.File.Warnings {
+		content.WriteString(m.Theme.StatusWarning.Render("  "+mutagen.Icon("⚠", "!")+" "+warning) + "\n")
+	}
+	content.WriteString("\n" + m.Theme.ModalHelp.Render(fmt.Sprintf("Press %s to edit the file, Esc or '%s' to close", keys.Edit.Help().Key, keys.SyncStatus.Help().Key)))
+
+	return m.Theme.ModalBorder.Render(
+		m.Theme.ModalTitle.Render(" Config Issues ") + "\n\n" + content.String(),
+	)
+}
+
 func (m Model) renderSyncStatusModal() string {
+	if m.Selection.IsProjectSelected() {
+		if projIdx := m.Selection.SelectedProjectIndex(); projIdx < len(m.Projects) && m.Projects[projIdx].HasWarnings() {
+			return m.renderProjectWarnings(m.Projects[projIdx])
+		}
+	}
 	if m.GetSelectedSession == nil {
 		return m.Theme.ModalBorder.Render("No session selected")
 	}
 
+	swapped := ""
+	if projIdx, specIdx := m.Selection.SelectedSpec(); projIdx >= 0 && projIdx < len(m.Projects) && specIdx >= 0 {
+		if spec := &m.Projects[projIdx].Specs[specIdx]; m.Projects[projIdx].File.LooksSwapped(spec.Name) {
+			swapped = m.swapWarning(spec.Name)
+		}
+	}
+
 	session := m.GetSelectedSession()
 	if session == nil {
+		text := "No session selected or session not running\n\n"
+		if swapped != "" {
+			text = "Session not running\n\n" + swapped + "\n"
+		}
 		return m.Theme.ModalBorder.Render(
 			m.Theme.ModalTitle.Render(" Sync Status ") + "\n\n" +
-				"No session selected or session not running\n\n" +
-				m.Theme.ModalHelp.Render("Press Esc or 'i' to close"),
+				text +
+				m.Theme.ModalHelp.Render(fmt.Sprintf("Press Esc or '%s' to close", keys.SyncStatus.Help().Key)),
 		)
 	}
 
 	var content strings.Builder
+	if spec := m.selectedSpec(); spec != nil {
+		content.WriteString(m.Theme.HelpKey.Render("Spec: ") + spec.Name + "\n")
+	}
 	content.WriteString(m.Theme.HelpKey.Render("Session: ") + session.Name + "\n")
-	content.WriteString(m.Theme.HelpKey.Render("Status: ") + session.StatusIcon() + " " + session.Status + "\n")
-	if session.Mode != nil {
-		content.WriteString(m.Theme.HelpKey.Render("Mode: ") + *session.Mode + "\n")
+	if session.Identifier != "" {
+		content.WriteString(m.Theme.HelpKey.Render("Identifier: ") + session.Identifier + "\n")
+	}
+	if started := m.sessionStarted(session); started != "" {
+		content.WriteString(m.Theme.HelpKey.Render("Created: ") + started + "\n")
+	}
+	if session.IsManaged() {
+		content.WriteString(m.Theme.HelpKey.Render("Origin: ") + "● created by mutagui (" + session.GetLabel(mutagen.ProjectLabel) + ")\n")
+	} else {
+		content.WriteString(m.Theme.HelpKey.Render("Origin: ") + mutagen.Icon("○", "o") + " created outside mutagui\n")
+		content.WriteString(m.Theme.StatusWarning.Render(
+			mutagen.Icon("⚠", "!")+" Terminating and restarting recreates it from the project file,\n  whose settings may differ from how it was created.") + "\n")
+	}
+	content.WriteString(swapped)
+	content.WriteString(m.Theme.HelpKey.Render("Status: ") + session.StatusIcon() + " " + session.Status + m.transferReadout(session) + "\n")
+	if problems := session.Problems(); len(problems) > 0 {
+		label := "Problems:"
+		if session.IsHalted() {
+			label = "Halted because:"
+		}
+		content.WriteString(m.Theme.StatusError.Render(label) + "\n")
+		for i, problem := range problems {
+			if i == maxScanProblemsShown {
+				content.WriteString(fmt.Sprintf("  +%d more\n", len(problems)-maxScanProblemsShown))
+				break
+			}
+			content.WriteString("  " + problem + "\n")
+		}
 	}
-	content.WriteString(m.Theme.HelpKey.Render("Paused: ") + fmt.Sprintf("%v", session.Paused) + "\n\n")
+	content.WriteString(m.Theme.HelpKey.Render("Paused: ") + fmt.Sprintf("%v", session.Paused) + "\n")
+	if spec := m.selectedSpec(); spec != nil && spec.Scheduled {
+		content.WriteString(m.Theme.HelpKey.Render("Sync: ") + "⏱ paused between scheduled flushes (C for continuous)\n")
+	}
+	if session.CreatingVersion != "" {
+		content.WriteString(m.Theme.HelpKey.Render("Created by: ") + "mutagen " + session.CreatingVersion)
+		if session.Version != 0 {
+			content.WriteString(fmt.Sprintf(" (session format %d)", session.Version))
+		}
+		content.WriteString("\n")
+		if session.CreatedByDifferentVersion(m.MutagenVersion) {
+			content.WriteString(m.Theme.StatusWarning.Render(fmt.Sprintf(
+				"%s Installed mutagen is %s; sessions from other releases often fail to reach their agents.\n  Terminate and start the session to recreate it.",
+				mutagen.Icon("⚠", "!"), m.MutagenVersion)) + "\n")
+		}
+	}
+	if len(session.Labels) > 0 {
+		labelKeys := make([]string, 0, len(session.Labels))
+		for k := range session.Labels {
+			labelKeys = append(labelKeys, k)
+		}
+		sort.Strings(labelKeys)
+		content.WriteString(m.Theme.HelpKey.Render("Labels:") + "\n")
+		for _, k := range labelKeys {
+			content.WriteString("  " + k + "=" + session.Labels[k] + "\n")
+		}
+	}
+	content.WriteString(m.renderSessionConfig(session))
+	content.WriteString("\n")
 
 	// Alpha endpoint
 	content.WriteString(m.Theme.ConflictAlpha.Bold(true).Render("Alpha (α):") + "\n")
@@ -1252,18 +3050,73 @@ func (m Model) renderSyncStatusModal() string {
 		content.WriteString(m.Theme.StatusError.Bold(true).Render(fmt.Sprintf("\nConflicts: %d\n", session.ConflictCount())))
 	}
 
+	if !session.Compression.IsEmpty() {
+		content.WriteString(m.Theme.HelpKey.Render("\nCompression: ") + formatCompression(session.Compression) + "\n")
+	}
+
 	// Successful cycles
 	if session.SuccessfulCycles != nil {
 		content.WriteString(m.Theme.HelpKey.Render(fmt.Sprintf("\nSuccessful Cycles: %d\n", *session.SuccessfulCycles)))
+		if m.CycleHistory != nil {
+			if history := m.CycleHistory(); len(history) > 0 {
+				content.WriteString(m.Theme.HelpKey.Render("Activity: ") + sparkline(history) + " " +
+					m.Theme.ModalHelp.Render("(cycles per 10s, newest last)") + "\n")
+			}
+		}
+	}
+
+	// Settings from the project file, and whether each is the spec's own
+	if m.DescribeFileSettings != nil {
+		if lines := m.DescribeFileSettings(); len(lines) > 0 {
+			content.WriteString("\n" + m.Theme.HelpKey.Render("From the project file:") + "\n")
+			for _, line := range lines {
+				content.WriteString("  " + line + "\n")
+			}
+		}
 	}
 
-	content.WriteString("\n" + m.Theme.ModalHelp.Render("Press Esc or 'i' to close"))
+	// Ignore patterns that match nothing
+	if lines := m.ignoresLines(); len(lines) > 0 {
+		content.WriteString("\n" + m.Theme.HelpKey.Render("Ignores:") + "\n")
+		for _, line := range lines {
+			content.WriteString("  " + line + "\n")
+		}
+	}
+
+	content.WriteString("\n" + m.Theme.ModalHelp.Render(fmt.Sprintf(
+		"Press %s to copy the identifier, Esc or '%s' to close", keys.Copy.Help().Key, keys.SyncStatus.Help().Key)))
 
 	return m.Theme.ModalBorder.Render(
 		m.Theme.ModalTitle.Render(" Sync Status ") + "\n\n" + content.String(),
 	)
 }
 
+// renderSessionConfig lists the configuration the session is running with,
+// and beside each setting that differs, the value its project file gives.
+func (m Model) renderSessionConfig(session *mutagen.SyncSession) string {
+	// The file's value of each setting it can give
+	file := make(map[string]string)
+	header := "Running config:"
+	if m.GetFileConfig != nil {
+		if config, ok := m.GetFileConfig(); ok {
+			for _, setting := range config.FileSettings() {
+				file[setting.Name] = setting.Value
+			}
+			header = "Running config (configured in file, where it differs):"
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString(m.Theme.HelpKey.Render(header) + "\n")
+	for _, setting := range session.Config().Settings() {
+		sb.WriteString("  " + setting.Name + ": " + setting.Value)
+		if value, ok := file[setting.Name]; ok && value != setting.Value {
+			sb.WriteString(m.Theme.StatusWarning.Render(" ≠ file: " + value))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
 func (m Model) formatEndpointDetails(e *mutagen.Endpoint) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("  %s %s\n", e.StatusIcon(), e.DisplayPath()))
@@ -1283,8 +3136,28 @@ func (m Model) formatEndpointDetails(e *mutagen.Endpoint) string {
 		sb.WriteString(counts + "\n")
 	}
 
-	if e.TotalFileSize != nil {
+	free := m.freeSpaceReadout(e)
+	switch {
+	case e.TotalFileSize != nil && free != "":
+		sb.WriteString(fmt.Sprintf("  Total Size: %s  %s\n", formatBytes(*e.TotalFileSize), free))
+	case e.TotalFileSize != nil:
 		sb.WriteString(fmt.Sprintf("  Total Size: %s\n", formatBytes(*e.TotalFileSize)))
+	case free != "":
+		sb.WriteString("  " + free + "\n")
+	}
+	if !e.Compression.IsEmpty() {
+		sb.WriteString("  Compression: " + formatCompression(e.Compression) + "\n")
+	}
+
+	if e.HasScanProblems() {
+		sb.WriteString(m.Theme.StatusWarning.Render(fmt.Sprintf("  %s Scan problems: %d", mutagen.Icon("⚠", "!"), len(e.ScanProblems))) + "\n")
+		for i, problem := range e.ScanProblems {
+			if i == maxScanProblemsShown {
+				sb.WriteString(fmt.Sprintf("    +%d more\n", len(e.ScanProblems)-maxScanProblemsShown))
+				break
+			}
+			sb.WriteString(fmt.Sprintf("    %s: %s\n", problem.Path, problem.Error))
+		}
 	}
 
 	sb.WriteString("\n")
@@ -1337,6 +3210,10 @@ func (m Model) overlayModal(base, modal string) string {
 }
 
 // Helper functions
+
+// applyTilde shortens the path of an endpoint for display with the path
+// abbreviations, which include the home directory, or else replaces
+// another user's home directory with ~user.
 func applyTilde(endpoint string) string {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -1354,6 +3231,9 @@ func applyTilde(endpoint string) string {
 }
 
 func applyTildeToPath(path string, home string) string {
+	if abbreviated, ok := mutagen.AbbreviatePath(path); ok {
+		return abbreviated
+	}
 	if home != "" && strings.HasPrefix(path, home) {
 		return "~" + path[len(home):]
 	}
@@ -1399,6 +3279,52 @@ func summarizeChanges(changes []mutagen.Change) string {
 	return strings.Join(parts, ", ")
 }
 
+// transferReadout returns the session's staging speed and ETA to append to
+// its status, or "" if it isn't staging or hasn't been measured yet.
+func (m Model) transferReadout(session *mutagen.SyncSession) string {
+	if m.TransferRate == nil || !session.IsStaging() {
+		return ""
+	}
+	rate, ok := m.TransferRate(session)
+	if !ok {
+		return ""
+	}
+	return formatTransferRate(rate)
+}
+
+// maxScanProblemsShown caps the scan problems listed per endpoint in the
+// sync status dialog.
+const maxScanProblemsShown = 10
+
+// maxHaltReasonWidth caps the reason shown after a halted session's status
+// in spec rows; the sync status dialog shows it in full.
+const maxHaltReasonWidth = 50
+
+// scanProblemMark returns a marker for an endpoint with scan problems, to
+// follow its status icon in spec rows.
+func scanProblemMark(e *mutagen.Endpoint) string {
+	if e.HasScanProblems() {
+		return mutagen.Icon("⚠", "!")
+	}
+	return ""
+}
+
+// formatCompression describes compression as in "zstandard, 3.1x, 1.2 GB on
+// wire", leaving out whatever mutagen didn't report.
+func formatCompression(c *mutagen.Compression) string {
+	var parts []string
+	if c.Algorithm != "" {
+		parts = append(parts, c.Algorithm)
+	}
+	if ratio, ok := c.Ratio(); ok {
+		parts = append(parts, fmt.Sprintf("%.1fx", ratio))
+	}
+	if c.BytesOnWire != nil {
+		parts = append(parts, formatBytes(*c.BytesOnWire)+" on wire")
+	}
+	return strings.Join(parts, ", ")
+}
+
 func formatBytes(b uint64) string {
 	const unit = 1024
 	if b < unit {
@@ -1419,6 +3345,174 @@ func min(a, b int) int {
 	return b
 }
 
+// statusColumnWidth is the width status text is padded to in spec rows,
+// so that the text following it lines up.
+const statusColumnWidth = 24
+
+// hostColumnWidth is the width of the host tag column shown after spec
+// names when ShowHost is on.
+const hostColumnWidth = 14
+
+// hostTag returns a short tag such as "@studio" naming a remote host, or ""
+// for a local endpoint. The user name and domain are dropped, except from
+// IP addresses.
+func hostTag(host string) string {
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	if host == "" {
+		return ""
+	}
+	if c := host[0]; !(c >= '0' && c <= '9') && c != '[' {
+		if i := strings.Index(host, "."); i > 0 {
+			host = host[:i]
+		}
+	}
+	return "@" + host
+}
+
+// specHostTag returns the host tag for a spec's beta endpoint, taken from
+// the running session if there is one and from the project file otherwise.
+func specHostTag(proj *project.Project, spec *project.SyncSpec) string {
+	if spec.RunningSession != nil {
+		if spec.RunningSession.Beta.Host == nil {
+			return ""
+		}
+		return hostTag(*spec.RunningSession.Beta.Host)
+	}
+	def, ok := proj.File.Sessions[spec.Name]
+	if !ok {
+		return ""
+	}
+	if e := project.ParseEndpoint(def.Beta); e.IsSSH() {
+		return hostTag(e.Host)
+	}
+	return ""
+}
+
+// hostColumn returns the padded host tag column for a spec row, or "" when
+// host tags are hidden.
+func (m Model) hostColumn(proj *project.Project, spec *project.SyncSpec, selected bool) string {
+	if !m.ShowHost {
+		return ""
+	}
+	tag := truncateString(specHostTag(proj, spec), hostColumnWidth-1) + " "
+	if !m.Compact {
+		tag = padRight(tag, hostColumnWidth)
+	}
+	if selected {
+		return tag
+	}
+	return m.Theme.SessionBeta.Render(tag)
+}
+
+// badgeColumnWidth is the width reserved at the right edge of a row that
+// carries a badge.
+const badgeColumnWidth = 16
+
+// conflictBadgeText returns e.g. "1 conflict" or "3 conflicts".
+func conflictBadgeText(count int) string {
+	if count == 1 {
+		return "1 conflict"
+	}
+	return fmt.Sprintf("%d conflicts", count)
+}
+
+// padRight pads s with spaces to the given display width.
+// Widths are measured with lipgloss.Width, so styled text and wide glyphs are handled.
+func padRight(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// withBadge lays out a row with a badge right-aligned at maxWidth, so
+// badges form a column across rows. The body is truncated if it would run
+// into the badge column.
+func withBadge(body, badge string, maxWidth int) string {
+	if badge == "" {
+		return truncateLine(body, maxWidth)
+	}
+	badgeWidth := lipgloss.Width(badge)
+	column := badgeColumnWidth
+	if badgeWidth+1 > column {
+		column = badgeWidth + 1
+	}
+	body = truncateLine(strings.TrimRight(body, " "), maxWidth-column)
+	return padRight(body, maxWidth-badgeWidth) + badge
+}
+
+// fitEndpoints shortens the alpha and beta paths to fit together in width
+// columns. A path short enough to fit in half the width is kept whole and
+// the other gets the rest. Paths are shortened in the middle unless
+// TruncatePathEnds is set, in which case the row is cut at its end instead.
+func (m Model) fitEndpoints(alpha, beta string, width int) (string, string) {
+	alphaWidth, betaWidth := lipgloss.Width(alpha), lipgloss.Width(beta)
+	if m.TruncatePathEnds || alphaWidth+betaWidth <= width {
+		return alpha, beta
+	}
+	half := width / 2
+	switch {
+	case alphaWidth <= half:
+		return alpha, abbreviatePath(beta, width-alphaWidth)
+	case betaWidth <= width-half:
+		return abbreviatePath(alpha, width-betaWidth), beta
+	}
+	// Both are long: beta gets whatever alpha's abbreviation leaves
+	alpha = abbreviatePath(alpha, half)
+	return alpha, abbreviatePath(beta, width-lipgloss.Width(alpha))
+}
+
+// minAbbreviatedPath is the narrowest width abbreviatePath shortens to.
+const minAbbreviatedPath = 8
+
+// abbreviatePath shortens a path to maxWidth columns by replacing the
+// directories in its middle with "…", keeping its root and as many trailing
+// components as fit, e.g. "/Users/…/research/src". A path whose last
+// component doesn't fit is cut in the middle of its characters.
+func abbreviatePath(path string, maxWidth int) string {
+	maxWidth = max(maxWidth, minAbbreviatedPath)
+	if lipgloss.Width(path) <= maxWidth {
+		return path
+	}
+
+	// The head is the root and the first component after it, such as
+	// "/Users", "~", "~alice", "host:/srv", or "docker://web"
+	rootEnd := 0
+	if i := strings.Index(path, "://"); i >= 0 {
+		rootEnd = i + len("://")
+	} else if i := strings.Index(path, ":"); i >= 0 && !strings.Contains(path[:i], "/") {
+		rootEnd = i + 1
+	}
+	if strings.HasPrefix(path[rootEnd:], "/") {
+		rootEnd++
+	}
+	head, rest := path, ""
+	if i := strings.Index(path[rootEnd:], "/"); i >= 0 {
+		head, rest = path[:rootEnd+i], path[rootEnd+i+1:]
+	}
+	if rest != "" {
+		components := strings.Split(rest, "/")
+		best := ""
+		for k := 1; k < len(components); k++ {
+			candidate := head + "/…/" + strings.Join(components[len(components)-k:], "/")
+			if lipgloss.Width(candidate) > maxWidth {
+				break
+			}
+			best = candidate
+		}
+		if best != "" {
+			return best
+		}
+	}
+
+	runes := []rune(path)
+	keep := maxWidth - 1
+	front := keep / 2
+	return string(runes[:front]) + "…" + string(runes[len(runes)-(keep-front):])
+}
+
 // truncateString truncates a string to maxLen characters, adding ... if needed.
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -0,0 +1,1496 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// newTestModel creates a model with the given projects and selection rebuilt.
+func newTestModel(projects ...*project.Project) Model {
+	m := NewModel(LightTheme())
+	m.Projects = projects
+	m.Selection.RebuildFromProjects(projects)
+	return m
+}
+
+func keyPress(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestHandleKeyPress_IgnoresRepeatedActionOnSameTarget(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 2, true))
+	m.OnStart = func(ctx context.Context) *StatusMessage { return nil }
+
+	updated, cmd := m.handleKeyPress(keyPress("s"))
+	m = updated.(Model)
+	if cmd == nil || !m.IsLoading {
+		t.Fatal("first press should start the operation")
+	}
+
+	updated, _ = m.handleKeyPress(keyPress("s"))
+	m = updated.(Model)
+	if m.StatusMessage == nil || m.StatusMessage.Text != "Operation in progress" {
+		t.Errorf("StatusMessage = %v, want operation in progress", m.StatusMessage)
+	}
+}
+
+func TestThoroughRefresh_IgnoredWhileOperationRuns(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 2, true))
+	m.OnStart = func(ctx context.Context) *StatusMessage { return nil }
+	m.OnThoroughRefresh = func(ctx context.Context) *StatusMessage { return nil }
+
+	updated, _ := m.handleKeyPress(keyPress("s"))
+	m = updated.(Model)
+	updated, _ = m.handleKeyPress(keyPress("R"))
+	m = updated.(Model)
+	if m.LoadingText != "Starting..." {
+		t.Errorf("R during a start ran anyway (loading %q)", m.LoadingText)
+	}
+	if m.StatusMessage == nil || m.StatusMessage.Text != "Operation in progress" {
+		t.Errorf("StatusMessage = %v, want operation in progress", m.StatusMessage)
+	}
+}
+
+func TestHandleKeyPress_AllowsActionOnDifferentTarget(t *testing.T) {
+	m := newTestModel(makeTestProject("a", 1, true), makeTestProject("b", 1, true))
+	m.OnStart = func(ctx context.Context) *StatusMessage { return nil }
+
+	updated, _ := m.handleKeyPress(keyPress("s"))
+	m = updated.(Model)
+	m.Selection.SelectNext()
+
+	updated, _ = m.handleKeyPress(keyPress("s"))
+	m = updated.(Model)
+	if m.StatusMessage != nil {
+		t.Errorf("StatusMessage = %v, want none for a different target", m.StatusMessage)
+	}
+}
+
+func TestOperationDone_ClearsTarget(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, true))
+	m.OnStart = func(ctx context.Context) *StatusMessage { return nil }
+
+	updated, cmd := m.handleKeyPress(keyPress("s"))
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if m.IsLoading {
+		t.Error("the start's own done message should end it")
+	}
+
+	updated, _ = m.handleKeyPress(keyPress("s"))
+	m = updated.(Model)
+	if m.StatusMessage != nil {
+		t.Errorf("StatusMessage = %v, want none after completion", m.StatusMessage)
+	}
+}
+
+func TestOperationGuard_OutlastsRefreshes(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, true))
+	m.OnStart = func(ctx context.Context) *StatusMessage { return nil }
+
+	m = press(m, "s")
+	// An auto-refresh finishes while the start is still running
+	updated, _ := m.Update(RefreshDoneMsg{})
+	m = updated.(Model)
+	if !m.IsLoading || m.LoadingText != "Starting..." {
+		t.Errorf("loading %v %q, want the start still shown", m.IsLoading, m.LoadingText)
+	}
+	m = press(m, "s")
+	if m.StatusMessage == nil || m.StatusMessage.Text != "Operation in progress" {
+		t.Errorf("StatusMessage = %v, want operation in progress", m.StatusMessage)
+	}
+}
+
+func TestOperationGuard_EndsOnlyItsOwnTarget(t *testing.T) {
+	m := newTestModel(makeTestProject("a", 1, true), makeTestProject("b", 1, true))
+	m.OnStart = func(ctx context.Context) *StatusMessage { return nil }
+
+	updated, first := m.handleKeyPress(keyPress("s"))
+	m = updated.(Model)
+	m.Selection.SelectNext()
+	m = press(m, "s")
+
+	// The first project's start ends; the second's is still running
+	updated, _ = m.Update(first())
+	m = updated.(Model)
+	if !m.IsLoading {
+		t.Error("the second start should still be in progress")
+	}
+	m = press(m, "s")
+	if m.StatusMessage == nil || m.StatusMessage.Text != "Operation in progress" {
+		t.Errorf("StatusMessage = %v, want operation in progress", m.StatusMessage)
+	}
+}
+
+func TestOperationGuard_ProjectCoversItsSpecs(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 2, false))
+	m.OnStart = func(ctx context.Context) *StatusMessage { return nil }
+
+	m = press(m, "s") // The project
+	m.Selection.SelectNext()
+	m = press(m, "s") // One of its specs
+	if m.StatusMessage == nil || m.StatusMessage.Text != "Operation in progress" {
+		t.Errorf("StatusMessage = %v, want the project's start to cover its spec", m.StatusMessage)
+	}
+}
+
+func TestFirstRunModal_WritesConfig(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.ActiveModal = ModalFirstRun
+	m.ConfigPath = "/tmp/config.toml"
+	written := false
+	m.OnWriteConfig = func() error {
+		written = true
+		return nil
+	}
+
+	updated, _ := m.Update(keyPress("y"))
+	m = updated.(Model)
+
+	if !written {
+		t.Error("OnWriteConfig was not called")
+	}
+	if m.ActiveModal != ModalNone {
+		t.Errorf("ActiveModal = %v, want ModalNone", m.ActiveModal)
+	}
+	if m.StatusMessage == nil || m.StatusMessage.Type != StatusInfo {
+		t.Errorf("StatusMessage = %+v, want info", m.StatusMessage)
+	}
+}
+
+func TestFirstRunModal_Skip(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.ActiveModal = ModalFirstRun
+	m.OnWriteConfig = func() error {
+		t.Error("OnWriteConfig should not be called when skipping")
+		return nil
+	}
+	declined := false
+	m.OnDeclineConfig = func() { declined = true }
+
+	updated, _ := m.Update(keyPress("n"))
+	m = updated.(Model)
+
+	if m.ActiveModal != ModalNone {
+		t.Errorf("ActiveModal = %v, want ModalNone", m.ActiveModal)
+	}
+	if !declined {
+		t.Error("skipping should remember the decline")
+	}
+}
+
+func TestHandleKeyPress_FoldFromSpecSelectsParent(t *testing.T) {
+	projects := []*project.Project{
+		makeTestProject("a", 2, false),
+		makeTestProject("b", 2, false),
+	}
+	m := newTestModel(projects...)
+	m.OnToggleFold = func(projIdx int) {
+		projects[projIdx].Folded = !projects[projIdx].Folded
+	}
+	// Select the last spec of project a; after folding, clamping alone
+	// would leave the selection on one of project b's rows.
+	m.Selection.SetIndex(2)
+
+	updated, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyLeft})
+	m = updated.(Model)
+
+	item := m.Selection.SelectedItem()
+	if item == nil || item.Type != SelectableProject || item.ProjectIndex != 0 {
+		t.Errorf("selected item = %+v, want project a header", item)
+	}
+}
+
+func TestHandleKeyPress_FilterTyping(t *testing.T) {
+	m := newTestModel(makeTestProject("alpha", 1, true), makeTestProject("beta", 1, true))
+
+	for _, k := range []string{"/", "b", "e"} {
+		updated, _ := m.handleKeyPress(keyPress(k))
+		m = updated.(Model)
+	}
+	if m.Selection.TotalItems() != 2 {
+		t.Errorf("TotalItems() while filtering = %d, want 2 (beta and its spec)", m.Selection.TotalItems())
+	}
+
+	updated, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.editingFilter || m.Selection.Filter().Query != "be" {
+		t.Errorf("after Enter: editing = %v, query = %q", m.editingFilter, m.Selection.Filter().Query)
+	}
+
+	updated, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if !m.Selection.Filter().IsEmpty() || m.Selection.TotalItems() != 2 {
+		t.Errorf("Esc should clear the filter, got %d items", m.Selection.TotalItems())
+	}
+}
+
+func TestRenderSyncStatusModal_FlagsVersionMismatch(t *testing.T) {
+	m := newTestModel()
+	m.MutagenVersion = "0.18.1"
+	session := &mutagen.SyncSession{Name: "s", CreatingVersion: "0.17.2", Version: 1}
+	m.GetSelectedSession = func() *mutagen.SyncSession { return session }
+
+	if out := m.renderSyncStatusModal(); !strings.Contains(out, "Installed mutagen is 0.18.1") {
+		t.Errorf("modal should warn about version mismatch:\n%s", out)
+	}
+
+	session.CreatingVersion = "0.18.0"
+	if out := m.renderSyncStatusModal(); strings.Contains(out, "Installed mutagen is") {
+		t.Errorf("modal should not warn for a patch difference:\n%s", out)
+	}
+}
+
+func TestRenderSyncStatusModal_ShowsOrigin(t *testing.T) {
+	m := newTestModel()
+	session := &mutagen.SyncSession{Name: "s"}
+	m.GetSelectedSession = func() *mutagen.SyncSession { return session }
+
+	if out := m.renderSyncStatusModal(); !strings.Contains(out, "created outside mutagui") || !strings.Contains(out, "recreates it from the project file") {
+		t.Errorf("modal should flag external session:\n%s", out)
+	}
+
+	session.Labels = map[string]string{mutagen.ProjectLabel: "apollo"}
+	out := m.renderSyncStatusModal()
+	if !strings.Contains(out, "created by mutagui (apollo)") || strings.Contains(out, "recreates it") {
+		t.Errorf("modal should show managed session without warning:\n%s", out)
+	}
+}
+
+func TestSessionPolling_StopsWhenModalCloses(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	polls := 0
+	m.OnPollSession = func(ctx context.Context) error {
+		polls++
+		return nil
+	}
+
+	updated, cmd := m.handleKeyPress(keyPress("i"))
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("opening sync status should schedule a poll")
+	}
+
+	// A due poll runs while the dialog is open and reschedules itself
+	updated, cmd = m.Update(SessionPollMsg{gen: m.pollGen})
+	m = updated.(Model)
+	msg := cmd()
+	if polls != 1 {
+		t.Fatalf("polls = %d, want 1", polls)
+	}
+	if _, next := m.Update(msg); next == nil {
+		t.Error("poll should reschedule while the dialog is open")
+	}
+
+	// After closing, due polls are dropped
+	updated, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if _, cmd = m.Update(SessionPollMsg{gen: m.pollGen}); cmd != nil {
+		t.Error("poll should not run after the dialog closes")
+	}
+}
+
+func TestSessionMonitor_StreamsUntilModalCloses(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	updates := make(chan mutagen.SyncSession, 1)
+	var monitorCtx context.Context
+	m.OnMonitorSession = func(ctx context.Context) (<-chan mutagen.SyncSession, error) {
+		monitorCtx = ctx
+		return updates, nil
+	}
+	var applied []string
+	m.OnSessionUpdate = func(session mutagen.SyncSession) {
+		applied = append(applied, session.Status)
+	}
+
+	updated, cmd := m.Update(keyPress("i"))
+	m = updated.(Model)
+	if monitorCtx == nil || cmd == nil {
+		t.Fatal("opening sync status should start monitoring")
+	}
+
+	// Each update is applied and the next one awaited
+	updates <- mutagen.SyncSession{Name: "spec-a", Status: "watching"}
+	updated, cmd = m.Update(cmd())
+	m = updated.(Model)
+	if len(applied) != 1 || applied[0] != "watching" {
+		t.Errorf("applied = %v, want [watching]", applied)
+	}
+	if cmd == nil {
+		t.Error("an update should wait for the next one")
+	}
+
+	// Closing the dialog cancels the stream
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if monitorCtx.Err() == nil {
+		t.Error("closing the dialog should stop monitoring")
+	}
+	if m.stopMonitor != nil {
+		t.Error("stopMonitor should be cleared")
+	}
+}
+
+func TestSessionMonitor_FallsBackToPolling(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.OnPollSession = func(ctx context.Context) error { return nil }
+	m.OnMonitorSession = func(ctx context.Context) (<-chan mutagen.SyncSession, error) {
+		return nil, errors.New("can't stream")
+	}
+
+	updated, cmd := m.Update(keyPress("i"))
+	m = updated.(Model)
+	if _, ok := cmd().(SessionPollMsg); !ok {
+		t.Error("should poll when monitoring fails")
+	}
+
+	// A stream that ends also falls back to polling
+	_, cmd = m.Update(SessionUpdateMsg{gen: m.pollGen})
+	if cmd == nil {
+		t.Fatal("an ended stream should schedule a poll")
+	}
+	if _, ok := cmd().(SessionPollMsg); !ok {
+		t.Error("an ended stream should fall back to polling")
+	}
+}
+
+func TestPushKey_ConfirmsDirection(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.Selection.SetIndex(1)
+	pushed := false
+	m.OnPush = func(ctx context.Context) *StatusMessage {
+		pushed = true
+		return nil
+	}
+	m.ConfirmCreatePush = true
+	m.DescribePush = func() []string {
+		return []string{"spec-a: copying FROM /local TO server:/remote, overwriting server:/remote"}
+	}
+
+	updated, _ := m.handleKeyPress(keyPress("P"))
+	m = updated.(Model)
+	if m.ActiveModal != ModalConfirm {
+		t.Fatalf("ActiveModal = %v, want ModalConfirm", m.ActiveModal)
+	}
+	if out := m.renderConfirmModal(); !strings.Contains(out, "copying FROM /local TO server:/remote") {
+		t.Errorf("confirmation should describe the direction:\n%s", out)
+	}
+
+	updated, cmd := m.handleKeyPress(keyPress("y"))
+	m = updated.(Model)
+	if m.ActiveModal != ModalNone || !m.IsLoading || cmd == nil {
+		t.Fatalf("after confirming: modal = %v, loading = %v", m.ActiveModal, m.IsLoading)
+	}
+	cmd()
+	if !pushed {
+		t.Error("confirming should run the push")
+	}
+}
+
+func TestPushKey_CancelConfirmation(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.OnPush = func(ctx context.Context) *StatusMessage {
+		t.Error("push should not run when cancelled")
+		return nil
+	}
+	m.ConfirmCreatePush = true
+	m.DescribePush = func() []string { return []string{"spec-a: copying FROM a TO b, overwriting b"} }
+
+	updated, _ := m.handleKeyPress(keyPress("P"))
+	updated, cmd := updated.(Model).handleKeyPress(keyPress("n"))
+	m = updated.(Model)
+	if m.ActiveModal != ModalNone || m.IsLoading || cmd != nil {
+		t.Errorf("after cancelling: modal = %v, loading = %v", m.ActiveModal, m.IsLoading)
+	}
+}
+
+func TestWithBadge_AlignsBadgeColumn(t *testing.T) {
+	const width = 60
+	rows := []string{
+		withBadge("▶ short", "3 conflicts", width),
+		withBadge("▶ a much longer row ⏳ with wide glyphs 📦", "12 conflicts", width),
+		withBadge(strings.Repeat("x", 80), "1 conflict", width),
+	}
+	for _, row := range rows {
+		if got := lipgloss.Width(row); got != width {
+			t.Errorf("width of %q = %d, want %d", row, got, width)
+		}
+	}
+	if !strings.HasSuffix(rows[2], "… "+strings.Repeat(" ", badgeColumnWidth-len("1 conflict")-1)+"1 conflict") {
+		t.Errorf("long body should be truncated before the badge column: %q", rows[2])
+	}
+
+	if got := withBadge("no badge", "", width); got != "no badge" {
+		t.Errorf("withBadge() without badge = %q", got)
+	}
+}
+
+func TestUndoKey_UnavailableShowsReason(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.OnUndo = func(ctx context.Context) *StatusMessage {
+		t.Error("OnUndo should not run when undo is unavailable")
+		return nil
+	}
+	m.DescribeUndo = func() (string, bool) { return "A flush can't be undone", false }
+
+	updated, _ := m.handleKeyPress(keyPress("U"))
+	m = updated.(Model)
+	if m.ActiveModal != ModalNone {
+		t.Errorf("ActiveModal = %v, want ModalNone", m.ActiveModal)
+	}
+	if m.StatusMessage == nil || m.StatusMessage.Text != "A flush can't be undone" {
+		t.Errorf("StatusMessage = %+v, want the reason", m.StatusMessage)
+	}
+}
+
+func TestUndoKey_ConfirmsBeforeUndoing(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.OnUndo = func(ctx context.Context) *StatusMessage { return nil }
+	m.DescribeUndo = func() (string, bool) { return "Recreate web", true }
+
+	updated, _ := m.handleKeyPress(keyPress("U"))
+	m = updated.(Model)
+	if m.ActiveModal != ModalConfirm {
+		t.Fatalf("ActiveModal = %v, want ModalConfirm", m.ActiveModal)
+	}
+	if out := m.renderConfirmModal(); !strings.Contains(out, "Recreate web?") {
+		t.Errorf("confirmation should say what undo will do:\n%s", out)
+	}
+}
+
+func TestHostTag(t *testing.T) {
+	tests := []struct {
+		host, want string
+	}{
+		{"studio", "@studio"},
+		{"me@studio.local", "@studio"},
+		{"192.168.1.5", "@192.168.1.5"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := hostTag(tt.host); got != tt.want {
+			t.Errorf("hostTag(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestRenderSpecRow_ShowsHostTag(t *testing.T) {
+	proj := makeTestProject("p", 2, false)
+	host := "deploy@studio.lan"
+	proj.Specs[1].State = project.RunningTwoWay
+	proj.Specs[1].RunningSession = &mutagen.SyncSession{Name: "spec-b", Status: "watching", Beta: mutagen.Endpoint{Host: &host}}
+	m := newTestModel(proj)
+
+	if out := m.renderSpecRow(proj, &proj.Specs[0], 100, true); strings.Contains(out, "@server") {
+		t.Errorf("host tag shown while ShowHost is off: %q", out)
+	}
+
+	updated, _ := m.handleKeyPress(keyPress("H"))
+	m = updated.(Model)
+	if out := m.renderSpecRow(proj, &proj.Specs[0], 100, true); !strings.Contains(out, "@server") {
+		t.Errorf("not-running row should tag host from project file: %q", out)
+	}
+	if out := m.renderSpecRow(proj, &proj.Specs[1], 100, true); !strings.Contains(out, "@studio") {
+		t.Errorf("running row should tag host from session: %q", out)
+	}
+}
+
+func TestRenderSpecRow_DisplayRules(t *testing.T) {
+	infra := makeTestProject("infra", 1, false)
+	app := makeTestProject("app", 1, false)
+	m := newTestModel(infra, app)
+	m.ShowPaths = false
+	m.DisplayModeFor = func(proj *project.Project) (bool, bool) {
+		return true, proj == infra
+	}
+
+	showsPaths := func(m Model, proj *project.Project) bool {
+		return strings.Contains(m.renderSpecRow(proj, &proj.Specs[0], 120, true), "server:/remote")
+	}
+	if !showsPaths(m, infra) || showsPaths(m, app) {
+		t.Error("rule should show paths for infra only")
+	}
+
+	updated, _ := m.handleKeyPress(keyPress("m"))
+	m = updated.(Model)
+	if showsPaths(m, infra) || !showsPaths(m, app) {
+		t.Error("toggling should flip both the ruled and unruled projects")
+	}
+}
+
+func TestRenderSpecRow_NewVersusStopped(t *testing.T) {
+	proj := makeTestProject("p", 2, false)
+	proj.Specs[1].EverStarted = true
+	m := newTestModel(proj)
+	m.ShowPaths = false
+
+	if out := m.renderSpecRow(proj, &proj.Specs[0], 100, true); !strings.Contains(out, "New") {
+		t.Errorf("never-started spec should show New: %q", out)
+	}
+	if out := m.renderSpecRow(proj, &proj.Specs[1], 100, true); !strings.Contains(out, "Stopped") {
+		t.Errorf("previously started spec should show Stopped: %q", out)
+	}
+}
+
+func TestRenderSpecRow_ToggleSessionNames(t *testing.T) {
+	proj := makeTestProject("p", 1, false)
+	mode := "one-way-replica"
+	proj.Specs[0].State = project.RunningPush
+	proj.Specs[0].RunningSession = &mutagen.SyncSession{Name: "spec-a-push", Status: "watching", Mode: &mode}
+	m := newTestModel(proj)
+
+	if out := m.renderSpecRow(proj, &proj.Specs[0], 100, true); strings.Contains(out, "spec-a-push") {
+		t.Errorf("row should show the spec name by default: %q", out)
+	}
+	updated, _ := m.handleKeyPress(keyPress("N"))
+	m = updated.(Model)
+	if out := m.renderSpecRow(proj, &proj.Specs[0], 100, true); !strings.Contains(out, "spec-a-push") {
+		t.Errorf("row should show the session name after N: %q", out)
+	}
+
+	m.Selection.SelectNext()
+	m.GetSelectedSession = func() *mutagen.SyncSession { return proj.Specs[0].RunningSession }
+	out := m.renderSyncStatusModal()
+	if !strings.Contains(out, "Spec: spec-a") || !strings.Contains(out, "Session: spec-a-push") {
+		t.Errorf("modal should show both names:\n%s", out)
+	}
+}
+
+func TestProjectWithWarnings_FlaggedAndListed(t *testing.T) {
+	proj := makeTestProject("broken", 0, true)
+	proj.File.Warnings = []string{"line 1: sync is a list, but should map session names to definitions"}
+	m := newTestModel(proj)
+
+	if out := m.renderProjectHeader(proj, 100, true); !strings.Contains(out, "config issue") {
+		t.Errorf("header should flag the config issue: %q", out)
+	}
+
+	updated, _ := m.handleKeyPress(keyPress("i"))
+	m = updated.(Model)
+	if out := m.renderSyncStatusModal(); !strings.Contains(out, "sync is a list") {
+		t.Errorf("modal should list the warnings:\n%s", out)
+	}
+
+	var edited int
+	m.OnOpenEditor = func(projIdx int) error { edited++; return nil }
+	updated, _ = m.handleKeyPress(keyPress("e"))
+	m = updated.(Model)
+	if edited != 1 || m.ActiveModal != ModalNone {
+		t.Errorf("e should close the listing and open the editor (edited %d, modal %v)", edited, m.ActiveModal)
+	}
+}
+
+func TestRenderSyncStatusModal_ShowsIgnoreAnalysis(t *testing.T) {
+	m := newTestModel()
+	m.GetSelectedSession = func() *mutagen.SyncSession { return &mutagen.SyncSession{Name: "s"} }
+	described := 0
+	m.DescribeIgnores = func() []string { described++; return []string{"Match nothing: target/"} }
+
+	msg := m.checkIgnores()()
+	if out := m.renderSyncStatusModal(); !strings.Contains(out, "Checking…") {
+		t.Errorf("modal should say the ignores are being checked:\n%s", out)
+	}
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	for range 3 {
+		if out := m.renderSyncStatusModal(); !strings.Contains(out, "Match nothing: target/") {
+			t.Errorf("modal should show the ignore analysis:\n%s", out)
+		}
+	}
+	if described != 1 {
+		t.Errorf("described the ignores %d times, want once per opening", described)
+	}
+}
+
+func TestEditKey_ShowsEditorError(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, true))
+	suspended := false
+	m.SuspendAndRun = func(func()) tea.Cmd { suspended = true; return nil }
+	m.OnOpenEditor = func(int) error { return errors.New("editor 'nope' not found in PATH") }
+
+	updated, _ := m.handleKeyPress(keyPress("e"))
+	m = updated.(Model)
+	if suspended {
+		t.Error("a failed editor launch should not suspend the TUI")
+	}
+	if m.StatusMessage == nil || m.StatusMessage.Text != "editor 'nope' not found in PATH" {
+		t.Errorf("StatusMessage = %+v, want the editor error", m.StatusMessage)
+	}
+
+	m.OnOpenEditor = func(int) error { return ErrTerminalEditor }
+	m.handleKeyPress(keyPress("e"))
+	if !suspended {
+		t.Error("a terminal editor should suspend the TUI")
+	}
+}
+
+func TestView_Borderless(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 2, false))
+	m.Width, m.Height = 80, 20
+
+	bordered := m.View()
+	if !strings.Contains(bordered, "╭") {
+		t.Fatal("default view should have borders")
+	}
+
+	updated, _ := m.handleKeyPress(keyPress("B"))
+	m = updated.(Model)
+	flat := m.View()
+	if strings.Contains(flat, "╭") || strings.Contains(flat, "│") {
+		t.Errorf("borderless view still has borders:\n%s", flat)
+	}
+	if got := lipgloss.Height(flat); got != m.Height {
+		t.Errorf("borderless view height = %d, want %d", got, m.Height)
+	}
+	if got := lipgloss.Height(bordered); got != m.Height {
+		t.Errorf("bordered view height = %d, want %d", got, m.Height)
+	}
+}
+
+func TestSortKey_CyclesSortAndPicksUpProjects(t *testing.T) {
+	first, second := makeTestProject("a", 1, true), makeTestProject("b", 1, true)
+	m := newTestModel(first, second)
+	m.OnCycleSort = func() *StatusMessage {
+		return &StatusMessage{Type: StatusInfo, Text: "Sorting projects by status"}
+	}
+	m.GetProjects = func() []*project.Project { return []*project.Project{second, first} }
+
+	updated, _ := m.handleKeyPress(keyPress("o"))
+	m = updated.(Model)
+	if m.StatusMessage == nil || m.StatusMessage.Text != "Sorting projects by status" {
+		t.Errorf("status = %+v, want the sort order", m.StatusMessage)
+	}
+	if m.Projects[0] != second {
+		t.Errorf("projects not picked up after sorting")
+	}
+}
+
+func TestMouseClick_SelectsRowInBothLayouts(t *testing.T) {
+	for _, borderless := range []bool{false, true} {
+		m := newTestModel(makeTestProject("proj", 2, false))
+		m.Width, m.Height = 80, 20
+		m.Borderless = borderless
+
+		// Find the row the second spec is drawn on
+		row := -1
+		for i, line := range strings.Split(m.View(), "\n") {
+			if strings.Contains(line, "spec-b") {
+				row = i
+			}
+		}
+		updated, _ := m.handleMouseEvent(tea.MouseMsg{X: 10, Y: row, Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease})
+		m = updated.(Model)
+		if got := m.Selection.RawIndex(); got != 2 {
+			t.Errorf("borderless=%v: click on row %d selected item %d, want 2", borderless, row, got)
+		}
+	}
+}
+
+func TestOperationDone_OffersFix(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	offered := []string{"Link ~/.mutagen on server?"}
+	m.OfferFix = func() []string {
+		lines := offered
+		offered = nil
+		return lines
+	}
+	fixed := false
+	m.OnFix = func(ctx context.Context) *StatusMessage { fixed = true; return nil }
+
+	updated, _ := m.Update(OperationDoneMsg{Status: &StatusMessage{Type: StatusError, Text: "Failed to start session"}})
+	m = updated.(Model)
+	if m.ActiveModal != ModalConfirm || m.confirmation == nil || m.confirmation.Lines[0] != "Link ~/.mutagen on server?" {
+		t.Fatalf("modal = %v, confirmation = %+v; want the fix offered", m.ActiveModal, m.confirmation)
+	}
+
+	updated, cmd := m.handleKeyPress(keyPress("y"))
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("confirming should run the fix")
+	}
+	cmd()
+	if !fixed {
+		t.Error("OnFix was not called")
+	}
+
+	// With nothing to offer, the status is shown as usual
+	updated, _ = m.Update(OperationDoneMsg{Status: &StatusMessage{Text: "done"}})
+	if m = updated.(Model); m.ActiveModal != ModalNone || m.StatusMessage.Text != "done" {
+		t.Errorf("modal = %v, status = %+v; want only the status", m.ActiveModal, m.StatusMessage)
+	}
+}
+
+func TestAbbreviatePath(t *testing.T) {
+	tests := []struct {
+		path  string
+		width int
+		want  string
+	}{
+		{"/Users/me/src/research/src", 40, "/Users/me/src/research/src"},
+		{"/Users/me/code/work/research/src", 22, "/Users/…/research/src"},
+		{"/Users/me/code/work/research/src", 14, "/Users/…/src"},
+		{"server:/srv/app/releases/current", 24, "server:/srv/…/current"},
+		{"~/code/clients/acme/site", 18, "~/…/acme/site"},
+		{"~alice/code/clients/acme/site", 16, "~alice/…/site"},
+		{"docker://web/var/www/html", 20, "docker://web/…/html"},
+		// The leaf alone doesn't fit, so the characters are cut instead
+		{"/a/averyveryverylongdirectoryname", 12, "/a/av…ryname"},
+	}
+	for _, tt := range tests {
+		got := abbreviatePath(tt.path, tt.width)
+		if got != tt.want {
+			t.Errorf("abbreviatePath(%q, %d) = %q, want %q", tt.path, tt.width, got, tt.want)
+		}
+		if w := lipgloss.Width(got); w > tt.width {
+			t.Errorf("abbreviatePath(%q, %d) is %d wide", tt.path, tt.width, w)
+		}
+	}
+}
+
+func TestRenderSpecRow_AbbreviatesPaths(t *testing.T) {
+	mutagen.SetPathAbbreviations(map[string]string{"/mnt/bigdisk": "…/bigdisk", "/srv": "S:"})
+	t.Cleanup(func() { mutagen.SetPathAbbreviations(nil) })
+	proj := makeTestProject("proj", 1, false)
+	proj.File.Sessions["spec-a"] = project.SessionDefinition{Alpha: "/mnt/bigdisk/web", Beta: "server:/srv/web"}
+	spec := &proj.Specs[0]
+	m := newTestModel(proj)
+	m.ShowPaths = true
+
+	// A spec that isn't running shows its definition's paths
+	if row := m.renderSpecRow(proj, spec, 200, true); !strings.Contains(row, "…/bigdisk/web ⇄ server:S:/web") {
+		t.Errorf("row = %q, want abbreviated paths", row)
+	}
+	// A running one shows its session's
+	spec.State = project.RunningTwoWay
+	host := "server"
+	spec.RunningSession = &mutagen.SyncSession{
+		Name:  "spec-a",
+		Alpha: mutagen.Endpoint{Path: "/mnt/bigdisk/web"},
+		Beta:  mutagen.Endpoint{Path: "/srv/web", Host: &host},
+	}
+	if row := m.renderSpecRow(proj, spec, 200, true); !strings.Contains(row, "…/bigdisk/web") || !strings.Contains(row, "server:S:/web") {
+		t.Errorf("row = %q, want abbreviated paths", row)
+	}
+}
+
+func TestRenderSpecRow_KeepsPathLeaves(t *testing.T) {
+	proj := makeTestProject("proj", 1, false)
+	def := proj.File.Sessions["spec-a"]
+	def.Alpha = "/Users/someone/projects/clients/acme/research-notebooks"
+	def.Beta = "server:/home/someone/projects/clients/acme/research-notebooks"
+	proj.File.Sessions["spec-a"] = def
+	m := newTestModel(proj)
+	m.ShowPaths = true
+
+	line := m.renderSpecRow(proj, &proj.Specs[0], 110, true)
+	if lipgloss.Width(line) > 110 {
+		t.Errorf("row is %d wide, want at most 110: %q", lipgloss.Width(line), line)
+	}
+	if strings.Count(line, "research-notebooks") != 2 {
+		t.Errorf("both leaf directories should be visible: %q", line)
+	}
+
+	m.TruncatePathEnds = true
+	line = m.renderSpecRow(proj, &proj.Specs[0], 110, true)
+	if !strings.HasSuffix(line, "…") || strings.Count(line, "research-notebooks") == 2 {
+		t.Errorf("with TruncatePathEnds the row should be cut at the end: %q", line)
+	}
+}
+
+func TestFormatCompression(t *testing.T) {
+	onWire, transferred := uint64(1<<30), uint64(3<<30)
+	tests := []struct {
+		c    mutagen.Compression
+		want string
+	}{
+		{mutagen.Compression{Algorithm: "zstandard"}, "zstandard"},
+		{mutagen.Compression{BytesOnWire: &onWire}, "1.0 GB on wire"},
+		{mutagen.Compression{Algorithm: "deflate", BytesOnWire: &onWire, BytesTransferred: &transferred}, "deflate, 3.0x, 1.0 GB on wire"},
+	}
+	for _, tt := range tests {
+		if got := formatCompression(&tt.c); got != tt.want {
+			t.Errorf("formatCompression(%+v) = %q, want %q", tt.c, got, tt.want)
+		}
+	}
+}
+
+func TestView_EmptyState(t *testing.T) {
+	m := newTestModel()
+	m.Width, m.Height = 80, 24
+	m.SearchPaths = func() []string { return []string{"/home/me/code", "~/.config/mutagen/projects"} }
+
+	view := m.View()
+	for _, want := range []string{"No mutagen project files found", "/home/me/code", "~/.config/mutagen/projects", "-d <dir>"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("empty view is missing %q:\n%s", want, view)
+		}
+	}
+	if got := lipgloss.Height(view); got != m.Height {
+		t.Errorf("empty view height = %d, want %d", got, m.Height)
+	}
+}
+
+func TestStart_RunningSpecWithChangedSettingsAsksToRestart(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	m.OnStart = func(ctx context.Context) *StatusMessage { return nil }
+	m.OnReconcile = func(ctx context.Context) *StatusMessage { return nil }
+	changes := []string{"mode: two-way-safe → one-way-safe"}
+	m.DescribeReconcile = func() []string { return changes }
+
+	m = press(m, "j")
+	m = press(m, "s")
+	if m.ActiveModal != ModalConfirm || !strings.Contains(m.renderConfirmModal(), changes[0]) {
+		t.Fatalf("s should ask to restart with the changes listed; modal = %v", m.ActiveModal)
+	}
+
+	// Without changes, s starts as before
+	m = press(m, "n")
+	changes = nil
+	updated, cmd := m.handleKeyPress(keyPress("s"))
+	if updated.(Model).ActiveModal != ModalNone || cmd == nil {
+		t.Error("s without setting changes should start directly")
+	}
+}
+
+func TestReset_AsksForConfirmation(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	reset := false
+	m.OnReset = func(ctx context.Context) *StatusMessage {
+		reset = true
+		return nil
+	}
+	m.DescribeReset = func() []string { return []string{"spec-a"} }
+
+	m = press(m, "x")
+	if m.ActiveModal != ModalConfirm || !strings.Contains(m.renderConfirmModal(), "Reset spec-a?") {
+		t.Fatalf("x should ask for confirmation; modal = %v", m.ActiveModal)
+	}
+	updated, cmd := m.handleKeyPress(keyPress("y"))
+	if updated.(Model).ActiveModal != ModalNone || cmd == nil {
+		t.Fatal("y should run the reset")
+	}
+	cmd()
+	if !reset {
+		t.Error("OnReset was not called")
+	}
+}
+
+func TestRefresh_TickAndKeyMayReuseList(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	var forced []bool
+	m.OnRefresh = func(ctx context.Context, force bool) error {
+		forced = append(forced, force)
+		return nil
+	}
+	m.OnPause = func(ctx context.Context) *StatusMessage { return nil }
+
+	_, cmd := m.handleKeyPress(keyPress("r"))
+	cmd()
+	updated, cmd := m.Update(TickMsg(time.Now()))
+	m = updated.(Model)
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		for _, cmd := range batch {
+			cmd()
+		}
+	}
+	// Refreshes after an action always list sessions again
+	m.Selection.SelectNext()
+	_, cmd = m.handleKeyPress(keyPress("p"))
+	cmd()
+
+	if len(forced) != 3 || forced[0] || forced[1] || !forced[2] {
+		t.Errorf("force = %v, want [false false true]", forced)
+	}
+}
+
+func TestRefreshDone_FailureKeepsLastList(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	err := errors.New("mutagen sync list failed: unable to connect to daemon")
+
+	updated, _ := m.Update(RefreshDoneMsg{Err: err})
+	if status := updated.(Model).StatusMessage; status.Type != StatusError {
+		t.Errorf("status = %+v, want an error before anything was listed", status)
+	}
+
+	last := time.Date(2025, 1, 1, 9, 41, 0, 0, time.Local)
+	m.LastRefresh = &last
+	updated, _ = m.Update(RefreshDoneMsg{Err: err})
+	want := "Failed to refresh sessions; showing them as of 09:41:00: " + err.Error()
+	if status := updated.(Model).StatusMessage; status.Type != StatusWarning || status.Text != want {
+		t.Errorf("status = %+v, want the warning %q", status, want)
+	}
+}
+
+func TestRestartDaemon_RefreshesAfterConfirmation(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	var calls []string
+	m.OnRestartDaemon = func(ctx context.Context) *StatusMessage {
+		calls = append(calls, "restart")
+		return &StatusMessage{Type: StatusInfo, Text: "Restarted the Mutagen daemon"}
+	}
+	m.OnRefresh = func(ctx context.Context, force bool) error {
+		calls = append(calls, "refresh")
+		return nil
+	}
+
+	m = press(m, "D")
+	if m.ActiveModal != ModalConfirm || !strings.Contains(m.renderConfirmModal(), "RESTART MUTAGEN DAEMON") {
+		t.Fatalf("D should ask for confirmation; modal = %v", m.ActiveModal)
+	}
+	_, cmd := m.handleKeyPress(keyPress("y"))
+	if cmd == nil {
+		t.Fatal("y should restart the daemon")
+	}
+	msg, ok := cmd().(OperationDoneMsg)
+	if !ok || msg.Status == nil || msg.Status.Text != "Restarted the Mutagen daemon" {
+		t.Errorf("msg = %+v, want the restart status", msg)
+	}
+	if strings.Join(calls, ",") != "restart,refresh" {
+		t.Errorf("calls = %v, want restart then refresh", calls)
+	}
+}
+
+func TestFormatEndpointDetails_ScanProblems(t *testing.T) {
+	m := newTestModel()
+	e := &mutagen.Endpoint{Protocol: "local", Path: "/a", Connected: true, Scanned: true}
+	if strings.Contains(m.formatEndpointDetails(e), "Scan problems") {
+		t.Error("endpoint without problems should not list any")
+	}
+
+	for i := 0; i < 12; i++ {
+		e.ScanProblems = append(e.ScanProblems, mutagen.ScanProblem{
+			Path:  fmt.Sprintf("link%d", i),
+			Error: "invalid symbolic link: target is absolute",
+		})
+	}
+	details := m.formatEndpointDetails(e)
+	for _, want := range []string{"Scan problems: 12", "link0: invalid symbolic link", "link9:", "+2 more"} {
+		if !strings.Contains(details, want) {
+			t.Errorf("details missing %q:\n%s", want, details)
+		}
+	}
+	if strings.Contains(details, "link10:") {
+		t.Errorf("details should stop after %d problems:\n%s", maxScanProblemsShown, details)
+	}
+}
+
+func TestRenderSpecRow_MarksScanProblems(t *testing.T) {
+	proj := makeTestProject("proj", 1, false)
+	spec := &proj.Specs[0]
+	spec.State = project.RunningTwoWay
+	spec.RunningSession = &mutagen.SyncSession{
+		Name:   "spec-a",
+		Status: "watching",
+		Alpha:  mutagen.Endpoint{Protocol: "local", Path: "/a", Connected: true},
+		Beta: mutagen.Endpoint{Protocol: "local", Path: "/b", Connected: true,
+			ScanProblems: []mutagen.ScanProblem{{Path: "x", Error: "permission denied"}}},
+	}
+	m := newTestModel(proj)
+	m.ShowPaths = true
+	if row := m.renderSpecRow(proj, spec, 120, true); !strings.Contains(row, "⟳⚠/b") {
+		t.Errorf("paths row = %q, want a mark on beta", row)
+	}
+	m.ShowPaths = false
+	if row := m.renderSpecRow(proj, spec, 120, true); !strings.Contains(row, "⚠ scan problems") {
+		t.Errorf("status row = %q, want a scan problems note", row)
+	}
+}
+
+func TestRenderProjectHeader_Orphaned(t *testing.T) {
+	proj := &project.Project{
+		File:     project.ProjectFile{Path: "Orphaned sessions"},
+		Specs:    []project.SyncSpec{{Name: "stray", State: project.RunningTwoWay, RunningSession: &mutagen.SyncSession{Name: "stray"}}},
+		Orphaned: true,
+	}
+	m := newTestModel(proj)
+
+	line := m.renderProjectHeader(proj, 100, false)
+	if !strings.Contains(line, "Orphaned sessions") || !strings.Contains(line, "1 session(s) not in any project file") {
+		t.Errorf("header = %q", line)
+	}
+}
+
+func TestRenderHeader_Summary(t *testing.T) {
+	m := newTestModel()
+	m.Width = 100
+	m.GetSummary = func() Summary { return Summary{Running: 3, Paused: 1, Conflicting: 2, Bytes: 2048} }
+
+	header := m.renderHeader()
+	if want := "3 running · 1 paused · 2 conflicting · 2.0 KB"; !strings.Contains(header, want) {
+		t.Errorf("header = %q, want it to contain %q", header, want)
+	}
+
+	m.Width = 30
+	header = m.renderHeader()
+	if lipgloss.Height(header) != 3 {
+		t.Errorf("narrow header is %d lines, want 3", lipgloss.Height(header))
+	}
+	if !strings.Contains(header, "…") {
+		t.Errorf("narrow header = %q, want a truncated summary", header)
+	}
+}
+
+func TestFormatStagingTotal(t *testing.T) {
+	tests := []struct {
+		total StagingTotal
+		want  string
+	}{
+		{StagingTotal{}, ""},
+		{StagingTotal{Received: 450, Expected: 1000, Files: 12}, "Initial sync 45% ████░░░░░░ 450 B of 1000 B, 12 files left"},
+		{StagingTotal{Received: 2048, Expected: 1024}, "Initial sync 100% ██████████ 2.0 KB of 1.0 KB"},
+		{StagingTotal{Files: 3}, "Initial sync, 3 files left"},
+	}
+	for _, tt := range tests {
+		if got := formatStagingTotal(tt.total); got != tt.want {
+			t.Errorf("formatStagingTotal(%+v) = %q, want %q", tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestRenderStatus_StagingTotal(t *testing.T) {
+	m := newTestModel()
+	m.Width = 120
+	total := StagingTotal{}
+	m.GetStagingTotal = func() StagingTotal { return total }
+	if status := m.renderStatus(); strings.Contains(status, "Initial sync") {
+		t.Errorf("status = %q, want no progress while nothing stages", status)
+	}
+	total = StagingTotal{Received: 1, Expected: 4, Files: 2}
+	if status := m.renderStatus(); !strings.Contains(status, "Ready | Initial sync 25%") {
+		t.Errorf("status = %q, want the overall progress", status)
+	}
+}
+
+func TestFormatSummary_OmitsZeroConflicts(t *testing.T) {
+	if got, want := formatSummary(Summary{Running: 1}), "1 running · 0 paused · 0 B"; got != want {
+		t.Errorf("formatSummary() = %q, want %q", got, want)
+	}
+}
+
+func TestTerminate_AsksForConfirmation(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 2, true))
+	terminated := false
+	m.OnTerminate = func(ctx context.Context) *StatusMessage {
+		terminated = true
+		return nil
+	}
+	m.ConfirmTerminate = true
+	m.DescribeTerminate = func() []string { return []string{"spec-a", "spec-b (paused)"} }
+
+	m = press(m, "t")
+	if m.ActiveModal != ModalConfirm {
+		t.Fatalf("t should ask for confirmation; modal = %v", m.ActiveModal)
+	}
+	if out := m.renderConfirmModal(); !strings.Contains(out, "Terminate 2 session(s)?") || !strings.Contains(out, "spec-b (paused)") {
+		t.Errorf("confirmation should count and name the sessions:\n%s", out)
+	}
+
+	updated, cmd := m.handleKeyPress(keyPress("y"))
+	m = updated.(Model)
+	if m.ActiveModal != ModalNone || !m.IsLoading || cmd == nil {
+		t.Fatalf("after confirming: modal = %v, loading = %v", m.ActiveModal, m.IsLoading)
+	}
+	cmd()
+	if !terminated {
+		t.Error("confirming should run the terminate")
+	}
+}
+
+func TestTerminate_CancelConfirmation(t *testing.T) {
+	for _, cancel := range []tea.KeyMsg{keyPress("n"), {Type: tea.KeyEsc}} {
+		m := newTestModel(makeTestProject("proj", 1, true))
+		m.OnTerminate = func(ctx context.Context) *StatusMessage {
+			t.Error("terminate should not run when cancelled")
+			return nil
+		}
+		m.ConfirmTerminate = true
+		m.DescribeTerminate = func() []string { return []string{"spec-a"} }
+
+		updated, _ := m.handleKeyPress(keyPress("t"))
+		updated, cmd := updated.(Model).handleKeyPress(cancel)
+		m = updated.(Model)
+		if m.ActiveModal != ModalNone || m.IsLoading || cmd != nil {
+			t.Errorf("after %q: modal = %v, loading = %v", cancel.String(), m.ActiveModal, m.IsLoading)
+		}
+	}
+}
+
+func TestTerminate_NoConfirmation(t *testing.T) {
+	for name, m := range map[string]Model{
+		"disabled":    newTestModel(makeTestProject("proj", 1, true)),
+		"not running": newTestModel(makeTestProject("proj", 1, false)),
+	} {
+		m.OnTerminate = func(ctx context.Context) *StatusMessage { return nil }
+		m.ConfirmTerminate = name != "disabled"
+		m.DescribeTerminate = func() []string {
+			if name == "not running" {
+				return nil
+			}
+			return []string{"spec-a"}
+		}
+
+		updated, cmd := m.handleKeyPress(keyPress("t"))
+		if m = updated.(Model); m.ActiveModal != ModalNone || cmd == nil {
+			t.Errorf("%s: t should terminate right away; modal = %v", name, m.ActiveModal)
+		}
+	}
+}
+
+func TestRenderSpecRow_ShowsHaltReason(t *testing.T) {
+	proj := makeTestProject("proj", 1, false)
+	spec := &proj.Specs[0]
+	spec.State = project.RunningTwoWay
+	spec.RunningSession = &mutagen.SyncSession{
+		Name:      "spec-a",
+		Status:    "halted-on-root-emptied",
+		LastError: "beta root emptied; " + strings.Repeat("refusing to propagate deletions ", 3),
+	}
+	m := newTestModel(proj)
+	m.ShowPaths = false
+
+	row := m.renderSpecRow(proj, spec, 200, true)
+	if !strings.Contains(row, "Halted") || !strings.Contains(row, "beta root emptied") {
+		t.Errorf("row = %q, want the halt reason", row)
+	}
+	if !strings.Contains(row, "…") || strings.Count(row, "refusing") == 3 {
+		t.Errorf("row = %q, want a truncated halt reason", row)
+	}
+}
+
+func TestRenderSpecRow_ShowsReplicaCount(t *testing.T) {
+	proj := makeTestProject("proj", 1, false)
+	spec := &proj.Specs[0]
+	session := &mutagen.SyncSession{Name: "spec-a@east", Status: "watching"}
+	spec.State = project.RunningTwoWay
+	spec.RunningSession = session
+	spec.BetaSessions = []*mutagen.SyncSession{session, nil, {Name: "spec-a@north"}}
+	m := newTestModel(proj)
+
+	m.ShowPaths = false
+	if row := m.renderSpecRow(proj, spec, 200, true); !strings.Contains(row, "2/3 running") {
+		t.Errorf("row = %q, want the replica count", row)
+	}
+	m.ShowPaths = true
+	if row := m.renderSpecRow(proj, spec, 200, true); !strings.Contains(row, "(2/3 running)") {
+		t.Errorf("row = %q, want the replica count after the paths", row)
+	}
+}
+
+func TestRenderSyncStatusModal_ListsProblems(t *testing.T) {
+	m := newTestModel()
+	session := &mutagen.SyncSession{
+		Name:      "s",
+		Status:    "halted-on-root-type-change",
+		LastError: "root type changed",
+		Beta: mutagen.Endpoint{TransitionProblems: []mutagen.ScanProblem{
+			{Path: "build/out", Error: "permission denied"},
+		}},
+	}
+	m.GetSelectedSession = func() *mutagen.SyncSession { return session }
+
+	out := m.renderSyncStatusModal()
+	for _, want := range []string{"Halted because:", "root type changed", "β build/out: permission denied"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("modal should contain %q:\n%s", want, out)
+		}
+	}
+
+	session.Status, session.LastError, session.Beta.TransitionProblems = "watching", "", nil
+	if out := m.renderSyncStatusModal(); strings.Contains(out, "Problems:") || strings.Contains(out, "Halted") {
+		t.Errorf("modal should list no problems for a healthy session:\n%s", out)
+	}
+}
+
+func TestStartAllKey(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	started := false
+	m.OnStartAll = func(ctx context.Context) *StatusMessage {
+		started = true
+		return nil
+	}
+
+	updated, cmd := m.handleKeyPress(keyPress("S"))
+	if m = updated.(Model); !m.IsLoading || cmd == nil {
+		t.Fatal("S should start all projects")
+	}
+	cmd()
+	if !started {
+		t.Error("OnStartAll was not called")
+	}
+}
+
+func TestTerminateAllKey_AsksForConfirmation(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, true))
+	terminated := false
+	m.OnTerminateAll = func(ctx context.Context) *StatusMessage {
+		terminated = true
+		return nil
+	}
+	m.DescribeTermAll = func() []string { return []string{"proj: 1 session(s)", "other: 2 session(s)"} }
+
+	m = press(m, "T")
+	if m.ActiveModal != ModalConfirm {
+		t.Fatalf("T should ask for confirmation; modal = %v", m.ActiveModal)
+	}
+	if out := m.renderConfirmModal(); !strings.Contains(out, "Terminate the sessions of 2 project(s)?") || !strings.Contains(out, "other: 2 session(s)") {
+		t.Errorf("confirmation should list the projects:\n%s", out)
+	}
+	updated, cmd := m.handleKeyPress(keyPress("y"))
+	if updated.(Model).ActiveModal != ModalNone || cmd == nil {
+		t.Fatal("y should terminate all projects")
+	}
+	cmd()
+	if !terminated {
+		t.Error("OnTerminateAll was not called")
+	}
+}
+
+func TestTerminateAllKey_NothingRunning(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	m.OnTerminateAll = func(ctx context.Context) *StatusMessage {
+		t.Error("nothing should be terminated")
+		return nil
+	}
+	m.DescribeTermAll = func() []string { return nil }
+
+	m = press(m, "T")
+	if m.ActiveModal != ModalNone || m.StatusMessage == nil || m.StatusMessage.Text != "No sessions running" {
+		t.Errorf("modal = %v, status = %v", m.ActiveModal, m.StatusMessage)
+	}
+}
+
+func TestOpenBetaKey(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	m.Selection.SetIndex(1)
+
+	// The beta is opened from a command, not while handling the key
+	opened := false
+	m.OnOpenBeta = func() (*exec.Cmd, *StatusMessage) { opened = true; return exec.Command("true"), nil }
+	_, cmd := m.handleKeyPress(keyPress("O"))
+	if cmd == nil || opened {
+		t.Fatalf("O should return a command that opens the beta (opened = %v)", opened)
+	}
+
+	// A remote beta runs a shell in the terminal
+	msg := cmd()
+	if open, ok := msg.(OpenBetaMsg); !ok || open.Cmd == nil {
+		t.Fatalf("msg = %#v, want an OpenBetaMsg with the shell", msg)
+	}
+	if _, cmd := m.Update(msg); cmd == nil {
+		t.Error("the shell should be run")
+	}
+
+	// A local beta is opened by the app, which reports it
+	updated, _ := m.Update(OpenBetaMsg{Status: &StatusMessage{Type: StatusInfo, Text: "Opened /local"}})
+	if m = updated.(Model); m.StatusMessage == nil || m.StatusMessage.Text != "Opened /local" {
+		t.Errorf("StatusMessage = %v", m.StatusMessage)
+	}
+
+	updated, _ = m.Update(ShellClosedMsg{Err: errors.New("exit status 255")})
+	if m = updated.(Model); m.StatusMessage.Type != StatusError || !strings.Contains(m.StatusMessage.Text, "exit status 255") {
+		t.Errorf("StatusMessage = %v, want the shell's error", m.StatusMessage)
+	}
+}
+
+func TestProjectFileChangedMsg(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	m.IsLoading = true
+	reloaded := makeTestProject("proj", 2, false)
+	var loaded, gotPath string
+	file := &project.ProjectFile{Path: "/projects/proj.yml"}
+	m.LoadProjectFile = func(path string) (*project.ProjectFile, error) {
+		loaded = path
+		return file, nil
+	}
+	m.OnReloadProjectFile = func(path string, f *project.ProjectFile, err error) *StatusMessage {
+		if f != file || err != nil {
+			t.Errorf("OnReloadProjectFile(%q, %v, %v), want the loaded file", path, f, err)
+		}
+		gotPath = path
+		return &StatusMessage{Type: StatusInfo, Text: "Reloaded proj: added spec-b"}
+	}
+	m.GetProjects = func() []*project.Project { return []*project.Project{reloaded} }
+
+	_, cmd := m.Update(ProjectFileChangedMsg{Path: "/projects/proj.yml"})
+	if cmd == nil {
+		t.Fatal("a changed project file should be reloaded")
+	}
+	// The file is read in the command, and applied in Update
+	msg := cmd()
+	if loaded != "/projects/proj.yml" || gotPath != "" {
+		t.Fatalf("loaded %q, applied %q; want only the load", loaded, gotPath)
+	}
+	updated, cmd := m.Update(msg)
+	m = updated.(Model)
+	if gotPath != "/projects/proj.yml" {
+		t.Errorf("reloaded %q", gotPath)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(m.Projects[0].Specs) != 2 || m.StatusMessage == nil || m.StatusMessage.Text != "Reloaded proj: added spec-b" {
+		t.Errorf("Projects = %v, StatusMessage = %v", m.Projects, m.StatusMessage)
+	}
+	if !m.IsLoading {
+		t.Error("a reload shouldn't end a running operation")
+	}
+}
+
+func TestFocus_TitleAndKey(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	m.Width, m.Height = 100, 20
+	focused := false
+	m.OnFocus = func(ctx context.Context) *StatusMessage {
+		focused = !focused
+		return nil
+	}
+	m.GetFocus = func() (string, bool) { return "spec-a", focused }
+
+	_, cmd := m.handleKeyPress(keyPress("z"))
+	if cmd == nil {
+		t.Fatal("z should toggle focus")
+	}
+	cmd()
+	if !strings.Contains(m.View(), "[focus: spec-a]") {
+		t.Errorf("title should name the focus:\n%s", m.View())
+	}
+}
+
+func TestRestartKey_SpecsOnly(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	restarts := 0
+	m.OnRestart = func(ctx context.Context) *StatusMessage {
+		restarts++
+		return nil
+	}
+
+	if _, cmd := m.handleKeyPress(keyPress("X")); cmd != nil {
+		t.Error("X on a project shouldn't restart anything")
+	}
+	m.Selection.SelectNext()
+	_, cmd := m.handleKeyPress(keyPress("X"))
+	if cmd == nil {
+		t.Fatal("X on a spec should restart it")
+	}
+	cmd()
+	if restarts != 1 {
+		t.Errorf("restarts = %d, want 1", restarts)
+	}
+}
+
+func TestRestartKey_AsksAsTerminateDoes(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	m.Selection.SelectNext()
+	restarts := 0
+	m.OnRestart = func(ctx context.Context) *StatusMessage {
+		restarts++
+		return nil
+	}
+	m.ConfirmTerminate = true
+	m.DescribeTerminate = func() []string { return []string{"spec-a"} }
+
+	m = press(m, "X")
+	if m.ActiveModal != ModalConfirm {
+		t.Fatalf("X should ask for confirmation; modal = %v", m.ActiveModal)
+	}
+	if out := m.renderConfirmModal(); !strings.Contains(out, "Restart 1 session(s)?") || !strings.Contains(out, "spec-a") {
+		t.Errorf("confirmation should count and name the sessions:\n%s", out)
+	}
+	updated, cmd := m.handleKeyPress(keyPress("y"))
+	if updated.(Model).ActiveModal != ModalNone || cmd == nil {
+		t.Fatal("confirming should start the restart")
+	}
+	cmd()
+	if restarts != 1 {
+		t.Errorf("restarts = %d, want 1", restarts)
+	}
+
+	m.ActiveModal = ModalNone
+	m.ConfirmThresholds = ConfirmThresholds{TerminateSessions: 1}
+	if updated, cmd := m.handleKeyPress(keyPress("X")); updated.(Model).ActiveModal != ModalNone || cmd == nil {
+		t.Error("a restart within the terminate threshold should run right away")
+	}
+}
+
+func TestRenderSyncStatusModal_RunningConfig(t *testing.T) {
+	m := newTestModel()
+	mode := "one-way-replica"
+	session := &mutagen.SyncSession{Name: "s", Mode: &mode, Ignore: &mutagen.IgnoreConfiguration{Paths: []string{".env"}},
+		Compression: &mutagen.Compression{Algorithm: "zstandard"}}
+	m.GetSelectedSession = func() *mutagen.SyncSession { return session }
+
+	out := m.renderSyncStatusModal()
+	for _, want := range []string{"Running config:", "Mode: one-way-replica", "Ignores: .env", "Watch: default"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("modal should contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "≠ file") {
+		t.Errorf("modal shouldn't compare with a file it doesn't have:\n%s", out)
+	}
+
+	m.GetFileConfig = func() (mutagen.SessionConfig, bool) {
+		return mutagen.SessionConfig{Mode: mode, IgnorePaths: []string{".env", "build"}}, true
+	}
+	out = m.renderSyncStatusModal()
+	if !strings.Contains(out, "Ignores: .env ≠ file: .env, build") {
+		t.Errorf("modal should show the file's ignores beside the running ones:\n%s", out)
+	}
+	if strings.Contains(out, "Mode: one-way-replica ≠") {
+		t.Errorf("modal shouldn't flag a mode that matches:\n%s", out)
+	}
+	if strings.Contains(out, "Compression: zstandard ≠") {
+		t.Errorf("modal shouldn't compare compression, which files can't set:\n%s", out)
+	}
+}
+
+func TestSwappedEndpoints_MarkedAndExplained(t *testing.T) {
+	proj := makeTestProject("proj", 2, false)
+	proj.File.Sessions["spec-b"] = project.SessionDefinition{Alpha: "server:/remote", Beta: "/local"}
+	m := newTestModel(proj)
+
+	if row := m.renderSpecRow(proj, &proj.Specs[0], 100, true); strings.Contains(row, "⚠") {
+		t.Errorf("local-to-remote row shouldn't be marked: %q", row)
+	}
+	if row := m.renderSpecRow(proj, &proj.Specs[1], 100, true); !strings.Contains(row, "spec-b ⚠") {
+		t.Errorf("remote-to-local row should be marked: %q", row)
+	}
+
+	m.GetSelectedSession = func() *mutagen.SyncSession { return nil }
+	m.Selection.SelectNext()
+	m.Selection.SelectNext()
+	if out := m.renderSyncStatusModal(); !strings.Contains(out, "alpha is remote and its beta local") {
+		t.Errorf("sync status should explain the mark:\n%s", out)
+	}
+
+	proj.File.Hooks.RemoteAlpha = true
+	if row := m.renderSpecRow(proj, &proj.Specs[1], 100, true); strings.Contains(row, "⚠") {
+		t.Errorf("remote_alpha should silence the mark: %q", row)
+	}
+}
+
+func TestRenderSyncStatusModal_FileSettings(t *testing.T) {
+	m := newTestModel()
+	session := &mutagen.SyncSession{Name: "s"}
+	m.GetSelectedSession = func() *mutagen.SyncSession { return session }
+	m.DescribeFileSettings = func() []string { return []string{"watch.mode: force-poll (spec, overriding defaults)"} }
+
+	out := m.renderSyncStatusModal()
+	if !strings.Contains(out, "From the project file:") || !strings.Contains(out, "watch.mode: force-poll (spec, overriding defaults)") {
+		t.Errorf("modal should list the file's settings with their sources:\n%s", out)
+	}
+
+	m.DescribeFileSettings = func() []string { return nil }
+	if out := m.renderSyncStatusModal(); strings.Contains(out, "From the project file:") {
+		t.Errorf("modal shouldn't show an empty section:\n%s", out)
+	}
+}
@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// wheelLines is how many lines a notch of the mouse wheel scrolls a dialog.
+const wheelLines = 3
+
+// modalBodyTop is the number of rows above a dialog's body: its top border,
+// its padding, its title, and the blank line below the title.
+const modalBodyTop = 4
+
+func (m Model) handleMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action == tea.MouseActionPress {
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			return m.scrollWheel(-1), nil
+		case tea.MouseButtonWheelDown:
+			return m.scrollWheel(1), nil
+		}
+	}
+
+	// Only handle left click
+	if msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionRelease {
+		return m, nil
+	}
+	if m.ActiveModal != ModalNone {
+		return m.clickModal(msg.Y), nil
+	}
+	if m.forwards.shown {
+		return m, nil
+	}
+
+	clickedIndex, ok := m.listRowAt(msg.Y)
+	if !ok {
+		return m, nil
+	}
+	// Clicking the selected project toggles its fold
+	item := m.Selection.ItemAt(clickedIndex)
+	if item != nil && item.Type == SelectableProject && clickedIndex == m.Selection.RawIndex() && m.OnToggleFold != nil {
+		m.OnToggleFold(item.ProjectIndex)
+		m.Selection.RebuildFromProjects(m.Projects)
+		m.Selection.SelectProject(item.ProjectIndex)
+		return m, nil
+	}
+	// Select the clicked project or spec
+	m.Selection.SetIndex(clickedIndex)
+	return m, nil
+}
+
+// listRowAt returns the index of the list item drawn on screen row y, or
+// false if there is none there.
+func (m Model) listRowAt(y int) (int, bool) {
+	// The first item follows the header, the list's top border, its title,
+	// and the column header of a table; the status and help bars follow the
+	// last
+	border := m.borderSize() / 2
+	listTop := lipgloss.Height(m.renderHeader()) + border + 1
+	if len(m.Columns) > 0 {
+		listTop++
+	}
+	listBottom := m.Height - lipgloss.Height(m.renderStatus()) - lipgloss.Height(m.renderHelp()) - border
+	if y < listTop || y >= listBottom {
+		return 0, false
+	}
+	index := y - listTop
+	return index, index < m.Selection.TotalItems()
+}
+
+// scrollWheel scrolls the open dialog by a few lines for each notch of the
+// wheel, or moves the list selection by a row. Dialogs with a selected row
+// rather than a scroll offset move the selection.
+func (m Model) scrollWheel(delta int) Model {
+	scroll := func(offset, total, height int) int {
+		return clampOffset(offset+delta*wheelLines, total, height)
+	}
+	switch m.ActiveModal {
+	case ModalNone:
+		if delta < 0 {
+			m.Selection.SelectPrevious()
+		} else {
+			m.Selection.SelectNext()
+		}
+	case ModalHelp:
+		m.modalOffset = scroll(m.modalOffset, len(m.helpLines()), m.modalBodyHeight(0))
+	case ModalConflicts:
+		lines, _, _ := m.conflictListLines()
+		m.modalOffset = scroll(m.modalOffset, len(lines), m.conflictListHeight())
+	case ModalEventLog:
+		m.modalOffset = scroll(m.modalOffset, len(m.eventLogLines()), m.modalBodyHeight(0))
+	case ModalSessionLog:
+		m.logOffset = scroll(m.logOffset, len(m.sessionLogLines()), m.modalBodyHeight(0))
+	case ModalDaemonSessions:
+		if !m.daemon.confirming {
+			m.daemon.index = max(min(m.daemon.index+delta, len(m.daemon.sessions)-1), 0)
+		}
+	}
+	return m
+}
+
+// clickModal selects the row clicked on in a dialog that has selectable
+// rows.
+func (m Model) clickModal(y int) Model {
+	switch m.ActiveModal {
+	case ModalConflicts:
+		if index, ok := m.conflictAtRow(y); ok {
+			m.conflictCursor = index
+			m.followConflictCursor()
+		}
+	case ModalDaemonSessions:
+		if index, ok := m.daemonSessionAtRow(y); ok && !m.daemon.confirming {
+			m.daemon.index = index
+		}
+	}
+	return m
+}
+
+// modalTop returns the screen row of a dialog's top border, as
+// overlayModal centers it.
+func (m Model) modalTop(modal string) int {
+	return max((m.Height-lipgloss.Height(modal))/2, 0)
+}
+
+// windowRowAt returns the index of the line drawn on screen row y by
+// renderScrollWindow, for a window of height lines starting at offset
+// whose first row is drawn on row top.
+func windowRowAt(y, top, offset, total, height int) (int, bool) {
+	start := clampOffset(offset, total, height)
+	row := y - top
+	if start > 0 {
+		row-- // The count of lines above
+	}
+	if row < 0 || row >= min(height, total-start) {
+		return 0, false
+	}
+	return start + row, true
+}
+
+// conflictAtRow returns the index of the conflict drawn on screen row y
+// of the conflicts dialog.
+func (m Model) conflictAtRow(y int) (int, bool) {
+	if m.GetConflicts == nil {
+		return 0, false
+	}
+	lines, owners, _, _ := m.conflictListRows()
+	if len(lines) == 0 {
+		return 0, false
+	}
+	header := strings.Count(m.conflictModalHeader(m.GetConflicts()), "\n")
+	top := m.modalTop(m.renderConflictModal()) + modalBodyTop + header
+	line, ok := windowRowAt(y, top, m.modalOffset, len(lines), m.conflictListHeight())
+	if !ok || owners[line] < 0 {
+		return 0, false
+	}
+	return owners[line], true
+}
+
+// daemonSessionAtRow returns the index of the session drawn on screen row
+// y of the daemon sessions dialog.
+func (m Model) daemonSessionAtRow(y int) (int, bool) {
+	d := m.daemon
+	if d.err != nil || len(d.sessions) == 0 {
+		return 0, false
+	}
+	start, end := m.daemonSessionsWindow()
+	row := y - m.modalTop(m.renderDaemonSessionsModal()) - modalBodyTop
+	if start > 0 {
+		row-- // The count of sessions above
+	}
+	if row < 0 || row >= end-start {
+		return 0, false
+	}
+	return start + row, true
+}
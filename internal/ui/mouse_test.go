@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+func click(m Model, y int) Model {
+	updated, _ := m.handleMouseEvent(tea.MouseMsg{X: 10, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease})
+	return updated.(Model)
+}
+
+func wheel(m Model, button tea.MouseButton) Model {
+	updated, _ := m.handleMouseEvent(tea.MouseMsg{X: 10, Y: 5, Button: button, Action: tea.MouseActionPress})
+	return updated.(Model)
+}
+
+// screenRow returns the last row of the view that contains text.
+func screenRow(t *testing.T, m Model, text string) int {
+	t.Helper()
+	row := -1
+	for i, line := range strings.Split(m.View(), "\n") {
+		if strings.Contains(line, text) {
+			row = i
+		}
+	}
+	if row < 0 {
+		t.Fatalf("%q isn't on screen:\n%s", text, m.View())
+	}
+	return row
+}
+
+func TestListRowAt(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 2, false))
+	m.Width, m.Height = 80, 20
+
+	row := screenRow(t, m, "spec-b")
+	if index, ok := m.listRowAt(row); !ok || index != 2 {
+		t.Errorf("listRowAt(%d) = %d, %v; want 2", row, index, ok)
+	}
+	if _, ok := m.listRowAt(row + 1); ok {
+		t.Error("the row below the last item has no item")
+	}
+	if _, ok := m.listRowAt(0); ok {
+		t.Error("the header has no item")
+	}
+
+	// With a dialog open, a click on the list doesn't select anything
+	m.ActiveModal = ModalHelp
+	if m = click(m, row); m.Selection.RawIndex() != 0 {
+		t.Errorf("click behind a dialog selected item %d", m.Selection.RawIndex())
+	}
+}
+
+func TestConflictsModal_ClickSelects(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.Width, m.Height = 100, 30
+	conflicts := make([]mutagen.Conflict, 40)
+	for i := range conflicts {
+		conflicts[i] = mutagen.Conflict{Root: fmt.Sprintf("file%d", i)}
+	}
+	m.GetConflicts = func() []SessionConflicts {
+		return []SessionConflicts{{SpecName: "web", Session: &mutagen.SyncSession{}, Conflicts: conflicts}}
+	}
+	m = press(m, "c")
+
+	m = click(m, screenRow(t, m, "file2"))
+	if m.conflictCursor != 2 {
+		t.Errorf("conflictCursor = %d, want 2", m.conflictCursor)
+	}
+	m = click(m, screenRow(t, m, "web"))
+	if m.conflictCursor != 2 {
+		t.Errorf("clicking the session name moved the cursor to %d", m.conflictCursor)
+	}
+
+	// Rows are found below the count of lines scrolled away
+	m = wheel(m, tea.MouseButtonWheelDown)
+	m = wheel(m, tea.MouseButtonWheelDown)
+	if m.modalOffset != 2*wheelLines {
+		t.Fatalf("modalOffset = %d after two notches, want %d", m.modalOffset, 2*wheelLines)
+	}
+	m = click(m, screenRow(t, m, "file9"))
+	if m.conflictCursor != 9 {
+		t.Errorf("conflictCursor = %d after scrolling, want 9", m.conflictCursor)
+	}
+}
+
+func TestDaemonSessionsModal_ClickAndWheelSelect(t *testing.T) {
+	m := newTestModel()
+	m.Width, m.Height = 120, 30
+	m.ActiveModal = ModalDaemonSessions
+	m.daemon.sessions = []mutagen.SyncSession{{Name: "alpha"}, {Name: "bravo"}, {Name: "charlie"}}
+
+	m = click(m, screenRow(t, m, "charlie"))
+	if m.daemon.index != 2 {
+		t.Errorf("index = %d after clicking charlie, want 2", m.daemon.index)
+	}
+	m = wheel(m, tea.MouseButtonWheelUp)
+	if m.daemon.index != 1 {
+		t.Errorf("index = %d after wheel up, want 1", m.daemon.index)
+	}
+}
+
+func TestWheel_ScrollsHelpAndList(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 2, false))
+	m.Height = 20
+
+	m = wheel(m, tea.MouseButtonWheelDown)
+	if m.Selection.RawIndex() != 1 {
+		t.Errorf("selection = %d after wheel down, want 1", m.Selection.RawIndex())
+	}
+
+	m = press(m, "?")
+	m = wheel(m, tea.MouseButtonWheelDown)
+	if m.modalOffset != wheelLines {
+		t.Errorf("modalOffset = %d, want %d", m.modalOffset, wheelLines)
+	}
+	m = wheel(m, tea.MouseButtonWheelUp)
+	m = wheel(m, tea.MouseButtonWheelUp)
+	if m.modalOffset != 0 {
+		t.Errorf("modalOffset = %d, want the top", m.modalOffset)
+	}
+	if m.Selection.RawIndex() != 1 {
+		t.Errorf("wheel in the help moved the list selection to %d", m.Selection.RawIndex())
+	}
+}
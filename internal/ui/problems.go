@@ -0,0 +1,34 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+// SpecHasProblems returns whether any of a spec's sessions has conflicts,
+// scan problems, or has halted, or is running with an endpoint that isn't
+// connected. Paused sessions aren't connected, and don't count for that.
+func SpecHasProblems(spec *project.SyncSpec) bool {
+	for _, session := range spec.Sessions() {
+		switch {
+		case session.HasConflicts(), session.HasScanProblems(), session.IsHalted():
+			return true
+		case !session.Paused && (!session.Alpha.Connected || !session.Beta.Connected):
+			return true
+		}
+	}
+	return false
+}
+
+// toggleProblemsOnly switches the list between every spec and only those
+// with problems.
+func (m Model) toggleProblemsOnly() (tea.Model, tea.Cmd) {
+	on := !m.Selection.ProblemsOnly()
+	m.Selection.SetProblemsOnly(on, m.Projects)
+	if on {
+		m.StatusMessage = &StatusMessage{Type: StatusInfo, Text: "Showing only specs with problems"}
+	} else {
+		m.StatusMessage = &StatusMessage{Type: StatusInfo, Text: "Showing all specs"}
+	}
+	return m, nil
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+// Spec rows on terminals narrower than narrowRowWidth show staging progress
+// as a percentage; wider ones draw a bar up to maxRowBarWidth cells wide.
+const (
+	narrowRowWidth = 76
+	minRowBarWidth = 4
+	maxRowBarWidth = 12
+)
+
+// progressCells splits a bar of width cells into the filled cells for pct
+// percent and the empty remainder. pct is clamped to 0–100.
+func progressCells(pct, width int) (filled, empty string) {
+	pct = min(max(pct, 0), 100)
+	n := pct * width / 100
+	return strings.Repeat(mutagen.Icon("█", "#"), n), strings.Repeat(mutagen.Icon("░", "-"), width-n)
+}
+
+// renderProgressBar draws pct percent as a bar of width cells in the
+// theme's progress colors.
+func (m Model) renderProgressBar(pct, width int) string {
+	filled, empty := progressCells(pct, width)
+	return m.Theme.ProgressFilled.Render(filled) + m.Theme.ProgressEmpty.Render(empty)
+}
+
+// rowBarWidth returns the width of the staging bar in a spec row maxWidth
+// columns wide, or 0 if the row is too narrow for one.
+func rowBarWidth(maxWidth int) int {
+	if maxWidth < narrowRowWidth {
+		return 0
+	}
+	return min(minRowBarWidth+(maxWidth-narrowRowWidth)/4, maxRowBarWidth)
+}
+
+// stagingStatus returns the status column text for a session staging pct
+// percent on endpoint, as in "Staging α ████░░░░". Rows too narrow for a
+// bar show the percentage instead, as in "Staging α (45%)". Selected rows
+// are drawn unstyled, like the rest of the selected row.
+func (m Model) stagingStatus(endpoint string, pct, maxWidth int, selected bool) string {
+	width := rowBarWidth(maxWidth)
+	if width == 0 {
+		return fmt.Sprintf("Staging %s (%d%%)", endpoint, pct)
+	}
+	if selected {
+		filled, empty := progressCells(pct, width)
+		return "Staging " + endpoint + " " + filled + empty
+	}
+	return "Staging " + endpoint + " " + m.renderProgressBar(pct, width)
+}
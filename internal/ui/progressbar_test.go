@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestRenderProgressBar(t *testing.T) {
+	m := newTestModel()
+	tests := []struct {
+		pct  int
+		want string
+	}{
+		{0, "░░░░░░░░░░"},
+		{50, "█████░░░░░"},
+		{100, "██████████"},
+		{150, "██████████"},
+		{-5, "░░░░░░░░░░"},
+	}
+	for _, tt := range tests {
+		filled, empty := progressCells(tt.pct, 10)
+		if got := filled + empty; got != tt.want {
+			t.Errorf("progressCells(%d, 10) = %q, want %q", tt.pct, got, tt.want)
+		}
+		if got := m.renderProgressBar(tt.pct, 10); !strings.Contains(got, filled) || !strings.Contains(got, empty) {
+			t.Errorf("renderProgressBar(%d, 10) = %q, want the cells %q", tt.pct, got, tt.want)
+		}
+	}
+}
+
+func TestRowBarWidth(t *testing.T) {
+	tests := []struct {
+		maxWidth, want int
+	}{
+		{60, 0},
+		{narrowRowWidth, minRowBarWidth},
+		{100, 10},
+		{200, maxRowBarWidth},
+	}
+	for _, tt := range tests {
+		if got := rowBarWidth(tt.maxWidth); got != tt.want {
+			t.Errorf("rowBarWidth(%d) = %d, want %d", tt.maxWidth, got, tt.want)
+		}
+	}
+}
+
+func TestRenderSpecRow_StagingBar(t *testing.T) {
+	received, expected := uint64(50), uint64(100)
+	proj := makeTestProject("p", 1, false)
+	proj.Specs[0].State = project.RunningTwoWay
+	proj.Specs[0].RunningSession = &mutagen.SyncSession{
+		Name:   "spec-a",
+		Status: "staging-beta",
+		Beta: mutagen.Endpoint{StagingProgress: &mutagen.StagingProgress{
+			ReceivedFiles: &received, ExpectedFiles: &expected,
+		}},
+	}
+	m := newTestModel(proj)
+
+	if out := m.renderSpecRow(proj, &proj.Specs[0], 100, true); !strings.Contains(out, "Staging β █████░░░░░") {
+		t.Errorf("wide row should draw a bar: %q", out)
+	}
+	if out := m.renderSpecRow(proj, &proj.Specs[0], 70, true); !strings.Contains(out, "Staging β (50%)") {
+		t.Errorf("narrow row should show the percentage: %q", out)
+	}
+}
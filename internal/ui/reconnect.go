@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// Disconnection describes a session whose endpoints have stayed
+// disconnected past the reconnect threshold.
+type Disconnection struct {
+	For      time.Duration // How long the session has been disconnected
+	Attempts int           // Reconnects tried so far, automatic or by hand
+}
+
+// formatDisconnection renders a disconnection for a spec row, as in
+// " disconnected 3m (ctrl+r reconnects)", or " reconnecting (attempt 2)"
+// once reconnects have been tried.
+func formatDisconnection(d Disconnection) string {
+	if d.Attempts > 0 {
+		return fmt.Sprintf(" reconnecting (attempt %d)", d.Attempts)
+	}
+	return " disconnected " + formatElapsed(d.For) + " (" + keys.Reconnect.Help().Key + " reconnects)"
+}
+
+// formatElapsed formats a duration coarsely, as in 45s, 3m, or 2h5m.
+func formatElapsed(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	default:
+		return fmt.Sprintf("%dh%dm", int(d/time.Hour), int(d/time.Minute)%60)
+	}
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestFormatDisconnection(t *testing.T) {
+	tests := []struct {
+		d    Disconnection
+		want string
+	}{
+		{Disconnection{For: 45 * time.Second}, " disconnected 45s (ctrl+r reconnects)"},
+		{Disconnection{For: 3*time.Minute + 20*time.Second}, " disconnected 3m (ctrl+r reconnects)"},
+		{Disconnection{For: 2*time.Hour + 5*time.Minute}, " disconnected 2h5m (ctrl+r reconnects)"},
+		{Disconnection{For: time.Hour, Attempts: 2}, " reconnecting (attempt 2)"},
+	}
+	for _, tt := range tests {
+		if got := formatDisconnection(tt.d); got != tt.want {
+			t.Errorf("formatDisconnection(%+v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestRenderSpecRow_ShowsDisconnection(t *testing.T) {
+	proj := makeTestProject("proj", 1, false)
+	spec := &proj.Specs[0]
+	spec.State = project.RunningTwoWay
+	spec.RunningSession = &mutagen.SyncSession{Name: "spec-a", Status: "connecting-beta"}
+	m := newTestModel(proj)
+	m.ShowPaths = false
+
+	if row := m.renderSpecRow(proj, spec, 200, true); strings.Contains(row, "disconnected") {
+		t.Errorf("row = %q, want no disconnection without the callback", row)
+	}
+	m.Disconnection = func(*mutagen.SyncSession) (Disconnection, bool) {
+		return Disconnection{For: 5 * time.Minute}, true
+	}
+	if row := m.renderSpecRow(proj, spec, 200, true); !strings.Contains(row, "disconnected 5m (ctrl+r reconnects)") {
+		t.Errorf("row = %q, want the disconnection", row)
+	}
+}
+
+func TestReconnectKey(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 1, false))
+	m.Selection.SetIndex(1)
+	reconnected := 0
+	m.OnReconnect = func(ctx context.Context) *StatusMessage {
+		reconnected++
+		return &StatusMessage{Type: StatusInfo, Text: "Reconnecting 1 session(s)"}
+	}
+
+	updated, cmd := m.handleKeyPress(keyPress("ctrl+r"))
+	if cmd == nil || !updated.(Model).IsLoading {
+		t.Fatal("ctrl+r should start a reconnect")
+	}
+	cmd()
+	if reconnected != 1 {
+		t.Errorf("reconnected = %d, want 1", reconnected)
+	}
+}
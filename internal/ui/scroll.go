@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// modalBodyHeight returns how many lines of a scrolling dialog's body fit on
+// screen, leaving room for the dialog's border, padding, title, and footer,
+// and for fixed lines shown above the scrolled lines.
+func (m Model) modalBodyHeight(fixed int) int {
+	return max(m.Height-10-fixed, 3)
+}
+
+// clampOffset keeps a scroll offset within the lines of a dialog's body, so
+// that a window of height lines is as full as it can be.
+func clampOffset(offset, total, height int) int {
+	return max(min(offset, total-height), 0)
+}
+
+// scrollOffset returns offset moved by a scroll key: ↑ and ↓ move a line,
+// page up and page down a window. ok is false if msg isn't a scroll key.
+func scrollOffset(msg tea.KeyMsg, offset, total, height int) (newOffset int, ok bool) {
+	switch {
+	case key.Matches(msg, keys.Up):
+		offset--
+	case key.Matches(msg, keys.Down):
+		offset++
+	case key.Matches(msg, keys.PageUp):
+		offset -= max(height-1, 1)
+	case key.Matches(msg, keys.PageDown):
+		offset += max(height-1, 1)
+	default:
+		return offset, false
+	}
+	return clampOffset(offset, total, height), true
+}
+
+// followOffset returns an offset that keeps lines first through last in a
+// window of height lines, moving offset as little as possible.
+func followOffset(offset, first, last, height int) int {
+	if last >= offset+height {
+		offset = last - height + 1
+	}
+	return max(min(offset, first), 0)
+}
+
+// renderScrollWindow renders the lines of a dialog's body that fit in
+// height, starting at offset, with counts of the lines scrolled out of view.
+func (m Model) renderScrollWindow(lines []string, offset, height int) string {
+	start := clampOffset(offset, len(lines), height)
+	end := min(start+height, len(lines))
+
+	var content strings.Builder
+	if start > 0 {
+		content.WriteString(m.Theme.ModalHelp.Render(fmt.Sprintf("↑ %d more above", start)) + "\n")
+	}
+	for _, line := range lines[start:end] {
+		content.WriteString(line + "\n")
+	}
+	if end < len(lines) {
+		content.WriteString(m.Theme.ModalHelp.Render(fmt.Sprintf("↓ %d more below", len(lines)-end)) + "\n")
+	}
+	return content.String()
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+func TestClampOffset(t *testing.T) {
+	tests := []struct {
+		offset, total, height, want int
+	}{
+		{0, 10, 4, 0},
+		{3, 10, 4, 3},
+		{7, 10, 4, 6},  // The last window is kept full
+		{-2, 10, 4, 0}, // Not before the first line
+		{5, 3, 4, 0},   // Content shorter than the window doesn't scroll
+	}
+	for _, tt := range tests {
+		if got := clampOffset(tt.offset, tt.total, tt.height); got != tt.want {
+			t.Errorf("clampOffset(%d, %d, %d) = %d, want %d", tt.offset, tt.total, tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestScrollOffset(t *testing.T) {
+	tests := []struct {
+		key            string
+		offset, height int
+		want           int
+	}{
+		{"down", 0, 10, 1},
+		{"up", 0, 10, 0},
+		{"pgdown", 0, 5, 4},    // A page keeps one line of context
+		{"pgdown", 18, 10, 20}, // 30 lines in a window of 10 stop at 20
+		{"pgup", 3, 10, 0},
+	}
+	for _, tt := range tests {
+		if got, ok := scrollOffset(keyPress(tt.key), tt.offset, 30, tt.height); !ok || got != tt.want {
+			t.Errorf("scrollOffset(%s, %d) = %d, %v; want %d", tt.key, tt.offset, got, ok, tt.want)
+		}
+	}
+	if _, ok := scrollOffset(keyPress("x"), 0, 30, 10); ok {
+		t.Error("x isn't a scroll key")
+	}
+}
+
+func TestFollowOffset(t *testing.T) {
+	tests := []struct {
+		offset, first, last, height, want int
+	}{
+		{0, 2, 4, 10, 0},    // Already visible
+		{0, 12, 15, 10, 6},  // Below: scroll until the last line shows
+		{8, 3, 5, 10, 3},    // Above: scroll back to the first line
+		{0, 12, 30, 10, 12}, // Taller than the window: show its start
+	}
+	for _, tt := range tests {
+		if got := followOffset(tt.offset, tt.first, tt.last, tt.height); got != tt.want {
+			t.Errorf("followOffset(%d, %d, %d, %d) = %d, want %d", tt.offset, tt.first, tt.last, tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestHelpModal_Scrolls(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.Height = 20
+	m = press(m, "?")
+	lines := len(m.helpLines())
+	height := m.modalBodyHeight(0)
+	if lines <= height {
+		t.Fatalf("help has %d lines, want more than %d for this test", lines, height)
+	}
+
+	out := m.renderHelpModal()
+	if !strings.Contains(out, fmt.Sprintf("%d more below", lines-height)) || strings.Contains(out, "more above") {
+		t.Errorf("first page should only show what's below:\n%s", out)
+	}
+
+	// Paging past the end stops at the last full window
+	for range 20 {
+		m = press(m, "pgdown")
+	}
+	if m.modalOffset != lines-height {
+		t.Errorf("modalOffset = %d, want %d", m.modalOffset, lines-height)
+	}
+	out = m.renderHelpModal()
+	if !strings.Contains(out, fmt.Sprintf("%d more above", lines-height)) || strings.Contains(out, "more below") {
+		t.Errorf("last page should only show what's above:\n%s", out)
+	}
+
+	m = press(m, "up")
+	if m.modalOffset != lines-height-1 {
+		t.Errorf("modalOffset = %d after up, want %d", m.modalOffset, lines-height-1)
+	}
+	m = press(m, "?")
+	if m.ActiveModal != ModalNone {
+		t.Error("? should still close the help")
+	}
+}
+
+func TestConflictsModal_ScrollsToCursor(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.Height = 24
+	conflicts := make([]mutagen.Conflict, 10)
+	for i := range conflicts {
+		conflicts[i] = mutagen.Conflict{Root: fmt.Sprintf("file%d", i)}
+	}
+	m.GetConflicts = func() []SessionConflicts {
+		return []SessionConflicts{{SpecName: "web", Session: &mutagen.SyncSession{}, Conflicts: conflicts}}
+	}
+	m = press(m, "c")
+
+	for range 9 {
+		m = press(m, "down")
+	}
+	lines, first, last := m.conflictListLines()
+	height := m.conflictListHeight()
+	if m.modalOffset > first || last >= m.modalOffset+height {
+		t.Errorf("cursor lines %d-%d not within window %d+%d", first, last, m.modalOffset, height)
+	}
+	if m.modalOffset != clampOffset(m.modalOffset, len(lines), height) {
+		t.Errorf("modalOffset = %d isn't clamped", m.modalOffset)
+	}
+	if out := m.renderConflictModal(); !strings.Contains(out, "file9") || strings.Contains(out, "file0") {
+		t.Errorf("the last conflict should be in view, the first scrolled away:\n%s", out)
+	}
+
+	for range 9 {
+		m = press(m, "up")
+	}
+	if m.modalOffset != 0 {
+		t.Errorf("modalOffset = %d back at the first conflict, want 0", m.modalOffset)
+	}
+}
@@ -1,6 +1,9 @@
 package ui
 
-import "github.com/osteele/mutagui/internal/project"
+import (
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
 
 // SelectableItemType represents the type of item in the selection list.
 type SelectableItemType int
@@ -10,6 +13,8 @@ const (
 	SelectableProject SelectableItemType = iota
 	// SelectableSpec represents a sync spec within a project.
 	SelectableSpec
+	// SelectableForward represents a forward session in the forwards view.
+	SelectableForward
 )
 
 // SelectableItem represents an item that can be selected in the unified panel.
@@ -17,12 +22,15 @@ type SelectableItem struct {
 	Type         SelectableItemType
 	ProjectIndex int
 	SpecIndex    int // Only valid when Type == SelectableSpec
+	ForwardIndex int // Only valid when Type == SelectableForward
 }
 
 // SelectionManager manages selection state in the unified project/spec tree.
 type SelectionManager struct {
 	items         []SelectableItem
 	selectedIndex int
+	filter        Filter
+	problemsOnly  bool // List only specs with problems; see SpecHasProblems
 }
 
 // NewSelectionManager creates a new SelectionManager.
@@ -33,11 +41,103 @@ func NewSelectionManager() *SelectionManager {
 	}
 }
 
+// SetFilter sets the filter applied by subsequent rebuilds.
+func (sm *SelectionManager) SetFilter(f Filter) {
+	sm.filter = f
+}
+
+// ApplyFilter sets the filter and rebuilds the items, keeping the selected
+// item if it still matches, else its project if that still matches. When
+// neither does, the first match is selected.
+func (sm *SelectionManager) ApplyFilter(f Filter, projects []*project.Project) {
+	prev := sm.SelectedItem()
+	var target SelectableItem
+	if prev != nil {
+		target = *prev
+	}
+
+	sm.filter = f
+	sm.RebuildFromProjects(projects)
+	if prev == nil || sm.SelectItem(target) {
+		return
+	}
+	sm.selectedIndex = 0
+	sm.SelectProject(target.ProjectIndex)
+}
+
+// Filter returns the active filter.
+func (sm *SelectionManager) Filter() Filter {
+	return sm.filter
+}
+
+// SetProblemsOnly lists only specs with problems, with their projects, or
+// lists every spec again, and rebuilds the items as ApplyFilter does.
+func (sm *SelectionManager) SetProblemsOnly(on bool, projects []*project.Project) {
+	prev := sm.SelectedItem()
+	var target SelectableItem
+	if prev != nil {
+		target = *prev
+	}
+
+	sm.problemsOnly = on
+	sm.RebuildFromProjects(projects)
+	if prev == nil || sm.SelectItem(target) {
+		return
+	}
+	sm.selectedIndex = 0
+	sm.SelectProject(target.ProjectIndex)
+}
+
+// ProblemsOnly returns whether only specs with problems are listed.
+func (sm *SelectionManager) ProblemsOnly() bool {
+	return sm.problemsOnly
+}
+
+// matchesSpec returns whether the filter and problems-only mode list the spec.
+func (sm *SelectionManager) matchesSpec(proj *project.Project, spec *project.SyncSpec) bool {
+	return sm.filter.MatchesSpec(proj, spec) && (!sm.problemsOnly || SpecHasProblems(spec))
+}
+
+// matchesProject returns whether the filter and problems-only mode list
+// the project.
+func (sm *SelectionManager) matchesProject(proj *project.Project) bool {
+	if !sm.problemsOnly {
+		return sm.filter.MatchesProject(proj)
+	}
+	for i := range proj.Specs {
+		if sm.matchesSpec(proj, &proj.Specs[i]) {
+			return true
+		}
+	}
+	return false
+}
+
 // RebuildFromProjects rebuilds the items list from projects.
+// While a filter or problems-only mode is active, only matching projects
+// and specs are included, and matching specs are shown even in folded
+// projects. The selected item
+// stays selected if it is still listed, even if the items before it
+// changed; otherwise the selection keeps its position.
 func (sm *SelectionManager) RebuildFromProjects(projects []*project.Project) {
+	prev := sm.SelectedItem()
+	var target SelectableItem
+	if prev != nil {
+		target = *prev
+	}
+	defer func() {
+		if prev != nil {
+			sm.SelectItem(target)
+		}
+	}()
+
 	sm.items = sm.items[:0] // Clear but keep capacity
+	filtering := !sm.filter.IsEmpty() || sm.problemsOnly
 
 	for projIdx, proj := range projects {
+		if filtering && !sm.matchesProject(proj) {
+			continue
+		}
+
 		// Add project header
 		sm.items = append(sm.items, SelectableItem{
 			Type:         SelectableProject,
@@ -45,8 +145,11 @@ func (sm *SelectionManager) RebuildFromProjects(projects []*project.Project) {
 		})
 
 		// Add specs if unfolded
-		if !proj.Folded {
+		if !proj.Folded || filtering {
 			for specIdx := range proj.Specs {
+				if filtering && !sm.matchesSpec(proj, &proj.Specs[specIdx]) {
+					continue
+				}
 				sm.items = append(sm.items, SelectableItem{
 					Type:         SelectableSpec,
 					ProjectIndex: projIdx,
@@ -64,6 +167,25 @@ func (sm *SelectionManager) RebuildFromProjects(projects []*project.Project) {
 	}
 }
 
+// RebuildFromForwards rebuilds the items list from forward sessions. The
+// filter doesn't apply to forwards.
+func (sm *SelectionManager) RebuildFromForwards(forwards []mutagen.ForwardSession) {
+	sm.items = sm.items[:0]
+	for i := range forwards {
+		sm.items = append(sm.items, SelectableItem{
+			Type:         SelectableForward,
+			ProjectIndex: -1,
+			ForwardIndex: i,
+		})
+	}
+
+	if len(sm.items) > 0 && sm.selectedIndex >= len(sm.items) {
+		sm.selectedIndex = len(sm.items) - 1
+	} else if len(sm.items) == 0 {
+		sm.selectedIndex = 0
+	}
+}
+
 // TotalItems returns the total number of items.
 func (sm *SelectionManager) TotalItems() int {
 	return len(sm.items)
@@ -107,6 +229,16 @@ func (sm *SelectionManager) SelectedSpec() (int, int) {
 	return item.ProjectIndex, item.SpecIndex
 }
 
+// SelectedForward returns the index of the selected forward session, or -1
+// if no forward is selected.
+func (sm *SelectionManager) SelectedForward() int {
+	item := sm.SelectedItem()
+	if item == nil || item.Type != SelectableForward {
+		return -1
+	}
+	return item.ForwardIndex
+}
+
 // IsProjectSelected returns true if a project is selected (not a spec).
 func (sm *SelectionManager) IsProjectSelected() bool {
 	item := sm.SelectedItem()
@@ -153,6 +285,30 @@ func (sm *SelectionManager) SetIndex(index int) {
 	}
 }
 
+// SelectProject moves the selection to the header of the given project.
+// It is used after folding so that a selected spec hands its selection to
+// its parent rather than to whatever item now occupies its index.
+func (sm *SelectionManager) SelectProject(projIdx int) {
+	for i, item := range sm.items {
+		if item.Type == SelectableProject && item.ProjectIndex == projIdx {
+			sm.selectedIndex = i
+			return
+		}
+	}
+}
+
+// SelectItem moves the selection to the given item.
+// Returns false and leaves the selection unchanged if the item is not listed.
+func (sm *SelectionManager) SelectItem(target SelectableItem) bool {
+	for i, item := range sm.items {
+		if item == target {
+			sm.selectedIndex = i
+			return true
+		}
+	}
+	return false
+}
+
 // ItemAt returns the item at the given index, or nil if out of bounds.
 func (sm *SelectionManager) ItemAt(index int) *SelectableItem {
 	if index >= 0 && index < len(sm.items) {
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/osteele/mutagui/internal/mutagen"
@@ -102,6 +103,37 @@ func TestSelectionManager_RebuildFromProjects_Unfolded(t *testing.T) {
 	}
 }
 
+func TestSelectionManager_RebuildFromProjects_KeepsSelectedItem(t *testing.T) {
+	sm := NewSelectionManager()
+	projects := []*project.Project{
+		makeTestProject("p1", 2, false),
+		makeTestProject("p2", 2, false),
+	}
+	sm.RebuildFromProjects(projects)
+	sm.SetIndex(4) // p2's spec-a
+	want := *sm.SelectedItem()
+
+	// Folding p1 removes items before the selection
+	projects[0].Folded = true
+	sm.RebuildFromProjects(projects)
+	if got := sm.SelectedItem(); got == nil || *got != want {
+		t.Errorf("SelectedItem() = %+v, want %+v", got, want)
+	}
+
+	// A rebuild with nothing changed keeps the selection too
+	sm.RebuildFromProjects(projects)
+	if got := sm.SelectedItem(); got == nil || *got != want {
+		t.Errorf("SelectedItem() = %+v, want %+v", got, want)
+	}
+
+	// When the selected item goes away, the position is kept
+	projects[1].Folded = true
+	sm.RebuildFromProjects(projects)
+	if sm.RawIndex() != 1 {
+		t.Errorf("RawIndex() = %d, want the last item", sm.RawIndex())
+	}
+}
+
 func TestSelectionManager_SelectNext_Wraps(t *testing.T) {
 	sm := NewSelectionManager()
 	projects := []*project.Project{
@@ -308,3 +340,109 @@ func TestSelectionManager_WithRunningSessions(t *testing.T) {
 		t.Errorf("SelectedSpec() = (%d, %d), want (0, 0)", projIdx, specIdx)
 	}
 }
+
+func TestSelectionManager_SelectProject(t *testing.T) {
+	projects := []*project.Project{
+		makeTestProject("a", 2, false),
+		makeTestProject("b", 2, false),
+	}
+	sm := NewSelectionManager()
+	sm.RebuildFromProjects(projects)
+
+	sm.SelectProject(1)
+	if sm.RawIndex() != 3 || !sm.IsProjectSelected() {
+		t.Errorf("SelectProject(1) index = %d, want 3 (project b header)", sm.RawIndex())
+	}
+
+	// Unknown project leaves the selection alone
+	sm.SelectProject(5)
+	if sm.RawIndex() != 3 {
+		t.Errorf("SelectProject(5) index = %d, want unchanged 3", sm.RawIndex())
+	}
+}
+
+// runSpec gives a spec a running session, connected on both sides, that
+// change can then break.
+func runSpec(spec *project.SyncSpec, change func(s *mutagen.SyncSession)) {
+	session := &mutagen.SyncSession{
+		Name:   spec.Name,
+		Status: "watching",
+		Alpha:  mutagen.Endpoint{Connected: true},
+		Beta:   mutagen.Endpoint{Connected: true},
+	}
+	if change != nil {
+		change(session)
+	}
+	spec.State = project.RunningTwoWay
+	spec.RunningSession = session
+}
+
+func TestSelectionManager_ProblemsOnly(t *testing.T) {
+	healthy := makeTestProject("healthy", 3, true)
+	runSpec(&healthy.Specs[0], nil)
+	runSpec(&healthy.Specs[1], func(s *mutagen.SyncSession) {
+		s.Paused = true
+		s.Alpha.Connected, s.Beta.Connected = false, false
+	})
+	// healthy.Specs[2] isn't running
+
+	broken := makeTestProject("broken", 5, true)
+	runSpec(&broken.Specs[0], nil)
+	runSpec(&broken.Specs[1], func(s *mutagen.SyncSession) {
+		s.Conflicts = []mutagen.Conflict{{Root: "a.txt"}}
+	})
+	runSpec(&broken.Specs[2], func(s *mutagen.SyncSession) { s.Beta.Connected = false })
+	runSpec(&broken.Specs[3], func(s *mutagen.SyncSession) {
+		s.Alpha.ScanProblems = []mutagen.ScanProblem{{Path: "x", Error: "denied"}}
+	})
+	runSpec(&broken.Specs[4], func(s *mutagen.SyncSession) { s.Status = "halted-on-root-emptied" })
+
+	projects := []*project.Project{healthy, broken}
+	sm := NewSelectionManager()
+	sm.RebuildFromProjects(projects)
+	sm.SetProblemsOnly(true, projects)
+
+	var listed []string
+	for _, item := range sm.Items() {
+		if item.Type == SelectableSpec {
+			listed = append(listed, projects[item.ProjectIndex].Specs[item.SpecIndex].Name)
+		} else {
+			listed = append(listed, projects[item.ProjectIndex].File.DisplayName())
+		}
+	}
+	want := []string{"broken", "spec-b", "spec-c", "spec-d", "spec-e"}
+	if strings.Join(listed, " ") != strings.Join(want, " ") {
+		t.Errorf("items = %v, want %v", listed, want)
+	}
+
+	// The mode survives a refresh, and only the specs still broken stay
+	broken.Specs[1].RunningSession.Conflicts = nil
+	sm.RebuildFromProjects(projects)
+	if sm.TotalItems() != 4 {
+		t.Errorf("TotalItems() = %d after a conflict was resolved, want 4", sm.TotalItems())
+	}
+
+	sm.SetProblemsOnly(false, projects)
+	if sm.TotalItems() != 2 {
+		t.Errorf("TotalItems() = %d with every spec listed, want the 2 folded projects", sm.TotalItems())
+	}
+}
+
+func TestProblemsOnly_EmptyState(t *testing.T) {
+	proj := makeTestProject("proj", 1, false)
+	runSpec(&proj.Specs[0], nil)
+	m := newTestModel(proj)
+	m.Width, m.Height = 100, 20
+
+	m = press(m, "!")
+	if m.Selection.TotalItems() != 0 {
+		t.Errorf("TotalItems() = %d, want nothing listed", m.Selection.TotalItems())
+	}
+	if view := m.View(); !strings.Contains(view, "No problems") || !strings.Contains(view, "[problems only]") {
+		t.Errorf("view should say there are no problems:\n%s", view)
+	}
+	m = press(m, "!")
+	if m.Selection.ProblemsOnly() || m.Selection.TotalItems() != 2 {
+		t.Errorf("! again should list every spec; TotalItems() = %d", m.Selection.TotalItems())
+	}
+}
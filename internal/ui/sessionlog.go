@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/osteele/mutagui/internal/mutagen"
+)
+
+// sessionLogLines returns the contents of the session log dialog for the
+// selected spec: the full state of its running session, or the endpoints
+// from its project file if it isn't running.
+func (m Model) sessionLogLines() []string {
+	projIdx, _ := m.Selection.SelectedSpec()
+	spec := m.selectedSpec()
+	if spec == nil {
+		return []string{"Select a spec to see its session log."}
+	}
+
+	session := spec.RunningSession
+	if session == nil {
+		lines := []string{
+			m.Theme.HelpKey.Render("Spec: ") + spec.Name,
+			m.Theme.HelpKey.Render("Status: ") + mutagen.Icon("○", "o") + " not running",
+			"",
+		}
+		def, ok := m.Projects[projIdx].File.Sessions[spec.Name]
+		if !ok {
+			return append(lines, "The project file has no definition for this spec.")
+		}
+		lines = append(lines,
+			"Configured in "+m.Projects[projIdx].File.Path+":",
+			m.Theme.ConflictAlpha.Bold(true).Render("Alpha (α): ")+def.Alpha,
+			m.Theme.ConflictBeta.Bold(true).Render("Beta (β): ")+strings.Join(def.BetaEndpoints(), ", "))
+		if def.Mode != nil {
+			lines = append(lines, m.Theme.HelpKey.Render("Mode: ")+*def.Mode)
+		}
+		return lines
+	}
+
+	lines := []string{
+		m.Theme.HelpKey.Render("Session: ") + session.Name,
+		m.Theme.HelpKey.Render("Status: ") + session.StatusText() + " (" + session.Status + ")",
+	}
+	if session.Mode != nil {
+		lines = append(lines, m.Theme.HelpKey.Render("Mode: ")+*session.Mode)
+	}
+	if session.Paused {
+		lines = append(lines, m.Theme.HelpKey.Render("Paused: ")+"yes")
+	}
+	if session.LastError != "" {
+		label := "Last error: "
+		if session.IsHalted() {
+			label = "Halted: "
+		}
+		lines = append(lines, m.Theme.StatusError.Render(label+session.LastError))
+	}
+	if session.HasConflicts() {
+		lines = append(lines, m.Theme.StatusWarning.Render(fmt.Sprintf("Conflicts: %d (%s to view)", session.ConflictCount(), keys.Conflicts.Help().Key)))
+	}
+
+	for _, e := range []struct {
+		name     string
+		endpoint *mutagen.Endpoint
+	}{{"Alpha (α)", &session.Alpha}, {"Beta (β)", &session.Beta}} {
+		lines = append(lines, "", m.Theme.HelpKey.Render(e.name+": ")+e.endpoint.DisplayPath())
+		state := "disconnected"
+		if e.endpoint.Connected {
+			state = "connected"
+			if e.endpoint.Scanned {
+				state += ", scanned"
+			} else {
+				state += ", not scanned"
+			}
+		}
+		lines = append(lines, "  "+e.endpoint.StatusIcon()+" "+state)
+		if n := len(e.endpoint.ScanProblems); n > 0 {
+			lines = append(lines, m.Theme.StatusWarning.Render(fmt.Sprintf("  Scan problems: %d", n)))
+			for _, p := range e.endpoint.ScanProblems {
+				lines = append(lines, "    "+p.Path+": "+p.Error)
+			}
+		}
+	}
+	return lines
+}
+
+func (m Model) handleSessionLogKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, keys.Log) {
+		m.ActiveModal = ModalNone
+	} else if offset, ok := scrollOffset(msg, m.logOffset, len(m.sessionLogLines()), m.modalBodyHeight(0)); ok {
+		m.logOffset = offset
+	}
+	return m, nil
+}
+
+func (m Model) renderSessionLogModal() string {
+	var content strings.Builder
+	content.WriteString(m.renderScrollWindow(m.sessionLogLines(), m.logOffset, m.modalBodyHeight(0)))
+	content.WriteString("\n" + m.Theme.ModalHelp.Render(fmt.Sprintf("↑/↓ scroll  Esc or '%s' close", keys.Log.Help().Key)))
+
+	return m.Theme.ModalBorder.Render(
+		m.Theme.ModalTitle.Render(" Session Log ") + "\n\n" + content.String(),
+	)
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestRenderSessionLogModal_Running(t *testing.T) {
+	proj := makeTestProject("p", 1, false)
+	mode := "two-way-safe"
+	proj.Specs[0].State = project.RunningTwoWay
+	proj.Specs[0].RunningSession = &mutagen.SyncSession{
+		Name:      "spec-a",
+		Status:    "halted-on-root-emptied",
+		Mode:      &mode,
+		LastError: "beta root emptied",
+		Alpha:     mutagen.Endpoint{Protocol: "local", Path: "/local", Connected: true, Scanned: true},
+		Beta: mutagen.Endpoint{Protocol: "local", Path: "/remote", Connected: true, Scanned: true,
+			ScanProblems: []mutagen.ScanProblem{{Path: "link", Error: "invalid symbolic link"}}},
+	}
+	m := newTestModel(proj)
+	m.Height = 40
+	m.Selection.SetIndex(1)
+
+	m = press(m, "L")
+	if m.ActiveModal != ModalSessionLog {
+		t.Fatalf("ActiveModal = %v, want ModalSessionLog", m.ActiveModal)
+	}
+	out := m.renderSessionLogModal()
+	for _, want := range []string{"(halted-on-root-emptied)", "Mode: two-way-safe", "Halted: beta root emptied",
+		"Scan problems: 1", "link: invalid symbolic link"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderSessionLogModal_NotRunning(t *testing.T) {
+	m := newTestModel(makeTestProject("p", 1, false))
+	m.Height = 40
+	m.Selection.SetIndex(1)
+
+	out := m.renderSessionLogModal()
+	for _, want := range []string{"not running", "Configured in /test/p.yml", "Alpha (α): /local", "Beta (β): server:/remote"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSessionLog_Scrolls(t *testing.T) {
+	proj := makeTestProject("p", 1, false)
+	session := &mutagen.SyncSession{Name: "spec-a", Status: "watching"}
+	for i := 0; i < 30; i++ {
+		session.Alpha.ScanProblems = append(session.Alpha.ScanProblems, mutagen.ScanProblem{Path: fmt.Sprintf("file%d", i), Error: "permission denied"})
+	}
+	proj.Specs[0].State = project.RunningTwoWay
+	proj.Specs[0].RunningSession = session
+	m := newTestModel(proj)
+	m.Height = 20
+	m.Selection.SetIndex(1)
+	m = press(m, "L")
+
+	moreBelow := regexp.MustCompile(`↓ \d+ more`)
+	if out := m.renderSessionLogModal(); !moreBelow.MatchString(out) {
+		t.Fatalf("long log should offer scrolling:\n%s", out)
+	}
+	for i := 0; i < 100; i++ {
+		m = press(m, "j")
+	}
+	out := m.renderSessionLogModal()
+	if !strings.Contains(out, "file29:") || moreBelow.MatchString(out) {
+		t.Errorf("scrolling down should reach the last line:\n%s", out)
+	}
+	if !strings.Contains(out, "↑ ") {
+		t.Errorf("scrolled log should show the lines above:\n%s", out)
+	}
+}
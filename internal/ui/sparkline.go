@@ -0,0 +1,33 @@
+package ui
+
+import "strings"
+
+// sparkBlocks are the sparkline levels, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values as a row of block characters scaled to the
+// largest value. Zero is always the lowest block and any non-zero value is
+// drawn above it, so a stalled stretch stands out from a slow one.
+func sparkline(values []uint64) string {
+	var max uint64
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	top := uint64(len(sparkBlocks) - 1)
+	var sb strings.Builder
+	for _, v := range values {
+		var level uint64
+		switch {
+		case v == 0:
+			level = 0
+		case max == 1:
+			level = top
+		default:
+			level = 1 + (v-1)*(top-1)/(max-1)
+		}
+		sb.WriteRune(sparkBlocks[level])
+	}
+	return sb.String()
+}
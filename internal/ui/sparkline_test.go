@@ -0,0 +1,21 @@
+package ui
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []uint64
+		want   string
+	}{
+		{nil, ""},
+		{[]uint64{0, 0}, "▁▁"},
+		{[]uint64{0, 1, 8}, "▁▂█"},
+		{[]uint64{5, 5, 0}, "██▁"},
+		{[]uint64{1, 100}, "▂█"},
+	}
+	for _, tt := range tests {
+		if got := sparkline(tt.values); got != tt.want {
+			t.Errorf("sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Summary totals the sessions across all projects, for the header.
+type Summary struct {
+	Running     int    // Running sessions that aren't paused
+	Paused      int    // Paused sessions
+	Conflicting int    // Sessions with at least one conflict
+	Bytes       uint64 // Total size of the synced files
+}
+
+// formatSummary renders a summary as in "3 running · 1 paused · 2.1 GB".
+// Conflicts are left out when there are none.
+func formatSummary(s Summary) string {
+	parts := []string{fmt.Sprintf("%d running", s.Running), fmt.Sprintf("%d paused", s.Paused)}
+	if s.Conflicting > 0 {
+		parts = append(parts, fmt.Sprintf("%d conflicting", s.Conflicting))
+	}
+	parts = append(parts, formatBytes(s.Bytes))
+	return strings.Join(parts, " · ")
+}
+
+// StagingTotal totals the staging of every running session, for the
+// status bar while a big start makes its initial sync.
+type StagingTotal struct {
+	Received uint64 // Bytes received by the staging endpoints
+	Expected uint64 // Bytes they are expected to receive; zero if unknown
+	Files    int    // Files still to be staged
+}
+
+// stagingBarWidth is the number of cells in the staging progress bar.
+const stagingBarWidth = 10
+
+// formatStagingTotal renders the staging total as in
+// "Initial sync 45% ████░░░░░░ 1.2 GB of 2.7 GB, 340 files left", or
+// returns "" if no session is staging. The bar is left unstyled, since the
+// status bar styles the whole line.
+func formatStagingTotal(t StagingTotal) string {
+	if t.Expected == 0 && t.Files == 0 {
+		return ""
+	}
+	var s string
+	if t.Expected > 0 {
+		fraction := float64(t.Received) / float64(t.Expected)
+		if fraction > 1 {
+			fraction = 1
+		}
+		pct := int(fraction * 100)
+		filled, empty := progressCells(pct, stagingBarWidth)
+		s = fmt.Sprintf("Initial sync %d%% %s %s of %s", pct, filled+empty, formatBytes(t.Received), formatBytes(t.Expected))
+	} else {
+		s = "Initial sync"
+	}
+	if t.Files > 0 {
+		s += fmt.Sprintf(", %d files left", t.Files)
+	}
+	return s
+}
@@ -58,6 +58,10 @@ type Theme struct {
 	ConfirmPushBorder lipgloss.Style
 	ConfirmPullBorder lipgloss.Style
 	ConfirmWarning    lipgloss.Style
+
+	// Progress bars
+	ProgressFilled lipgloss.Style
+	ProgressEmpty  lipgloss.Style
 }
 
 // DarkTheme returns a theme for dark terminals.
@@ -103,6 +107,9 @@ func DarkTheme() Theme {
 		ConfirmPushBorder: lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("12")).Padding(1, 2), // Blue border
 		ConfirmPullBorder: lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("13")).Padding(1, 2), // Magenta border
 		ConfirmWarning:    lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11")),                                           // Yellow
+
+		ProgressFilled: lipgloss.NewStyle().Foreground(lipgloss.Color("10")), // Lime
+		ProgressEmpty:  lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
 	}
 }
 
@@ -149,6 +156,9 @@ func LightTheme() Theme {
 		ConfirmPushBorder: lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("4")).Padding(1, 2), // Blue border
 		ConfirmPullBorder: lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("5")).Padding(1, 2), // Purple border
 		ConfirmWarning:    lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("1")),                                           // Red
+
+		ProgressFilled: lipgloss.NewStyle().Foreground(lipgloss.Color("2")), // Dark green
+		ProgressEmpty:  lipgloss.NewStyle().Foreground(lipgloss.Color("250")),
 	}
 }
 
@@ -0,0 +1,58 @@
+package ui
+
+import "github.com/osteele/mutagui/internal/mutagen"
+
+// ConfirmThresholds spare terminates and resets too small to be worth a
+// question their confirmations. Zero thresholds confirm every one.
+type ConfirmThresholds struct {
+	// TerminateSessions is the number of sessions a terminate may stop
+	// without asking; it asks for more
+	TerminateSessions int
+
+	// ResetFiles is the number of files a session needs on either endpoint
+	// for a reset of it to ask first
+	ResetFiles int
+}
+
+// confirmTerminate returns true if terminating this many sessions should
+// ask first.
+func (t ConfirmThresholds) confirmTerminate(sessions int) bool {
+	return sessions > max(t.TerminateSessions, 0)
+}
+
+// confirmReset returns true if resetting the sessions should ask first:
+// if any of them has ResetFiles files on either endpoint, or hasn't
+// counted its files yet, since it may be a big one.
+func (t ConfirmThresholds) confirmReset(sessions []*mutagen.SyncSession) bool {
+	if t.ResetFiles <= 0 {
+		return true
+	}
+	for _, session := range sessions {
+		if session.Alpha.Files == nil || session.Beta.Files == nil {
+			return true
+		}
+		if max(*session.Alpha.Files, *session.Beta.Files) >= uint64(t.ResetFiles) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectedRunningSessions returns the running sessions of the selected
+// spec, or of each spec of the selected project, with every replica of a
+// spec with several betas, as DescribeReset and DescribeTerminate name them.
+func (m Model) selectedRunningSessions() []*mutagen.SyncSession {
+	projIdx := m.Selection.SelectedProjectIndex()
+	if projIdx < 0 || projIdx >= len(m.Projects) {
+		return nil
+	}
+	specs := m.Projects[projIdx].Specs
+	if _, specIdx := m.Selection.SelectedSpec(); specIdx >= 0 && specIdx < len(specs) {
+		specs = specs[specIdx : specIdx+1]
+	}
+	var sessions []*mutagen.SyncSession
+	for i := range specs {
+		sessions = append(sessions, specs[i].Sessions()...)
+	}
+	return sessions
+}
@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+)
+
+func TestConfirmThresholds_Terminate(t *testing.T) {
+	tests := []struct {
+		threshold, sessions int
+		want                bool
+	}{
+		{0, 1, true},
+		{0, 0, false},
+		{3, 3, false},
+		{3, 4, true},
+		{-1, 1, true},
+	}
+	for _, tt := range tests {
+		thresholds := ConfirmThresholds{TerminateSessions: tt.threshold}
+		if got := thresholds.confirmTerminate(tt.sessions); got != tt.want {
+			t.Errorf("confirmTerminate(%d) with threshold %d = %v, want %v", tt.sessions, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+// sessionWithFiles returns a session whose endpoints hold the given numbers
+// of files, where a negative number is one that hasn't been counted.
+func sessionWithFiles(alpha, beta int) *mutagen.SyncSession {
+	count := func(n int) *uint64 {
+		if n < 0 {
+			return nil
+		}
+		files := uint64(n)
+		return &files
+	}
+	return &mutagen.SyncSession{
+		Alpha: mutagen.Endpoint{Files: count(alpha)},
+		Beta:  mutagen.Endpoint{Files: count(beta)},
+	}
+}
+
+func TestConfirmThresholds_Reset(t *testing.T) {
+	tests := []struct {
+		name     string
+		minFiles int
+		sessions []*mutagen.SyncSession
+		want     bool
+	}{
+		{"no threshold asks for any session", 0, []*mutagen.SyncSession{sessionWithFiles(1, 1)}, true},
+		{"small session", 1000, []*mutagen.SyncSession{sessionWithFiles(10, 20)}, false},
+		{"big beta", 1000, []*mutagen.SyncSession{sessionWithFiles(10, 1000)}, true},
+		{"one big session of several", 1000, []*mutagen.SyncSession{sessionWithFiles(5, 5), sessionWithFiles(5000, 4999)}, true},
+		{"files not counted yet", 1000, []*mutagen.SyncSession{sessionWithFiles(10, -1)}, true},
+		{"no sessions", 1000, nil, false},
+	}
+	for _, tt := range tests {
+		thresholds := ConfirmThresholds{ResetFiles: tt.minFiles}
+		if got := thresholds.confirmReset(tt.sessions); got != tt.want {
+			t.Errorf("%s: confirmReset() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTerminate_ConfirmsOnlyAboveThreshold(t *testing.T) {
+	m := newTestModel(makeTestProject("proj", 3, false))
+	m.OnTerminate = func(ctx context.Context) *StatusMessage { return nil }
+	m.ConfirmTerminate = true
+	m.ConfirmThresholds = ConfirmThresholds{TerminateSessions: 2}
+	names := []string{"spec-a", "spec-b"}
+	m.DescribeTerminate = func() []string { return names }
+
+	updated, cmd := m.handleKeyPress(keyPress("t"))
+	if updated.(Model).ActiveModal != ModalNone || cmd == nil {
+		t.Fatal("terminating 2 sessions with a threshold of 2 should run right away")
+	}
+
+	names = append(names, "spec-c")
+	if m = press(m, "t"); m.ActiveModal != ModalConfirm {
+		t.Errorf("terminating 3 sessions should ask; modal = %v", m.ActiveModal)
+	}
+}
+
+func TestReset_ConfirmsOnlyBigSessions(t *testing.T) {
+	proj := makeTestProject("proj", 2, false)
+	for i := range proj.Specs {
+		proj.Specs[i].State = project.RunningTwoWay
+		proj.Specs[i].RunningSession = sessionWithFiles(50, 50)
+	}
+	m := newTestModel(proj)
+	reset := 0
+	m.OnReset = func(ctx context.Context) *StatusMessage {
+		reset++
+		return nil
+	}
+	m.DescribeReset = func() []string { return []string{"spec-a", "spec-b"} }
+	m.ConfirmThresholds = ConfirmThresholds{ResetFiles: 100}
+
+	updated, cmd := m.handleKeyPress(keyPress("x"))
+	if m := updated.(Model); m.ActiveModal != ModalNone || !m.IsLoading || cmd == nil {
+		t.Fatalf("resetting small sessions should run right away; modal = %v", m.ActiveModal)
+	}
+	cmd()
+	if reset != 1 {
+		t.Errorf("reset = %d, want 1", reset)
+	}
+
+	// A big session in the project asks first
+	proj.Specs[1].RunningSession = sessionWithFiles(50, 5000)
+	if m = press(m, "x"); m.ActiveModal != ModalConfirm {
+		t.Errorf("resetting a project with a big session should ask; modal = %v", m.ActiveModal)
+	}
+
+	// With only the small spec selected, it doesn't
+	m.ActiveModal = ModalNone
+	m.Selection.SelectNext()
+	if m = press(m, "x"); m.ActiveModal != ModalNone {
+		t.Errorf("resetting the small spec should run right away; modal = %v", m.ActiveModal)
+	}
+}
+
+func TestReset_ConfirmsBigReplica(t *testing.T) {
+	proj := makeTestProject("proj", 1, false)
+	spec := &proj.Specs[0]
+	spec.State = project.RunningTwoWay
+	spec.RunningSession = sessionWithFiles(50, 50)
+	spec.BetaSessions = []*mutagen.SyncSession{spec.RunningSession, sessionWithFiles(50, 5000)}
+	m := newTestModel(proj)
+	m.OnReset = func(ctx context.Context) *StatusMessage { return nil }
+	m.DescribeReset = func() []string { return []string{"spec-a@one", "spec-a@two"} }
+	m.ConfirmThresholds = ConfirmThresholds{ResetFiles: 100}
+	m.Selection.SelectNext()
+
+	if m = press(m, "x"); m.ActiveModal != ModalConfirm {
+		t.Errorf("resetting a spec with a big second replica should ask; modal = %v", m.ActiveModal)
+	}
+}
@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// TransferRate is the measured speed of a session that is staging files.
+type TransferRate struct {
+	BytesPerSecond float64
+	Remaining      time.Duration // Time left on the current file; zero if it can't be estimated
+}
+
+// formatTransferRate renders a rate as in " 3.2 MB/s ETA 00:42", to append
+// to a staging status.
+func formatTransferRate(r TransferRate) string {
+	s := " " + formatBytes(uint64(r.BytesPerSecond)) + "/s"
+	if r.Remaining > 0 {
+		s += " ETA " + formatETA(r.Remaining)
+	}
+	return s
+}
+
+// formatETA formats a duration as mm:ss, or h:mm:ss from an hour up.
+func formatETA(d time.Duration) string {
+	secs := int64(d.Round(time.Second) / time.Second)
+	if secs < 1 {
+		secs = 1
+	}
+	if secs >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+	}
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTransferRate(t *testing.T) {
+	tests := []struct {
+		rate TransferRate
+		want string
+	}{
+		{TransferRate{BytesPerSecond: 3.2 * 1024 * 1024, Remaining: 42 * time.Second}, " 3.2 MB/s ETA 00:42"},
+		{TransferRate{BytesPerSecond: 512}, " 512 B/s"},
+		{TransferRate{BytesPerSecond: 1024, Remaining: 90 * time.Minute}, " 1.0 KB/s ETA 1:30:00"},
+	}
+	for _, tt := range tests {
+		if got := formatTransferRate(tt.rate); got != tt.want {
+			t.Errorf("formatTransferRate(%+v) = %q, want %q", tt.rate, got, tt.want)
+		}
+	}
+}
@@ -5,18 +5,28 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/osteele/mutagui/internal/app"
 	"github.com/osteele/mutagui/internal/config"
 	"github.com/osteele/mutagui/internal/mutagen"
+	"github.com/osteele/mutagui/internal/project"
+	"github.com/osteele/mutagui/internal/state"
 	"github.com/osteele/mutagui/internal/ui"
 )
 
 var (
 	projectDir = flag.String("d", "", "Directory to search for mutagen project files (default: current directory)")
 	showHelp   = flag.Bool("h", false, "Show help")
+	dryRun     = flag.Bool("dry-run", false, "Report mutating mutagen commands instead of running them")
+	export     = flag.String("export", "", "Print the state of all projects in `format` (json or yaml), then exit")
+	session    = flag.String("session", "", "Perform --action on the `name`d session, then exit")
+	action     = flag.String("action", "", "What to do to --session: start, pause, resume, terminate, flush, or reset")
 )
 
 func main() {
@@ -41,25 +51,82 @@ func main() {
 }
 
 func run() error {
+	if *export != "" && *export != "json" && *export != "yaml" {
+		return fmt.Errorf("unknown export format %q (want json or yaml)", *export)
+	}
+	if (*session == "") != (*action == "") {
+		return fmt.Errorf("--session and --action must be given together")
+	}
+	if *action != "" && !slices.Contains(app.SessionActions, *action) {
+		return fmt.Errorf("unknown action %q (want %s)", *action, strings.Join(app.SessionActions, ", "))
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	keyMap, err := ui.KeyMapFromConfig(cfg.Keys.Bindings)
+	if err != nil {
+		return fmt.Errorf("failed to load config: [keys]: %w", err)
+	}
+	ui.UseKeyMap(keyMap)
+	mutagen.SetPathAbbreviations(cfg.UI.PathAbbreviations)
+	mutagen.SetASCIIIcons(cfg.UI.ASCIIIcons || ui.TerminalLacksUnicode())
 
 	// Create app
 	mainApp := app.NewApp(cfg)
 
+	// In dry-run mode, record mutating commands instead of executing them
+	var dryRunMu sync.Mutex
+	var dryRunCommands []string
+	if *dryRun {
+		mainApp.DryRun = true
+		mainApp.Client = app.NewMutagenClient(cfg,
+			mutagen.NewDryRunRunner(mutagen.ExecRunner{}, func(cmdline string) {
+				dryRunMu.Lock()
+				dryRunCommands = append(dryRunCommands, cmdline)
+				dryRunMu.Unlock()
+				mainApp.LogEvent(ui.StatusInfo, "[dry-run] would run: "+cmdline)
+			}))
+	}
+
+	// Remember state between runs (kept in memory only for dry runs,
+	// exports, and session actions)
+	if !*dryRun && *export == "" && *session == "" {
+		if store, err := state.Open(state.DefaultPath()); err == nil {
+			mainApp.Store = store
+		} else {
+			mainApp.LogEvent(ui.StatusWarning, "Failed to load saved state: "+err.Error())
+		}
+	}
+
 	// Check if mutagen is installed
 	if !mainApp.Client.IsInstalled() {
+		if binary := app.MutagenBinary(cfg); binary != mutagen.DefaultBinary {
+			return fmt.Errorf("mutagen is not installed at %s; check [client] binary or MUTAGUI_MUTAGEN_BIN", binary)
+		}
 		return fmt.Errorf("mutagen is not installed or not in PATH")
 	}
 
+	if *export != "" {
+		return runExport(mainApp)
+	}
+	if *session != "" {
+		err := runSessionAction(mainApp)
+		printDryRunCommands(dryRunCommands)
+		return err
+	}
+
 	// Get theme
 	theme := ui.GetTheme(string(cfg.UI.Theme))
 
 	// Create model
 	model := ui.NewModel(theme)
+	model.Clock = mainApp.Clock
+	if version, err := mainApp.Client.GetVersion(); err == nil {
+		model.MutagenVersion = version
+	}
 
 	// Load projects
 	ctx := context.Background()
@@ -67,23 +134,41 @@ func run() error {
 		model.StatusMessage = &ui.StatusMessage{Type: ui.StatusWarning, Text: "Failed to load some projects: " + err.Error()}
 	}
 
+	mainApp.ApplyFoldState()
+
 	// Rebuild selection from projects
 	mainApp.State.Selection.RebuildFromProjects(mainApp.State.Projects)
 
 	// Share state between app and model
 	model.Projects = mainApp.State.Projects
 	model.Selection = mainApp.State.Selection
+	model.Events = mainApp.State.Events
 	model.ShowPaths = mainApp.State.ShowPaths
+	model.ShowHost = cfg.UI.ShowHost
+	model.Borderless = cfg.UI.Borderless
+	model.Compact = cfg.UI.Density == config.DensityCompact
+	model.TruncatePathEnds = cfg.UI.PathEllipsis == config.PathEllipsisEnd
+	model.Columns = cfg.UI.Columns
+	model.SpaceMarks = cfg.Keys.Space == config.SpaceActionMark
+	// Notifications and auto-reconnects happen on refreshes, so they slow
+	// rather than stop them
+	model.PauseUnfocused = cfg.Refresh.PauseWhenUnfocused && !cfg.Notifications.Enabled && !cfg.Recovery.AutoReconnect
+	model.IdleAfter = time.Duration(cfg.Refresh.IdleAfterSecs) * time.Second
+	model.IdleInterval = time.Duration(cfg.Refresh.IdleIntervalSecs) * time.Second
+	model.DisplayModeFor = func(proj *project.Project) (bool, bool) {
+		mode, ok := cfg.DisplayModeFor(proj.File.DisplayName())
+		return mode == config.DisplayModePaths, ok
+	}
 
 	// Initial session refresh
-	if err := mainApp.RefreshSessions(ctx); err != nil {
+	if err := mainApp.RefreshSessions(ctx, true); err != nil {
 		model.StatusMessage = &ui.StatusMessage{Type: ui.StatusWarning, Text: "Failed to refresh sessions: " + err.Error()}
 	}
 	model.LastRefresh = mainApp.State.LastRefresh
 
 	// Set up callbacks
-	model.OnRefresh = func(ctx context.Context) error {
-		err := mainApp.RefreshSessions(ctx)
+	model.OnRefresh = func(ctx context.Context, force bool) error {
+		err := mainApp.RefreshSessions(ctx, force)
 		model.LastRefresh = mainApp.State.LastRefresh
 		if mainApp.State.StatusMessage != nil {
 			model.StatusMessage = &ui.StatusMessage{
@@ -94,6 +179,17 @@ func run() error {
 		return err
 	}
 
+	model.OnThoroughRefresh = func(ctx context.Context) *ui.StatusMessage {
+		mainApp.ThoroughRefresh(ctx)
+		model.LastRefresh = mainApp.State.LastRefresh
+		return getStatus(mainApp)
+	}
+
+	model.OnRestartDaemon = func(ctx context.Context) *ui.StatusMessage {
+		mainApp.RestartDaemon(ctx)
+		return getStatus(mainApp)
+	}
+
 	model.OnStart = func(ctx context.Context) *ui.StatusMessage {
 		if model.Selection.IsSpecSelected() {
 			mainApp.StartSelectedSpec(ctx)
@@ -123,6 +219,74 @@ func run() error {
 		return getStatus(mainApp)
 	}
 
+	model.DescribeIgnores = func() []string {
+		return mainApp.DescribeSelectedIgnores(context.Background())
+	}
+
+	model.GetFileConfig = mainApp.SelectedFileConfig
+	model.DescribeFileSettings = mainApp.DescribeSelectedFileSettings
+	model.CycleHistory = mainApp.SelectedCycleHistory
+
+	model.OnPauseAll = func(ctx context.Context) *ui.StatusMessage {
+		mainApp.TogglePauseAll(ctx)
+		return getStatus(mainApp)
+	}
+
+	model.OnRestart = func(ctx context.Context) *ui.StatusMessage {
+		mainApp.RestartSelected(ctx)
+		return getStatus(mainApp)
+	}
+
+	model.OnFocus = func(ctx context.Context) *ui.StatusMessage {
+		mainApp.ToggleFocus(ctx)
+		return getStatus(mainApp)
+	}
+	model.GetFocus = mainApp.FocusTarget
+
+	model.OnStartAll = func(ctx context.Context) *ui.StatusMessage {
+		mainApp.StartAllProjects(ctx)
+		return getStatus(mainApp)
+	}
+	model.OnTerminateAll = func(ctx context.Context) *ui.StatusMessage {
+		mainApp.TerminateAll(ctx)
+		return getStatus(mainApp)
+	}
+	model.DescribeTermAll = mainApp.DescribeTerminateAll
+
+	model.SearchPaths = mainApp.SearchPaths
+	model.TransferRate = mainApp.TransferRate
+	model.FreeSpace = mainApp.Client.EndpointFreeSpace
+	model.Disconnection = mainApp.Disconnection
+	model.OnReconnect = func(ctx context.Context) *ui.StatusMessage {
+		mainApp.ReconnectSelected(ctx)
+		return getStatus(mainApp)
+	}
+
+	model.OnPauseMarked = func(ctx context.Context, specs []*project.SyncSpec) *ui.StatusMessage {
+		mainApp.TogglePauseMarked(ctx, specs)
+		return getStatus(mainApp)
+	}
+
+	model.OnScheduledFlush = mainApp.RunScheduledFlushes
+
+	model.OnToggleSchedule = func(ctx context.Context) *ui.StatusMessage {
+		mainApp.ToggleScheduleSelected(ctx)
+		return getStatus(mainApp)
+	}
+
+	model.OnReconcile = func(ctx context.Context) *ui.StatusMessage {
+		mainApp.ReconcileSelectedSpec(ctx)
+		return getStatus(mainApp)
+	}
+	model.DescribeReconcile = mainApp.DescribeSettingsChanges
+
+	model.OnReset = func(ctx context.Context) *ui.StatusMessage {
+		mainApp.ResetSelected(ctx)
+		return getStatus(mainApp)
+	}
+	model.DescribeReset = mainApp.DescribeRunningSelected
+	model.DescribeTerminate = mainApp.DescribeRunningSelected
+
 	model.OnPush = func(ctx context.Context) *ui.StatusMessage {
 		if model.Selection.IsSpecSelected() {
 			mainApp.PushSelectedSpec(ctx)
@@ -145,15 +309,60 @@ func run() error {
 	// Set confirmation preferences from config
 	model.ConfirmPushToBeta = cfg.Confirmations.PushToBeta
 	model.ConfirmPullToAlpha = cfg.Confirmations.PullToAlpha
+	model.ConfirmCreatePush = cfg.Confirmations.CreatePush || cfg.Confirmations.PushPreview
+	model.ConfirmTerminate = cfg.Confirmations.Terminate
+	model.ConfirmThresholds = ui.ConfirmThresholds{
+		TerminateSessions: cfg.Confirmations.TerminateThresholdSessions,
+		ResetFiles:        cfg.Confirmations.ResetMinFiles,
+	}
+	model.DescribePush = mainApp.DescribePushSelected
+
+	// Offer to write a starter config on first run (never in dry-run mode),
+	// until the offer is declined
+	if path := config.Path(); path != "" && !*dryRun && !mainApp.Store.ConfigDeclined() {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			model.ConfigPath = path
+			model.OnWriteConfig = func() error {
+				return config.WriteDefault(path)
+			}
+			model.OnDeclineConfig = mainApp.DeclineStarterConfig
+			model.ActiveModal = ui.ModalFirstRun
+		}
+	}
 
 	model.OnToggleFold = func(projIdx int) {
 		mainApp.ToggleProjectFold(projIdx)
 	}
 
+	model.OnCycleSort = func() *ui.StatusMessage {
+		mainApp.CycleSort()
+		return getStatus(mainApp)
+	}
+
+	model.OnCopy = func() *ui.StatusMessage {
+		mainApp.CopySelectedPaths()
+		return getStatus(mainApp)
+	}
+
+	model.OnCopyIdentifier = func() *ui.StatusMessage {
+		mainApp.CopySelectedIdentifier()
+		return getStatus(mainApp)
+	}
+
+	model.OnOpenBeta = func() (*exec.Cmd, *ui.StatusMessage) {
+		cmd := mainApp.OpenSelectedBeta()
+		return cmd, getStatus(mainApp)
+	}
+
 	model.OnOpenEditor = func(projIdx int) error {
 		return mainApp.OpenEditor(projIdx)
 	}
 
+	model.OnResolveConflict = func(ctx context.Context, session *mutagen.SyncSession, conflict mutagen.Conflict, winner string) *ui.StatusMessage {
+		mainApp.ResolveConflict(ctx, session, conflict, winner)
+		return getStatus(mainApp)
+	}
+
 	model.GetConflicts = func() []ui.SessionConflicts {
 		return mainApp.GetConflictsForSelection()
 	}
@@ -162,8 +371,61 @@ func run() error {
 		return mainApp.GetSelectedSession()
 	}
 
+	model.ListDaemonSessions = mainApp.Client.ListSessions
+	model.OnTerminateSession = func(ctx context.Context, identifier, name string) *ui.StatusMessage {
+		mainApp.TerminateSession(ctx, identifier, name)
+		return getStatus(mainApp)
+	}
+
+	model.ListForwards = mainApp.Client.ListForwards
+	model.OnPauseForward = func(ctx context.Context, identifier, name string) *ui.StatusMessage {
+		mainApp.PauseForward(ctx, identifier, name)
+		return getStatus(mainApp)
+	}
+	model.OnResumeForward = func(ctx context.Context, identifier, name string) *ui.StatusMessage {
+		mainApp.ResumeForward(ctx, identifier, name)
+		return getStatus(mainApp)
+	}
+	model.OnTerminateForward = func(ctx context.Context, identifier, name string) *ui.StatusMessage {
+		mainApp.TerminateForward(ctx, identifier, name)
+		return getStatus(mainApp)
+	}
+
+	model.OfferFix = mainApp.OfferFix
+	model.OnFix = func(ctx context.Context) *ui.StatusMessage {
+		mainApp.ApplyFix(ctx)
+		return getStatus(mainApp)
+	}
+
+	model.OnUndo = func(ctx context.Context) *ui.StatusMessage {
+		mainApp.UndoLast(ctx)
+		return getStatus(mainApp)
+	}
+	model.DescribeUndo = mainApp.DescribeUndo
+
+	model.OnPollSession = func(ctx context.Context) error {
+		return mainApp.PollSelectedSession(ctx)
+	}
+	model.OnMonitorSession = mainApp.MonitorSelectedSession
+	model.OnSessionUpdate = mainApp.ApplySessionUpdate
+
+	model.GetProjects = func() []*project.Project {
+		return mainApp.State.Projects
+	}
+	model.GetSummary = mainApp.Summary
+	model.GetStagingTotal = func() ui.StagingTotal {
+		received, expected, files := mainApp.AggregateStagingProgress()
+		return ui.StagingTotal{Received: received, Expected: expected, Files: files}
+	}
+
+	model.LoadProjectFile = mainApp.LoadProjectFile
+	model.OnReloadProjectFile = func(path string, file *project.ProjectFile, err error) *ui.StatusMessage {
+		mainApp.ReloadProjectFile(path, file, err)
+		return getStatus(mainApp)
+	}
+
 	// Create program
-	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithReportFocus())
 
 	// Set up auto-refresh
 	if cfg.Refresh.Enabled {
@@ -175,19 +437,63 @@ func run() error {
 				if mainApp.ShouldQuit() {
 					return
 				}
-				p.Send(ui.TickMsg(time.Now()))
+				p.Send(ui.TickMsg(mainApp.Clock.Now()))
 			}
 		}()
 	}
 
+	// Flush scheduled specs on a ticker of their own, independent of
+	// auto-refresh
+	go func() {
+		ticker := time.NewTicker(app.ScheduledFlushCheckInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if mainApp.ShouldQuit() {
+				return
+			}
+			p.Send(ui.ScheduleTickMsg(mainApp.Clock.Now()))
+		}
+	}()
+
+	// Reload project files when they are edited
+	if cfg.Projects.WatchFiles {
+		stop, err := mainApp.WatchProjectFiles(func(path string) {
+			p.Send(ui.ProjectFileChangedMsg{Path: path})
+		})
+		if err != nil {
+			mainApp.LogEvent(ui.StatusWarning, "Can't watch project files: "+err.Error())
+		} else {
+			defer stop()
+		}
+	}
+
 	// Run the program
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("application error: %w", err)
 	}
 
+	dryRunMu.Lock()
+	defer dryRunMu.Unlock()
+	printDryRunCommands(dryRunCommands)
 	return nil
 }
 
+// printDryRunCommands prints the commands that were skipped in dry-run mode.
+func printDryRunCommands(commands []string) {
+	if !*dryRun {
+		return
+	}
+	if len(commands) == 0 {
+		fmt.Println("Dry run: no mutating commands would have been run")
+		return
+	}
+	fmt.Println("Dry run: the following commands would have been run:")
+	for _, cmdline := range commands {
+		fmt.Println("  " + cmdline)
+	}
+}
+
 // getStatus returns the current status message from the app as a UI status message
 func getStatus(mainApp *app.App) *ui.StatusMessage {
 	if mainApp.State.StatusMessage != nil {
@@ -198,3 +504,34 @@ func getStatus(mainApp *app.App) *ui.StatusMessage {
 	}
 	return nil
 }
+
+// runExport prints a snapshot of every project's sessions instead of
+// starting the TUI.
+func runExport(mainApp *app.App) error {
+	ctx := context.Background()
+	if err := mainApp.LoadProjects(ctx, *projectDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load some projects: %v\n", err)
+	}
+	if err := mainApp.RefreshSessions(ctx, true); err != nil {
+		return fmt.Errorf("failed to refresh sessions: %w", err)
+	}
+	return app.WriteSnapshot(os.Stdout, *export, mainApp.Snapshot())
+}
+
+// runSessionAction performs --action on --session instead of starting the
+// TUI, and prints what it did. Project files are only loaded to start a
+// session, since that needs its definition.
+func runSessionAction(mainApp *app.App) error {
+	ctx := context.Background()
+	if *action == "start" {
+		if err := mainApp.LoadProjects(ctx, *projectDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to load some projects: %v\n", err)
+		}
+	}
+	result, err := mainApp.RunSessionAction(ctx, *session, *action)
+	if err != nil {
+		return err
+	}
+	fmt.Println(result)
+	return nil
+}